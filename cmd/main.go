@@ -2,7 +2,9 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 
 	"uvui/internal/app"
 	"uvui/internal/services"
@@ -11,12 +13,35 @@ import (
 )
 
 func main() {
+	flag.Usage = func() {
+		_, _ = os.Stderr.WriteString("usage: uvui [project-dir]\n")
+	}
+	flag.Parse()
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if flag.NArg() > 0 {
+		projectDir = flag.Arg(0)
+	}
+
 	executor := services.NewCommandExecutor()
 	uvInstaller := services.NewUVInstaller(executor)
 	pythonManager := services.NewPythonManager(executor)
 	projectManager := services.NewProjectManager(executor)
+	uvLocator := services.NewUVLocator(executor)
+	environmentManager := services.NewEnvironmentManager(executor)
+	scriptManager := services.NewScriptManager(executor)
+	taskManager := services.NewTaskManager(executor)
+	testRunner := services.NewTestRunner(executor)
+	codeChecker := services.NewCodeChecker(executor)
+	toolManager := services.NewToolManager(executor)
+	cacheManager := services.NewCacheManager(executor)
 
-	model := app.NewModel(uvInstaller, pythonManager, projectManager, executor)
+	model := app.NewModel(uvInstaller, pythonManager, projectManager, uvLocator, environmentManager,
+		scriptManager, taskManager, testRunner, codeChecker, toolManager, cacheManager, executor)
+	model.SetProjectRoot(projectDir)
 
 	program := tea.NewProgram(
 		model,
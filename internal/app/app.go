@@ -2,15 +2,20 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"uvui/internal/services"
 	"uvui/internal/types"
 	"uvui/internal/ui"
 	"uvui/internal/ui/panels"
@@ -26,6 +31,14 @@ const (
 	InputModeProjectName
 	// InputModePythonVersion indicates that the text input is for selecting a Python version.
 	InputModePythonVersion
+	// InputModeUVVersion indicates that the text input is for entering the uv version to update to.
+	InputModeUVVersion
+	// InputModeConfirm indicates that a yes/no confirmation prompt is shown.
+	InputModeConfirm
+	// InputModeLockSearch indicates that the text input filters the lockfile browser.
+	InputModeLockSearch
+	// InputModeForm indicates that a dialog form is open.
+	InputModeForm
 )
 
 // getDirection converts a key string to a direction for navigation.
@@ -43,7 +56,9 @@ func getDirection(key string) int {
 // Init initializes the application.
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
-		CheckUVStatus(m.UVInstaller),
+		CheckUVStatus(m.newContext(uvContextKey, loadTimeoutOperation), m.UVInstaller),
+		m.discoverUVBinaries(),
+		LoadProjectStatus(m.loadContext(projectContextKey), m.ProjectManager),
 		tea.EnterAltScreen,
 	)
 }
@@ -61,6 +76,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleEnterKey()
 		} else if m.InputMode == InputModePythonVersion && (msg.String() == "up" || msg.String() == "down") {
 			return m.handleVerticalNavigation(getDirection(msg.String()))
+		} else if m.InputMode == InputModeConfirm {
+			return m.handleConfirmKey(msg)
+		} else if m.InputMode == InputModeForm {
+			return m.handleFormKey(msg)
 		} else if m.InputMode != InputModeNone {
 			return m.handleTextInput(msg)
 		}
@@ -69,6 +88,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ui.UVInstalledMsg:
 		return m.handleUVInstalledMsg(msg)
 
+	case ui.UVOperationMsg:
+		return m.handleUVOperationMsg(msg)
+
+	case ui.UVBinariesLoadedMsg:
+		return m.handleUVBinariesLoadedMsg(msg)
+
 	case ui.PythonVersionsLoadedMsg:
 		return m.handlePythonVersionsLoadedMsg(msg)
 
@@ -83,6 +108,87 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ui.ProjectOperationMsg:
 		return m.handleProjectOperationMsg(msg)
+
+	case ui.LockFileLoadedMsg:
+		return m.handleLockFileLoadedMsg(msg)
+
+	case ui.DependencyOperationMsg:
+		return m.handleDependencyOperationMsg(msg)
+
+	case ui.EnvironmentsLoadedMsg:
+		return m.handleEnvironmentsLoadedMsg(msg)
+
+	case ui.EnvironmentOperationMsg:
+		return m.handleEnvironmentOperationMsg(msg)
+
+	case ui.ToolsLoadedMsg:
+		return m.handleToolsLoadedMsg(msg)
+
+	case ui.ToolOperationMsg:
+		return m.handleToolOperationMsg(msg)
+
+	case ui.ToolRunMsg:
+		return m.handleToolRunMsg(msg)
+
+	case ui.RunTargetsLoadedMsg:
+		return m.handleRunTargetsLoadedMsg(msg)
+
+	case ui.ScriptRunMsg:
+		return m.handleScriptRunMsg(msg)
+
+	case ui.TasksLoadedMsg:
+		return m.handleTasksLoadedMsg(msg)
+
+	case ui.TaskRunMsg:
+		return m.handleTaskRunMsg(msg)
+
+	case ui.TestRunMsg:
+		return m.handleTestRunMsg(msg)
+
+	case ui.TestMatrixLoadedMsg:
+		return m.handleTestMatrixLoadedMsg(msg)
+
+	case ui.MatrixRunMsg:
+		return m.handleMatrixRunMsg(msg)
+
+	case ui.DiagnosticsMsg:
+		return m.handleDiagnosticsMsg(msg)
+
+	case ui.RuffFixMsg:
+		return m.handleRuffFixMsg(msg)
+
+	case ui.EditorClosedMsg:
+		return m.handleEditorClosedMsg(msg)
+
+	case ui.ScriptMetadataLoadedMsg:
+		return m.handleScriptMetadataLoadedMsg(msg)
+
+	case ui.ScriptOperationMsg:
+		return m.handleScriptOperationMsg(msg)
+
+	case ui.CacheLoadedMsg:
+		return m.handleCacheLoadedMsg(msg)
+
+	case ui.CacheOperationMsg:
+		return m.handleCacheOperationMsg(msg)
+
+	case ui.InstalledPackagesLoadedMsg:
+		return m.handleInstalledPackagesLoadedMsg(msg)
+
+	case ui.PackageDetailsLoadedMsg:
+		return m.handlePackageDetailsLoadedMsg(msg)
+
+	case ui.PackageFreezeLoadedMsg:
+		return m.handlePackageFreezeLoadedMsg(msg)
+
+	case ui.CommandOutputMsg:
+		return m.handleCommandOutputMsg(msg)
+
+	case ui.JobFinishedMsg:
+		return m.handleJobFinishedMsg(msg)
+
+	case ui.JobTickMsg:
+		return m.handleJobTickMsg()
 	}
 
 	return m, nil
@@ -92,22 +198,67 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *Model) handleTextInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if msg.Type == tea.KeyEsc {
+		if m.InputMode == InputModeLockSearch {
+			m.State.ProjectState.Lock.Query = ""
+		}
+		m.InputMode = InputModeNone
+		m.TextInput.Reset()
+		return m, nil
+	}
+
+	if m.InputMode == InputModeLockSearch {
+		if msg.Type == tea.KeyEnter {
+			m.InputMode = InputModeNone
+			m.TextInput.Reset()
+			return m, nil
+		}
+		m.TextInput, cmd = m.TextInput.Update(msg)
+		m.State.ProjectState.Lock.Query = m.TextInput.Value()
+		m.State.ProjectState.Lock.Selected = 0
+		return m, cmd
+	}
+
+	if msg.Type == tea.KeyEnter && m.InputMode == InputModeUVVersion {
+		version := strings.TrimSpace(m.TextInput.Value())
+		m.InputMode = InputModeNone
+		m.TextInput.Reset()
+		return m, m.updateUV(version)
+	}
+
 	if msg.Type == tea.KeyEnter {
 		projectName := m.TextInput.Value()
 		m.State.ProjectState.Name = projectName
 		m.InputMode = InputModeNone // Reset input mode
 		m.TextInput.Reset()
 
-		m.SetOperation("init", projectName, true)
 		m.AddMessage(fmt.Sprintf("Initializing new project '%s'...", projectName))
 		options := types.InitOptions{} // No python version
-		return m, InitProject(m.ProjectManager, projectName, options)
+		return m, m.EnqueueJob("init", projectName, projectResources, InitProject(m.ProjectManager, projectName, options))
 	}
 
 	m.TextInput, cmd = m.TextInput.Update(msg)
 	return m, cmd
 }
 
+// handleConfirmKey answers the pending confirmation prompt.
+func (m *Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pending := m.confirm
+	switch msg.String() {
+	case "y", "Y", "enter":
+		m.confirm = nil
+		m.InputMode = InputModeNone
+		if pending != nil {
+			return m, pending.action()
+		}
+	case "n", "N", "esc":
+		m.confirm = nil
+		m.InputMode = InputModeNone
+		m.AddMessage("Cancelled")
+	}
+	return m, nil
+}
+
 // handleTabNavigation handles tab and shift+tab navigation between panels.
 func (m *Model) handleTabNavigation(direction int) (tea.Model, tea.Cmd) {
 	if direction > 0 {
@@ -123,13 +274,43 @@ func (m *Model) handleTabNavigation(direction int) (tea.Model, tea.Cmd) {
 	// Load Python versions when entering Python panel
 	if m.State.ActivePanel == types.PythonPanel && m.State.Installed && !m.State.PythonVersions.Loading {
 		m.State.PythonVersions.Loading = true
-		return m, LoadPythonVersions(m.PythonManager)
+		return m, LoadPythonVersions(m.loadContext(pythonContextKey), m.PythonManager)
 	}
 
 	// Load project status when entering Project panel
 	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed && !m.State.ProjectState.Loading {
 		m.State.ProjectState.Loading = true
-		return m, LoadProjectStatus(m.ProjectManager)
+		return m, LoadProjectStatus(m.loadContext(projectContextKey), m.ProjectManager)
+	}
+
+	// Look for virtual environments when entering Environment panel
+	if m.State.ActivePanel == types.EnvironmentPanel && m.State.Installed && !m.State.Environments.Loading {
+		m.State.Environments.Loading = true
+		return m, LoadEnvironments(m.loadContext(environmentsContextKey), m.EnvironmentManager)
+	}
+
+	// Look for scripts when entering Run panel
+	if m.State.ActivePanel == types.RunPanel && m.State.Installed && !m.State.Run.Loading {
+		m.State.Run.Loading = true
+		return m, LoadRunTargets(m.loadContext(scriptsContextKey), m.ScriptManager)
+	}
+
+	// Read the tasks when entering Tasks panel
+	if m.State.ActivePanel == types.TasksPanel && m.State.Installed && !m.State.Tasks.Loading {
+		m.State.Tasks.Loading = true
+		return m, LoadTasks(m.loadContext(tasksContextKey), m.TaskManager)
+	}
+
+	// Load tools when entering Tools panel
+	if m.State.ActivePanel == types.ToolsPanel && m.State.Installed && !m.State.Tools.Loading {
+		m.State.Tools.Loading = true
+		return m, LoadTools(m.loadContext(toolsContextKey), m.ToolManager)
+	}
+
+	// Measure the cache when entering Cache panel
+	if m.State.ActivePanel == types.CachePanel && m.State.Installed && !m.State.Cache.Loading {
+		m.State.Cache.Loading = true
+		return m, LoadCache(m.loadContext(cacheContextKey), m.CacheManager)
 	}
 
 	return m, nil
@@ -137,6 +318,87 @@ func (m *Model) handleTabNavigation(direction int) (tea.Model, tea.Cmd) {
 
 // handleVerticalNavigation handles up/down arrow navigation within panels.
 func (m *Model) handleVerticalNavigation(direction int) (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.JobsPanel && m.InputMode == InputModeNone {
+		m.State.Jobs.Selected = clampIndex(m.State.Jobs.Selected+direction, len(m.State.Jobs.Jobs))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.ProjectPanel && m.State.ProjectState.Lock.Visible {
+		lock := &m.State.ProjectState.Lock
+		lock.Selected = clampIndex(lock.Selected+direction, len(panels.FilterLockPackages(lock.Lock, lock.Query)))
+		return m, nil
+	}
+
+	if m.treeViewActive() {
+		m.MoveTreeCursor(direction)
+		return m, nil
+	}
+
+	if m.packagesViewActive() {
+		return m, m.movePackageSelection(direction)
+	}
+
+	if m.State.ActivePanel == types.RunPanel && m.InputMode == InputModeNone {
+		run := &m.State.Run
+		if run.Script.Visible {
+			if run.Script.Metadata != nil {
+				run.Script.Selected = clampIndex(run.Script.Selected+direction, len(run.Script.Metadata.Dependencies))
+			}
+		} else if run.ShowHistory {
+			run.RunSelected = clampIndex(run.RunSelected+direction, len(run.History))
+		} else {
+			run.Selected = clampIndex(run.Selected+direction, len(run.Targets))
+		}
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.TasksPanel && m.InputMode == InputModeNone {
+		m.State.Tasks.Selected = clampIndex(m.State.Tasks.Selected+direction, len(m.State.Tasks.Tasks))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.TestsPanel && m.State.Tests.Matrix.Visible && m.InputMode == InputModeNone {
+		matrix := &m.State.Tests.Matrix
+		matrix.Selected = clampIndex(matrix.Selected+direction, len(matrix.Pythons()))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.TestsPanel && m.InputMode == InputModeNone {
+		m.State.Tests.Selected = clampIndex(m.State.Tests.Selected+direction, len(m.State.Tests.VisibleCases()))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.DiagnosticsPanel && m.InputMode == InputModeNone {
+		m.State.Diagnostics.Selected = clampIndex(m.State.Diagnostics.Selected+direction, len(m.State.Diagnostics.Visible()))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.CachePanel && m.State.Cache.Info != nil && m.InputMode == InputModeNone {
+		m.State.Cache.Selected = clampIndex(m.State.Cache.Selected+direction, len(m.State.Cache.Info.Packages))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.ToolsPanel && m.State.Tools.ShowHistory && m.InputMode == InputModeNone {
+		m.State.Tools.RunSelected = clampIndex(m.State.Tools.RunSelected+direction, len(m.State.Tools.History))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.ToolsPanel && m.InputMode == InputModeNone {
+		m.State.Tools.Selected = clampIndex(m.State.Tools.Selected+direction, len(m.State.Tools.Tools))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.EnvironmentPanel && m.InputMode == InputModeNone {
+		envs := &m.State.Environments
+		envs.Selected = clampIndex(envs.Selected+direction, len(envs.Environments))
+		return m, nil
+	}
+
+	if m.State.ActivePanel == types.StatusPanel && m.InputMode == InputModeNone {
+		m.State.UVBinaries.Selected = clampIndex(m.State.UVBinaries.Selected+direction, len(m.State.UVBinaries.Binaries))
+		return m, nil
+	}
+
 	if m.State.ActivePanel == types.PythonPanel || m.InputMode == InputModePythonVersion {
 		// Merge available and installed for display order
 		allVersions := panels.MergePythonVersions(m.State.PythonVersions.Available, m.State.PythonVersions.Installed)
@@ -156,16 +418,76 @@ func (m *Model) handleEnterKey() (tea.Model, tea.Cmd) {
 	if m.InputMode == InputModePythonVersion {
 		selectedVersion := m.GetSelectedPythonVersion()
 		if selectedVersion != nil {
-			m.SetOperation("init", m.State.ProjectState.Name, true)
 			m.AddMessage(fmt.Sprintf("Initializing new project '%s' with python %s...", m.State.ProjectState.Name, selectedVersion.Version))
 			options := types.InitOptions{PythonVersion: selectedVersion.Version}
-			return m, InitProject(m.ProjectManager, m.State.ProjectState.Name, options)
+			return m, m.EnqueueJob("init", m.State.ProjectState.Name, projectResources, InitProject(m.ProjectManager, m.State.ProjectState.Name, options))
 		}
-	} else if m.State.ActivePanel == types.PythonPanel && m.State.Installed && !m.State.Operation.InProgress {
+	} else if m.State.ActivePanel == types.PythonPanel && m.State.Installed {
 		if selectedVersion := m.GetSelectedPythonVersion(); selectedVersion != nil && !selectedVersion.Installed {
-			m.SetOperation("install", selectedVersion.Version, true)
-			m.AddMessage(fmt.Sprintf("Installing Python %s...", selectedVersion.Version))
-			return m, InstallPythonVersion(m.PythonManager, selectedVersion.Version)
+			request := selectedVersion.Request()
+			m.AddMessage(fmt.Sprintf("Installing Python %s...", request))
+			return m, m.EnqueueJob("install", request, pythonResources(request), InstallPythonVersion(m.PythonManager, request))
+		}
+	} else if m.State.ActivePanel == types.ProjectPanel && m.State.ProjectState.Lock.Visible {
+		m.State.ProjectState.Lock.Expanded = !m.State.ProjectState.Lock.Expanded
+	} else if m.treeViewActive() {
+		m.ToggleTreeNode()
+	} else if m.packagesViewActive() {
+		return m, m.togglePackageDetails()
+	} else if m.State.ActivePanel == types.EnvironmentPanel && m.State.Installed {
+		if env := m.GetSelectedEnvironment(); env != nil {
+			return m, m.openPackagesView(*env)
+		}
+	} else if m.State.ActivePanel == types.ToolsPanel && m.State.Tools.ShowHistory && m.State.Installed {
+		if run := m.GetSelectedToolRun(); run != nil {
+			return m, m.runTool(run.Options)
+		}
+	} else if m.State.ActivePanel == types.RunPanel && m.State.Run.Script.Visible && m.State.Installed {
+		return m.openRunForm(m.State.Run.Script.Path)
+	} else if m.State.ActivePanel == types.RunPanel && m.State.Run.ShowHistory && m.State.Installed {
+		if run := m.GetSelectedScriptRun(); run != nil {
+			return m, m.runScript(run.Options)
+		}
+	} else if m.State.ActivePanel == types.RunPanel && m.State.Installed {
+		if target := m.GetSelectedRunTarget(); target != nil {
+			return m.openRunForm(target.Name)
+		}
+	} else if m.State.ActivePanel == types.TasksPanel && m.State.Installed {
+		if task := m.GetSelectedTask(); task != nil {
+			name := task.Name
+			m.AddMessage(fmt.Sprintf("Running task %s...", name))
+			return m, m.EnqueueJob("task", name, projectResources, RunTask(m.TaskManager, name))
+		}
+	} else if m.State.ActivePanel == types.TestsPanel && m.State.Tests.Matrix.Visible && m.State.Installed {
+		if pythons := m.State.Tests.Matrix.Pythons(); len(pythons) > 0 {
+			return m, m.runTestMatrix(pythons)
+		}
+	} else if m.State.ActivePanel == types.TestsPanel && m.State.Installed {
+		if test := m.GetSelectedTestCase(); test != nil {
+			options := types.PytestOptions{Tests: []string{test.NodeID}, Args: m.State.Tests.Options.Args}
+			return m, m.runTests(options, test.NodeID, true)
+		}
+	} else if m.State.ActivePanel == types.DiagnosticsPanel {
+		if diagnostic := m.GetSelectedDiagnostic(); diagnostic != nil {
+			return m, OpenInEditor(m.CodeChecker, *diagnostic)
+		}
+	} else if m.State.ActivePanel == types.CachePanel {
+		if pkg := m.GetSelectedCachePackage(); pkg != nil {
+			cache := &m.State.Cache
+			if cache.Marked == nil {
+				cache.Marked = make(map[string]bool)
+			}
+			cache.Marked[pkg.Name] = !cache.Marked[pkg.Name]
+		}
+	} else if m.State.ActivePanel == types.JobsPanel {
+		if job := m.GetSelectedJob(); job != nil {
+			m.ShowJobOutput(job.ID)
+		}
+	} else if m.State.ActivePanel == types.StatusPanel {
+		if binary := m.GetSelectedUVBinary(); binary != nil {
+			m.SelectUV(binary.Path)
+			m.AddMessage(fmt.Sprintf("Using %s for this session", binary.Path))
+			return m, CheckUVStatus(m.newContext(uvContextKey, loadTimeoutOperation), m.UVInstaller)
 		}
 	}
 	return m, nil
@@ -173,11 +495,53 @@ func (m *Model) handleEnterKey() (tea.Model, tea.Cmd) {
 
 // handleDeleteKey handles delete key press.
 func (m *Model) handleDeleteKey() (tea.Model, tea.Cmd) {
-	if m.State.ActivePanel == types.PythonPanel && m.State.Installed && !m.State.Operation.InProgress {
+	if m.State.ActivePanel == types.PythonPanel && m.State.Installed {
 		if selectedVersion := m.GetSelectedPythonVersion(); selectedVersion != nil && selectedVersion.Installed && !selectedVersion.Current {
-			m.SetOperation("uninstall", selectedVersion.Version, true)
-			m.AddMessage(fmt.Sprintf("Uninstalling Python %s...", selectedVersion.Version))
-			return m, UninstallPythonVersion(m.PythonManager, selectedVersion.Version)
+			request := selectedVersion.Request()
+			m.AddMessage(fmt.Sprintf("Uninstalling Python %s...", request))
+			return m, m.EnqueueJob("uninstall", request, pythonResources(request), UninstallPythonVersion(m.PythonManager, request))
+		}
+	} else if m.State.ActivePanel == types.StatusPanel && m.State.Installed && m.State.Managed {
+		if path, ok := m.UVInstaller.ManagedPath(); ok {
+			m.Confirm(fmt.Sprintf("Uninstall uv from %s?", path), func() tea.Cmd {
+				m.AddMessage("Uninstalling UV...")
+				return m.EnqueueJob("uninstall", "uv", []string{"uv"}, UninstallUV(m.UVInstaller))
+			})
+		}
+	} else if m.State.ActivePanel == types.ProjectPanel && m.State.Installed {
+		if status := m.State.ProjectState.Status; status != nil && status.IsProject {
+			return m.openDependencyForm(true)
+		}
+	} else if m.State.ActivePanel == types.EnvironmentPanel && m.State.Installed {
+		if env := m.GetSelectedEnvironment(); env != nil {
+			m.confirmEnvironmentOperation("delete", *env)
+		}
+	} else if m.State.ActivePanel == types.ToolsPanel && m.State.Tools.ShowHistory {
+		m.State.Tools.RemoveToolRun(m.State.Tools.RunSelected)
+	} else if m.State.ActivePanel == types.CachePanel && m.State.Installed {
+		m.confirmCleanPackages()
+	} else if m.State.ActivePanel == types.RunPanel && m.State.Run.Script.Visible && m.State.Installed {
+		if dep := m.GetSelectedScriptDependency(); dep != nil {
+			script, name := m.State.Run.Script.Path, dep.Name
+			m.Confirm(fmt.Sprintf("Remove %s from %s?", name, script), func() tea.Cmd {
+				m.AddMessage(fmt.Sprintf("Removing %s from %s...", name, script))
+				return m.EnqueueJob("remove", script+" "+name, scriptResources(script), RemoveScriptDependency(m.ScriptManager, script, name))
+			})
+		}
+	} else if m.State.ActivePanel == types.RunPanel && m.State.Run.ShowHistory {
+		m.State.Run.RemoveRun(m.State.Run.RunSelected)
+	} else if m.State.ActivePanel == types.ToolsPanel && m.State.Installed {
+		if tool := m.GetSelectedTool(); tool != nil {
+			name := tool.Name
+			m.Confirm(fmt.Sprintf("Uninstall tool %s?", name), func() tea.Cmd {
+				m.AddMessage(fmt.Sprintf("Uninstalling %s...", name))
+				return m.EnqueueJob("uninstall", name, toolResources, UninstallTool(m.ToolManager, name))
+			})
+		}
+	} else if m.State.ActivePanel == types.JobsPanel {
+		if removed := m.Jobs.ClearFinished(); removed > 0 {
+			m.AddMessage(fmt.Sprintf("Cleared %d finished jobs", removed))
+			m.refreshJobs()
 		}
 	}
 	return m, nil
@@ -185,12 +549,18 @@ func (m *Model) handleDeleteKey() (tea.Model, tea.Cmd) {
 
 // handlePinKey handles pin key press.
 func (m *Model) handlePinKey() (tea.Model, tea.Cmd) {
-	if m.State.ActivePanel == types.PythonPanel && m.State.Installed && !m.State.Operation.InProgress {
+	if m.State.ActivePanel == types.PythonPanel && m.State.Installed {
 		if selectedVersion := m.GetSelectedPythonVersion(); selectedVersion != nil && selectedVersion.Installed {
-			m.SetOperation("pin", selectedVersion.Version, true)
-			m.AddMessage(fmt.Sprintf("Pinning Python %s...", selectedVersion.Version))
-			return m, PinPythonVersion(m.PythonManager, selectedVersion.Version)
+			request := selectedVersion.PinRequest()
+			m.AddMessage(fmt.Sprintf("Pinning Python %s...", request))
+			return m, m.EnqueueJob("pin", request, projectResources, PinPythonVersion(m.PythonManager, request))
 		}
+	} else if m.State.ActivePanel == types.StatusPanel {
+		if binary := m.GetSelectedUVBinary(); binary != nil {
+			return m.pinUVForProject(binary.Path)
+		}
+	} else if m.State.ActivePanel == types.CachePanel {
+		return m.handlePruneKey(false)
 	}
 	return m, nil
 }
@@ -202,21 +572,24 @@ func (m *Model) handleInstallRefresh() (tea.Model, tea.Cmd) {
 		if !m.State.Installed && !m.State.Installing {
 			m.State.Installing = true
 			m.AddMessage("Installing UV...")
-			return m, InstallUV(m.UVInstaller)
+			return m, m.EnqueueJob(installUVTimeoutOperation, "", []string{"uv"}, InstallUV(m.UVInstaller))
 		}
 	case types.PythonPanel:
 		if m.State.Installed && !m.State.PythonVersions.Loading {
 			m.State.PythonVersions.Loading = true
 			m.AddMessage("Refreshing Python versions...")
-			return m, LoadPythonVersions(m.PythonManager)
+			return m, LoadPythonVersions(m.loadContext(pythonContextKey), m.PythonManager)
+		}
+	case types.ToolsPanel:
+		if m.State.Installed {
+			return m.OpenForm(panels.NewToolForm())
 		}
 	case types.ProjectPanel:
 		// Initialize new project
-		if m.State.Installed && !m.State.Operation.InProgress {
+		if m.State.Installed {
 			if m.State.ProjectState.Status == nil || !m.State.ProjectState.Status.IsProject {
-				m.SetOperation("init", "", true)
 				m.AddMessage("Initializing new project...")
-				return m, InitProject(m.ProjectManager, "", types.InitOptions{})
+				return m, m.EnqueueJob("init", "", projectResources, InitProject(m.ProjectManager, "", types.InitOptions{}))
 			}
 		}
 	}
@@ -227,19 +600,72 @@ func (m *Model) handleInstallRefresh() (tea.Model, tea.Cmd) {
 func (m *Model) handleRefresh() (tea.Model, tea.Cmd) {
 	switch m.State.ActivePanel {
 	case types.StatusPanel:
+		if m.State.Installing {
+			return m, nil
+		}
 		m.AddMessage("Refreshing UV status...")
-		return m, CheckUVStatus(m.UVInstaller)
+		return m, tea.Batch(
+			CheckUVStatus(m.newContext(uvContextKey, loadTimeoutOperation), m.UVInstaller),
+			m.discoverUVBinaries(),
+		)
 	case types.PythonPanel:
 		if m.State.Installed && !m.State.PythonVersions.Loading {
 			m.State.PythonVersions.Loading = true
 			m.AddMessage("Refreshing Python versions...")
-			return m, LoadPythonVersions(m.PythonManager)
+			return m, LoadPythonVersions(m.loadContext(pythonContextKey), m.PythonManager)
 		}
 	case types.ProjectPanel:
 		if m.State.Installed && !m.State.ProjectState.Loading {
 			m.State.ProjectState.Loading = true
 			m.AddMessage("Refreshing project status...")
-			return m, LoadProjectStatus(m.ProjectManager)
+			return m, LoadProjectStatus(m.loadContext(projectContextKey), m.ProjectManager)
+		}
+	case types.RunPanel:
+		if m.State.Run.Script.Visible && m.State.Installed {
+			m.AddMessage("Reloading script metadata...")
+			return m, m.openScriptView(m.State.Run.Script.Path)
+		}
+		if m.State.Installed && !m.State.Run.Loading {
+			m.State.Run.Loading = true
+			m.AddMessage("Looking for scripts...")
+			return m, LoadRunTargets(m.loadContext(scriptsContextKey), m.ScriptManager)
+		}
+	case types.TasksPanel:
+		if m.State.Installed && !m.State.Tasks.Loading {
+			m.State.Tasks.Loading = true
+			m.AddMessage("Reading tasks...")
+			return m, LoadTasks(m.loadContext(tasksContextKey), m.TaskManager)
+		}
+	case types.TestsPanel:
+		if m.State.Installed && m.State.Tests.Matrix.Visible && !m.State.Tests.Matrix.Loading {
+			m.AddMessage("Reading the test matrix...")
+			return m, m.loadTestMatrix()
+		}
+	case types.DiagnosticsPanel:
+		if m.State.Installed {
+			return m, m.runChecks()
+		}
+	case types.CachePanel:
+		if m.State.Installed && !m.State.Cache.Loading {
+			m.State.Cache.Loading = true
+			m.AddMessage("Measuring the cache...")
+			return m, LoadCache(m.loadContext(cacheContextKey), m.CacheManager)
+		}
+	case types.ToolsPanel:
+		if m.State.Installed && !m.State.Tools.Loading {
+			m.State.Tools.Loading = true
+			m.AddMessage("Refreshing tools...")
+			return m, LoadTools(m.loadContext(toolsContextKey), m.ToolManager)
+		}
+	case types.EnvironmentPanel:
+		if m.packagesViewActive() {
+			m.AddMessage("Refreshing installed packages...")
+			return m, m.openPackagesView(m.State.Environments.Packages.Env)
+		}
+		if m.State.Installed && !m.State.Environments.Loading {
+			m.State.Environments.Loading = true
+			m.AddMessage("Refreshing virtual environments...")
+			return m, LoadEnvironments(m.loadContext(environmentsContextKey), m.EnvironmentManager)
 		}
 	}
 	return m, nil
@@ -262,21 +688,92 @@ func (m *Model) getCurrentPanelHelp() string {
 	case types.ProjectPanel:
 		return panels.GetProjectPanelHelp()
 	case types.EnvironmentPanel:
+		if m.State.Environments.Packages.Visible {
+			return panels.GetPackagesViewHelp()
+		}
 		return panels.GetEnvironmentPanelHelp()
+	case types.RunPanel:
+		if m.State.Run.Script.Visible {
+			return panels.GetScriptViewHelp()
+		}
+		if m.State.Run.ShowHistory {
+			return panels.GetRunHistoryHelp()
+		}
+		return panels.GetRunPanelHelp()
+	case types.TasksPanel:
+		return panels.GetTasksPanelHelp()
+	case types.TestsPanel:
+		if m.State.Tests.Matrix.Visible {
+			return panels.GetMatrixViewHelp()
+		}
+		return panels.GetTestsPanelHelp()
+	case types.DiagnosticsPanel:
+		return panels.GetDiagnosticsPanelHelp()
+	case types.ToolsPanel:
+		if m.State.Tools.ShowHistory {
+			return panels.GetToolRunsHelp()
+		}
+		return panels.GetToolsPanelHelp()
+	case types.CachePanel:
+		return panels.GetCachePanelHelp()
+	case types.JobsPanel:
+		return panels.GetJobsPanelHelp()
 	default:
 		return "q=quit, tab=next panel, shift+tab=prev panel"
 	}
 }
 
+// handleUpdateUVKey updates uv to the latest release, or asks for the version
+// to update to.
+func (m *Model) handleUpdateUVKey(askVersion bool) (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.ToolsPanel && m.State.Installed {
+		return m, m.upgradeTools(askVersion)
+	}
+	if m.State.ActivePanel != types.StatusPanel || !m.State.Installed {
+		return m, nil
+	}
+	if askVersion {
+		m.InputMode = InputModeUVVersion
+		m.TextInput.Reset()
+		m.TextInput.Placeholder = "uv version (e.g. 0.5.0)"
+		return m, nil
+	}
+	return m, m.updateUV("")
+}
+
+// updateUV queues `uv self update` to version, or to the latest release.
+func (m *Model) updateUV(version string) tea.Cmd {
+	if version == "" {
+		m.AddMessage("Updating UV to the latest release...")
+	} else {
+		m.AddMessage(fmt.Sprintf("Updating UV to %s...", version))
+	}
+	return m.EnqueueJob("update", strings.TrimSpace("uv "+version), []string{"uv"}, SelfUpdateUV(m.UVInstaller, version))
+}
+
 // handleUVInstalledMsg handles the message for when UV installation is complete.
 func (m *Model) handleUVInstalledMsg(msg ui.UVInstalledMsg) (tea.Model, tea.Cmd) {
 	m.State.Installing = false
 	if msg.Success {
 		m.State.Installed = true
 		m.State.Version = msg.Version
+		m.State.Managed = msg.Managed
+		m.State.Details, _ = services.ParseUVVersion(msg.Version)
+		if !m.State.Details.AtLeast(m.Config.MinUVVersion) {
+			m.AddMessage(fmt.Sprintf("Warning: uv %s is older than %s, the oldest version uvui supports", m.State.Details.Version, m.Config.MinUVVersion))
+		}
+		if msg.Path != "" {
+			m.State.Path = msg.Path
+			m.AddMessage(fmt.Sprintf("UV installed at %s", msg.Path))
+			// A uv installed outside PATH would not be found by the services.
+			if !services.DirOnPath(filepath.Dir(msg.Path)) {
+				m.SelectUV(msg.Path)
+				m.AddMessage(fmt.Sprintf("%s is not on PATH; using %s for this session", filepath.Dir(msg.Path), msg.Path))
+			}
+		}
 		m.AddMessage("UV installation completed successfully!")
 	} else if msg.Error != nil {
-		m.AddMessage(fmt.Sprintf("UV installation failed: %v", msg.Error))
+		m.AddMessage(describeFailure("install UV", msg.Error))
 	} else {
 		m.State.Installed = false
 		m.AddMessage("UV is not installed")
@@ -284,8 +781,115 @@ func (m *Model) handleUVInstalledMsg(msg ui.UVInstalledMsg) (tea.Model, tea.Cmd)
 	return m, nil
 }
 
+// discoverUVBinaries starts looking for the uv binaries on this machine.
+func (m *Model) discoverUVBinaries() tea.Cmd {
+	m.State.UVBinaries.Loading = true
+	return DiscoverUVBinaries(m.loadContext(uvBinariesContextKey), m.UVLocator)
+}
+
+// handleUVBinariesLoadedMsg handles the list of discovered uv binaries.
+func (m *Model) handleUVBinariesLoadedMsg(msg ui.UVBinariesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(uvBinariesContextKey)
+	m.State.UVBinaries.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(describeFailure("find uv binaries", msg.Error))
+		return m, nil
+	}
+	m.State.UVBinaries.Binaries = msg.Binaries
+	m.State.UVBinaries.Selected = clampIndex(m.State.UVBinaries.Selected, len(msg.Binaries))
+	return m, nil
+}
+
+// GetSelectedUVBinary returns the uv binary selected on the Status panel.
+func (m *Model) GetSelectedUVBinary() *types.UVBinary {
+	binaries := m.State.UVBinaries.Binaries
+	if m.State.UVBinaries.Selected < 0 || m.State.UVBinaries.Selected >= len(binaries) {
+		return nil
+	}
+	return &binaries[m.State.UVBinaries.Selected]
+}
+
+// handleTrustKey asks to trust the current project, so that uvui runs the uv
+// binaries it contains and the one its settings ask for.
+func (m *Model) handleTrustKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.StatusPanel {
+		return m, nil
+	}
+	if m.State.UVBinaries.ProjectTrusted {
+		m.AddMessage("The project is already trusted")
+		return m, nil
+	}
+	root := m.ProjectManager.ProjectRoot()
+	m.Confirm(fmt.Sprintf("Trust %s? uvui will run the uv binaries it contains.", root), func() tea.Cmd {
+		m.TrustProject()
+		m.AddMessage(fmt.Sprintf("Trusting %s for this session", root))
+		return tea.Batch(
+			CheckUVStatus(m.newContext(uvContextKey, loadTimeoutOperation), m.UVInstaller),
+			m.discoverUVBinaries(),
+		)
+	})
+	return m, nil
+}
+
+// pinUVForProject makes the current project use the uv binary at path, or
+// stops doing so if it already does.
+func (m *Model) pinUVForProject(path string) (tea.Model, tea.Cmd) {
+	root := m.ProjectManager.ProjectRoot()
+	settings, err := LoadProjectSettings(root)
+	if err != nil {
+		m.AddMessage(fmt.Sprintf("Failed to read %s: %v", projectSettingsFile, err))
+		return m, nil
+	}
+
+	if settings.UVPath == path {
+		settings.UVPath = ""
+	} else {
+		settings.UVPath = path
+	}
+	if err := SaveProjectSettings(root, settings); err != nil {
+		m.AddMessage(fmt.Sprintf("Failed to write %s: %v", projectSettingsFile, err))
+		return m, nil
+	}
+
+	m.State.UVBinaries.ProjectPath = settings.UVPath
+	m.SelectUV(settings.UVPath)
+	if settings.UVPath != "" {
+		// Choosing the project's uv is as good as trusting the project.
+		m.TrustProject()
+	}
+	if settings.UVPath == "" {
+		m.AddMessage("The project no longer pins a uv binary")
+	} else {
+		m.AddMessage(fmt.Sprintf("Using %s for this project", settings.UVPath))
+	}
+	return m, CheckUVStatus(m.newContext(uvContextKey, loadTimeoutOperation), m.UVInstaller)
+}
+
+// handleUVOperationMsg handles the result of a uv self-management operation
+// and refreshes the UV status.
+func (m *Model) handleUVOperationMsg(msg ui.UVOperationMsg) (tea.Model, tea.Cmd) {
+	if !msg.Success {
+		m.AddMessage(describeFailure(msg.Operation+" UV", msg.Error))
+		return m, nil
+	}
+
+	switch msg.Operation {
+	case "update":
+		m.AddMessage("UV updated successfully")
+	case "uninstall":
+		m.State.Installed = false
+		m.State.Managed = false
+		m.State.Version = ""
+		m.State.Path = ""
+		m.State.Details = types.UVVersion{}
+		m.AddMessage("UV uninstalled successfully")
+	}
+	return m, CheckUVStatus(m.newContext(uvContextKey, loadTimeoutOperation), m.UVInstaller)
+}
+
 // handlePythonVersionsLoadedMsg handles the message for when Python versions are loaded.
 func (m *Model) handlePythonVersionsLoadedMsg(msg ui.PythonVersionsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(pythonContextKey)
 	m.State.PythonVersions.Loading = false
 	if msg.Error != nil {
 		m.AddMessage(fmt.Sprintf("Failed to load Python versions: %v", msg.Error))
@@ -299,17 +903,22 @@ func (m *Model) handlePythonVersionsLoadedMsg(msg ui.PythonVersionsLoadedMsg) (t
 
 // handlePythonOperationMsg handles the message for when a Python operation is complete.
 func (m *Model) handlePythonOperationMsg(msg ui.PythonOperationMsg) (tea.Model, tea.Cmd) {
-	m.CompleteOperation(msg.Success, msg.Error)
-
 	if !msg.Success {
-		m.AddMessage(fmt.Sprintf("Failed to %s Python %s: %v", msg.Operation, msg.Target, msg.Error))
+		m.AddMessage(describeFailure(fmt.Sprintf("%s Python %s", msg.Operation, msg.Target), msg.Error))
 		return m, nil
 	}
 
 	m.AddMessage(fmt.Sprintf("Successfully %sed Python %s", msg.Operation, msg.Target))
 	// Reload Python versions after successful operation
 	m.State.PythonVersions.Loading = true
-	return m, LoadPythonVersions(m.PythonManager)
+	if msg.Operation == "pin" {
+		// The pinned version is read from the project status.
+		return m, tea.Batch(
+			LoadPythonVersions(m.loadContext(pythonContextKey), m.PythonManager),
+			LoadProjectStatus(m.loadContext(projectContextKey), m.ProjectManager),
+		)
+	}
+	return m, LoadPythonVersions(m.loadContext(pythonContextKey), m.PythonManager)
 }
 
 // View renders the application UI.
@@ -331,6 +940,17 @@ func (m *Model) View() string {
 		content = m.TextInput.View()
 	case InputModePythonVersion:
 		content = panels.RenderPythonPanel(m.State)
+	case InputModeUVVersion:
+		content = "Update uv to version (esc to cancel):\n\n" + m.TextInput.View()
+	case InputModeLockSearch:
+		content = m.renderActivePanel() + "\n" + m.TextInput.View()
+	case InputModeForm:
+		content = ui.ActivePanelStyle.Render(panels.RenderForm(m.State.Form))
+	case InputModeConfirm:
+		content = m.renderActivePanel()
+		if m.confirm != nil {
+			content += "\n" + ui.WarningMessageStyle.Render(m.confirm.prompt+" (y/n)")
+		}
 	default:
 		content = m.renderActivePanel()
 	}
@@ -339,22 +959,21 @@ func (m *Model) View() string {
 	statusBar := m.renderStatusBar()
 
 	// Help line
-	helpLine := ui.HelpStyle.Render("? for help | q to quit | tab to navigate")
+	helpLine := ui.HelpStyle.Render("? for help | q to quit | tab to navigate | o to toggle output | x to cancel")
+
+	sections := []string{header, tabs, content}
+	if m.State.Output.Visible {
+		sections = append(sections, panels.RenderOutputPane(m.State.Output, m.outputHeight()))
+	}
+	sections = append(sections, statusBar, helpLine)
 
 	// Combine all elements
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		tabs,
-		content,
-		statusBar,
-		helpLine,
-	)
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
 // renderTabs renders the navigation tabs.
 func (m *Model) renderTabs() string {
-	tabNames := []string{"Status", "Python", "Project", "Environment"}
+	tabNames := []string{"Status", "Python", "Project", "Environment", "Run", "Tasks", "Tests", "Diagnostics", "Tools", "Cache", "Jobs"}
 	var tabs []string
 
 	for i, name := range tabNames {
@@ -387,6 +1006,21 @@ func (m *Model) renderActivePanel() string {
 		content = panels.RenderProjectPanel(m.State)
 	case types.EnvironmentPanel:
 		content = panels.RenderEnvironmentPanel(m.State)
+	case types.RunPanel:
+		content = panels.RenderRunPanel(m.State)
+	case types.TasksPanel:
+		content = panels.RenderTasksPanel(m.State)
+	case types.TestsPanel:
+		content = panels.RenderTestsPanel(m.State)
+	case types.DiagnosticsPanel:
+		content = panels.RenderDiagnosticsPanel(m.State)
+	case types.ToolsPanel:
+		content = panels.RenderToolsPanel(m.State)
+	case types.CachePanel:
+		content = panels.RenderCachePanel(m.State)
+	case types.JobsPanel:
+		style = ui.ActivePanelStyle
+		content = panels.RenderJobsPanel(m.State)
 	default:
 		content = "Unknown panel"
 	}
@@ -411,11 +1045,17 @@ func (m *Model) renderStatusBar() string {
 
 // handleSyncKey handles sync key press.
 func (m *Model) handleSyncKey() (tea.Model, tea.Cmd) {
-	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed && !m.State.Operation.InProgress {
+	if m.State.ActivePanel == types.ToolsPanel && m.State.Installed {
+		m.Confirm("Add the tool directory to PATH in your shell configuration (uv tool update-shell)?", func() tea.Cmd {
+			m.AddMessage("Updating shell configuration...")
+			return m.EnqueueJob("update-shell", "", []string{"shell"}, UpdateShell(m.ToolManager))
+		})
+		return m, nil
+	}
+	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed {
 		if m.State.ProjectState.Status != nil && m.State.ProjectState.Status.IsProject {
-			m.SetOperation("sync", "", true)
 			m.AddMessage("Syncing project dependencies...")
-			return m, SyncProject(m.ProjectManager)
+			return m, m.EnqueueJob("sync", "", syncResources, SyncProject(m.ProjectManager))
 		}
 	}
 	return m, nil
@@ -423,24 +1063,77 @@ func (m *Model) handleSyncKey() (tea.Model, tea.Cmd) {
 
 // handleLockOrLibKey handles lock/lib key press.
 func (m *Model) handleLockOrLibKey() (tea.Model, tea.Cmd) {
-	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed && !m.State.Operation.InProgress {
+	if m.State.ActivePanel == types.RunPanel && m.State.Run.Script.Visible && m.State.Installed {
+		script := m.State.Run.Script.Path
+		m.AddMessage(fmt.Sprintf("Locking %s...", script))
+		return m, m.EnqueueJob("lock", script, scriptResources(script), LockScript(m.ScriptManager, script))
+	}
+	if m.State.ActivePanel == types.RunPanel && m.State.Run.ShowHistory && m.State.Installed {
+		if run := m.GetSelectedScriptRun(); run != nil {
+			return m.OpenForm(panels.NewRunForm(run.Options))
+		}
+		return m, nil
+	}
+	if m.State.ActivePanel == types.ToolsPanel && m.State.Installed {
+		var options types.ToolRunOptions
+		if run := m.GetSelectedToolRun(); run != nil && m.State.Tools.ShowHistory {
+			options = run.Options
+		}
+		return m.OpenForm(panels.NewToolRunForm(options))
+	}
+	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed {
 		if m.State.ProjectState.Status != nil && m.State.ProjectState.Status.IsProject {
 			// Lock dependencies if in project
-			m.SetOperation("lock", "", true)
 			m.AddMessage("Locking project dependencies...")
-			return m, LockProject(m.ProjectManager)
+			return m, m.EnqueueJob("lock", "", projectResources, LockProject(m.ProjectManager))
 		}
 		// Initialize as library if not in project
-		m.SetOperation("init", "library", true)
 		m.AddMessage("Initializing library project...")
-		return m, InitProject(m.ProjectManager, "", types.InitOptions{Lib: true})
+		return m, m.EnqueueJob("init", "library", projectResources, InitProject(m.ProjectManager, "", types.InitOptions{Lib: true}))
+
+	}
+	return m, nil
+}
 
+// handleBackKey closes the package inspector or the script view.
+func (m *Model) handleBackKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.RunPanel && m.State.Run.Script.Visible {
+		m.closeScriptView()
+	}
+	if m.State.ActivePanel == types.TestsPanel {
+		m.State.Tests.Matrix.Visible = false
+	}
+	if m.packagesViewActive() {
+		m.State.Environments.Packages.Visible = false
+		m.releaseContext(packagesContextKey)
+		m.releaseContext(packageContextKey)
+		m.releaseContext(freezeContextKey)
 	}
 	return m, nil
 }
 
 // handleToggleKey handles toggle key press.
 func (m *Model) handleToggleKey() (tea.Model, tea.Cmd) {
+	if m.packagesViewActive() {
+		return m, m.togglePackageFreeze()
+	}
+	if m.State.ActivePanel == types.ToolsPanel {
+		m.State.Tools.ShowHistory = !m.State.Tools.ShowHistory
+		return m, nil
+	}
+	if m.State.ActivePanel == types.RunPanel && !m.State.Run.Script.Visible {
+		m.State.Run.ShowHistory = !m.State.Run.ShowHistory
+		return m, nil
+	}
+	if m.State.ActivePanel == types.TasksPanel {
+		m.State.Tasks.ShowLog = !m.State.Tasks.ShowLog
+		return m, nil
+	}
+	if m.State.ActivePanel == types.TestsPanel && !m.State.Tests.Matrix.Visible {
+		m.State.Tests.ShowAll = !m.State.Tests.ShowAll
+		m.State.Tests.Selected = 0
+		return m, nil
+	}
 	if m.State.ActivePanel == types.ProjectPanel {
 		if m.State.ProjectState.Status != nil && m.State.ProjectState.Status.IsProject {
 			m.ToggleTreeView()
@@ -450,23 +1143,36 @@ func (m *Model) handleToggleKey() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleAppKey handles app key press.
+// handleAppKey initializes an app project, or opens the add dependency dialog
+// when there already is a project. In the script view it adds a dependency to
+// the script instead.
 func (m *Model) handleAppKey() (tea.Model, tea.Cmd) {
-	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed && !m.State.Operation.InProgress {
+	if m.State.ActivePanel == types.RunPanel && m.State.Run.Script.Visible && m.State.Installed {
+		return m.OpenForm(panels.NewScriptDependencyForm(m.State.Run.Script.Path))
+	}
+	if m.State.ActivePanel == types.TestsPanel && !m.State.Tests.Matrix.Visible && m.State.Installed {
+		return m.OpenForm(panels.NewPytestForm(m.State.Tests.Options))
+	}
+	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed {
 		if m.State.ProjectState.Status == nil || !m.State.ProjectState.Status.IsProject {
-			m.SetOperation("init", "app", true)
 			m.AddMessage("Initializing app project...")
-			return m, InitProject(m.ProjectManager, "", types.InitOptions{App: true})
+			return m, m.EnqueueJob("init", "app", projectResources, InitProject(m.ProjectManager, "", types.InitOptions{App: true}))
 		}
+		return m.openDependencyForm(false)
 	}
 	return m, nil
 }
 
-// handleNewProjectKey handles new project key press.
+// handleNewProjectKey handles new project key press. On the Environment panel
+// it opens the create environment dialog instead.
 func (m *Model) handleNewProjectKey() (tea.Model, tea.Cmd) {
-	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed && !m.State.Operation.InProgress {
+	if m.State.ActivePanel == types.EnvironmentPanel && m.State.Installed {
+		return m.openVenvForm()
+	}
+	if m.State.ActivePanel == types.ProjectPanel && m.State.Installed {
 		if m.State.ProjectState.Status == nil || !m.State.ProjectState.Status.IsProject {
 			m.InputMode = InputModeProjectName
+			m.TextInput.Placeholder = "Project Name"
 			m.AddMessage("Please enter the project name:")
 			return m, textinput.Blink
 		}
@@ -476,6 +1182,7 @@ func (m *Model) handleNewProjectKey() (tea.Model, tea.Cmd) {
 
 // handleProjectStatusLoadedMsg handles the message for when project status is loaded.
 func (m *Model) handleProjectStatusLoadedMsg(msg ui.ProjectStatusLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(projectContextKey)
 	m.UpdateProjectStatus(msg.Status)
 	if msg.Error != nil {
 		m.AddMessage(fmt.Sprintf("Error loading project status: %s", msg.Error))
@@ -486,7 +1193,7 @@ func (m *Model) handleProjectStatusLoadedMsg(msg ui.ProjectStatusLoadedMsg) (tea
 
 	// If we have a project, load dependencies
 	if msg.Status != nil && msg.Status.IsProject {
-		return m, LoadProjectDependencies(m.ProjectManager)
+		return m, LoadProjectDependencies(m.loadContext(dependencyContextKey), m.ProjectManager)
 	}
 
 	return m, nil
@@ -494,6 +1201,7 @@ func (m *Model) handleProjectStatusLoadedMsg(msg ui.ProjectStatusLoadedMsg) (tea
 
 // handleProjectDependenciesLoadedMsg handles the message for when project dependencies are loaded.
 func (m *Model) handleProjectDependenciesLoadedMsg(msg ui.ProjectDependenciesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(dependencyContextKey)
 	m.UpdateProjectDependencies(msg.Dependencies, msg.Tree)
 	if msg.Error != nil {
 		m.AddMessage(fmt.Sprintf("Error loading dependencies: %s", msg.Error))
@@ -505,29 +1213,1153 @@ func (m *Model) handleProjectDependenciesLoadedMsg(msg ui.ProjectDependenciesLoa
 
 // handleProjectOperationMsg handles the message for when a project operation is complete.
 func (m *Model) handleProjectOperationMsg(msg ui.ProjectOperationMsg) (tea.Model, tea.Cmd) {
-	m.CompleteOperation(msg.Success, msg.Error)
-
 	if !msg.Success {
-		m.AddMessage(fmt.Sprintf("Failed to %s: %v", msg.Operation, msg.Error))
+		m.AddMessage(describeFailure(msg.Operation, msg.Error))
 		return m, nil
 	}
 
-	if msg.Operation == "init" && msg.ProjectDir != "" && msg.ProjectDir != "." {
-		err := os.Chdir(msg.ProjectDir)
-		if err != nil {
-			m.AddMessage(fmt.Sprintf("Failed to change directory to %s: %v", msg.ProjectDir, err))
-			return m, nil
-		}
-		m.AddMessage(fmt.Sprintf("Changed directory to %s", msg.ProjectDir))
+	if msg.Operation == "init" && msg.ProjectDir != "" && msg.ProjectDir != m.ProjectManager.ProjectRoot() {
+		m.SetProjectRoot(msg.ProjectDir)
+		m.AddMessage(fmt.Sprintf("Switched project to %s", msg.ProjectDir))
 	}
 
 	m.AddMessage(fmt.Sprintf("Successfully completed %s operation", msg.Operation))
-	// Reload project status and dependencies after successful operations
+	return m, m.reloadProject()
+}
+
+// reloadProject reloads the project status and dependencies, and the lockfile
+// if it is being browsed, after an operation changed the project.
+func (m *Model) reloadProject() tea.Cmd {
 	m.State.ProjectState.Loading = true
-	return m, tea.Batch(
-		LoadProjectStatus(m.ProjectManager),
-		LoadProjectDependencies(m.ProjectManager),
-	)
+	cmds := []tea.Cmd{
+		LoadProjectStatus(m.loadContext(projectContextKey), m.ProjectManager),
+		LoadProjectDependencies(m.loadContext(dependencyContextKey), m.ProjectManager),
+	}
+	if m.State.ProjectState.Lock.Visible {
+		m.State.ProjectState.Lock.Loading = true
+		cmds = append(cmds, LoadLockFile(m.loadContext(lockContextKey), m.ProjectManager))
+	}
+	return tea.Batch(cmds...)
+}
+
+// openDependencyForm opens the add (or remove) dependency dialog, as it was
+// last left.
+func (m *Model) openDependencyForm(remove bool) (tea.Model, tea.Cmd) {
+	form := m.State.ProjectState.DependencyForm
+	if form.ID == "" || (form.ID == panels.RemoveDependencyForm) != remove {
+		form = panels.NewDependencyForm(remove)
+	}
+	form.Focus = 0
+	form.Error = ""
+	form.Details = nil
+	return m.OpenForm(form)
+}
+
+// submitDependencyForm queues uv add or uv remove for the dialog.
+func (m *Model) submitDependencyForm(form *panels.FormState) tea.Cmd {
+	pkg := form.Value(panels.DependencyFieldPackage)
+	target := form.Value(panels.DependencyFieldTarget)
+	if pkg == "" {
+		form.Error = "Enter a package name, path or git URL"
+		return nil
+	}
+	if (target == "optional" || target == "group") && form.Value(panels.DependencyFieldGroup) == "" {
+		form.Error = "Enter the name of the group"
+		if target == "optional" {
+			form.Error = "Enter the name of the extra"
+		}
+		return nil
+	}
+
+	options := types.DependencyOptions{
+		Target:   target,
+		Group:    form.Value(panels.DependencyFieldGroup),
+		Editable: form.Checked(panels.DependencyFieldEditable),
+		Frozen:   form.Checked(panels.DependencyFieldFrozen),
+		NoSync:   form.Checked(panels.DependencyFieldNoSync),
+	}
+	resources := syncResources
+	if options.Frozen || options.NoSync {
+		resources = projectResources
+	}
+	m.CloseForm()
+
+	if form.ID == panels.RemoveDependencyForm {
+		m.AddMessage(fmt.Sprintf("Removing %s...", pkg))
+		return m.EnqueueJob("remove", pkg, resources, RemoveDependency(m.ProjectManager, pkg, options))
+	}
+	requirement := panels.DependencyRequirement(form)
+	m.AddMessage(fmt.Sprintf("Adding %s...", requirement))
+	return m.EnqueueJob("add", requirement, resources, AddDependency(m.ProjectManager, requirement, options))
+}
+
+// handleDependencyOperationMsg handles the result of uv add or uv remove. A
+// failure reopens the dialog with uv's explanation so the entry can be fixed.
+func (m *Model) handleDependencyOperationMsg(msg ui.DependencyOperationMsg) (tea.Model, tea.Cmd) {
+	if !msg.Success {
+		action := fmt.Sprintf("%s %s", msg.Operation, msg.Package)
+		m.AddMessage(describeFailure(action, msg.Error))
+
+		form := m.State.ProjectState.DependencyForm
+		if (form.ID == panels.RemoveDependencyForm) != (msg.Operation == "remove") || errors.Is(msg.Error, context.Canceled) {
+			return m, nil
+		}
+		form.Error = describeFailure(action, msg.Error)
+		form.Details = msg.Details
+		m.State.ProjectState.DependencyForm = form
+		if m.InputMode == InputModeNone && m.State.ActivePanel == types.ProjectPanel {
+			return m.OpenForm(form)
+		}
+		return m, nil
+	}
+
+	verb := "Added"
+	if msg.Operation == "remove" {
+		verb = "Removed"
+	}
+	m.AddMessage(fmt.Sprintf("%s %s", verb, msg.Package))
+	return m, m.reloadProject()
+}
+
+// handleEnvironmentsLoadedMsg handles the virtual environments found for the
+// project.
+func (m *Model) handleEnvironmentsLoadedMsg(msg ui.EnvironmentsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(environmentsContextKey)
+	m.State.Environments.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to list virtual environments: %v", msg.Error))
+		return m, nil
+	}
+	m.UpdateEnvironments(msg.Environments)
+	return m, nil
+}
+
+// openVenvForm opens the create environment dialog, suggesting the project's
+// Python version.
+func (m *Model) openVenvForm() (tea.Model, tea.Cmd) {
+	python := ""
+	if status := m.State.ProjectState.Status; status != nil {
+		python = status.PythonVersion
+	}
+	return m.OpenForm(panels.NewVenvForm(python))
+}
+
+// submitVenvForm queues uv venv for the create environment dialog. Existing
+// environments are not replaced; they are recreated with R.
+func (m *Model) submitVenvForm(form *panels.FormState) tea.Cmd {
+	options := panels.VenvFormOptions(form)
+	path := options.Path
+	if path == "" {
+		path = ".venv"
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.EnvironmentManager.ProjectRoot(), path)
+	}
+	path, _ = filepath.Abs(path)
+	for _, env := range m.State.Environments.Environments {
+		if env.Path == path {
+			form.Error = fmt.Sprintf("%s already exists; press R on the Environment panel to recreate it", env.Name)
+			return nil
+		}
+	}
+
+	projectEnv, _ := m.EnvironmentManager.ProjectEnvironment()
+	project := path == projectEnv
+	m.CloseForm()
+	m.AddMessage(fmt.Sprintf("Creating virtual environment %s...", path))
+	return m.EnqueueJob("create", path, environmentResources(path, project), CreateEnvironment(m.EnvironmentManager, options, project))
+}
+
+// handleRecreateKey recreates the selected virtual environment.
+func (m *Model) handleRecreateKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.EnvironmentPanel && m.State.Installed {
+		if env := m.GetSelectedEnvironment(); env != nil {
+			m.confirmEnvironmentOperation("recreate", *env)
+		}
+	}
+	return m, nil
+}
+
+// confirmEnvironmentOperation asks before deleting or recreating env, which
+// discards everything installed in it.
+func (m *Model) confirmEnvironmentOperation(operation string, env types.VirtualEnv) {
+	prompt := fmt.Sprintf("Delete virtual environment %s?", env.Name)
+	progress := fmt.Sprintf("Deleting %s...", env.Name)
+	job := DeleteEnvironment(m.EnvironmentManager, env)
+	if operation == "recreate" {
+		prompt = fmt.Sprintf("Recreate virtual environment %s? Installed packages are removed.", env.Name)
+		progress = fmt.Sprintf("Recreating %s...", env.Name)
+		job = RecreateEnvironment(m.EnvironmentManager, env)
+	}
+	if env.Active {
+		prompt += " It is the active environment."
+	}
+	m.Confirm(prompt, func() tea.Cmd {
+		m.AddMessage(progress)
+		return m.EnqueueJob(operation, env.Name, environmentResources(env.Path, env.Project), job)
+	})
+}
+
+// handleEnvironmentOperationMsg handles the result of an environment
+// operation and looks for environments again. Changes to the project
+// environment also reload the project status.
+func (m *Model) handleEnvironmentOperationMsg(msg ui.EnvironmentOperationMsg) (tea.Model, tea.Cmd) {
+	if !msg.Success {
+		m.AddMessage(describeFailure(fmt.Sprintf("%s virtual environment %s", msg.Operation, msg.Target), msg.Error))
+	} else {
+		m.AddMessage(fmt.Sprintf("Successfully completed %s of %s", msg.Operation, msg.Target))
+	}
+
+	m.State.Environments.Loading = true
+	cmds := []tea.Cmd{LoadEnvironments(m.loadContext(environmentsContextKey), m.EnvironmentManager)}
+	if msg.Project && m.State.ProjectState.Status != nil && m.State.ProjectState.Status.IsProject {
+		cmds = append(cmds, m.reloadProject())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// packagesViewActive reports whether keys go to the package inspector.
+func (m *Model) packagesViewActive() bool {
+	return m.State.ActivePanel == types.EnvironmentPanel && m.InputMode == InputModeNone &&
+		m.State.Environments.Packages.Visible
+}
+
+// openPackagesView opens the package inspector for env, or reloads it.
+func (m *Model) openPackagesView(env types.VirtualEnv) tea.Cmd {
+	view := &m.State.Environments.Packages
+	if !view.Visible || view.Env.Path != env.Path {
+		*view = panels.PackagesViewState{}
+	}
+	view.Visible = true
+	view.Loading = true
+	view.Env = env
+	view.Details = nil
+	view.Freeze = nil
+	if view.ShowFreeze {
+		view.FreezeLoading = true
+		return tea.Batch(
+			LoadInstalledPackages(m.loadContext(packagesContextKey), m.EnvironmentManager, env.Interpreter),
+			LoadPackageFreeze(m.loadContext(freezeContextKey), m.EnvironmentManager, env.Interpreter),
+		)
+	}
+	return LoadInstalledPackages(m.loadContext(packagesContextKey), m.EnvironmentManager, env.Interpreter)
+}
+
+// movePackageSelection moves the package inspector's cursor, loading the
+// details of the new selection if they are shown.
+func (m *Model) movePackageSelection(direction int) tea.Cmd {
+	view := &m.State.Environments.Packages
+	if view.ShowFreeze {
+		view.Selected = clampIndex(view.Selected+direction, len(view.Freeze))
+		return nil
+	}
+	previous := view.Selected
+	view.Selected = clampIndex(view.Selected+direction, len(view.Packages))
+	if view.Expanded && view.Selected != previous {
+		return m.loadPackageDetails()
+	}
+	return nil
+}
+
+// togglePackageDetails shows or hides the metadata and files of the selected
+// package.
+func (m *Model) togglePackageDetails() tea.Cmd {
+	view := &m.State.Environments.Packages
+	if view.ShowFreeze || view.Loading {
+		return nil
+	}
+	view.Expanded = !view.Expanded
+	if view.Expanded {
+		return m.loadPackageDetails()
+	}
+	return nil
+}
+
+// loadPackageDetails loads the details of the selected package unless they
+// are already loaded.
+func (m *Model) loadPackageDetails() tea.Cmd {
+	view := &m.State.Environments.Packages
+	pkg := m.GetSelectedInstalledPackage()
+	if pkg == nil || (view.Details != nil && view.Details.Name == pkg.Name) {
+		return nil
+	}
+	view.DetailsLoading = true
+	return LoadPackageDetails(m.loadContext(packageContextKey), m.EnvironmentManager, view.Env.Interpreter, pkg.Name)
+}
+
+// togglePackageFreeze switches the package inspector between the package list
+// and the output of uv pip freeze.
+func (m *Model) togglePackageFreeze() tea.Cmd {
+	view := &m.State.Environments.Packages
+	view.ShowFreeze = !view.ShowFreeze
+	view.Selected = 0
+	if view.ShowFreeze && view.Freeze == nil && !view.FreezeLoading {
+		view.FreezeLoading = true
+		return LoadPackageFreeze(m.loadContext(freezeContextKey), m.EnvironmentManager, view.Env.Interpreter)
+	}
+	return nil
+}
+
+// handleInstalledPackagesLoadedMsg handles the packages listed for the
+// package inspector.
+func (m *Model) handleInstalledPackagesLoadedMsg(msg ui.InstalledPackagesLoadedMsg) (tea.Model, tea.Cmd) {
+	view := &m.State.Environments.Packages
+	if !view.Visible || msg.Python != view.Env.Interpreter {
+		return m, nil
+	}
+	m.releaseContext(packagesContextKey)
+	view.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to list installed packages: %v", msg.Error))
+		return m, nil
+	}
+	if msg.CheckError != nil {
+		m.AddMessage(fmt.Sprintf("Failed to check installed packages: %v", msg.CheckError))
+	}
+
+	view.Packages = msg.Packages
+	view.Issues = msg.Issues
+	if !view.ShowFreeze {
+		view.Selected = clampIndex(view.Selected, len(view.Packages))
+	}
+	if len(msg.Issues) > 0 {
+		m.AddMessage(fmt.Sprintf("Found %d broken requirements in %s", len(msg.Issues), view.Env.Name))
+	}
+	if view.Expanded && !view.ShowFreeze {
+		return m, m.loadPackageDetails()
+	}
+	return m, nil
+}
+
+// handlePackageDetailsLoadedMsg handles the metadata and files of the selected
+// package.
+func (m *Model) handlePackageDetailsLoadedMsg(msg ui.PackageDetailsLoadedMsg) (tea.Model, tea.Cmd) {
+	view := &m.State.Environments.Packages
+	if !view.Visible || msg.Python != view.Env.Interpreter {
+		return m, nil
+	}
+	m.releaseContext(packageContextKey)
+	view.DetailsLoading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to load package details: %v", msg.Error))
+		return m, nil
+	}
+	view.Details = msg.Details
+	return m, nil
+}
+
+// handlePackageFreezeLoadedMsg handles the output of uv pip freeze.
+func (m *Model) handlePackageFreezeLoadedMsg(msg ui.PackageFreezeLoadedMsg) (tea.Model, tea.Cmd) {
+	view := &m.State.Environments.Packages
+	if !view.Visible || msg.Python != view.Env.Interpreter {
+		return m, nil
+	}
+	m.releaseContext(freezeContextKey)
+	view.FreezeLoading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to freeze packages: %v", msg.Error))
+		return m, nil
+	}
+	view.Freeze = msg.Lines
+	return m, nil
+}
+
+// handleToolsLoadedMsg handles the installed tools.
+func (m *Model) handleToolsLoadedMsg(msg ui.ToolsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(toolsContextKey)
+	m.State.Tools.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to load tools: %v", msg.Error))
+		return m, nil
+	}
+
+	m.State.Tools.Tools = msg.Tools
+	m.State.Tools.Selected = clampIndex(m.State.Tools.Selected, len(msg.Tools))
+	m.State.Tools.BinDir = msg.BinDir
+	m.State.Tools.OnPath = services.DirOnPath(msg.BinDir)
+	if !m.State.Tools.OnPath {
+		m.AddMessage(fmt.Sprintf("Tool directory %s is not on PATH", msg.BinDir))
+	}
+	return m, nil
+}
+
+// submitToolForm queues uv tool install for the install tool dialog.
+func (m *Model) submitToolForm(form *panels.FormState) tea.Cmd {
+	options := panels.ToolFormOptions(form)
+	if options.Package == "" {
+		form.Error = "Enter a package name, path or git URL"
+		return nil
+	}
+	m.CloseForm()
+	m.AddMessage(fmt.Sprintf("Installing %s...", options.Package))
+	return m.EnqueueJob("install", options.Package, toolResources, InstallTool(m.ToolManager, options))
+}
+
+// upgradeTools upgrades the selected tool, or all tools.
+func (m *Model) upgradeTools(all bool) tea.Cmd {
+	if all {
+		m.AddMessage("Upgrading all tools...")
+		return m.EnqueueJob("upgrade", "all tools", toolResources, UpgradeTool(m.ToolManager, ""))
+	}
+	tool := m.GetSelectedTool()
+	if tool == nil {
+		return nil
+	}
+	m.AddMessage(fmt.Sprintf("Upgrading %s...", tool.Name))
+	return m.EnqueueJob("upgrade", tool.Name, toolResources, UpgradeTool(m.ToolManager, tool.Name))
+}
+
+// handleRunTargetsLoadedMsg handles the entry points and Python files of the
+// project.
+func (m *Model) handleRunTargetsLoadedMsg(msg ui.RunTargetsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(scriptsContextKey)
+	m.State.Run.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to look for scripts: %v", msg.Error))
+		return m, nil
+	}
+
+	m.State.Run.Targets = msg.Targets
+	m.State.Run.Selected = clampIndex(m.State.Run.Selected, len(msg.Targets))
+	return m, nil
+}
+
+// submitRunForm runs the target entered in the run dialog.
+func (m *Model) submitRunForm(form *panels.FormState) tea.Cmd {
+	options := panels.RunFormOptions(form)
+	if options.Target == "" {
+		form.Error = "Enter an entry point or file to run"
+		return nil
+	}
+	for _, entry := range options.Env {
+		if key, _, ok := strings.Cut(entry, "="); !ok || key == "" {
+			form.Error = fmt.Sprintf("Environment entries must look like KEY=VALUE, not %q", entry)
+			return nil
+		}
+	}
+	m.CloseForm()
+	return m.runScript(options)
+}
+
+// openRunForm opens the run dialog for target, filled in from its last run.
+func (m *Model) openRunForm(target string) (tea.Model, tea.Cmd) {
+	options := types.RunOptions{Target: target}
+	if last, ok := m.State.Run.LastRun(target); ok {
+		options = last.Options
+	}
+	return m.OpenForm(panels.NewRunForm(options))
+}
+
+// runScript runs a target with uv run as a job whose output is shown in the
+// output pane.
+func (m *Model) runScript(options types.RunOptions) tea.Cmd {
+	m.AddMessage(fmt.Sprintf("Running %s...", options.Target))
+	return m.EnqueueJob("run", panels.JoinArgs(options.RunArgs()), projectResources, RunScript(m.ScriptManager, options))
+}
+
+// handleScriptRunMsg records a finished run in the history.
+func (m *Model) handleScriptRunMsg(msg ui.ScriptRunMsg) (tea.Model, tea.Cmd) {
+	run := msg.Run
+	m.State.Run.AddRun(run)
+
+	switch {
+	case run.Error == nil:
+		m.AddMessage(fmt.Sprintf("%s finished in %s", run.Options.Target, run.Duration.Round(100*time.Millisecond)))
+	case run.ExitCode > 0:
+		m.AddMessage(fmt.Sprintf("%s exited with status %d after %s", run.Options.Target, run.ExitCode, run.Duration.Round(100*time.Millisecond)))
+	default:
+		m.AddMessage(describeFailure("run "+run.Options.Target, run.Error))
+	}
+	return m, nil
+}
+
+// handleTasksLoadedMsg handles the tasks read from pyproject.toml.
+func (m *Model) handleTasksLoadedMsg(msg ui.TasksLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(tasksContextKey)
+	m.State.Tasks.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to read tasks: %v", msg.Error))
+		return m, nil
+	}
+	m.State.Tasks.Tasks = msg.Tasks
+	m.State.Tasks.Selected = clampIndex(m.State.Tasks.Selected, len(msg.Tasks))
+	return m, nil
+}
+
+// handleTaskRunMsg records the results of a task run.
+func (m *Model) handleTaskRunMsg(msg ui.TaskRunMsg) (tea.Model, tea.Cmd) {
+	m.State.Tasks.AddResults(msg.Results)
+
+	var duration time.Duration
+	for _, result := range msg.Results {
+		duration += result.Duration
+	}
+	duration = duration.Round(100 * time.Millisecond)
+
+	if msg.Error == nil {
+		m.AddMessage(fmt.Sprintf("Task %s finished in %s", msg.Task, duration))
+		return m, nil
+	}
+	if n := len(msg.Results); n > 0 && msg.Results[n-1].ExitCode > 0 {
+		failed := msg.Results[n-1]
+		m.AddMessage(fmt.Sprintf("Task %s stopped: %s exited with status %d after %s", msg.Task, failed.Task, failed.ExitCode, duration))
+		return m, nil
+	}
+	m.AddMessage(describeFailure("run task "+msg.Task, msg.Error))
+	return m, nil
+}
+
+// handleRerunFailedKey reruns the tests that failed in the last run, or the
+// matrix with the Pythons it failed with.
+func (m *Model) handleRerunFailedKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.TestsPanel && m.State.Tests.Matrix.Visible && m.State.Installed {
+		failed := m.State.Tests.Matrix.Failed()
+		if len(failed) == 0 {
+			m.AddMessage("No failed Pythons to rerun")
+			return m, nil
+		}
+		return m, m.runTestMatrix(failed)
+	}
+	if m.State.ActivePanel != types.TestsPanel || !m.State.Installed || m.State.Tests.Report == nil {
+		return m, nil
+	}
+	failures := m.State.Tests.Report.Failures()
+	if len(failures) == 0 {
+		m.AddMessage("No failed tests to rerun")
+		return m, nil
+	}
+	options := types.PytestOptions{Args: m.State.Tests.Options.Args}
+	for _, test := range failures {
+		options.Tests = append(options.Tests, test.NodeID)
+	}
+	return m, m.runTests(options, fmt.Sprintf("%d failed", len(failures)), true)
+}
+
+// submitPytestForm runs the tests entered in the run tests dialog.
+func (m *Model) submitPytestForm(form *panels.FormState) tea.Cmd {
+	options := panels.PytestFormOptions(form)
+	m.CloseForm()
+	m.State.Tests.Options = options
+	target := "all"
+	if len(options.Tests) > 0 {
+		target = panels.JoinArgs(options.Tests)
+	}
+	return m.runTests(options, target, false)
+}
+
+// runTests queues a pytest run. A rerun of some tests is merged into the last
+// results instead of replacing them.
+func (m *Model) runTests(options types.PytestOptions, target string, rerun bool) tea.Cmd {
+	m.AddMessage(fmt.Sprintf("Running %s...", panels.PytestCommandLine(options)))
+	return m.EnqueueJob("pytest", target, projectResources, RunTests(m.TestRunner, options, rerun))
+}
+
+// handleTestRunMsg shows the results of a pytest run.
+func (m *Model) handleTestRunMsg(msg ui.TestRunMsg) (tea.Model, tea.Cmd) {
+	if msg.Report == nil {
+		m.AddMessage(describeFailure("run pytest", msg.Error))
+		return m, nil
+	}
+	m.State.Tests.SetReport(msg.Report, msg.Rerun)
+	m.AddMessage(fmt.Sprintf("Tests: %s in %s", panels.TestSummary(msg.Report), msg.Report.Duration.Round(10*time.Millisecond)))
+	return m, nil
+}
+
+// handleMatrixKey opens the test matrix of the Tests panel, or closes it.
+func (m *Model) handleMatrixKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.TestsPanel || !m.State.Installed {
+		return m, nil
+	}
+	matrix := &m.State.Tests.Matrix
+	matrix.Visible = !matrix.Visible
+	if matrix.Visible && matrix.Matrix == nil && !matrix.Loading {
+		return m, m.loadTestMatrix()
+	}
+	return m, nil
+}
+
+// loadTestMatrix reads the test matrix again.
+func (m *Model) loadTestMatrix() tea.Cmd {
+	m.State.Tests.Matrix.Loading = true
+	return LoadTestMatrix(m.loadContext(matrixContextKey), m.TestRunner, m.PythonManager)
+}
+
+// runTestMatrix queues a run of the test matrix with pythons. The job claims
+// the project, which uv run may lock, and each Python.
+func (m *Model) runTestMatrix(pythons []string) tea.Cmd {
+	matrix := *m.State.Tests.Matrix.Matrix
+	resources := append([]string(nil), projectResources...)
+	for _, python := range pythons {
+		resources = append(resources, pythonResources(python)...)
+	}
+	m.AddMessage(fmt.Sprintf("Running %s with Python %s...", panels.JoinArgs(matrix.Command), strings.Join(pythons, ", ")))
+	return m.EnqueueJob(panels.MatrixJobOperation, strings.Join(pythons, " "), resources, RunTestMatrix(m.TestRunner, matrix, pythons))
+}
+
+// handleTestMatrixLoadedMsg shows the test matrix.
+func (m *Model) handleTestMatrixLoadedMsg(msg ui.TestMatrixLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(matrixContextKey)
+	matrix := &m.State.Tests.Matrix
+	matrix.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to read the test matrix: %v", msg.Error))
+		return m, nil
+	}
+	matrix.Matrix = &msg.Matrix
+	matrix.Selected = clampIndex(matrix.Selected, len(msg.Matrix.Python))
+	return m, nil
+}
+
+// handleMatrixRunMsg records the results of a test matrix run.
+func (m *Model) handleMatrixRunMsg(msg ui.MatrixRunMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Results) == 0 {
+		m.AddMessage(describeFailure("run the test matrix", msg.Error))
+		return m, nil
+	}
+	m.State.Tests.Matrix.AddResults(msg.Results)
+
+	var failed []string
+	for _, result := range msg.Results {
+		if result.Error != nil {
+			failed = append(failed, result.Python)
+		}
+	}
+	summary := fmt.Sprintf("Matrix: %d of %d Pythons passed", len(msg.Results)-len(failed), len(msg.Results))
+	if len(failed) > 0 {
+		summary += ", failed with " + strings.Join(failed, ", ")
+	}
+	m.AddMessage(summary)
+	return m, nil
+}
+
+// runChecks queues a run of ruff and mypy on the project.
+func (m *Model) runChecks() tea.Cmd {
+	m.AddMessage("Running ruff and mypy...")
+	return m.EnqueueJob("check", strings.Join(panels.DiagnosticsTools, " "), projectResources, RunChecks(m.CodeChecker, panels.DiagnosticsTools))
+}
+
+// handleFixKey asks to apply ruff's fixes to the project.
+func (m *Model) handleFixKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.DiagnosticsPanel || !m.State.Installed {
+		return m, nil
+	}
+	m.Confirm("Apply ruff's safe fixes to the project (ruff check --fix)?", func() tea.Cmd {
+		m.AddMessage("Running ruff check --fix...")
+		return m.EnqueueJob("fix", types.DiagnosticsRuff, projectResources, FixRuff(m.CodeChecker))
+	})
+	return m, nil
+}
+
+// submitDiagnosticsFilterForm applies the filter entered in the dialog.
+func (m *Model) submitDiagnosticsFilterForm(form *panels.FormState) tea.Cmd {
+	m.CloseForm()
+	diagnostics := &m.State.Diagnostics
+	diagnostics.Filter = panels.DiagnosticsFilterFormValue(form)
+	diagnostics.Selected = clampIndex(diagnostics.Selected, len(diagnostics.Visible()))
+	return nil
+}
+
+// handleDiagnosticsMsg shows the diagnostics of a run of the checks.
+func (m *Model) handleDiagnosticsMsg(msg ui.DiagnosticsMsg) (tea.Model, tea.Cmd) {
+	for _, err := range msg.Failures {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			m.AddMessage(describeFailure("run the checks", err))
+			return m, nil
+		}
+	}
+	m.State.Diagnostics.SetDiagnostics(msg.Diagnostics, msg.Failures)
+
+	summary := fmt.Sprintf("Diagnostics: %d found", len(msg.Diagnostics))
+	for _, tool := range panels.DiagnosticsTools {
+		if _, ok := msg.Failures[tool]; ok {
+			summary += fmt.Sprintf(", %s could not run", tool)
+		}
+	}
+	m.AddMessage(summary)
+	return m, nil
+}
+
+// handleRuffFixMsg reports a ruff check --fix run and checks the project
+// again.
+func (m *Model) handleRuffFixMsg(msg ui.RuffFixMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.AddMessage(describeFailure("apply ruff's fixes", msg.Error))
+		return m, nil
+	}
+	m.AddMessage("Applied ruff's fixes")
+	return m, m.runChecks()
+}
+
+// handleEditorClosedMsg reports an editor that could not open a file.
+func (m *Model) handleEditorClosedMsg(msg ui.EditorClosedMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to open %s in the editor: %v", msg.File, msg.Error))
+	}
+	return m, nil
+}
+
+// handleScriptMetadataKey opens the inline metadata of the selected Python
+// file on the Run panel, or closes it.
+func (m *Model) handleScriptMetadataKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.RunPanel || !m.State.Installed {
+		return m, nil
+	}
+	if m.State.Run.Script.Visible {
+		m.closeScriptView()
+		return m, nil
+	}
+	if m.State.Run.ShowHistory {
+		return m, nil
+	}
+	target := m.GetSelectedRunTarget()
+	if target == nil || target.Kind != types.RunTargetFile {
+		m.AddMessage("Select a Python file to see its inline metadata")
+		return m, nil
+	}
+	return m, m.openScriptView(target.Name)
+}
+
+// openScriptView opens the inline metadata view for script, or reloads it.
+func (m *Model) openScriptView(script string) tea.Cmd {
+	view := &m.State.Run.Script
+	if !view.Visible || view.Path != script {
+		*view = panels.ScriptViewState{}
+	}
+	view.Visible = true
+	view.Loading = true
+	view.Path = script
+	return LoadScriptMetadata(m.loadContext(scriptContextKey), m.ScriptManager, script)
+}
+
+// closeScriptView closes the inline metadata view.
+func (m *Model) closeScriptView() {
+	m.State.Run.Script = panels.ScriptViewState{}
+	m.releaseContext(scriptContextKey)
+}
+
+// handleScriptMetadataLoadedMsg shows the inline metadata of a script.
+func (m *Model) handleScriptMetadataLoadedMsg(msg ui.ScriptMetadataLoadedMsg) (tea.Model, tea.Cmd) {
+	view := &m.State.Run.Script
+	if !view.Visible || view.Path != msg.Path {
+		return m, nil
+	}
+	m.releaseContext(scriptContextKey)
+	view.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to read the metadata of %s: %v", msg.Path, msg.Error))
+		return m, nil
+	}
+	view.Metadata = msg.Metadata
+	view.Selected = clampIndex(view.Selected, len(msg.Metadata.Dependencies))
+	return m, nil
+}
+
+// submitScriptDependencyForm queues uv add --script for the dialog.
+func (m *Model) submitScriptDependencyForm(form *panels.FormState) tea.Cmd {
+	if form.Value(panels.DependencyFieldPackage) == "" {
+		form.Error = "Enter a package name, path or git URL"
+		return nil
+	}
+	script := m.State.Run.Script.Path
+	requirement := panels.DependencyRequirement(form)
+	m.CloseForm()
+	m.AddMessage(fmt.Sprintf("Adding %s to %s...", requirement, script))
+	return m.EnqueueJob("add", script+" "+requirement, scriptResources(script), AddScriptDependency(m.ScriptManager, script, requirement))
+}
+
+// handleScriptOperationMsg handles the result of uv add, uv remove or uv lock
+// on a script and reloads its metadata. A failed add reopens the dialog with
+// uv's explanation.
+func (m *Model) handleScriptOperationMsg(msg ui.ScriptOperationMsg) (tea.Model, tea.Cmd) {
+	view := m.State.Run.Script
+	shown := view.Visible && view.Path == msg.Script && m.State.ActivePanel == types.RunPanel
+
+	if !msg.Success {
+		action := fmt.Sprintf("%s %s", msg.Operation, msg.Script)
+		if msg.Package != "" {
+			action = fmt.Sprintf("%s %s in %s", msg.Operation, msg.Package, msg.Script)
+		}
+		m.AddMessage(describeFailure(action, msg.Error))
+		if msg.Operation == "add" && shown && m.InputMode == InputModeNone && !errors.Is(msg.Error, context.Canceled) {
+			form := panels.NewScriptDependencyForm(msg.Script)
+			form.Field(panels.DependencyFieldPackage).Value = msg.Package
+			panels.UpdateScriptDependencyForm(&form, msg.Script)
+			form.Error = describeFailure(action, msg.Error)
+			form.Details = msg.Details
+			return m.OpenForm(form)
+		}
+		return m, nil
+	}
+
+	switch msg.Operation {
+	case "add":
+		m.AddMessage(fmt.Sprintf("Added %s to %s", msg.Package, msg.Script))
+	case "remove":
+		m.AddMessage(fmt.Sprintf("Removed %s from %s", msg.Package, msg.Script))
+	case "lock":
+		m.AddMessage(fmt.Sprintf("Locked %s", msg.Script))
+	}
+
+	var cmds []tea.Cmd
+	if view.Visible && view.Path == msg.Script {
+		cmds = append(cmds, m.openScriptView(msg.Script))
+	}
+	// Adding the first dependency creates the metadata block.
+	if !m.State.Run.Loading {
+		m.State.Run.Loading = true
+		cmds = append(cmds, LoadRunTargets(m.loadContext(scriptsContextKey), m.ScriptManager))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleCacheLoadedMsg handles the measured cache.
+func (m *Model) handleCacheLoadedMsg(msg ui.CacheLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(cacheContextKey)
+	cache := &m.State.Cache
+	cache.Loading = false
+	if msg.Error != nil {
+		m.AddMessage(fmt.Sprintf("Failed to read the cache: %v", msg.Error))
+		return m, nil
+	}
+
+	cache.Info = msg.Info
+	cache.Selected = clampIndex(cache.Selected, len(msg.Info.Packages))
+	for name := range cache.Marked {
+		if !slices.ContainsFunc(msg.Info.Packages, func(pkg types.CachePackage) bool { return pkg.Name == name }) {
+			delete(cache.Marked, name)
+		}
+	}
+	return m, nil
+}
+
+// confirmCleanPackages asks before removing the marked packages, or the
+// selected one, from the cache.
+func (m *Model) confirmCleanPackages() {
+	packages := m.State.Cache.MarkedPackages()
+	if len(packages) == 0 {
+		pkg := m.GetSelectedCachePackage()
+		if pkg == nil {
+			return
+		}
+		packages = []string{pkg.Name}
+	}
+
+	var size int64
+	for _, pkg := range m.State.Cache.Info.Packages {
+		if slices.Contains(packages, pkg.Name) {
+			size += pkg.Size
+		}
+	}
+	names := strings.Join(packages, ", ")
+	m.Confirm(fmt.Sprintf("Remove %s (%s) from the cache?", names, panels.FormatBytes(size)), func() tea.Cmd {
+		m.AddMessage(fmt.Sprintf("Cleaning %s from the cache...", names))
+		return m.EnqueueJob("clean", names, cacheResources, CleanCache(m.CacheManager, packages))
+	})
+}
+
+// handleCleanCacheKey asks before removing everything from the cache.
+func (m *Model) handleCleanCacheKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.CachePanel || !m.State.Installed {
+		return m, nil
+	}
+
+	prompt := "Remove everything from the uv cache?"
+	if info := m.State.Cache.Info; info != nil {
+		prompt = fmt.Sprintf("Remove everything (%s) from the uv cache at %s?", panels.FormatBytes(info.Size), info.Dir)
+	}
+	m.Confirm(prompt, func() tea.Cmd {
+		m.AddMessage("Cleaning the cache...")
+		return m.EnqueueJob("clean", "cache", cacheResources, CleanCache(m.CacheManager, nil))
+	})
+	return m, nil
+}
+
+// handlePruneKey asks before pruning the cache, for CI if ci is set.
+func (m *Model) handlePruneKey(ci bool) (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.CachePanel || !m.State.Installed {
+		return m, nil
+	}
+
+	prompt := "Prune unused entries from the uv cache (uv cache prune)?"
+	target := "cache"
+	if ci {
+		prompt = "Prune the uv cache for CI, removing pre-built wheels and unpacked sources (uv cache prune --ci)?"
+		target = "cache --ci"
+	}
+	m.Confirm(prompt, func() tea.Cmd {
+		m.AddMessage("Pruning the cache...")
+		return m.EnqueueJob("prune", target, cacheResources, PruneCache(m.CacheManager, ci))
+	})
+	return m, nil
+}
+
+// handleCacheOperationMsg reports the bytes a clean or prune reclaimed and
+// measures the cache again.
+func (m *Model) handleCacheOperationMsg(msg ui.CacheOperationMsg) (tea.Model, tea.Cmd) {
+	if !msg.Success {
+		m.AddMessage(describeFailure(strings.TrimSpace(msg.Operation+" cache "+msg.Target), msg.Error))
+		return m, nil
+	}
+
+	summary := fmt.Sprintf("Reclaimed %s by pruning the cache", panels.FormatBytes(msg.Reclaimed))
+	switch {
+	case msg.Operation == "clean" && msg.Target != "":
+		summary = fmt.Sprintf("Reclaimed %s by cleaning %s", panels.FormatBytes(msg.Reclaimed), msg.Target)
+	case msg.Operation == "clean":
+		summary = fmt.Sprintf("Reclaimed %s by cleaning the cache", panels.FormatBytes(msg.Reclaimed))
+	case msg.Target != "":
+		summary = fmt.Sprintf("Reclaimed %s by pruning the cache for CI", panels.FormatBytes(msg.Reclaimed))
+	}
+	m.AddMessage(summary)
+	m.State.Cache.Reclaimed = summary
+	m.State.Cache.Marked = nil
+	m.State.Cache.Loading = true
+	return m, LoadCache(m.loadContext(cacheContextKey), m.CacheManager)
+}
+
+// submitToolRunForm runs the tool entered in the run tool dialog.
+func (m *Model) submitToolRunForm(form *panels.FormState) tea.Cmd {
+	options := panels.ToolRunFormOptions(form)
+	if options.Package == "" {
+		form.Error = "Enter the package to run"
+		return nil
+	}
+	m.CloseForm()
+	return m.runTool(options)
+}
+
+// runTool runs a tool with uvx: interactive tools take over the terminal,
+// others run as a job whose output is shown in the output pane.
+func (m *Model) runTool(options types.ToolRunOptions) tea.Cmd {
+	line := panels.JoinArgs(options.RunArgs())
+	if options.Interactive {
+		m.AddMessage(fmt.Sprintf("Running uvx %s...", line))
+		return ExecTool(m.ToolManager, options)
+	}
+	m.AddMessage(fmt.Sprintf("Running uvx %s in the background...", line))
+	return m.EnqueueJob("uvx", line, nil, RunTool(m.ToolManager, options))
+}
+
+// handleToolRunMsg records a finished uvx run in the history.
+func (m *Model) handleToolRunMsg(msg ui.ToolRunMsg) (tea.Model, tea.Cmd) {
+	run := msg.Run
+	m.State.Tools.AddToolRun(run)
+
+	name := run.Options.Executable()
+	switch {
+	case run.Error == nil:
+		m.AddMessage(fmt.Sprintf("%s finished in %s", name, run.Duration.Round(100*time.Millisecond)))
+	case run.ExitCode > 0:
+		m.AddMessage(fmt.Sprintf("%s exited with status %d", name, run.ExitCode))
+	default:
+		m.AddMessage(describeFailure("run "+name, run.Error))
+	}
+	return m, nil
+}
+
+// handleToolOperationMsg handles the result of a uv tool operation and
+// reloads the tools.
+func (m *Model) handleToolOperationMsg(msg ui.ToolOperationMsg) (tea.Model, tea.Cmd) {
+	target := msg.Target
+	if target == "" && msg.Operation == "upgrade" {
+		target = "all tools"
+	}
+	if !msg.Success {
+		m.AddMessage(describeFailure(strings.TrimSpace(msg.Operation+" "+target), msg.Error))
+		return m, nil
+	}
+
+	switch msg.Operation {
+	case "update-shell":
+		m.AddMessage("Updated the shell configuration; restart your shell to use the new PATH")
+		return m, nil
+	case "install":
+		m.AddMessage(fmt.Sprintf("Installed %s", target))
+	case "upgrade":
+		m.AddMessage(fmt.Sprintf("Upgraded %s", target))
+	case "uninstall":
+		m.AddMessage(fmt.Sprintf("Uninstalled %s", target))
+	}
+	m.State.Tools.Loading = true
+	return m, LoadTools(m.loadContext(toolsContextKey), m.ToolManager)
+}
+
+// handleBrowseLockKey opens the lockfile browser on the Project panel, or
+// closes it if it is open.
+func (m *Model) handleBrowseLockKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel != types.ProjectPanel || !m.State.Installed {
+		return m, nil
+	}
+	lock := &m.State.ProjectState.Lock
+	if lock.Visible {
+		*lock = panels.LockBrowserState{}
+		m.releaseContext(lockContextKey)
+		return m, nil
+	}
+
+	status := m.State.ProjectState.Status
+	if status == nil || !status.IsProject {
+		return m, nil
+	}
+	if !status.HasLockFile {
+		m.AddMessage("No uv.lock found. Press 'l' to lock the project first.")
+		return m, nil
+	}
+	lock.Visible = true
+	lock.Loading = true
+	return m, LoadLockFile(m.loadContext(lockContextKey), m.ProjectManager)
+}
+
+// treeViewActive reports whether the dependency tree is shown and takes the
+// navigation keys.
+func (m *Model) treeViewActive() bool {
+	return m.State.ActivePanel == types.ProjectPanel && m.InputMode == InputModeNone &&
+		m.State.ProjectState.ShowTree && !m.State.ProjectState.Lock.Visible
+}
+
+// handleTreeExpandKey expands or collapses the dependency tree node under the
+// cursor.
+func (m *Model) handleTreeExpandKey(expanded bool) (tea.Model, tea.Cmd) {
+	if m.treeViewActive() {
+		m.SetTreeNodeExpanded(expanded)
+	}
+	return m, nil
+}
+
+// handleOccurrenceKey moves the dependency tree cursor to another node of the
+// same package.
+func (m *Model) handleOccurrenceKey(direction int) (tea.Model, tea.Cmd) {
+	if !m.treeViewActive() {
+		return m, nil
+	}
+	if row := m.GetSelectedTreeRow(); row != nil && !m.JumpToTreeOccurrence(direction) {
+		m.AddMessage(fmt.Sprintf("%s appears only once in the tree", row.Node.Name))
+	}
+	return m, nil
+}
+
+// handleSearchKey starts filtering the lockfile browser.
+func (m *Model) handleSearchKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.DiagnosticsPanel && m.State.Diagnostics.Diagnostics != nil {
+		return m.OpenForm(panels.NewDiagnosticsFilterForm(m.State.Diagnostics.Filter, m.State.Diagnostics.Diagnostics))
+	}
+	if m.State.ActivePanel != types.ProjectPanel || !m.State.ProjectState.Lock.Visible {
+		return m, nil
+	}
+	m.InputMode = InputModeLockSearch
+	m.TextInput.Reset()
+	m.TextInput.Placeholder = "Search packages"
+	m.TextInput.SetValue(m.State.ProjectState.Lock.Query)
+	return m, textinput.Blink
+}
+
+// handleLockFileLoadedMsg handles the loaded uv.lock.
+func (m *Model) handleLockFileLoadedMsg(msg ui.LockFileLoadedMsg) (tea.Model, tea.Cmd) {
+	m.releaseContext(lockContextKey)
+	lock := &m.State.ProjectState.Lock
+	lock.Loading = false
+	if msg.Error != nil {
+		lock.Visible = false
+		m.AddMessage(describeFailure("read uv.lock", msg.Error))
+		return m, nil
+	}
+
+	lock.Lock = msg.Lock
+	lock.Selected = clampIndex(lock.Selected, len(panels.FilterLockPackages(lock.Lock, lock.Query)))
+	if msg.Lock != nil {
+		m.AddMessage(fmt.Sprintf("Loaded %d locked packages", len(msg.Lock.Packages)))
+	}
+	return m, nil
+}
+
+// handleCommandOutputMsg records a line of job output and keeps listening for
+// more.
+func (m *Model) handleCommandOutputMsg(msg ui.CommandOutputMsg) (tea.Model, tea.Cmd) {
+	m.Jobs.AppendOutput(msg.JobID, msg.Line)
+	if m.State.Output.JobID == msg.JobID {
+		m.AppendOutput(msg.Line)
+	}
+	return m, waitForOutput(msg.Stream)
+}
+
+// handleJobFinishedMsg records a finished job, starts any jobs that were
+// waiting for it and hands the job's result to its operation handler.
+func (m *Model) handleJobFinishedMsg(msg ui.JobFinishedMsg) (tea.Model, tea.Cmd) {
+	job, ok := m.Jobs.Finish(msg.JobID, msg.Error, time.Now())
+	if !ok {
+		return m, nil
+	}
+
+	m.finishOperation(job, msg.Error)
+	if m.State.Output.JobID == job.ID {
+		m.State.Output.Running = false
+	}
+	m.refreshJobs()
+
+	next := m.startJobs()
+	if msg.Result == nil {
+		return m, next
+	}
+	_, cmd := m.Update(msg.Result)
+	return m, tea.Batch(cmd, next)
+}
+
+// handleJobTickMsg refreshes job durations while jobs are running.
+func (m *Model) handleJobTickMsg() (tea.Model, tea.Cmd) {
+	m.refreshJobs()
+	if !m.Jobs.HasRunning() {
+		m.jobTicking = false
+		return m, nil
+	}
+	return m, tickJobs()
+}
+
+// handleToggleOutput handles the toggle output key press.
+func (m *Model) handleToggleOutput() (tea.Model, tea.Cmd) {
+	m.ToggleOutput()
+	return m, nil
+}
+
+// handleScrollOutput handles output pane scrolling; positive directions scroll up.
+func (m *Model) handleScrollOutput(direction int) (tea.Model, tea.Cmd) {
+	if m.State.Output.Visible {
+		m.ScrollOutput(direction * maxInt(1, m.outputHeight()/2))
+	}
+	return m, nil
+}
+
+// handleCancelKey cancels the selected job on the Jobs panel, or the most
+// recently started job elsewhere, killing its uv process.
+func (m *Model) handleCancelKey() (tea.Model, tea.Cmd) {
+	if m.State.ActivePanel == types.JobsPanel {
+		if job := m.GetSelectedJob(); job != nil && m.Jobs.Cancel(job.ID, time.Now()) {
+			m.AddMessage(fmt.Sprintf("Cancelling job #%d...", job.ID))
+			m.refreshJobs()
+			return m, m.startJobs()
+		}
+		m.AddMessage("No job to cancel")
+		return m, nil
+	}
+
+	if m.CancelOperation() {
+		m.AddMessage("Cancelling current operation...")
+	} else {
+		m.AddMessage("No operation to cancel")
+	}
+	return m, nil
+}
+
+// describeFailure formats the message shown when an operation does not
+// succeed, distinguishing cancellations and timeouts from ordinary failures.
+func describeFailure(action string, err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return fmt.Sprintf("Cancelled: %s", action)
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("Timed out: %s", action)
+	default:
+		return fmt.Sprintf("Failed to %s: %v", action, err)
+	}
 }
 
 // maxInt returns the larger of two integers.
@@ -1,7 +1,13 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
@@ -9,6 +15,7 @@ import (
 	"uvui/internal/services"
 	"uvui/internal/types"
 	"uvui/internal/ui"
+	"uvui/internal/ui/panels"
 )
 
 // mockCommandExecutor is a mock implementation of the CommandExecutorInterface.
@@ -16,6 +23,15 @@ type mockCommandExecutor struct {
 	services.CommandExecutorInterface
 	IsUVAvailableFunc func() bool
 	ExecuteFunc       func(command string, args ...string) ([]byte, error)
+	uvPath            string
+}
+
+func (m *mockCommandExecutor) SetUVPath(path string) {
+	m.uvPath = path
+}
+
+func (m *mockCommandExecutor) UVPath() string {
+	return m.uvPath
 }
 
 func (m *mockCommandExecutor) IsUVAvailable() bool {
@@ -25,20 +41,41 @@ func (m *mockCommandExecutor) IsUVAvailable() bool {
 	return true
 }
 
-func (m *mockCommandExecutor) Execute(command string, args ...string) ([]byte, error) {
+func (m *mockCommandExecutor) Execute(_ context.Context, command string, args ...string) ([]byte, error) {
 	if m.ExecuteFunc != nil {
 		return m.ExecuteFunc(command, args...)
 	}
 	return nil, nil
 }
 
+func (m *mockCommandExecutor) InDir(_ string) services.CommandExecutorInterface {
+	return m
+}
+
+func (m *mockCommandExecutor) WithEnv(_ []string) services.CommandExecutorInterface {
+	return m
+}
+
+func (m *mockCommandExecutor) Command(command string, args ...string) *exec.Cmd {
+	return exec.Command(command, args...)
+}
+
 // newTestModel creates a new model with mock services for testing.
 func newTestModel() *Model {
 	executor := &mockCommandExecutor{}
 	uvInstaller := services.NewUVInstaller(executor)
 	pythonManager := services.NewPythonManager(executor)
 	projectManager := services.NewProjectManager(executor)
-	return NewModel(uvInstaller, pythonManager, projectManager, executor)
+	return NewModel(uvInstaller, pythonManager, projectManager,
+		services.NewUVLocator(executor),
+		services.NewEnvironmentManager(executor),
+		services.NewScriptManager(executor),
+		services.NewTaskManager(executor),
+		services.NewTestRunner(executor),
+		services.NewCodeChecker(executor),
+		services.NewToolManager(executor),
+		services.NewCacheManager(executor),
+		executor)
 }
 
 func TestGetDirection(t *testing.T) {
@@ -130,6 +167,33 @@ func TestHandlePinKey(t *testing.T) {
 	assert.NotNil(t, cmd)
 }
 
+func TestPythonJobs_UseRequest(t *testing.T) {
+	m := newTestModel()
+	m.State.ActivePanel = types.PythonPanel
+	m.State.Installed = true
+	m.State.PythonVersions.Available = []types.PythonVersion{
+		{Version: "3.10.14", Key: "pypy-3.10.14-linux-x86_64-gnu", Implementation: "pypy", Variant: "default"},
+	}
+	m.State.PythonVersions.Installed = []types.PythonVersion{
+		{Version: "3.10.14", Key: "pypy-3.10.14-linux-x86_64-gnu", Implementation: "pypy", Variant: "default", Installed: true},
+	}
+
+	m.handlePinKey()
+
+	jobs := m.Jobs.Jobs()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "pin", jobs[0].Operation)
+	assert.Equal(t, "pypy-3.10.14-linux-x86_64-gnu", jobs[0].Target)
+
+	m.State.PythonVersions.Installed = nil
+	m.handleEnterKey()
+
+	jobs = m.Jobs.Jobs()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "install", jobs[1].Operation)
+	assert.Equal(t, []string{"python:pypy-3.10.14-linux-x86_64-gnu"}, jobs[1].Resources)
+}
+
 func TestHandleInstallRefresh(t *testing.T) {
 	m := newTestModel()
 	m.State.ActivePanel = types.StatusPanel
@@ -170,6 +234,86 @@ func TestHandleUVInstalledMsg(t *testing.T) {
 	assert.True(t, m.State.Installed)
 }
 
+func TestHandleUVInstalledMsg_ParsesVersion(t *testing.T) {
+	m := newTestModel()
+	m.Config.MinUVVersion = "0.5.0"
+
+	m.handleUVInstalledMsg(ui.UVInstalledMsg{Success: true, Version: "uv 0.4.30 (61ed2a2 2024-11-04)", Managed: true})
+
+	assert.Equal(t, "0.4.30", m.State.Details.Version)
+	assert.Equal(t, "61ed2a2", m.State.Details.Commit)
+	assert.True(t, m.State.Managed)
+	assert.Contains(t, m.State.Messages, "Warning: uv 0.4.30 is older than 0.5.0, the oldest version uvui supports")
+}
+
+func TestHandleUVInstalledMsg_SelectsBinaryOutsidePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", "/usr/bin")
+	m := newTestModel()
+	path := filepath.Join(dir, "uv")
+
+	m.handleUVInstalledMsg(ui.UVInstalledMsg{Success: true, Version: "uv 0.5.4", Path: path})
+	assert.Equal(t, path, m.CommandExecutor.UVPath())
+	assert.Equal(t, path, m.State.UVBinaries.Active)
+
+	// A uv installed into a directory on PATH is found without selecting it.
+	t.Setenv("PATH", dir)
+	m = newTestModel()
+	m.handleUVInstalledMsg(ui.UVInstalledMsg{Success: true, Version: "uv 0.5.4", Path: path})
+	assert.Empty(t, m.CommandExecutor.UVPath())
+}
+
+func TestHandleUpdateUVKey(t *testing.T) {
+	m := newTestModel()
+	m.State.Installed = true
+
+	_, cmd := m.handleUpdateUVKey(false)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "update", m.State.Jobs.Jobs[0].Operation)
+	assert.Equal(t, "uv", m.State.Jobs.Jobs[0].Target)
+
+	m.handleUpdateUVKey(true)
+	assert.Equal(t, InputModeUVVersion, m.InputMode)
+	m.TextInput.SetValue("0.5.4")
+	m.handleTextInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.Equal(t, "uv 0.5.4", m.State.Jobs.Jobs[1].Target)
+}
+
+func TestHandleDeleteKey_UninstallManagedUV(t *testing.T) {
+	m := newTestModel()
+	m.UVInstaller = &MockUVInstaller{managedPath: "/opt/uv/uv"}
+	m.State.Installed = true
+	m.State.Managed = true
+
+	m.handleDeleteKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	assert.Empty(t, m.State.Jobs.Jobs, "nothing runs before confirmation")
+
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.Empty(t, m.State.Jobs.Jobs)
+
+	m.handleDeleteKey()
+	_, cmd := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "uninstall", m.State.Jobs.Jobs[0].Operation)
+}
+
+func TestHandleUVOperationMsg_Uninstall(t *testing.T) {
+	m := newTestModel()
+	m.State.Installed = true
+	m.State.Managed = true
+	m.State.Path = "/opt/uv/uv"
+
+	_, cmd := m.handleUVOperationMsg(ui.UVOperationMsg{Operation: "uninstall", Success: true})
+
+	assert.NotNil(t, cmd, "UV status is refreshed")
+	assert.False(t, m.State.Installed)
+	assert.False(t, m.State.Managed)
+	assert.Empty(t, m.State.Path)
+}
+
 func TestHandlePythonVersionsLoadedMsg(t *testing.T) {
 	m := newTestModel()
 	model, cmd := m.handlePythonVersionsLoadedMsg(ui.PythonVersionsLoadedMsg{Available: []types.PythonVersion{{Version: "3.12.1"}}})
@@ -292,3 +436,1301 @@ func TestMaxInt(t *testing.T) {
 func TestGetOS(t *testing.T) {
 	assert.NotEmpty(t, getOS())
 }
+
+func TestHandleCommandOutputMsg(t *testing.T) {
+	m := newTestModel()
+	m.StartOutput("sync")
+
+	stream := make(chan tea.Msg, 1)
+	stream <- ui.ProjectOperationMsg{Operation: "sync", Success: true}
+
+	line := types.OutputLine{Stream: types.StreamStderr, Text: "Resolved 3 packages"}
+	model, cmd := m.handleCommandOutputMsg(ui.CommandOutputMsg{Line: line, Stream: stream})
+	assert.NotNil(t, model)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []types.OutputLine{line}, m.State.Output.Lines)
+
+	// The returned command keeps listening on the same stream.
+	_, ok := cmd().(ui.ProjectOperationMsg)
+	assert.True(t, ok)
+}
+
+func TestOutputPaneLifecycle(t *testing.T) {
+	m := newTestModel()
+	m.SetOperation("sync", "", true)
+	assert.True(t, m.State.Output.Visible)
+	assert.True(t, m.State.Output.Running)
+	assert.Equal(t, "sync", m.State.Output.Title)
+
+	m.CompleteOperation(true, nil)
+	assert.False(t, m.State.Output.Running)
+	assert.True(t, m.State.Output.Visible, "output is kept after completion")
+
+	m.handleToggleOutput()
+	assert.False(t, m.State.Output.Visible)
+}
+
+func TestHandleScrollOutput(t *testing.T) {
+	m := newTestModel()
+	m.State.Height = 40
+	m.StartOutput("sync")
+	for i := 0; i < 50; i++ {
+		m.AppendOutput(types.OutputLine{Text: "line"})
+	}
+
+	m.handleScrollOutput(1)
+	assert.Equal(t, 5, m.State.Output.Scroll)
+
+	m.handleScrollOutput(-1)
+	m.handleScrollOutput(-1)
+	assert.Equal(t, 0, m.State.Output.Scroll)
+}
+
+func TestAppendOutput_FullBuffer(t *testing.T) {
+	m := newTestModel()
+	m.State.Height = 40
+	m.StartOutput("sync")
+	for i := 0; i < panels.MaxOutputLines; i++ {
+		m.AppendOutput(types.OutputLine{Text: fmt.Sprintf("line %d", i)})
+	}
+	height := m.outputHeight()
+	bottom := func() string {
+		return m.State.Output.Lines[len(m.State.Output.Lines)-1-m.State.Output.Scroll].Text
+	}
+
+	m.State.Output.Scroll = 100
+	m.AppendOutput(types.OutputLine{Text: "new"})
+	assert.Len(t, m.State.Output.Lines, panels.MaxOutputLines)
+	assert.Equal(t, "line 899", bottom(), "the view stays on the same lines")
+
+	m.State.Output.Scroll = panels.MaxOutputLines - height
+	m.AppendOutput(types.OutputLine{Text: "newer"})
+	assert.Equal(t, panels.MaxOutputLines-height, m.State.Output.Scroll, "the offset stops at the oldest line kept")
+}
+
+func TestHandleCancelKey(t *testing.T) {
+	m := newTestModel()
+
+	_, cmd := m.handleCancelKey()
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.State.Messages, "No operation to cancel")
+
+	m.EnqueueJob("sync", "", syncResources, blockingJob)
+	m.handleCancelKey()
+	assert.ErrorIs(t, m.Jobs.find(1).ctx.Err(), context.Canceled)
+}
+
+func TestHandleCancelKey_JobsPanelCancelsSelectedJob(t *testing.T) {
+	m := newTestModel()
+	m.Jobs = NewJobManager(1, nil)
+	m.State.ActivePanel = types.JobsPanel
+
+	m.EnqueueJob("sync", "", syncResources, blockingJob)
+	m.EnqueueJob("install", "3.12", pythonResources("3.12"), blockingJob)
+	m.State.Jobs.Selected = 1
+
+	m.handleCancelKey()
+
+	job, _ := m.Jobs.Job(2)
+	assert.Equal(t, types.JobCancelled, job.State)
+	running, _ := m.Jobs.Job(1)
+	assert.Equal(t, types.JobRunning, running.State)
+	assert.Equal(t, types.JobCancelled, m.State.Jobs.Jobs[1].State)
+}
+
+func TestEnqueueJob_RejectsDuplicates(t *testing.T) {
+	m := newTestModel()
+
+	assert.NotNil(t, m.EnqueueJob("sync", "", syncResources, blockingJob))
+	assert.Nil(t, m.EnqueueJob("sync", "", syncResources, blockingJob))
+	assert.Contains(t, m.State.Messages, "sync is already queued")
+	assert.Len(t, m.State.Jobs.Jobs, 1)
+}
+
+func TestHandleJobFinishedMsg(t *testing.T) {
+	m := newTestModel()
+	m.Jobs = NewJobManager(1, nil)
+
+	m.EnqueueJob("lock", "", projectResources, blockingJob)
+	m.EnqueueJob("pin", "3.12", projectResources, blockingJob)
+	assert.Equal(t, 1, m.State.Operation.JobID)
+	assert.True(t, m.State.Operation.InProgress)
+
+	m.handleCommandOutputMsg(ui.CommandOutputMsg{JobID: 1, Line: types.OutputLine{Text: "Resolved"}})
+	assert.Len(t, m.State.Output.Lines, 1)
+
+	result := ui.ProjectOperationMsg{Operation: "lock", Success: true}
+	_, cmd := m.handleJobFinishedMsg(ui.JobFinishedMsg{JobID: 1, Result: result})
+	assert.NotNil(t, cmd)
+
+	finished, _ := m.Jobs.Job(1)
+	assert.Equal(t, types.JobSucceeded, finished.State)
+	assert.Equal(t, []types.OutputLine{{Text: "Resolved"}}, finished.Output)
+
+	// The queued pin starts once the lock releases the project.
+	assert.Equal(t, 2, m.State.Operation.JobID)
+	assert.Equal(t, "pin", m.State.Operation.Operation)
+	assert.True(t, m.State.Operation.InProgress)
+	assert.Contains(t, m.State.Messages, "Successfully completed lock operation")
+}
+
+func TestHandleJobFinishedMsg_OtherJobsStillRunning(t *testing.T) {
+	m := newTestModel()
+	m.Jobs = NewJobManager(2, nil)
+
+	m.EnqueueJob("install", "3.11", pythonResources("3.11"), blockingJob)
+	m.EnqueueJob("install", "3.12", pythonResources("3.12"), blockingJob)
+	assert.Equal(t, 2, m.State.Operation.JobID)
+
+	m.handleJobFinishedMsg(ui.JobFinishedMsg{JobID: 2})
+	assert.True(t, m.State.Operation.InProgress, "the 3.11 install is still running")
+	assert.Equal(t, 1, m.State.Operation.JobID)
+	assert.Equal(t, "3.11", m.State.Operation.Target)
+
+	m.handleJobFinishedMsg(ui.JobFinishedMsg{JobID: 1, Error: context.Canceled})
+	assert.False(t, m.State.Operation.InProgress)
+	assert.True(t, m.State.Operation.Cancelled)
+	assert.Equal(t, "3.11", m.State.Operation.Target)
+}
+
+func TestShowJobOutput(t *testing.T) {
+	m := newTestModel()
+	m.EnqueueJob("sync", "", syncResources, blockingJob)
+	m.Jobs.AppendOutput(1, types.OutputLine{Text: "Installed 3 packages"})
+	m.State.Output = panels.OutputState{}
+
+	m.ShowJobOutput(1)
+
+	assert.True(t, m.State.Output.Visible)
+	assert.True(t, m.State.Output.Running)
+	assert.Equal(t, 1, m.State.Output.JobID)
+	assert.Equal(t, "#1 sync", m.State.Output.Title)
+	assert.Len(t, m.State.Output.Lines, 1)
+}
+
+// blockingJob is a job that runs until it is cancelled.
+func blockingJob(ctx context.Context, _ services.OutputHandler) (tea.Msg, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestCompleteOperation_CancelledAndTimedOut(t *testing.T) {
+	m := newTestModel()
+
+	m.SetOperation("sync", "", true)
+	m.CompleteOperation(false, context.Canceled)
+	assert.True(t, m.State.Operation.Cancelled)
+	assert.False(t, m.State.Operation.TimedOut)
+
+	m.SetOperation("lock", "", true)
+	m.CompleteOperation(false, fmt.Errorf("uv lock: %w", context.DeadlineExceeded))
+	assert.False(t, m.State.Operation.Cancelled)
+	assert.True(t, m.State.Operation.TimedOut)
+}
+
+func TestOperationContextTimeout(t *testing.T) {
+	m := newTestModel()
+	m.Config.Timeouts = map[string]string{"lock": "1ms"}
+
+	ctx := m.newContext(uvContextKey, "lock")
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+	// Operations without a timeout are only stopped by cancellation.
+	ctx = m.newContext(uvContextKey, "sync")
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+}
+
+func TestDescribeFailure(t *testing.T) {
+	assert.Equal(t, "Cancelled: sync", describeFailure("sync", context.Canceled))
+	assert.Equal(t, "Timed out: lock", describeFailure("lock", context.DeadlineExceeded))
+	assert.Equal(t, "Failed to init: boom", describeFailure("init", fmt.Errorf("boom")))
+}
+
+func TestConfigTimeout(t *testing.T) {
+	config := &Config{Timeouts: map[string]string{"default": "30m", "lock": "10m", "sync": "0", "init": "bogus"}}
+
+	assert.Equal(t, 10*time.Minute, config.Timeout("lock"))
+	assert.Equal(t, 30*time.Minute, config.Timeout("install"))
+	assert.Equal(t, time.Duration(0), config.Timeout("sync"))
+	assert.Equal(t, time.Duration(0), config.Timeout("init"))
+	assert.Equal(t, []string{`init: invalid duration "bogus"`}, config.InvalidTimeouts())
+
+	config.Timeouts["pin"] = "-1m"
+	assert.Equal(t, []string{`init: invalid duration "bogus"`, `pin: negative duration "-1m"`}, config.InvalidTimeouts())
+}
+
+func TestDefaultConfig_LongRunningOperationsHaveNoTimeout(t *testing.T) {
+	config := DefaultConfig()
+	assert.Empty(t, config.InvalidTimeouts())
+	assert.Equal(t, 30*time.Minute, config.Timeout("sync"))
+	for _, operation := range []string{"run", "task", "pytest", panels.MatrixJobOperation, "uvx"} {
+		assert.Equal(t, time.Duration(0), config.Timeout(operation), operation)
+	}
+}
+
+func TestHandleProjectOperationMsg_InitSwitchesProjectRoot(t *testing.T) {
+	m := newTestModel()
+	m.SetProjectRoot("/work")
+
+	_, cmd := m.handleProjectOperationMsg(ui.ProjectOperationMsg{Success: true, Operation: "init", ProjectDir: "/work/demo"})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "/work/demo", m.ProjectManager.ProjectRoot())
+	assert.Equal(t, "/work/demo", m.PythonManager.ProjectRoot())
+}
+
+func TestHandleEnterKey_SelectsUVBinary(t *testing.T) {
+	m := newTestModel()
+	m.handleUVBinariesLoadedMsg(ui.UVBinariesLoadedMsg{Binaries: []types.UVBinary{
+		{Path: "/usr/bin/uv", Source: types.UVSourcePath},
+		{Path: "/opt/uv/uv", Source: types.UVSourceConfigured},
+	}})
+
+	m.handleVerticalNavigation(1)
+	_, cmd := m.handleEnterKey()
+
+	assert.NotNil(t, cmd, "UV status is refreshed for the new binary")
+	assert.Equal(t, "/opt/uv/uv", m.CommandExecutor.UVPath())
+	assert.Equal(t, "/opt/uv/uv", m.State.UVBinaries.Active)
+}
+
+func TestHandlePinKey_PinsUVForProject(t *testing.T) {
+	m := newTestModel()
+	root := t.TempDir()
+	m.SetProjectRoot(root)
+	uv := filepath.Join(root, ".venv", "bin", "uv")
+	m.handleUVBinariesLoadedMsg(ui.UVBinariesLoadedMsg{Binaries: []types.UVBinary{{Path: uv, Source: types.UVSourceProject}}})
+
+	m.handlePinKey()
+	assert.Equal(t, uv, m.CommandExecutor.UVPath())
+	assert.Equal(t, uv, m.State.UVBinaries.ProjectPath)
+	assert.True(t, m.State.UVBinaries.ProjectTrusted)
+
+	settings, err := LoadProjectSettings(root)
+	assert.NoError(t, err)
+	assert.Equal(t, uv, settings.UVPath)
+
+	// Pinning the same binary again removes the pin.
+	m.handlePinKey()
+	assert.Empty(t, m.CommandExecutor.UVPath())
+	settings, _ = LoadProjectSettings(root)
+	assert.Empty(t, settings.UVPath)
+}
+
+func TestSetProjectRoot_AppliesProjectUV(t *testing.T) {
+	m := newTestModel()
+	pinned := t.TempDir()
+	uv := filepath.Join(pinned, "bin", "uv")
+	assert.NoError(t, SaveProjectSettings(pinned, ProjectSettings{UVPath: uv}))
+
+	// The settings come with the checkout; they apply once it is trusted.
+	m.SetProjectRoot(pinned)
+	assert.Empty(t, m.CommandExecutor.UVPath())
+	assert.Equal(t, uv, m.State.UVBinaries.ProjectPath)
+	assert.False(t, m.State.UVBinaries.ProjectTrusted)
+	assert.Equal(t, pinned, m.UVLocator.ProjectRoot())
+
+	m.State.ActivePanel = types.StatusPanel
+	m.handleTrustKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	assert.Empty(t, m.CommandExecutor.UVPath(), "nothing runs before confirmation")
+	_, cmd := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.NotNil(t, cmd, "the uv binaries are searched again")
+	assert.Equal(t, uv, m.CommandExecutor.UVPath())
+	assert.True(t, m.State.UVBinaries.ProjectTrusted)
+
+	// A project without its own uv goes back to the one on PATH.
+	m.SetProjectRoot(t.TempDir())
+	assert.Empty(t, m.CommandExecutor.UVPath())
+	assert.Empty(t, m.State.UVBinaries.ProjectPath)
+	assert.False(t, m.State.UVBinaries.ProjectTrusted)
+
+	// Trust lasts for the session.
+	m.SetProjectRoot(pinned)
+	assert.Equal(t, uv, m.CommandExecutor.UVPath())
+}
+
+func TestHandleBrowseLockKey(t *testing.T) {
+	m := newTestModel()
+	m.State.ActivePanel = types.ProjectPanel
+	m.State.Installed = true
+	m.State.ProjectState.Status = &types.ProjectStatus{IsProject: true}
+
+	_, cmd := m.handleBrowseLockKey()
+	assert.Nil(t, cmd)
+	assert.False(t, m.State.ProjectState.Lock.Visible, "the browser needs a uv.lock")
+
+	m.State.ProjectState.Status.HasLockFile = true
+	_, cmd = m.handleBrowseLockKey()
+	assert.NotNil(t, cmd)
+	assert.True(t, m.State.ProjectState.Lock.Visible)
+	assert.True(t, m.State.ProjectState.Lock.Loading)
+
+	_, cmd = m.handleBrowseLockKey()
+	assert.Nil(t, cmd)
+	assert.Equal(t, panels.LockBrowserState{}, m.State.ProjectState.Lock)
+}
+
+func TestHandleLockFileLoadedMsg(t *testing.T) {
+	m := newTestModel()
+	m.State.ProjectState.Lock = panels.LockBrowserState{Visible: true, Loading: true}
+
+	m.handleLockFileLoadedMsg(ui.LockFileLoadedMsg{Lock: &types.LockFile{Packages: []types.LockPackage{{Name: "idna"}}}})
+	assert.False(t, m.State.ProjectState.Lock.Loading)
+	assert.Len(t, m.State.ProjectState.Lock.Lock.Packages, 1)
+	assert.Contains(t, m.State.Messages, "Loaded 1 locked packages")
+
+	m.State.ProjectState.Lock = panels.LockBrowserState{Visible: true, Loading: true}
+	m.handleLockFileLoadedMsg(ui.LockFileLoadedMsg{Error: fmt.Errorf("unsupported uv.lock version 2")})
+	assert.False(t, m.State.ProjectState.Lock.Visible)
+	assert.Contains(t, m.State.Messages, describeFailure("read uv.lock", fmt.Errorf("unsupported uv.lock version 2")))
+}
+
+func TestLockBrowserSearchAndNavigation(t *testing.T) {
+	m := newTestModel()
+	m.State.ActivePanel = types.ProjectPanel
+	m.State.ProjectState.Lock = panels.LockBrowserState{Visible: true, Lock: &types.LockFile{Packages: []types.LockPackage{
+		{Name: "anyio"}, {Name: "idna"}, {Name: "sniffio"},
+	}}}
+
+	m.handleVerticalNavigation(1)
+	m.handleVerticalNavigation(1)
+	m.handleVerticalNavigation(1)
+	assert.Equal(t, 2, m.State.ProjectState.Lock.Selected)
+
+	m.handleSearchKey()
+	assert.Equal(t, InputModeLockSearch, m.InputMode)
+	m.handleTextInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("id")})
+	assert.Equal(t, "id", m.State.ProjectState.Lock.Query)
+	assert.Equal(t, 0, m.State.ProjectState.Lock.Selected)
+
+	m.handleTextInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.Equal(t, "id", m.State.ProjectState.Lock.Query, "Enter keeps the filter")
+
+	m.handleEnterKey()
+	assert.True(t, m.State.ProjectState.Lock.Expanded)
+
+	m.handleSearchKey()
+	m.handleTextInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, m.State.ProjectState.Lock.Query, "Esc clears the filter")
+}
+
+// newTreeTestModel returns a model showing a dependency tree in which anyio
+// occurs twice, the second time inside pytest.
+func newTreeTestModel() *Model {
+	m := newTestModel()
+	m.State.ActivePanel = types.ProjectPanel
+	m.State.ProjectState.ShowTree = true
+	m.UpdateProjectDependencies(nil, &types.DependencyTree{Dependencies: []types.TreeNode{
+		{Name: "demo", Children: []types.TreeNode{
+			{Name: "anyio", Level: 1, Children: []types.TreeNode{{Name: "idna", Level: 2}}},
+			{Name: "pytest", Group: "dev", Level: 1, Children: []types.TreeNode{{Name: "anyio", Level: 2, Repeated: true}}},
+		}},
+	}})
+	return m
+}
+
+func TestDependencyTreeNavigation(t *testing.T) {
+	m := newTreeTestModel()
+
+	m.handleVerticalNavigation(1)
+	assert.Equal(t, "demo/anyio", m.GetSelectedTreeRow().Path)
+
+	m.handleEnterKey()
+	assert.True(t, m.State.ProjectState.Tree.Collapsed["demo/anyio"])
+	m.handleVerticalNavigation(1)
+	assert.Equal(t, "demo/pytest@group:dev", m.GetSelectedTreeRow().Path, "collapsed children are skipped")
+
+	// Collapsing a node that is already collapsed, or a leaf, moves to its parent.
+	m.handleTreeExpandKey(false)
+	m.handleTreeExpandKey(false)
+	assert.Equal(t, "demo", m.GetSelectedTreeRow().Path)
+
+	m.handleVerticalNavigation(1)
+	m.handleTreeExpandKey(true)
+	assert.False(t, m.State.ProjectState.Tree.Collapsed["demo/anyio"])
+}
+
+func TestDependencyTreeJumpToOccurrence(t *testing.T) {
+	m := newTreeTestModel()
+	m.State.ProjectState.Tree.Collapsed = map[string]bool{"demo/pytest@group:dev": true}
+	m.handleVerticalNavigation(1)
+
+	m.handleOccurrenceKey(1)
+	assert.Equal(t, "demo/pytest@group:dev/anyio", m.GetSelectedTreeRow().Path)
+	assert.False(t, m.State.ProjectState.Tree.Collapsed["demo/pytest@group:dev"], "the occurrence is revealed")
+
+	m.handleOccurrenceKey(1)
+	assert.Equal(t, "demo/anyio", m.GetSelectedTreeRow().Path, "jumping wraps around")
+
+	m.handleVerticalNavigation(-1)
+	m.handleOccurrenceKey(-1)
+	assert.Equal(t, "demo", m.GetSelectedTreeRow().Path)
+	assert.Contains(t, m.State.Messages, "demo appears only once in the tree")
+}
+
+// newProjectTestModel returns a model on the Project panel of a detected
+// project.
+func newProjectTestModel() *Model {
+	m := newTestModel()
+	m.State.ActivePanel = types.ProjectPanel
+	m.State.Installed = true
+	m.State.ProjectState.Status = &types.ProjectStatus{IsProject: true}
+	return m
+}
+
+func typeText(m *Model, text string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+}
+
+func TestDependencyDialog_Add(t *testing.T) {
+	m := newProjectTestModel()
+
+	_, cmd := m.handleAppKey()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.AddDependencyForm, m.State.Form.ID)
+
+	typeText(m, "httpx")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "0.27.0")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	form := &m.State.Form
+	assert.Equal(t, "httpx", form.Value(panels.DependencyFieldPackage))
+	assert.Equal(t, "0.27.0", form.Value(panels.DependencyFieldSpecifier))
+	assert.Equal(t, "optional", form.Value(panels.DependencyFieldTarget))
+	assert.Equal(t, "uv add httpx==0.27.0", form.Preview)
+
+	// The extra name is required for optional dependencies.
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "Enter the name of the extra", m.State.Form.Error)
+	assert.Equal(t, InputModeForm, m.InputMode)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "net")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.False(t, m.State.Form.Visible)
+
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "add", jobs[0].Operation)
+		assert.Equal(t, "httpx==0.27.0", jobs[0].Target)
+	}
+}
+
+func TestDependencyDialog_RemoveAndCancel(t *testing.T) {
+	m := newProjectTestModel()
+
+	m.handleDeleteKey()
+	assert.Equal(t, panels.RemoveDependencyForm, m.State.Form.ID)
+	typeText(m, "ruff")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.False(t, m.State.Form.Visible)
+
+	// Reopening keeps what was typed.
+	m.handleDeleteKey()
+	assert.Equal(t, "ruff", m.State.Form.Value(panels.DependencyFieldPackage))
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "remove", jobs[0].Operation)
+		assert.Equal(t, "ruff", jobs[0].Target)
+	}
+}
+
+func TestHandleDependencyOperationMsg(t *testing.T) {
+	m := newProjectTestModel()
+	form := panels.NewDependencyForm(false)
+	form.Field(panels.DependencyFieldPackage).Value = "nonexistent"
+	m.State.ProjectState.DependencyForm = form
+
+	details := []string{"× No solution found when resolving dependencies:"}
+	m.handleDependencyOperationMsg(ui.DependencyOperationMsg{
+		Operation: "add",
+		Package:   "nonexistent",
+		Error:     fmt.Errorf("exit status 1"),
+		Details:   details,
+	})
+	assert.True(t, m.State.Form.Visible, "the dialog reopens to show the error")
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, "nonexistent", m.State.Form.Value(panels.DependencyFieldPackage))
+	assert.Equal(t, "Failed to add nonexistent: exit status 1", m.State.Form.Error)
+	assert.Equal(t, details, m.State.Form.Details)
+	m.State.Width = 120
+	assert.Contains(t, m.View(), "No solution found")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_, cmd := m.handleDependencyOperationMsg(ui.DependencyOperationMsg{Operation: "add", Package: "httpx", Success: true})
+	assert.NotNil(t, cmd, "the dependencies are reloaded")
+	assert.True(t, m.State.ProjectState.Loading)
+	assert.Contains(t, m.State.Messages, "Added httpx")
+}
+
+func TestFailureDetails(t *testing.T) {
+	stderr := []string{
+		"Resolving dependencies...",
+		"  × No solution found when resolving dependencies:",
+		"  ╰─▶ Because nonexistent was not found in the package registry and your",
+		"      project depends on nonexistent, we can conclude that your project's",
+		"",
+		"      requirements are unsatisfiable.",
+	}
+	assert.Equal(t, []string{
+		"  × No solution found when resolving dependencies:",
+		"  ╰─▶ Because nonexistent was not found in the package registry and your",
+		"      project depends on nonexistent, we can conclude that your project's",
+		"      requirements are unsatisfiable.",
+	}, failureDetails(stderr))
+
+	assert.Equal(t, []string{"warning: something"}, failureDetails([]string{"warning: something"}))
+	assert.Nil(t, failureDetails(nil))
+}
+
+// newEnvironmentTestModel returns a model on the Environment panel of a
+// project with a .venv, after the environments have been listed.
+func newEnvironmentTestModel(t *testing.T) *Model {
+	t.Setenv("UV_PROJECT_ENVIRONMENT", "")
+	t.Setenv("VIRTUAL_ENV", "")
+	root := t.TempDir()
+	venv := filepath.Join(root, ".venv")
+	if err := os.MkdirAll(venv, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(venv, "pyvenv.cfg"), []byte("implementation = CPython\nversion_info = 3.12.7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newProjectTestModel()
+	m.SetProjectRoot(root)
+	m.State.ActivePanel = types.ProjectPanel
+	_, cmd := m.handleTabNavigation(1)
+	assert.Equal(t, types.EnvironmentPanel, m.State.ActivePanel)
+	assert.True(t, m.State.Environments.Loading)
+	m.Update(cmd())
+	return m
+}
+
+func TestEnvironmentPanel_List(t *testing.T) {
+	m := newEnvironmentTestModel(t)
+
+	assert.False(t, m.State.Environments.Loading)
+	env := m.GetSelectedEnvironment()
+	if assert.NotNil(t, env) {
+		assert.Equal(t, ".venv", env.Name)
+		assert.True(t, env.Project)
+		assert.Equal(t, "3.12.7", env.PythonVersion)
+	}
+	assert.Contains(t, m.renderActivePanel(), "[project]")
+}
+
+func TestEnvironmentPanel_Create(t *testing.T) {
+	m := newEnvironmentTestModel(t)
+	m.State.ProjectState.Status.PythonVersion = "3.12"
+
+	m.handleNewProjectKey()
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.CreateVenvForm, m.State.Form.ID)
+	assert.Equal(t, "3.12", m.State.Form.Value(panels.VenvFieldPython))
+
+	// The project environment already exists.
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, m.State.Form.Error, "already exists")
+
+	typeText(m, "envs/test")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	assert.Equal(t, "uv venv envs/test --python 3.12 --seed", m.State.Form.Preview)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, InputModeNone, m.InputMode)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "create", jobs[0].Operation)
+		assert.Equal(t, filepath.Join(m.EnvironmentManager.ProjectRoot(), "envs", "test"), jobs[0].Target)
+	}
+}
+
+func TestEnvironmentPanel_DeleteAndRecreate(t *testing.T) {
+	m := newEnvironmentTestModel(t)
+
+	m.handleDeleteKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Empty(t, m.Jobs.Jobs())
+
+	m.handleRecreateKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.NotNil(t, cmd)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "recreate", jobs[0].Operation)
+		assert.Equal(t, ".venv", jobs[0].Target)
+	}
+
+	_, cmd = m.handleEnvironmentOperationMsg(ui.EnvironmentOperationMsg{Operation: "recreate", Target: ".venv", Project: true, Success: true})
+	assert.NotNil(t, cmd)
+	assert.True(t, m.State.Environments.Loading)
+	assert.True(t, m.State.ProjectState.Loading, "the project status is reloaded")
+}
+
+func TestPackagesView(t *testing.T) {
+	m := newEnvironmentTestModel(t)
+	env := *m.GetSelectedEnvironment()
+
+	_, cmd := m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	view := &m.State.Environments.Packages
+	assert.True(t, view.Visible)
+	assert.True(t, view.Loading)
+	assert.Equal(t, panels.GetPackagesViewHelp(), m.getCurrentPanelHelp())
+
+	m.Update(ui.InstalledPackagesLoadedMsg{
+		Python: env.Interpreter,
+		Packages: []types.InstalledPackage{
+			{Name: "anyio", Version: "4.4.0"},
+			{Name: "httpx", Version: "0.27.0"},
+		},
+		Issues: []types.PackageIssue{{Package: "httpx", Requirement: "idna", Message: "The package `httpx` requires `idna`, but it's not installed"}},
+	})
+	assert.False(t, view.Loading)
+	assert.Len(t, view.Packages, 2)
+	assert.Contains(t, m.State.Messages, "Found 1 broken requirements in .venv")
+
+	// Expanding loads the details of the selection, and moving loads the next.
+	_, cmd = m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	assert.True(t, view.Expanded)
+	assert.True(t, view.DetailsLoading)
+	m.Update(ui.PackageDetailsLoadedMsg{Python: env.Interpreter, Details: &types.PackageDetails{InstalledPackage: view.Packages[0]}})
+	assert.False(t, view.DetailsLoading)
+	assert.Equal(t, "anyio", view.Details.Name)
+	_, cmd = m.handleVerticalNavigation(1)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "httpx", m.GetSelectedInstalledPackage().Name)
+
+	// The freeze output is loaded once.
+	_, cmd = m.handleToggleKey()
+	assert.NotNil(t, cmd)
+	assert.True(t, view.ShowFreeze)
+	m.Update(ui.PackageFreezeLoadedMsg{Python: env.Interpreter, Lines: []string{"anyio==4.4.0", "httpx==0.27.0"}})
+	assert.Equal(t, []string{"anyio==4.4.0", "httpx==0.27.0"}, view.Freeze)
+	m.handleToggleKey()
+	_, cmd = m.handleToggleKey()
+	assert.Nil(t, cmd)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, view.Visible)
+	m.Update(ui.InstalledPackagesLoadedMsg{Python: env.Interpreter, Error: fmt.Errorf("context canceled")})
+	assert.NotContains(t, m.State.Messages, "Failed to list installed packages: context canceled")
+}
+
+func newToolsTestModel(t *testing.T) *Model {
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+
+	m := newTestModel()
+	m.State.Installed = true
+	m.ToolManager = services.NewToolManager(&mockCommandExecutor{
+		ExecuteFunc: func(_ string, args ...string) ([]byte, error) {
+			if len(args) > 1 && args[1] == "dir" {
+				return []byte(binDir + "\n"), nil
+			}
+			return []byte("black v24.8.0 (/tools/black)\n- black (/bin/black)\nruff v0.6.9 (/tools/ruff)\n- ruff (/bin/ruff)\n"), nil
+		},
+	})
+	m.State.ActivePanel = types.DiagnosticsPanel
+	_, cmd := m.handleTabNavigation(1)
+	assert.Equal(t, types.ToolsPanel, m.State.ActivePanel)
+	assert.True(t, m.State.Tools.Loading)
+	m.Update(cmd())
+	return m
+}
+
+func TestToolsPanel_List(t *testing.T) {
+	m := newToolsTestModel(t)
+
+	assert.False(t, m.State.Tools.Loading)
+	assert.Len(t, m.State.Tools.Tools, 2)
+	assert.True(t, m.State.Tools.OnPath)
+	m.handleVerticalNavigation(1)
+	tool := m.GetSelectedTool()
+	if assert.NotNil(t, tool) {
+		assert.Equal(t, "ruff", tool.Name)
+		assert.Equal(t, "0.6.9", tool.Version)
+	}
+	assert.Equal(t, panels.GetToolsPanelHelp(), m.getCurrentPanelHelp())
+
+	m.Update(ui.ToolsLoadedMsg{BinDir: "/not/on/path"})
+	assert.False(t, m.State.Tools.OnPath)
+	assert.Nil(t, m.GetSelectedTool())
+	assert.Contains(t, m.State.Messages, "Tool directory /not/on/path is not on PATH")
+}
+
+func TestToolsPanel_Install(t *testing.T) {
+	m := newToolsTestModel(t)
+
+	m.handleInstallRefresh()
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.InstallToolForm, m.State.Form.ID)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotEmpty(t, m.State.Form.Error)
+
+	typeText(m, "httpie")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, InputModeNone, m.InputMode)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "install", jobs[0].Operation)
+		assert.Equal(t, "httpie", jobs[0].Target)
+	}
+
+	_, cmd = m.handleToolOperationMsg(ui.ToolOperationMsg{Operation: "install", Target: "httpie", Success: true})
+	assert.NotNil(t, cmd)
+	assert.True(t, m.State.Tools.Loading)
+	assert.Contains(t, m.State.Messages, "Installed httpie")
+}
+
+func TestToolsPanel_UpgradeAndUninstall(t *testing.T) {
+	m := newToolsTestModel(t)
+
+	m.handleUpdateUVKey(false)
+	m.handleUpdateUVKey(true)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 2) {
+		assert.Equal(t, "upgrade", jobs[0].Operation)
+		assert.Equal(t, "black", jobs[0].Target)
+		assert.Equal(t, "all tools", jobs[1].Target)
+	}
+
+	m.handleDeleteKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	jobs = m.Jobs.Jobs()
+	if assert.Len(t, jobs, 3) {
+		assert.Equal(t, "uninstall", jobs[2].Operation)
+		assert.Equal(t, "black", jobs[2].Target)
+	}
+
+	m.handleSyncKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.Len(t, m.Jobs.Jobs(), 4)
+
+	m.handleToolOperationMsg(ui.ToolOperationMsg{Operation: "uninstall", Target: "black", Error: assert.AnError})
+	assert.Contains(t, m.State.Messages[len(m.State.Messages)-1], "uninstall black")
+}
+
+func TestToolsPanel_Run(t *testing.T) {
+	m := newToolsTestModel(t)
+
+	m.handleLockOrLibKey()
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.RunToolForm, m.State.Form.ID)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotEmpty(t, m.State.Form.Error)
+
+	typeText(m, "ruff")
+	for i := 0; i < 4; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	typeText(m, "check .")
+	assert.Equal(t, "uvx ruff check .", m.State.Form.Preview)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "uvx", jobs[0].Operation)
+		assert.Equal(t, "ruff check .", jobs[0].Target)
+	}
+
+	options := types.ToolRunOptions{Package: "ruff", Args: []string{"check", "."}}
+	m.Update(ui.ToolRunMsg{Run: types.ToolRun{Options: options, ExitCode: 1, Error: assert.AnError}})
+	assert.Contains(t, m.State.Messages, "ruff exited with status 1")
+	assert.Len(t, m.State.Tools.History, 1)
+
+	// The history lists the run; Enter runs it again, interactive tools take
+	// over the terminal.
+	m.handleToggleKey()
+	assert.True(t, m.State.Tools.ShowHistory)
+	assert.Equal(t, panels.GetToolRunsHelp(), m.getCurrentPanelHelp())
+	m.Jobs.Finish(jobs[0].ID, assert.AnError, time.Now())
+	m.Jobs.ClearFinished()
+	_, cmd = m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	assert.Len(t, m.Jobs.Jobs(), 1)
+
+	m.handleLockOrLibKey()
+	assert.Equal(t, "uvx ruff check .", m.State.Form.Preview)
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	m.State.Tools.History[0].Options.Interactive = true
+	_, cmd = m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	assert.Len(t, m.Jobs.Jobs(), 1, "interactive tools do not run as jobs")
+
+	m.handleDeleteKey()
+	assert.Empty(t, m.State.Tools.History)
+}
+
+func newCacheTestModel(t *testing.T) (*Model, string) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{
+		"wheels-v5/pypi/httpx/0.27.0.msgpack": 100,
+		"wheels-v5/pypi/numpy/2.0.0.msgpack":  300,
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+	}
+
+	m := newTestModel()
+	m.State.Installed = true
+	m.CacheManager = services.NewCacheManager(&mockCommandExecutor{
+		ExecuteFunc: func(_ string, args ...string) ([]byte, error) {
+			return []byte(dir + "\n"), nil
+		},
+	})
+	m.State.ActivePanel = types.ToolsPanel
+	_, cmd := m.handleTabNavigation(1)
+	assert.Equal(t, types.CachePanel, m.State.ActivePanel)
+	assert.True(t, m.State.Cache.Loading)
+	m.Update(cmd())
+	return m, dir
+}
+
+func TestCachePanel_Inspect(t *testing.T) {
+	m, dir := newCacheTestModel(t)
+
+	cache := m.State.Cache
+	assert.False(t, cache.Loading)
+	if assert.NotNil(t, cache.Info) {
+		assert.Equal(t, dir, cache.Info.Dir)
+		assert.Equal(t, int64(400), cache.Info.Size)
+		assert.Len(t, cache.Info.Packages, 2)
+	}
+	assert.Equal(t, panels.GetCachePanelHelp(), m.getCurrentPanelHelp())
+	assert.Contains(t, m.renderActivePanel(), "Cached packages (2)")
+}
+
+func TestCachePanel_Clean(t *testing.T) {
+	m, _ := newCacheTestModel(t)
+
+	// Without marks, the selected package is cleaned.
+	m.handleDeleteKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	assert.Contains(t, m.confirm.prompt, "httpx (100 B)")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	m.handleEnterKey()
+	m.handleVerticalNavigation(1)
+	m.handleEnterKey()
+	assert.Equal(t, []string{"httpx", "numpy"}, m.State.Cache.MarkedPackages())
+	m.handleDeleteKey()
+	assert.Contains(t, m.confirm.prompt, "httpx, numpy (400 B)")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "clean", jobs[0].Operation)
+		assert.Equal(t, "httpx, numpy", jobs[0].Target)
+	}
+
+	_, cmd := m.handleCacheOperationMsg(ui.CacheOperationMsg{Operation: "clean", Target: "httpx, numpy", Reclaimed: 400, Success: true})
+	assert.NotNil(t, cmd)
+	assert.Contains(t, m.State.Messages, "Reclaimed 400 B by cleaning httpx, numpy")
+	assert.True(t, m.State.Cache.Loading)
+	assert.Empty(t, m.State.Cache.MarkedPackages())
+}
+
+func TestCachePanel_CleanAllAndPrune(t *testing.T) {
+	m, dir := newCacheTestModel(t)
+
+	m.handleCleanCacheKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	assert.Contains(t, m.confirm.prompt, "Remove everything (400 B) from the uv cache at "+dir)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	m.handlePinKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	m.handlePruneKey(true)
+	assert.Contains(t, m.confirm.prompt, "--ci")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 3) {
+		assert.Equal(t, "clean cache", jobs[0].Operation+" "+jobs[0].Target)
+		assert.Equal(t, "prune cache", jobs[1].Operation+" "+jobs[1].Target)
+		assert.Equal(t, "prune cache --ci", jobs[2].Operation+" "+jobs[2].Target)
+	}
+
+	m.handleCacheOperationMsg(ui.CacheOperationMsg{Operation: "prune", Target: "--ci", Reclaimed: 2048, Success: true})
+	assert.Equal(t, "Reclaimed 2.0 KiB by pruning the cache for CI", m.State.Cache.Reclaimed)
+}
+
+func newRunTestModel(t *testing.T) *Model {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte("[project]\nname = \"demo\"\n\n[project.scripts]\ndemo = \"demo.cli:main\"\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.py"), []byte("print('hi')\n"), 0o644))
+
+	m := newProjectTestModel()
+	m.SetProjectRoot(root)
+	m.State.ActivePanel = types.EnvironmentPanel
+	_, cmd := m.handleTabNavigation(1)
+	assert.Equal(t, types.RunPanel, m.State.ActivePanel)
+	assert.True(t, m.State.Run.Loading)
+	m.Update(cmd())
+	return m
+}
+
+func TestRunPanel_List(t *testing.T) {
+	m := newRunTestModel(t)
+
+	assert.False(t, m.State.Run.Loading)
+	if assert.Len(t, m.State.Run.Targets, 2) {
+		assert.Equal(t, types.RunTarget{Name: "demo", Kind: types.RunTargetScript, Spec: "demo.cli:main"}, m.State.Run.Targets[0])
+		assert.Equal(t, "main.py", m.State.Run.Targets[1].Name)
+	}
+	assert.Equal(t, panels.GetRunPanelHelp(), m.getCurrentPanelHelp())
+}
+
+func TestRunPanel_Run(t *testing.T) {
+	m := newRunTestModel(t)
+	m.handleVerticalNavigation(1)
+
+	m.handleEnterKey()
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.RunScriptForm, m.State.Form.ID)
+	assert.Equal(t, "main.py", m.State.Form.Value(panels.ScriptFieldTarget))
+
+	typeText(m, "--name 'a b'")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "DEBUG")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, m.State.Form.Error, "KEY=VALUE")
+
+	typeText(m, "=1")
+	assert.Equal(t, "DEBUG=1 uv run main.py --name 'a b'", m.State.Form.Preview)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, InputModeNone, m.InputMode)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "run", jobs[0].Operation)
+		assert.Equal(t, "main.py --name 'a b'", jobs[0].Target)
+	}
+
+	options := types.RunOptions{Target: "main.py", Args: []string{"--name", "a b"}, Env: []string{"DEBUG=1"}}
+	m.Update(ui.ScriptRunMsg{Run: types.ScriptRun{Options: options, Duration: 1500 * time.Millisecond, ExitCode: 2, Error: assert.AnError}})
+	assert.Contains(t, m.State.Messages, "main.py exited with status 2 after 1.5s")
+
+	// The next run of the target starts from the previous arguments.
+	m.handleEnterKey()
+	assert.Equal(t, "DEBUG=1 uv run main.py --name 'a b'", m.State.Form.Preview)
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	m.handleToggleKey()
+	assert.Equal(t, panels.GetRunHistoryHelp(), m.getCurrentPanelHelp())
+	m.Jobs.Finish(jobs[0].ID, assert.AnError, time.Now())
+	m.Jobs.ClearFinished()
+	_, cmd = m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	assert.Len(t, m.Jobs.Jobs(), 1)
+
+	m.handleDeleteKey()
+	assert.Empty(t, m.State.Run.History)
+}
+
+func TestRunPanel_ScriptMetadata(t *testing.T) {
+	m := newRunTestModel(t)
+	script := "# /// script\n# requires-python = \">=3.12\"\n# dependencies = [\"httpx\"]\n# ///\nimport httpx\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(m.ScriptManager.ProjectRoot(), "fetch.py"), []byte(script), 0o644))
+	_, cmd := m.handleRefresh()
+	m.Update(cmd())
+	if assert.Len(t, m.State.Run.Targets, 3) {
+		assert.True(t, m.State.Run.Targets[1].Inline)
+	}
+
+	// Entry points have no inline metadata.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	assert.False(t, m.State.Run.Script.Visible)
+
+	m.handleVerticalNavigation(1)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	assert.True(t, m.State.Run.Script.Visible)
+	assert.True(t, m.State.Run.Script.Loading)
+	m.Update(cmd())
+	view := m.State.Run.Script
+	assert.False(t, view.Loading)
+	if assert.NotNil(t, view.Metadata) {
+		assert.Equal(t, ">=3.12", view.Metadata.RequiresPython)
+		assert.Equal(t, "httpx", m.GetSelectedScriptDependency().Name)
+	}
+	assert.Equal(t, panels.GetScriptViewHelp(), m.getCurrentPanelHelp())
+
+	m.handleAppKey()
+	assert.Equal(t, panels.AddScriptDependencyForm, m.State.Form.ID)
+	typeText(m, "rich")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, ">=13")
+	assert.Equal(t, "uv add --script fetch.py rich>=13", m.State.Form.Preview)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, InputModeNone, m.InputMode)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "add", jobs[0].Operation)
+		assert.Equal(t, "fetch.py rich>=13", jobs[0].Target)
+	}
+
+	// A failed add reopens the dialog with uv's explanation.
+	m.Update(ui.ScriptOperationMsg{Operation: "add", Script: "fetch.py", Package: "rich>=13", Error: assert.AnError, Details: []string{"No solution found"}})
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, "rich>=13", m.State.Form.Value(panels.DependencyFieldPackage))
+	assert.Equal(t, []string{"No solution found"}, m.State.Form.Details)
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	_, cmd = m.Update(ui.ScriptOperationMsg{Operation: "lock", Script: "fetch.py", Success: true})
+	assert.NotNil(t, cmd)
+	assert.Contains(t, m.State.Messages, "Locked fetch.py")
+	assert.True(t, m.State.Run.Script.Loading)
+
+	m.handleDeleteKey()
+	if assert.NotNil(t, m.confirm) {
+		assert.Equal(t, "Remove httpx from fetch.py?", m.confirm.prompt)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.State.Run.Script.Visible)
+	assert.Equal(t, panels.GetRunPanelHelp(), m.getCurrentPanelHelp())
+}
+
+func TestTasksPanel_Run(t *testing.T) {
+	root := t.TempDir()
+	pyproject := "[project]\nname = \"demo\"\n\n[tool.uvui.tasks]\nlint = \"ruff check .\"\ntest = { cmd = \"pytest -q\", depends = [\"lint\"] }\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte(pyproject), 0o644))
+
+	m := newProjectTestModel()
+	m.SetProjectRoot(root)
+	m.State.ActivePanel = types.RunPanel
+	_, cmd := m.handleTabNavigation(1)
+	assert.Equal(t, types.TasksPanel, m.State.ActivePanel)
+	assert.True(t, m.State.Tasks.Loading)
+	m.Update(cmd())
+	assert.False(t, m.State.Tasks.Loading)
+	if assert.Len(t, m.State.Tasks.Tasks, 2) {
+		assert.Equal(t, []string{"lint"}, m.State.Tasks.Tasks[1].Depends)
+	}
+	assert.Equal(t, panels.GetTasksPanelHelp(), m.getCurrentPanelHelp())
+
+	m.handleVerticalNavigation(1)
+	_, cmd = m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "task", jobs[0].Operation)
+		assert.Equal(t, "test", jobs[0].Target)
+	}
+
+	m.Update(ui.TaskRunMsg{Task: "test", Results: []types.TaskResult{
+		{Task: "lint", Duration: time.Second},
+		{Task: "test", Duration: 500 * time.Millisecond, ExitCode: 2, Error: assert.AnError},
+	}, Error: assert.AnError})
+	assert.Contains(t, m.State.Messages, "Task test stopped: test exited with status 2 after 1.5s")
+	assert.Equal(t, 2, m.State.Tasks.Results["test"].ExitCode)
+	assert.NoError(t, m.State.Tasks.Results["lint"].Error)
+
+	m.Update(ui.TaskRunMsg{Task: "lint", Results: []types.TaskResult{{Task: "lint", Duration: time.Second}}})
+	assert.Contains(t, m.State.Messages, "Task lint finished in 1s")
+
+	m.handleToggleKey()
+	assert.True(t, m.State.Tasks.ShowLog)
+}
+
+func TestTestsPanel_Run(t *testing.T) {
+	m := newProjectTestModel()
+	m.State.ActivePanel = types.TasksPanel
+	m.handleTabNavigation(1)
+	assert.Equal(t, types.TestsPanel, m.State.ActivePanel)
+	assert.Equal(t, panels.GetTestsPanelHelp(), m.getCurrentPanelHelp())
+
+	m.handleAppKey()
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.PytestForm, m.State.Form.ID)
+	typeText(m, "tests")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "-x")
+	assert.Equal(t, "uv run pytest -x tests", m.State.Form.Preview)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.Equal(t, types.PytestOptions{Tests: []string{"tests"}, Args: []string{"-x"}}, m.State.Tests.Options)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "pytest", jobs[0].Operation)
+		assert.Equal(t, "tests", jobs[0].Target)
+	}
+
+	m.Update(ui.TestRunMsg{Options: m.State.Tests.Options, Report: &types.TestReport{
+		Duration: 1500 * time.Millisecond,
+		Cases: []types.TestCase{
+			{NodeID: "tests/test_a.py::test_ok", Outcome: types.TestPassed},
+			{NodeID: "tests/test_a.py::test_one", Outcome: types.TestFailed},
+			{NodeID: "tests/test_a.py::test_two", Outcome: types.TestFailed},
+			{NodeID: "tests/test_a.py::test_later", Outcome: types.TestSkipped},
+		},
+	}, Error: assert.AnError})
+	assert.Contains(t, m.State.Messages, "Tests: 1 passed, 2 failed, 1 skipped in 1.5s")
+	assert.Len(t, m.State.Tests.VisibleCases(), 2)
+
+	m.handleVerticalNavigation(1)
+	assert.Equal(t, "tests/test_a.py::test_two", m.GetSelectedTestCase().NodeID)
+	m.handleEnterKey()
+	jobs = m.Jobs.Jobs()
+	if assert.Len(t, jobs, 2) {
+		assert.Equal(t, "tests/test_a.py::test_two", jobs[1].Target)
+	}
+
+	m.handleRerunFailedKey()
+	jobs = m.Jobs.Jobs()
+	if assert.Len(t, jobs, 3) {
+		assert.Equal(t, "2 failed", jobs[2].Target)
+	}
+	assert.Contains(t, m.State.Messages, "Running uv run pytest -x tests/test_a.py::test_one tests/test_a.py::test_two...")
+
+	m.Update(ui.TestRunMsg{Rerun: true, Report: &types.TestReport{
+		Duration: 200 * time.Millisecond,
+		Cases:    []types.TestCase{{NodeID: "tests/test_a.py::test_two", Outcome: types.TestPassed}},
+	}})
+	assert.Contains(t, m.State.Messages, "Tests: 1 passed in 200ms")
+	assert.Len(t, m.State.Tests.Report.Cases, 4)
+	if assert.Len(t, m.State.Tests.VisibleCases(), 1) {
+		assert.Equal(t, "tests/test_a.py::test_one", m.GetSelectedTestCase().NodeID)
+	}
+
+	m.handleToggleKey()
+	assert.True(t, m.State.Tests.ShowAll)
+	assert.Len(t, m.State.Tests.VisibleCases(), 4)
+
+	m.Update(ui.TestRunMsg{Error: assert.AnError})
+	assert.Contains(t, m.State.Messages, "Failed to run pytest: "+assert.AnError.Error())
+}
+
+func TestTestsPanel_Matrix(t *testing.T) {
+	root := t.TempDir()
+	pyproject := "[project]\nname = \"demo\"\n\n[tool.uvui.matrix]\ncmd = \"pytest -q\"\npython = [\"3.11\", \"3.12\", \"3.13\"]\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte(pyproject), 0o644))
+
+	m := newProjectTestModel()
+	m.SetProjectRoot(root)
+	m.State.ActivePanel = types.TestsPanel
+	_, cmd := m.handleMatrixKey()
+	assert.True(t, m.State.Tests.Matrix.Visible)
+	assert.True(t, m.State.Tests.Matrix.Loading)
+	m.Update(cmd())
+	assert.False(t, m.State.Tests.Matrix.Loading)
+	assert.Equal(t, []string{"3.11", "3.12", "3.13"}, m.State.Tests.Matrix.Pythons())
+	assert.Equal(t, panels.GetMatrixViewHelp(), m.getCurrentPanelHelp())
+
+	_, cmd = m.handleEnterKey()
+	assert.NotNil(t, cmd)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "matrix", jobs[0].Operation)
+		assert.Equal(t, "3.11 3.12 3.13", jobs[0].Target)
+		assert.Contains(t, jobs[0].Resources, "python:3.12")
+	}
+	assert.Contains(t, panels.RenderTestsPanel(m.State), "⏳ running")
+
+	m.Update(ui.MatrixRunMsg{Pythons: []string{"3.11", "3.12", "3.13"}, Results: []types.MatrixResult{
+		{Python: "3.11", Duration: time.Second, ExitCode: 1, Error: assert.AnError},
+		{Python: "3.12", Duration: time.Second},
+		{Python: "3.13", Duration: time.Second},
+	}, Error: assert.AnError})
+	assert.Contains(t, m.State.Messages, "Matrix: 2 of 3 Pythons passed, failed with 3.11")
+
+	m.Jobs.Cancel(jobs[0].ID, time.Now())
+	m.handleRerunFailedKey()
+	jobs = m.Jobs.Jobs()
+	if assert.Len(t, jobs, 2) {
+		assert.Equal(t, "3.11", jobs[1].Target)
+	}
+
+	m.handleVerticalNavigation(1)
+	assert.Equal(t, 1, m.State.Tests.Matrix.Selected)
+	m.handleBackKey()
+	assert.False(t, m.State.Tests.Matrix.Visible)
+	assert.Equal(t, panels.GetTestsPanelHelp(), m.getCurrentPanelHelp())
+}
+
+func TestDiagnosticsPanel_Run(t *testing.T) {
+	m := newProjectTestModel()
+	m.State.ActivePanel = types.TestsPanel
+	m.handleTabNavigation(1)
+	assert.Equal(t, types.DiagnosticsPanel, m.State.ActivePanel)
+	assert.Equal(t, panels.GetDiagnosticsPanelHelp(), m.getCurrentPanelHelp())
+
+	_, cmd := m.handleRefresh()
+	assert.NotNil(t, cmd)
+	jobs := m.Jobs.Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "check", jobs[0].Operation)
+		assert.Equal(t, "ruff mypy", jobs[0].Target)
+	}
+
+	m.Update(ui.DiagnosticsMsg{
+		Diagnostics: []types.Diagnostic{
+			{Tool: types.DiagnosticsRuff, File: "src/app.py", Line: 1, Column: 8, Severity: types.SeverityError, Rule: "F401", Message: "`os` imported but unused", Fixable: true},
+			{Tool: types.DiagnosticsRuff, File: "tests/test_app.py", Line: 4, Column: 1, Severity: types.SeverityError, Rule: "E402", Message: "Module level import not at top of file"},
+		},
+		Failures: map[string]error{types.DiagnosticsMypy: assert.AnError},
+	})
+	assert.Contains(t, m.State.Messages, "Diagnostics: 2 found, mypy could not run")
+	assert.Len(t, m.State.Diagnostics.Visible(), 2)
+
+	m.handleVerticalNavigation(1)
+	assert.Equal(t, "tests/test_app.py", m.GetSelectedDiagnostic().File)
+
+	m.handleSearchKey()
+	assert.Equal(t, InputModeForm, m.InputMode)
+	assert.Equal(t, panels.DiagnosticsFilterForm, m.State.Form.ID)
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "F4")
+	assert.Equal(t, "1 of 2 diagnostics shown", m.State.Form.Preview)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, InputModeNone, m.InputMode)
+	assert.Equal(t, panels.DiagnosticsFilter{Rule: "F4"}, m.State.Diagnostics.Filter)
+	if assert.NotNil(t, m.GetSelectedDiagnostic()) {
+		assert.Equal(t, "F401", m.GetSelectedDiagnostic().Rule)
+	}
+
+	m.handleFixKey()
+	assert.Equal(t, InputModeConfirm, m.InputMode)
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	jobs = m.Jobs.Jobs()
+	if assert.Len(t, jobs, 2) {
+		assert.Equal(t, "fix", jobs[1].Operation)
+		assert.Equal(t, types.DiagnosticsRuff, jobs[1].Target)
+	}
+
+	m.Update(ui.RuffFixMsg{})
+	assert.Contains(t, m.State.Messages, "Applied ruff's fixes")
+
+	m.Update(ui.EditorClosedMsg{File: "src/app.py", Error: assert.AnError})
+	assert.Contains(t, m.State.Messages, fmt.Sprintf("Failed to open src/app.py in the editor: %v", assert.AnError))
+}
@@ -0,0 +1,52 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+	"strings"
+
+	"uvui/internal/services"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadCache measures the uv cache.
+func LoadCache(ctx context.Context, cacheManager services.CacheManagerInterface) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		info, err := cacheManager.Inspect(ctx)
+		return ui.CacheLoadedMsg{Info: info, Error: err}
+	})
+}
+
+// CleanCache returns a job that removes packages, or everything, from the
+// cache.
+func CleanCache(cacheManager services.CacheManagerInterface, packages []string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		reclaimed, err := cacheManager.Clean(ctx, packages, output)
+		return cacheOperationMsg("clean", strings.Join(packages, ", "), reclaimed, err), err
+	}
+}
+
+// PruneCache returns a job that removes unused entries from the cache.
+func PruneCache(cacheManager services.CacheManagerInterface, ci bool) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		reclaimed, err := cacheManager.Prune(ctx, ci, output)
+		target := ""
+		if ci {
+			target = "--ci"
+		}
+		return cacheOperationMsg("prune", target, reclaimed, err), err
+	}
+}
+
+// cacheOperationMsg reports the result of a cache operation.
+func cacheOperationMsg(operation, target string, reclaimed int64, err error) ui.CacheOperationMsg {
+	return ui.CacheOperationMsg{
+		Operation: operation,
+		Target:    target,
+		Reclaimed: reclaimed,
+		Success:   err == nil,
+		Error:     err,
+	}
+}
@@ -2,14 +2,49 @@
 package app
 
 import (
+	"context"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"uvui/internal/services"
+	"uvui/internal/types"
 	"uvui/internal/ui"
 )
 
+// outputBufferSize is the number of output lines buffered between a running
+// command and the UI before the command blocks.
+const outputBufferSize = 256
+
+// streamOperation runs fn in the background, forwarding every output line it
+// produces to the UI as a ui.CommandOutputMsg tagged with jobID, followed by
+// the message fn returns.
+func streamOperation(fn func(output services.OutputHandler) tea.Msg, jobID int) tea.Cmd {
+	return func() tea.Msg {
+		stream := make(chan tea.Msg, outputBufferSize)
+		go func() {
+			defer close(stream)
+			result := fn(func(line types.OutputLine) {
+				stream <- ui.CommandOutputMsg{JobID: jobID, Line: line, Stream: stream}
+			})
+			stream <- result
+		}()
+		return waitForOutput(stream)()
+	}
+}
+
+// waitForOutput waits for the next message from a streaming operation.
+func waitForOutput(stream <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
 // handleInitConfig creates a new keybindings.json file with default values.
 func (m *Model) handleInitConfig() (tea.Model, tea.Cmd) {
 	_, err := os.Stat("keybindings.json")
@@ -33,38 +68,65 @@ func (m *Model) handleInitConfig() (tea.Model, tea.Cmd) {
 }
 
 // CheckUVStatus checks the UV installation status.
-func CheckUVStatus(installer services.UVInstallerInterface) tea.Cmd {
+func CheckUVStatus(ctx context.Context, installer services.UVInstallerInterface) tea.Cmd {
 	return func() tea.Msg {
-		installed, version, err := installer.IsInstalled()
+		installed, version, err := installer.IsInstalled(ctx)
 		if err != nil {
 			return ui.UVInstalledMsg{Success: false, Error: err}
 		}
 
+		_, managed := installer.ManagedPath()
 		return ui.UVInstalledMsg{
 			Success: installed,
 			Version: version,
+			Managed: managed,
 			Error:   nil,
 		}
 	}
 }
 
-// InstallUV installs UV.
-func InstallUV(installer services.UVInstallerInterface) tea.Cmd {
+// DiscoverUVBinaries finds the uv binaries available on this machine.
+func DiscoverUVBinaries(ctx context.Context, locator services.UVLocatorInterface) tea.Cmd {
 	return func() tea.Msg {
-		err := installer.Install()
-		return ui.UVInstalledMsg{Success: err == nil, Error: err}
+		binaries, err := locator.Discover(ctx)
+		return ui.UVBinariesLoadedMsg{Binaries: binaries, Error: err}
+	}
+}
+
+// InstallUV returns a job that installs UV.
+func InstallUV(installer services.UVInstallerInterface) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		status, err := installer.Install(ctx, output)
+		return ui.UVInstalledMsg{Success: err == nil, Version: status.Version, Path: status.Path, Managed: status.Managed, Error: err}, err
+	}
+}
+
+// SelfUpdateUV returns a job that updates uv to version, or to the latest
+// release if version is empty.
+func SelfUpdateUV(installer services.UVInstallerInterface, version string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := installer.SelfUpdate(ctx, version, output)
+		return ui.UVOperationMsg{Operation: "update", Target: version, Success: err == nil, Error: err}, err
+	}
+}
+
+// UninstallUV returns a job that removes the uv installed by uvui.
+func UninstallUV(installer services.UVInstallerInterface) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := installer.Uninstall(ctx, output)
+		return ui.UVOperationMsg{Operation: "uninstall", Success: err == nil, Error: err}, err
 	}
 }
 
 // LoadPythonVersions loads Python version information.
-func LoadPythonVersions(manager services.PythonManagerInterface) tea.Cmd {
+func LoadPythonVersions(ctx context.Context, manager services.PythonManagerInterface) tea.Cmd {
 	return func() tea.Msg {
-		available, err := manager.ListAvailable()
+		available, err := manager.ListAvailable(ctx)
 		if err != nil {
 			return ui.PythonVersionsLoadedMsg{Error: err}
 		}
 
-		installed, err := manager.ListInstalled()
+		installed, err := manager.ListInstalled(ctx)
 		if err != nil {
 			return ui.PythonVersionsLoadedMsg{Available: available, Error: err}
 		}
@@ -76,41 +138,48 @@ func LoadPythonVersions(manager services.PythonManagerInterface) tea.Cmd {
 	}
 }
 
-// InstallPythonVersion installs a Python version.
-func InstallPythonVersion(manager services.PythonManagerInterface, version string) tea.Cmd {
-	return func() tea.Msg {
-		err := manager.Install(version)
+// InstallPythonVersion returns a job that installs a Python version.
+func InstallPythonVersion(manager services.PythonManagerInterface, version string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := manager.Install(ctx, version, output)
 		return ui.PythonOperationMsg{
 			Operation: "install",
 			Target:    version,
 			Success:   err == nil,
 			Error:     err,
-		}
+		}, err
 	}
 }
 
-// UninstallPythonVersion uninstalls a Python version.
-func UninstallPythonVersion(manager services.PythonManagerInterface, version string) tea.Cmd {
-	return func() tea.Msg {
-		err := manager.Uninstall(version)
+// UninstallPythonVersion returns a job that uninstalls a Python version.
+func UninstallPythonVersion(manager services.PythonManagerInterface, version string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := manager.Uninstall(ctx, version, output)
 		return ui.PythonOperationMsg{
 			Operation: "uninstall",
 			Target:    version,
 			Success:   err == nil,
 			Error:     err,
-		}
+		}, err
 	}
 }
 
-// PinPythonVersion pins a Python version.
-func PinPythonVersion(manager services.PythonManagerInterface, version string) tea.Cmd {
-	return func() tea.Msg {
-		err := manager.Pin(version)
+// PinPythonVersion returns a job that pins a Python version.
+func PinPythonVersion(manager services.PythonManagerInterface, version string) JobFunc {
+	return func(ctx context.Context, _ services.OutputHandler) (tea.Msg, error) {
+		err := manager.Pin(ctx, version)
 		return ui.PythonOperationMsg{
 			Operation: "pin",
 			Target:    version,
 			Success:   err == nil,
 			Error:     err,
-		}
+		}, err
 	}
 }
+
+// tickJobs schedules the next refresh of running job durations.
+func tickJobs() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return ui.JobTickMsg{}
+	})
+}
@@ -1,11 +1,14 @@
 package app
 
 import (
+	"context"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"uvui/internal/services"
 	"uvui/internal/types"
 	"uvui/internal/ui"
 )
@@ -13,18 +16,37 @@ import (
 // Mock services for testing commands
 type MockUVInstaller struct {
 	mock.Mock
+	managedPath string
 }
 
-func (m *MockUVInstaller) IsInstalled() (bool, string, error) {
+func (m *MockUVInstaller) IsInstalled(_ context.Context) (bool, string, error) {
 	args := m.Called()
 	return args.Bool(0), args.String(1), args.Error(2)
 }
 
-func (m *MockUVInstaller) Install() error {
+func (m *MockUVInstaller) Install(_ context.Context, _ services.OutputHandler) (types.UVStatus, error) {
 	args := m.Called()
+	return args.Get(0).(types.UVStatus), args.Error(1)
+}
+
+func (m *MockUVInstaller) SetInstallOptions(options types.UVInstallOptions) {
+	m.Called(options)
+}
+
+func (m *MockUVInstaller) SelfUpdate(_ context.Context, version string, _ services.OutputHandler) error {
+	args := m.Called(version)
 	return args.Error(0)
 }
 
+func (m *MockUVInstaller) Uninstall(_ context.Context, _ services.OutputHandler) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockUVInstaller) ManagedPath() (string, bool) {
+	return m.managedPath, m.managedPath != ""
+}
+
 func (m *MockUVInstaller) GetInstallCommand() (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
@@ -32,34 +54,43 @@ func (m *MockUVInstaller) GetInstallCommand() (string, error) {
 
 type MockPythonManager struct {
 	mock.Mock
+	root string
+}
+
+func (m *MockPythonManager) SetProjectRoot(dir string) {
+	m.root = dir
+}
+
+func (m *MockPythonManager) ProjectRoot() string {
+	return m.root
 }
 
-func (m *MockPythonManager) ListAvailable() ([]types.PythonVersion, error) {
+func (m *MockPythonManager) ListAvailable(_ context.Context) ([]types.PythonVersion, error) {
 	args := m.Called()
 	return args.Get(0).([]types.PythonVersion), args.Error(1)
 }
 
-func (m *MockPythonManager) ListInstalled() ([]types.PythonVersion, error) {
+func (m *MockPythonManager) ListInstalled(_ context.Context) ([]types.PythonVersion, error) {
 	args := m.Called()
 	return args.Get(0).([]types.PythonVersion), args.Error(1)
 }
 
-func (m *MockPythonManager) Install(version string) error {
+func (m *MockPythonManager) Install(_ context.Context, version string, _ services.OutputHandler) error {
 	args := m.Called(version)
 	return args.Error(0)
 }
 
-func (m *MockPythonManager) Uninstall(version string) error {
+func (m *MockPythonManager) Uninstall(_ context.Context, version string, _ services.OutputHandler) error {
 	args := m.Called(version)
 	return args.Error(0)
 }
 
-func (m *MockPythonManager) Pin(version string) error {
+func (m *MockPythonManager) Pin(_ context.Context, version string) error {
 	args := m.Called(version)
 	return args.Error(0)
 }
 
-func (m *MockPythonManager) Find(version string) (*types.PythonVersion, error) {
+func (m *MockPythonManager) Find(_ context.Context, version string) (*types.PythonVersion, error) {
 	args := m.Called(version)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -73,7 +104,7 @@ func TestCheckUVStatus(t *testing.T) {
 	// Test successful check
 	mockInstaller.On("IsInstalled").Return(true, "1.0.0", nil)
 
-	cmd := CheckUVStatus(mockInstaller)
+	cmd := CheckUVStatus(context.Background(), mockInstaller)
 	assert.NotNil(t, cmd)
 
 	// Execute the command
@@ -94,7 +125,7 @@ func TestCheckUVStatus_Error(t *testing.T) {
 	// Test error case
 	mockInstaller.On("IsInstalled").Return(false, "", assert.AnError)
 
-	cmd := CheckUVStatus(mockInstaller)
+	cmd := CheckUVStatus(context.Background(), mockInstaller)
 	assert.NotNil(t, cmd)
 
 	// Execute the command
@@ -113,18 +144,20 @@ func TestInstallUV(t *testing.T) {
 	mockInstaller := &MockUVInstaller{}
 
 	// Test successful installation
-	mockInstaller.On("Install").Return(nil)
+	mockInstaller.On("Install").Return(types.UVStatus{Installed: true, Version: "uv 0.5.0", Path: "/opt/uv/uv"}, nil)
 
-	cmd := InstallUV(mockInstaller)
-	assert.NotNil(t, cmd)
+	job := InstallUV(mockInstaller)
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.NoError(t, err)
 	uvMsg, ok := msg.(ui.UVInstalledMsg)
 
 	assert.True(t, ok)
 	assert.True(t, uvMsg.Success)
-	assert.Equal(t, "", uvMsg.Version)
+	assert.Equal(t, "uv 0.5.0", uvMsg.Version)
+	assert.Equal(t, "/opt/uv/uv", uvMsg.Path)
 	assert.Nil(t, uvMsg.Error)
 
 	mockInstaller.AssertExpectations(t)
@@ -134,13 +167,14 @@ func TestInstallUV_Error(t *testing.T) {
 	mockInstaller := &MockUVInstaller{}
 
 	// Test installation error
-	mockInstaller.On("Install").Return(assert.AnError)
+	mockInstaller.On("Install").Return(types.UVStatus{}, assert.AnError)
 
-	cmd := InstallUV(mockInstaller)
-	assert.NotNil(t, cmd)
+	job := InstallUV(mockInstaller)
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.Error(t, err)
 	uvMsg, ok := msg.(ui.UVInstalledMsg)
 
 	assert.True(t, ok)
@@ -151,6 +185,31 @@ func TestInstallUV_Error(t *testing.T) {
 	mockInstaller.AssertExpectations(t)
 }
 
+func TestSelfUpdateUV(t *testing.T) {
+	mockInstaller := &MockUVInstaller{}
+	mockInstaller.On("SelfUpdate", "0.5.4").Return(nil)
+
+	msg, err := SelfUpdateUV(mockInstaller, "0.5.4")(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ui.UVOperationMsg{Operation: "update", Target: "0.5.4", Success: true}, msg)
+
+	mockInstaller.AssertExpectations(t)
+}
+
+func TestUninstallUV_Error(t *testing.T) {
+	mockInstaller := &MockUVInstaller{}
+	mockInstaller.On("Uninstall").Return(assert.AnError)
+
+	msg, err := UninstallUV(mockInstaller)(context.Background(), nil)
+	assert.Error(t, err)
+	uvMsg, ok := msg.(ui.UVOperationMsg)
+	assert.True(t, ok)
+	assert.False(t, uvMsg.Success)
+	assert.Equal(t, "uninstall", uvMsg.Operation)
+
+	mockInstaller.AssertExpectations(t)
+}
+
 func TestLoadPythonVersions(t *testing.T) {
 	mockManager := &MockPythonManager{}
 
@@ -166,7 +225,7 @@ func TestLoadPythonVersions(t *testing.T) {
 	mockManager.On("ListAvailable").Return(available, nil)
 	mockManager.On("ListInstalled").Return(installed, nil)
 
-	cmd := LoadPythonVersions(mockManager)
+	cmd := LoadPythonVersions(context.Background(), mockManager)
 	assert.NotNil(t, cmd)
 
 	// Execute the command
@@ -187,7 +246,7 @@ func TestLoadPythonVersions_AvailableError(t *testing.T) {
 	// Test error in available versions
 	mockManager.On("ListAvailable").Return([]types.PythonVersion{}, assert.AnError)
 
-	cmd := LoadPythonVersions(mockManager)
+	cmd := LoadPythonVersions(context.Background(), mockManager)
 	assert.NotNil(t, cmd)
 
 	// Execute the command
@@ -213,7 +272,7 @@ func TestLoadPythonVersions_InstalledError(t *testing.T) {
 	mockManager.On("ListAvailable").Return(available, nil)
 	mockManager.On("ListInstalled").Return([]types.PythonVersion{}, assert.AnError)
 
-	cmd := LoadPythonVersions(mockManager)
+	cmd := LoadPythonVersions(context.Background(), mockManager)
 	assert.NotNil(t, cmd)
 
 	// Execute the command
@@ -234,11 +293,12 @@ func TestInstallPythonVersion(t *testing.T) {
 	// Test successful installation
 	mockManager.On("Install", "3.12.0").Return(nil)
 
-	cmd := InstallPythonVersion(mockManager, "3.12.0")
-	assert.NotNil(t, cmd)
+	job := InstallPythonVersion(mockManager, "3.12.0")
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.NoError(t, err)
 	pythonOpMsg, ok := msg.(ui.PythonOperationMsg)
 
 	assert.True(t, ok)
@@ -256,11 +316,12 @@ func TestInstallPythonVersion_Error(t *testing.T) {
 	// Test installation error
 	mockManager.On("Install", "3.12.0").Return(assert.AnError)
 
-	cmd := InstallPythonVersion(mockManager, "3.12.0")
-	assert.NotNil(t, cmd)
+	job := InstallPythonVersion(mockManager, "3.12.0")
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.Error(t, err)
 	pythonOpMsg, ok := msg.(ui.PythonOperationMsg)
 
 	assert.True(t, ok)
@@ -278,11 +339,12 @@ func TestUninstallPythonVersion(t *testing.T) {
 	// Test successful uninstallation
 	mockManager.On("Uninstall", "3.11.0").Return(nil)
 
-	cmd := UninstallPythonVersion(mockManager, "3.11.0")
-	assert.NotNil(t, cmd)
+	job := UninstallPythonVersion(mockManager, "3.11.0")
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.NoError(t, err)
 	pythonOpMsg, ok := msg.(ui.PythonOperationMsg)
 
 	assert.True(t, ok)
@@ -300,11 +362,12 @@ func TestUninstallPythonVersion_Error(t *testing.T) {
 	// Test uninstallation error
 	mockManager.On("Uninstall", "3.11.0").Return(assert.AnError)
 
-	cmd := UninstallPythonVersion(mockManager, "3.11.0")
-	assert.NotNil(t, cmd)
+	job := UninstallPythonVersion(mockManager, "3.11.0")
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.Error(t, err)
 	pythonOpMsg, ok := msg.(ui.PythonOperationMsg)
 
 	assert.True(t, ok)
@@ -322,11 +385,12 @@ func TestPinPythonVersion(t *testing.T) {
 	// Test successful pinning
 	mockManager.On("Pin", "3.11.0").Return(nil)
 
-	cmd := PinPythonVersion(mockManager, "3.11.0")
-	assert.NotNil(t, cmd)
+	job := PinPythonVersion(mockManager, "3.11.0")
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.NoError(t, err)
 	pythonOpMsg, ok := msg.(ui.PythonOperationMsg)
 
 	assert.True(t, ok)
@@ -344,11 +408,12 @@ func TestPinPythonVersion_Error(t *testing.T) {
 	// Test pinning error
 	mockManager.On("Pin", "3.11.0").Return(assert.AnError)
 
-	cmd := PinPythonVersion(mockManager, "3.11.0")
-	assert.NotNil(t, cmd)
+	job := PinPythonVersion(mockManager, "3.11.0")
+	assert.NotNil(t, job)
 
 	// Execute the command
-	msg := cmd()
+	msg, err := job(context.Background(), nil)
+	assert.Error(t, err)
 	pythonOpMsg, ok := msg.(ui.PythonOperationMsg)
 
 	assert.True(t, ok)
@@ -359,3 +424,43 @@ func TestPinPythonVersion_Error(t *testing.T) {
 
 	mockManager.AssertExpectations(t)
 }
+
+func TestStreamOperation(t *testing.T) {
+	cmd := streamOperation(func(output services.OutputHandler) tea.Msg {
+		output(types.OutputLine{Stream: types.StreamStdout, Text: "Downloading"})
+		return ui.PythonOperationMsg{Operation: "install", Success: true}
+	}, 3)
+
+	msg := cmd()
+	lineMsg, ok := msg.(ui.CommandOutputMsg)
+	assert.True(t, ok)
+	assert.Equal(t, "Downloading", lineMsg.Line.Text)
+	assert.Equal(t, 3, lineMsg.JobID)
+
+	result := waitForOutput(lineMsg.Stream)()
+	opMsg, ok := result.(ui.PythonOperationMsg)
+	assert.True(t, ok)
+	assert.True(t, opMsg.Success)
+
+	// The stream is closed once the result has been delivered.
+	assert.Nil(t, waitForOutput(lineMsg.Stream)())
+}
+
+func TestLoadTestMatrix_InstalledPythons(t *testing.T) {
+	mockManager := &MockPythonManager{}
+	mockManager.On("ListInstalled").Return([]types.PythonVersion{
+		{Version: "3.13.0", Implementation: "cpython", Variant: "default", Key: "cpython-3.13.0-linux-x86_64-gnu"},
+		{Version: "3.13.0", Path: "/usr/bin/python3.13"},
+		{Version: "3.10.14", Implementation: "pypy", Key: "pypy-3.10.14-linux-x86_64-gnu"},
+	}, nil)
+	testRunner := services.NewTestRunner(&mockCommandExecutor{})
+	testRunner.SetProjectRoot(t.TempDir())
+
+	msg := LoadTestMatrix(context.Background(), testRunner, mockManager)()
+	matrixMsg, ok := msg.(ui.TestMatrixLoadedMsg)
+	assert.True(t, ok)
+	assert.NoError(t, matrixMsg.Error)
+	assert.Equal(t, []string{"pytest"}, matrixMsg.Matrix.Command)
+	assert.Equal(t, []string{"3.13.0", "pypy-3.10.14-linux-x86_64-gnu"}, matrixMsg.Matrix.Python)
+	mockManager.AssertExpectations(t)
+}
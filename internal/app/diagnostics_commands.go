@@ -0,0 +1,58 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunChecks returns a job that runs each of tools on the project and collects
+// their diagnostics. A tool that cannot run does not keep the others from
+// reporting.
+func RunChecks(codeChecker services.CodeCheckerInterface, tools []string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		msg := ui.DiagnosticsMsg{Diagnostics: []types.Diagnostic{}, Failures: map[string]error{}}
+		var errs []error
+		for _, tool := range tools {
+			diagnostics, err := codeChecker.Check(ctx, tool, output)
+			if err != nil {
+				msg.Failures[tool] = err
+				errs = append(errs, fmt.Errorf("%s: %w", tool, err))
+				if ctx.Err() != nil {
+					break
+				}
+				continue
+			}
+			msg.Diagnostics = append(msg.Diagnostics, diagnostics...)
+		}
+		return msg, errors.Join(errs...)
+	}
+}
+
+// FixRuff returns a job that applies ruff's safe fixes to the project.
+func FixRuff(codeChecker services.CodeCheckerInterface) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := codeChecker.FixRuff(ctx, output)
+		return ui.RuffFixMsg{Error: err}, err
+	}
+}
+
+// OpenInEditor suspends the program and opens the editor at a diagnostic.
+func OpenInEditor(codeChecker services.CodeCheckerInterface, diagnostic types.Diagnostic) tea.Cmd {
+	cmd, err := codeChecker.EditorCommand(diagnostic)
+	if err != nil {
+		return func() tea.Msg {
+			return ui.EditorClosedMsg{File: diagnostic.File, Error: err}
+		}
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return ui.EditorClosedMsg{File: diagnostic.File, Error: err}
+	})
+}
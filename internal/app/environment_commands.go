@@ -0,0 +1,101 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadEnvironments looks for the project's virtual environments.
+func LoadEnvironments(ctx context.Context, environmentManager services.EnvironmentManagerInterface) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		envs, err := environmentManager.ListEnvironments(ctx)
+		return ui.EnvironmentsLoadedMsg{
+			Environments: envs,
+			Error:        err,
+		}
+	})
+}
+
+// LoadInstalledPackages lists the packages installed in the environment of
+// python and checks their requirements.
+func LoadInstalledPackages(ctx context.Context, environmentManager services.EnvironmentManagerInterface, python string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		packages, err := environmentManager.ListPackages(ctx, python)
+		if err != nil {
+			return ui.InstalledPackagesLoadedMsg{Python: python, Error: err}
+		}
+		issues, checkErr := environmentManager.CheckPackages(ctx, python)
+		return ui.InstalledPackagesLoadedMsg{
+			Python:     python,
+			Packages:   packages,
+			Issues:     issues,
+			CheckError: checkErr,
+		}
+	})
+}
+
+// LoadPackageDetails loads the metadata and files of an installed package.
+func LoadPackageDetails(ctx context.Context, environmentManager services.EnvironmentManagerInterface, python, name string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		details, err := environmentManager.ShowPackage(ctx, python, name)
+		return ui.PackageDetailsLoadedMsg{
+			Python:  python,
+			Details: details,
+			Error:   err,
+		}
+	})
+}
+
+// LoadPackageFreeze loads the environment's packages in requirements format.
+func LoadPackageFreeze(ctx context.Context, environmentManager services.EnvironmentManagerInterface, python string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		lines, err := environmentManager.FreezePackages(ctx, python)
+		return ui.PackageFreezeLoadedMsg{
+			Python: python,
+			Lines:  lines,
+			Error:  err,
+		}
+	})
+}
+
+// CreateEnvironment returns a job that creates a virtual environment.
+func CreateEnvironment(environmentManager services.EnvironmentManagerInterface, options types.VenvOptions, project bool) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := environmentManager.CreateEnvironment(ctx, options, output)
+		return environmentOperationMsg("create", options.Path, project, err), err
+	}
+}
+
+// RecreateEnvironment returns a job that deletes a virtual environment and
+// creates it again.
+func RecreateEnvironment(environmentManager services.EnvironmentManagerInterface, env types.VirtualEnv) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := environmentManager.RecreateEnvironment(ctx, env, output)
+		return environmentOperationMsg("recreate", env.Name, env.Project, err), err
+	}
+}
+
+// DeleteEnvironment returns a job that deletes a virtual environment.
+func DeleteEnvironment(environmentManager services.EnvironmentManagerInterface, env types.VirtualEnv) JobFunc {
+	return func(ctx context.Context, _ services.OutputHandler) (tea.Msg, error) {
+		err := environmentManager.DeleteEnvironment(ctx, env.Path)
+		return environmentOperationMsg("delete", env.Name, env.Project, err), err
+	}
+}
+
+// environmentOperationMsg reports the result of an environment operation.
+func environmentOperationMsg(operation, target string, project bool, err error) ui.EnvironmentOperationMsg {
+	return ui.EnvironmentOperationMsg{
+		Operation: operation,
+		Target:    target,
+		Project:   project,
+		Success:   err == nil,
+		Error:     err,
+	}
+}
@@ -0,0 +1,151 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"uvui/internal/ui/panels"
+)
+
+// OpenForm shows form as a dialog and focuses its first visible field.
+func (m *Model) OpenForm(form panels.FormState) (tea.Model, tea.Cmd) {
+	form.Visible = true
+	if field := form.Focused(); field == nil || field.Hidden {
+		for i, field := range form.Fields {
+			if !field.Hidden {
+				form.Focus = i
+				break
+			}
+		}
+	}
+	m.State.Form = form
+	m.InputMode = InputModeForm
+	m.loadFormField()
+	return m, textinput.Blink
+}
+
+// CloseForm hides the open dialog, remembering what was entered.
+func (m *Model) CloseForm() {
+	form := m.State.Form
+	form.Visible = false
+	switch form.ID {
+	case panels.AddDependencyForm, panels.RemoveDependencyForm:
+		m.State.ProjectState.DependencyForm = form
+	}
+	m.State.Form = panels.FormState{}
+	m.InputMode = InputModeNone
+	m.TextInput.Reset()
+}
+
+// loadFormField loads the focused text field of the form into the text input.
+func (m *Model) loadFormField() {
+	form := &m.State.Form
+	m.TextInput.Reset()
+	if field := form.Focused(); field != nil && field.Kind == panels.FieldText {
+		m.TextInput.Placeholder = field.Placeholder
+		m.TextInput.SetValue(field.Value)
+	}
+	form.Cursor = m.TextInput.Position()
+}
+
+// handleFormKey handles a key press in the open dialog: Tab and the arrow keys
+// move between fields, text fields are edited through the text input, and
+// choices and toggles change with ←/→ or Space.
+func (m *Model) handleFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := &m.State.Form
+
+	switch msg.String() {
+	case "esc":
+		m.CloseForm()
+		return m, nil
+	case "enter":
+		form.Error = ""
+		form.Details = nil
+		return m, m.submitForm()
+	case "tab", "down":
+		form.MoveFocus(1)
+		m.loadFormField()
+		return m, nil
+	case "shift+tab", "up":
+		form.MoveFocus(-1)
+		m.loadFormField()
+		return m, nil
+	}
+
+	field := form.Focused()
+	if field == nil {
+		return m, nil
+	}
+	if field.Kind == panels.FieldText {
+		var cmd tea.Cmd
+		m.TextInput, cmd = m.TextInput.Update(msg)
+		field.Value = m.TextInput.Value()
+		form.Cursor = m.TextInput.Position()
+		m.formChanged()
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "left":
+		form.Change(-1)
+	case "right", " ":
+		form.Change(1)
+	default:
+		return m, nil
+	}
+	m.formChanged()
+	return m, nil
+}
+
+// formChanged updates the fields and preview of the open dialog after an
+// edit.
+func (m *Model) formChanged() {
+	switch m.State.Form.ID {
+	case panels.AddDependencyForm, panels.RemoveDependencyForm:
+		panels.UpdateDependencyForm(&m.State.Form)
+	case panels.CreateVenvForm:
+		panels.UpdateVenvForm(&m.State.Form)
+	case panels.InstallToolForm:
+		panels.UpdateToolForm(&m.State.Form)
+	case panels.RunToolForm:
+		panels.UpdateToolRunForm(&m.State.Form)
+	case panels.RunScriptForm:
+		panels.UpdateRunForm(&m.State.Form)
+	case panels.AddScriptDependencyForm:
+		panels.UpdateScriptDependencyForm(&m.State.Form, m.State.Run.Script.Path)
+	case panels.PytestForm:
+		panels.UpdatePytestForm(&m.State.Form)
+	case panels.DiagnosticsFilterForm:
+		panels.UpdateDiagnosticsFilterForm(&m.State.Form, m.State.Diagnostics.Diagnostics)
+	}
+}
+
+// submitForm runs the action of the open dialog. The dialog stays open with
+// an error if its fields are incomplete.
+func (m *Model) submitForm() tea.Cmd {
+	form := m.State.Form
+	var cmd tea.Cmd
+	switch form.ID {
+	case panels.AddDependencyForm, panels.RemoveDependencyForm:
+		cmd = m.submitDependencyForm(&form)
+	case panels.CreateVenvForm:
+		cmd = m.submitVenvForm(&form)
+	case panels.InstallToolForm:
+		cmd = m.submitToolForm(&form)
+	case panels.RunToolForm:
+		cmd = m.submitToolRunForm(&form)
+	case panels.RunScriptForm:
+		cmd = m.submitRunForm(&form)
+	case panels.AddScriptDependencyForm:
+		cmd = m.submitScriptDependencyForm(&form)
+	case panels.PytestForm:
+		cmd = m.submitPytestForm(&form)
+	case panels.DiagnosticsFilterForm:
+		cmd = m.submitDiagnosticsFilterForm(&form)
+	}
+	if m.InputMode == InputModeForm {
+		m.State.Form.Error = form.Error
+	}
+	return cmd
+}
@@ -0,0 +1,307 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+	"uvui/internal/ui/panels"
+)
+
+// Resources claimed by jobs. Jobs that touch the project files serialize on
+// "project"; jobs that need a stable set of interpreters also claim "python".
+var (
+	projectResources = []string{"project"}
+	syncResources    = []string{"project", "python"}
+	toolResources    = []string{"tools"}
+	cacheResources   = []string{"cache"}
+)
+
+// pythonResources returns the resources claimed by a job that changes a
+// single Python installation.
+func pythonResources(version string) []string {
+	return []string{"python:" + version}
+}
+
+// scriptResources returns the resources claimed by a job that changes the
+// inline metadata or lockfile of a script.
+func scriptResources(script string) []string {
+	return []string{"script:" + script}
+}
+
+// environmentResources returns the resources claimed by a job that changes
+// the virtual environment at path. The project environment is also claimed by
+// project operations, which sync into it.
+func environmentResources(path string, project bool) []string {
+	resources := []string{"venv:" + path}
+	if project {
+		resources = append(resources, projectResources...)
+	}
+	return resources
+}
+
+// JobFunc performs the work of a job. It must honour ctx cancellation and
+// report progress through output. The returned message is handed to the model
+// once the job has finished.
+type JobFunc func(ctx context.Context, output services.OutputHandler) (tea.Msg, error)
+
+// jobEntry is a job together with the state needed to run and cancel it.
+type jobEntry struct {
+	job    types.Job
+	run    JobFunc
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// JobManager queues operations and runs independent ones concurrently, up to
+// a configurable limit. Jobs that share a resource run one after another in
+// the order they were queued.
+type JobManager struct {
+	entries []*jobEntry
+	limit   int
+	nextID  int
+	timeout func(operation string) time.Duration
+}
+
+// NewJobManager creates a job manager running at most limit jobs at once.
+// timeout returns the timeout for an operation, or zero for none.
+func NewJobManager(limit int, timeout func(operation string) time.Duration) *JobManager {
+	if limit < 1 {
+		limit = 1
+	}
+	if timeout == nil {
+		timeout = func(string) time.Duration { return 0 }
+	}
+	return &JobManager{limit: limit, nextID: 1, timeout: timeout}
+}
+
+// Limit returns the maximum number of concurrently running jobs.
+func (j *JobManager) Limit() int {
+	return j.limit
+}
+
+// Enqueue adds a job to the queue. It returns false without queueing if an
+// unfinished job for the same operation and target already exists.
+func (j *JobManager) Enqueue(operation, target string, resources []string, run JobFunc) (types.Job, bool) {
+	for _, entry := range j.entries {
+		if !entry.job.State.Finished() && entry.job.Operation == operation && entry.job.Target == target {
+			return entry.job, false
+		}
+	}
+
+	entry := &jobEntry{
+		job: types.Job{
+			ID:        j.nextID,
+			Operation: operation,
+			Target:    target,
+			Resources: resources,
+			State:     types.JobQueued,
+			QueuedAt:  time.Now(),
+		},
+		run: run,
+	}
+	j.nextID++
+	j.entries = append(j.entries, entry)
+	return entry.job, true
+}
+
+// Start marks every queued job that may run now as running and returns them.
+// A queued job may start when a slot is free and no earlier unfinished job
+// holds a conflicting resource.
+func (j *JobManager) Start(now time.Time) []types.Job {
+	running := 0
+	var held []string
+	for _, entry := range j.entries {
+		if entry.job.State == types.JobRunning {
+			running++
+			held = append(held, entry.job.Resources...)
+		}
+	}
+
+	var started []types.Job
+	for _, entry := range j.entries {
+		if entry.job.State != types.JobQueued {
+			continue
+		}
+		if running < j.limit && !anyResourceConflict(entry.job.Resources, held) {
+			entry.job.State = types.JobRunning
+			entry.job.StartedAt = now
+			if timeout := j.timeout(entry.job.Operation); timeout > 0 {
+				entry.ctx, entry.cancel = context.WithTimeout(context.Background(), timeout)
+			} else {
+				entry.ctx, entry.cancel = context.WithCancel(context.Background())
+			}
+			running++
+			started = append(started, entry.job)
+		}
+		// Queued jobs keep their resources reserved so later jobs cannot
+		// overtake them.
+		held = append(held, entry.job.Resources...)
+	}
+
+	return started
+}
+
+// Command returns the command that runs a started job.
+func (j *JobManager) Command(id int) tea.Cmd {
+	entry := j.find(id)
+	if entry == nil || entry.job.State != types.JobRunning {
+		return nil
+	}
+	return runJob(entry.ctx, id, entry.run)
+}
+
+// Finish records the result of a running job and returns the updated job.
+func (j *JobManager) Finish(id int, err error, now time.Time) (types.Job, bool) {
+	entry := j.find(id)
+	if entry == nil || entry.job.State.Finished() {
+		return types.Job{}, false
+	}
+
+	if entry.cancel != nil {
+		entry.cancel()
+	}
+	entry.job.FinishedAt = now
+	entry.job.Error = err
+	switch {
+	case err == nil:
+		entry.job.State = types.JobSucceeded
+	case errors.Is(err, context.Canceled):
+		entry.job.State = types.JobCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		entry.job.State = types.JobTimedOut
+	default:
+		entry.job.State = types.JobFailed
+	}
+	return entry.job, true
+}
+
+// Cancel stops a job. Queued jobs are cancelled immediately; running jobs are
+// cancelled through their context and finish once their command exits.
+func (j *JobManager) Cancel(id int, now time.Time) bool {
+	entry := j.find(id)
+	if entry == nil {
+		return false
+	}
+
+	switch entry.job.State {
+	case types.JobQueued:
+		entry.job.State = types.JobCancelled
+		entry.job.Error = context.Canceled
+		entry.job.FinishedAt = now
+		return true
+	case types.JobRunning:
+		entry.cancel()
+		return true
+	default:
+		return false
+	}
+}
+
+// LatestRunning returns the most recently started running job.
+func (j *JobManager) LatestRunning() (types.Job, bool) {
+	var latest *jobEntry
+	for _, entry := range j.entries {
+		if entry.job.State == types.JobRunning && (latest == nil || !entry.job.StartedAt.Before(latest.job.StartedAt)) {
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return types.Job{}, false
+	}
+	return latest.job, true
+}
+
+// HasRunning reports whether any job is running.
+func (j *JobManager) HasRunning() bool {
+	_, ok := j.LatestRunning()
+	return ok
+}
+
+// AppendOutput adds a line to a job's output log.
+func (j *JobManager) AppendOutput(id int, line types.OutputLine) {
+	entry := j.find(id)
+	if entry == nil {
+		return
+	}
+	entry.job.Output = append(entry.job.Output, line)
+	if len(entry.job.Output) > panels.MaxOutputLines {
+		entry.job.Output = entry.job.Output[len(entry.job.Output)-panels.MaxOutputLines:]
+	}
+}
+
+// Job returns the job with the given ID.
+func (j *JobManager) Job(id int) (types.Job, bool) {
+	entry := j.find(id)
+	if entry == nil {
+		return types.Job{}, false
+	}
+	return entry.job, true
+}
+
+// Jobs returns a snapshot of all jobs in queue order.
+func (j *JobManager) Jobs() []types.Job {
+	jobs := make([]types.Job, 0, len(j.entries))
+	for _, entry := range j.entries {
+		jobs = append(jobs, entry.job)
+	}
+	return jobs
+}
+
+// ClearFinished removes all finished jobs and returns how many were removed.
+func (j *JobManager) ClearFinished() int {
+	kept := j.entries[:0]
+	for _, entry := range j.entries {
+		if !entry.job.State.Finished() {
+			kept = append(kept, entry)
+		}
+	}
+	removed := len(j.entries) - len(kept)
+	j.entries = kept
+	return removed
+}
+
+// find returns the entry for a job ID.
+func (j *JobManager) find(id int) *jobEntry {
+	for _, entry := range j.entries {
+		if entry.job.ID == id {
+			return entry
+		}
+	}
+	return nil
+}
+
+// runJob runs a job in the background, forwarding its output lines as
+// ui.CommandOutputMsg values and finishing with a ui.JobFinishedMsg.
+func runJob(ctx context.Context, id int, run JobFunc) tea.Cmd {
+	return streamOperation(func(output services.OutputHandler) tea.Msg {
+		result, err := run(ctx, output)
+		return ui.JobFinishedMsg{JobID: id, Result: result, Error: err}
+	}, id)
+}
+
+// anyResourceConflict reports whether any resource in a conflicts with any in b.
+func anyResourceConflict(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if resourcesConflict(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourcesConflict reports whether two resources overlap. Resources are
+// hierarchical: "python" conflicts with "python:3.12", while "python:3.11"
+// and "python:3.12" are independent.
+func resourcesConflict(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+":") || strings.HasPrefix(b, a+":")
+}
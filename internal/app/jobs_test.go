@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+func startedIDs(jobs []types.Job) []int {
+	ids := []int{}
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func TestJobManager_ConcurrencyLimit(t *testing.T) {
+	jobs := NewJobManager(2, nil)
+	jobs.Enqueue("install", "3.11", pythonResources("3.11"), blockingJob)
+	jobs.Enqueue("install", "3.12", pythonResources("3.12"), blockingJob)
+	jobs.Enqueue("install", "3.13", pythonResources("3.13"), blockingJob)
+
+	now := time.Now()
+	assert.Equal(t, []int{1, 2}, startedIDs(jobs.Start(now)))
+	assert.Empty(t, jobs.Start(now))
+
+	jobs.Finish(1, nil, now)
+	assert.Equal(t, []int{3}, startedIDs(jobs.Start(now)))
+}
+
+func TestJobManager_ResourceConflictsRunInOrder(t *testing.T) {
+	jobs := NewJobManager(4, nil)
+	jobs.Enqueue("install", "3.12", pythonResources("3.12"), blockingJob)
+	jobs.Enqueue("sync", "", syncResources, blockingJob)
+	jobs.Enqueue("pin", "3.12", projectResources, blockingJob)
+	jobs.Enqueue("install", "3.13", pythonResources("3.13"), blockingJob)
+
+	now := time.Now()
+	// The sync waits for the 3.12 install and reserves the project and every
+	// Python installation, so neither later job may overtake it.
+	assert.Equal(t, []int{1}, startedIDs(jobs.Start(now)))
+
+	jobs.Finish(1, nil, now)
+	assert.Equal(t, []int{2}, startedIDs(jobs.Start(now)))
+
+	jobs.Finish(2, nil, now)
+	assert.Equal(t, []int{3, 4}, startedIDs(jobs.Start(now)))
+}
+
+func TestJobManager_RejectsDuplicateJobs(t *testing.T) {
+	jobs := NewJobManager(1, nil)
+
+	_, ok := jobs.Enqueue("sync", "", syncResources, blockingJob)
+	assert.True(t, ok)
+	existing, ok := jobs.Enqueue("sync", "", syncResources, blockingJob)
+	assert.False(t, ok)
+	assert.Equal(t, 1, existing.ID)
+
+	jobs.Start(time.Now())
+	jobs.Finish(1, nil, time.Now())
+	_, ok = jobs.Enqueue("sync", "", syncResources, blockingJob)
+	assert.True(t, ok, "finished jobs may be queued again")
+}
+
+func TestJobManager_FinishStates(t *testing.T) {
+	tests := []struct {
+		err   error
+		state types.JobState
+	}{
+		{nil, types.JobSucceeded},
+		{errors.New("boom"), types.JobFailed},
+		{context.Canceled, types.JobCancelled},
+		{context.DeadlineExceeded, types.JobTimedOut},
+	}
+
+	for _, tt := range tests {
+		jobs := NewJobManager(1, nil)
+		job, _ := jobs.Enqueue("lock", "", projectResources, blockingJob)
+		jobs.Start(time.Now())
+
+		finished, ok := jobs.Finish(job.ID, tt.err, time.Now())
+		assert.True(t, ok)
+		assert.Equal(t, tt.state, finished.State)
+
+		_, ok = jobs.Finish(job.ID, nil, time.Now())
+		assert.False(t, ok, "a job finishes only once")
+	}
+}
+
+func TestJobManager_Cancel(t *testing.T) {
+	jobs := NewJobManager(1, nil)
+	jobs.Enqueue("lock", "", projectResources, blockingJob)
+	jobs.Enqueue("sync", "", syncResources, blockingJob)
+	jobs.Start(time.Now())
+
+	assert.True(t, jobs.Cancel(2, time.Now()))
+	queued, _ := jobs.Job(2)
+	assert.Equal(t, types.JobCancelled, queued.State)
+
+	assert.True(t, jobs.Cancel(1, time.Now()))
+	assert.ErrorIs(t, jobs.find(1).ctx.Err(), context.Canceled)
+	running, _ := jobs.Job(1)
+	assert.Equal(t, types.JobRunning, running.State, "running jobs finish when their command exits")
+
+	jobs.Finish(1, context.Canceled, time.Now())
+	assert.False(t, jobs.Cancel(1, time.Now()))
+	assert.False(t, jobs.Cancel(99, time.Now()))
+}
+
+func TestJobManager_Timeout(t *testing.T) {
+	jobs := NewJobManager(1, func(operation string) time.Duration {
+		if operation == "lock" {
+			return time.Millisecond
+		}
+		return 0
+	})
+	jobs.Enqueue("lock", "", projectResources, blockingJob)
+	jobs.Start(time.Now())
+
+	ctx := jobs.find(1).ctx
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestJobManager_ClearFinished(t *testing.T) {
+	jobs := NewJobManager(2, nil)
+	jobs.Enqueue("lock", "", projectResources, blockingJob)
+	jobs.Enqueue("install", "3.12", pythonResources("3.12"), blockingJob)
+	jobs.Start(time.Now())
+	jobs.Finish(1, nil, time.Now())
+
+	assert.Equal(t, 1, jobs.ClearFinished())
+	assert.Equal(t, []int{2}, startedIDs(jobs.Jobs()))
+}
+
+func TestJobManager_Command(t *testing.T) {
+	jobs := NewJobManager(1, nil)
+	jobs.Enqueue("pin", "3.12", projectResources, func(_ context.Context, output services.OutputHandler) (tea.Msg, error) {
+		output(types.OutputLine{Text: "Pinned"})
+		return ui.PythonOperationMsg{Operation: "pin", Success: true}, nil
+	})
+	assert.Nil(t, jobs.Command(1), "queued jobs have no command")
+
+	jobs.Start(time.Now())
+	msg := jobs.Command(1)()
+	lineMsg, ok := msg.(ui.CommandOutputMsg)
+	assert.True(t, ok)
+	assert.Equal(t, 1, lineMsg.JobID)
+
+	finished, ok := waitForOutput(lineMsg.Stream)().(ui.JobFinishedMsg)
+	assert.True(t, ok)
+	assert.Equal(t, 1, finished.JobID)
+	assert.NoError(t, finished.Error)
+	assert.IsType(t, ui.PythonOperationMsg{}, finished.Result)
+}
+
+func TestResourcesConflict(t *testing.T) {
+	assert.True(t, resourcesConflict("project", "project"))
+	assert.True(t, resourcesConflict("python", "python:3.12"))
+	assert.True(t, resourcesConflict("python:3.12", "python"))
+	assert.False(t, resourcesConflict("python:3.11", "python:3.12"))
+	assert.False(t, resourcesConflict("python:3.1", "python:3.12"))
+	assert.False(t, resourcesConflict("project", "python"))
+}
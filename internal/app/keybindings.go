@@ -3,9 +3,15 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"uvui/internal/types"
 )
 
 // Keybindings holds the keybindings for the application.
@@ -27,12 +33,90 @@ type Keybindings struct {
 	InitNew        []string `json:"init_new"`
 	InstallRefresh []string `json:"install_refresh"`
 	InitConfig     []string `json:"init_config"`
+	ToggleOutput   []string `json:"toggle_output"`
+	ScrollUp       []string `json:"scroll_up"`
+	ScrollDown     []string `json:"scroll_down"`
+	Cancel         []string `json:"cancel"`
+	UpdateUV       []string `json:"update_uv"`
+	UpdateUVTo     []string `json:"update_uv_to"`
+	BrowseLock     []string `json:"browse_lock"`
+	Search         []string `json:"search"`
+	Collapse       []string `json:"collapse"`
+	Expand         []string `json:"expand"`
+	NextOccurrence []string `json:"next_occurrence"`
+	PrevOccurrence []string `json:"prev_occurrence"`
+	Recreate       []string `json:"recreate"`
+	Back           []string `json:"back"`
+	CleanCache     []string `json:"clean_cache"`
+	PruneCI        []string `json:"prune_ci"`
+	ScriptMetadata []string `json:"script_metadata"`
+	RerunFailed    []string `json:"rerun_failed"`
+	Matrix         []string `json:"matrix"`
+	Fix            []string `json:"fix"`
+	Trust          []string `json:"trust"`
 }
 
 // Config holds the application configuration.
 type Config struct {
-	Keybindings         Keybindings `json:"keybindings"`
-	KeybindingsNotFound bool        `json:"-"` // This field is not serialized
+	Keybindings Keybindings `json:"keybindings"`
+	// Timeouts maps operation names ("install", "sync", "lock", ...) to
+	// durations such as "10m". The "default" entry applies to operations
+	// without their own entry; an empty or "0" value disables the timeout.
+	// Operations that run the user's own code (run, task, pytest, matrix,
+	// uvx) have no timeout by default.
+	Timeouts map[string]string `json:"timeouts"`
+	// MaxConcurrentJobs limits how many queued operations run at once.
+	MaxConcurrentJobs int `json:"max_concurrent_jobs"`
+	// UVInstall configures where and from what UV is installed.
+	UVInstall types.UVInstallOptions `json:"uv_install"`
+	// UVPaths lists uv binaries to offer besides the ones found on PATH, in
+	// ~/.local/bin, ~/.cargo/bin and the project.
+	UVPaths []string `json:"uv_paths"`
+	// MinUVVersion is the oldest uv release whose output uvui can parse.
+	MinUVVersion        string `json:"min_uv_version"`
+	KeybindingsNotFound bool   `json:"-"` // This field is not serialized
+}
+
+// Timeout returns the configured timeout for an operation, or zero if the
+// operation should not time out.
+func (c *Config) Timeout(operation string) time.Duration {
+	value, ok := c.Timeouts[operation]
+	if !ok {
+		value = c.Timeouts["default"]
+	}
+
+	timeout, err := parseTimeout(value)
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+// InvalidTimeouts describes the configured timeouts that are not valid
+// durations, in operation order. Those operations run without a timeout.
+func (c *Config) InvalidTimeouts() []string {
+	var invalid []string
+	for _, operation := range slices.Sorted(maps.Keys(c.Timeouts)) {
+		if _, err := parseTimeout(c.Timeouts[operation]); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", operation, err))
+		}
+	}
+	return invalid
+}
+
+// parseTimeout parses a configured timeout; an empty value means none.
+func parseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("negative duration %q", value)
+	}
+	return timeout, nil
 }
 
 // LoadConfig loads the configuration from the given path.
@@ -47,13 +131,14 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
-	var config Config
-	err = json.Unmarshal(file, &config)
+	// Start from the defaults so bindings missing from the file keep working.
+	config := DefaultConfig()
+	err = json.Unmarshal(file, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // InitConfig creates a new keybindings.json file with default values.
@@ -88,7 +173,43 @@ func DefaultConfig() *Config {
 			InitNew:        []string{"n"},
 			InstallRefresh: []string{"i"},
 			InitConfig:     []string{"c"},
+			ToggleOutput:   []string{"o"},
+			ScrollUp:       []string{"pgup"},
+			ScrollDown:     []string{"pgdown"},
+			Cancel:         []string{"x"},
+			UpdateUV:       []string{"u"},
+			UpdateUVTo:     []string{"U"},
+			BrowseLock:     []string{"b"},
+			Search:         []string{"/"},
+			Collapse:       []string{"left"},
+			Expand:         []string{"right"},
+			NextOccurrence: []string{"*"},
+			PrevOccurrence: []string{"#"},
+			Recreate:       []string{"R"},
+			Back:           []string{"esc"},
+			CleanCache:     []string{"C"},
+			PruneCI:        []string{"P"},
+			ScriptMetadata: []string{"m"},
+			RerunFailed:    []string{"f"},
+			Matrix:         []string{"M"},
+			Fix:            []string{"F"},
+			Trust:          []string{"T"},
+		},
+		Timeouts: map[string]string{
+			"default":    "30m",
+			"load":       "2m",
+			"pin":        "1m",
+			"init":       "5m",
+			"lock":       "10m",
+			"install_uv": "10m",
+			"run":        "0",
+			"task":       "0",
+			"pytest":     "0",
+			"matrix":     "0",
+			"uvx":        "0",
 		},
+		MaxConcurrentJobs: 2,
+		MinUVVersion:      "0.5.0",
 	}
 }
 
@@ -129,6 +250,48 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleInstallRefresh()
 	case contains(m.Config.Keybindings.InitConfig, msg.String()):
 		return m.handleInitConfig()
+	case contains(m.Config.Keybindings.ToggleOutput, msg.String()):
+		return m.handleToggleOutput()
+	case contains(m.Config.Keybindings.ScrollUp, msg.String()):
+		return m.handleScrollOutput(1)
+	case contains(m.Config.Keybindings.ScrollDown, msg.String()):
+		return m.handleScrollOutput(-1)
+	case contains(m.Config.Keybindings.Cancel, msg.String()):
+		return m.handleCancelKey()
+	case contains(m.Config.Keybindings.UpdateUV, msg.String()):
+		return m.handleUpdateUVKey(false)
+	case contains(m.Config.Keybindings.UpdateUVTo, msg.String()):
+		return m.handleUpdateUVKey(true)
+	case contains(m.Config.Keybindings.BrowseLock, msg.String()):
+		return m.handleBrowseLockKey()
+	case contains(m.Config.Keybindings.Search, msg.String()):
+		return m.handleSearchKey()
+	case contains(m.Config.Keybindings.Collapse, msg.String()):
+		return m.handleTreeExpandKey(false)
+	case contains(m.Config.Keybindings.Expand, msg.String()):
+		return m.handleTreeExpandKey(true)
+	case contains(m.Config.Keybindings.NextOccurrence, msg.String()):
+		return m.handleOccurrenceKey(1)
+	case contains(m.Config.Keybindings.PrevOccurrence, msg.String()):
+		return m.handleOccurrenceKey(-1)
+	case contains(m.Config.Keybindings.Recreate, msg.String()):
+		return m.handleRecreateKey()
+	case contains(m.Config.Keybindings.Back, msg.String()):
+		return m.handleBackKey()
+	case contains(m.Config.Keybindings.CleanCache, msg.String()):
+		return m.handleCleanCacheKey()
+	case contains(m.Config.Keybindings.PruneCI, msg.String()):
+		return m.handlePruneKey(true)
+	case contains(m.Config.Keybindings.ScriptMetadata, msg.String()):
+		return m.handleScriptMetadataKey()
+	case contains(m.Config.Keybindings.RerunFailed, msg.String()):
+		return m.handleRerunFailedKey()
+	case contains(m.Config.Keybindings.Matrix, msg.String()):
+		return m.handleMatrixKey()
+	case contains(m.Config.Keybindings.Fix, msg.String()):
+		return m.handleFixKey()
+	case contains(m.Config.Keybindings.Trust, msg.String()):
+		return m.handleTrustKey()
 	}
 
 	return m, nil
@@ -2,7 +2,15 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
 	"uvui/internal/services"
 	"uvui/internal/types"
 	"uvui/internal/ui/panels"
@@ -10,23 +18,84 @@ import (
 
 // Model represents the application state and dependencies.
 type Model struct {
-	State           *panels.AppState
-	Config          *Config
-	UVInstaller     services.UVInstallerInterface
-	PythonManager   services.PythonManagerInterface
-	ProjectManager  services.ProjectManagerInterface
-	CommandExecutor services.CommandExecutorInterface
-	TextInput       textinput.Model
-	InputMode       InputMode
+	State              *panels.AppState
+	Config             *Config
+	UVInstaller        services.UVInstallerInterface
+	PythonManager      services.PythonManagerInterface
+	ProjectManager     services.ProjectManagerInterface
+	CommandExecutor    services.CommandExecutorInterface
+	UVLocator          services.UVLocatorInterface
+	EnvironmentManager services.EnvironmentManagerInterface
+	ScriptManager      services.ScriptManagerInterface
+	TaskManager        services.TaskManagerInterface
+	TestRunner         services.TestRunnerInterface
+	CodeChecker        services.CodeCheckerInterface
+	ToolManager        services.ToolManagerInterface
+	CacheManager       services.CacheManagerInterface
+	TextInput          textinput.Model
+	InputMode          InputMode
+	Jobs               *JobManager
+	cancels            map[string]context.CancelFunc
+	trustedProjects    map[string]bool // project roots whose own uv may run
+	jobTicking         bool
+	confirm            *confirmation
+}
+
+// confirmation is an action waiting for the user to answer a yes/no prompt.
+type confirmation struct {
+	prompt string
+	action func() tea.Cmd
 }
 
+// Context keys identify the background work a cancel function belongs to.
+const (
+	uvContextKey           = "uv"
+	uvBinariesContextKey   = "uv-binaries"
+	pythonContextKey       = "python-versions"
+	projectContextKey      = "project-status"
+	dependencyContextKey   = "project-dependencies"
+	lockContextKey         = "project-lock"
+	environmentsContextKey = "environments"
+	packagesContextKey     = "packages"
+	packageContextKey      = "package-details"
+	freezeContextKey       = "package-freeze"
+	scriptsContextKey      = "scripts"
+	scriptContextKey       = "script-metadata"
+	tasksContextKey        = "tasks"
+	matrixContextKey       = "test-matrix"
+	toolsContextKey        = "tools"
+	cacheContextKey        = "cache"
+)
+
+// Timeout names for work that is not a user-visible operation.
+const (
+	loadTimeoutOperation      = "load"
+	installUVTimeoutOperation = "install_uv"
+)
+
 // NewModel creates a new application model.
-func NewModel(uvInstaller services.UVInstallerInterface, pythonManager services.PythonManagerInterface, projectManager services.ProjectManagerInterface, commandExecutor services.CommandExecutorInterface) *Model {
+func NewModel(
+	uvInstaller services.UVInstallerInterface,
+	pythonManager services.PythonManagerInterface,
+	projectManager services.ProjectManagerInterface,
+	uvLocator services.UVLocatorInterface,
+	environmentManager services.EnvironmentManagerInterface,
+	scriptManager services.ScriptManagerInterface,
+	taskManager services.TaskManagerInterface,
+	testRunner services.TestRunnerInterface,
+	codeChecker services.CodeCheckerInterface,
+	toolManager services.ToolManagerInterface,
+	cacheManager services.CacheManagerInterface,
+	commandExecutor services.CommandExecutorInterface,
+) *Model {
 	config, err := LoadConfig()
 	if err != nil {
 		panic(err)
 	}
 
+	uvInstaller.SetInstallOptions(config.UVInstall)
+	uvLocator.SetSearchPaths(config.UVPaths)
+
 	ti := textinput.New()
 	ti.Placeholder = "Project Name"
 	ti.Focus()
@@ -38,6 +107,13 @@ func NewModel(uvInstaller services.UVInstallerInterface, pythonManager services.
 			types.PythonPanel,
 			types.ProjectPanel,
 			types.EnvironmentPanel,
+			types.RunPanel,
+			types.TasksPanel,
+			types.TestsPanel,
+			types.DiagnosticsPanel,
+			types.ToolsPanel,
+			types.CachePanel,
+			types.JobsPanel,
 		},
 		PythonVersions: panels.PythonVersions{
 			Available: []types.PythonVersion{},
@@ -53,18 +129,34 @@ func NewModel(uvInstaller services.UVInstallerInterface, pythonManager services.
 			Loading:        false,
 			ShowTree:       false,
 		},
-		Messages: []string{},
+		Jobs: panels.JobsState{
+			Jobs:  []types.Job{},
+			Limit: config.MaxConcurrentJobs,
+		},
+		MinUVVersion: config.MinUVVersion,
+		Messages:     []string{},
 	}
 
 	m := &Model{
-		State:           state,
-		Config:          config,
-		UVInstaller:     uvInstaller,
-		PythonManager:   pythonManager,
-		ProjectManager:  projectManager,
-		CommandExecutor: commandExecutor,
-		TextInput:       ti,
-		InputMode:       InputModeNone,
+		State:              state,
+		Config:             config,
+		UVInstaller:        uvInstaller,
+		PythonManager:      pythonManager,
+		ProjectManager:     projectManager,
+		CommandExecutor:    commandExecutor,
+		UVLocator:          uvLocator,
+		EnvironmentManager: environmentManager,
+		ScriptManager:      scriptManager,
+		TaskManager:        taskManager,
+		TestRunner:         testRunner,
+		CodeChecker:        codeChecker,
+		ToolManager:        toolManager,
+		CacheManager:       cacheManager,
+		TextInput:          ti,
+		InputMode:          InputModeNone,
+		Jobs:               NewJobManager(config.MaxConcurrentJobs, config.Timeout),
+		cancels:            make(map[string]context.CancelFunc),
+		trustedProjects:    make(map[string]bool),
 	}
 
 	if config.KeybindingsNotFound {
@@ -72,6 +164,9 @@ func NewModel(uvInstaller services.UVInstallerInterface, pythonManager services.
 		m.AddMessage("Press 'c' to create a default keybindings.json file.")
 		m.AddMessage("Create keybindings.json in the same directory as the executable to customize.")
 	}
+	for _, invalid := range config.InvalidTimeouts() {
+		m.AddMessage(fmt.Sprintf("Ignoring timeout in keybindings.json (%s); the operation will not time out.", invalid))
+	}
 
 	return m
 }
@@ -149,6 +244,10 @@ func (m *Model) UpdateProjectStatus(status *types.ProjectStatus) {
 func (m *Model) UpdateProjectDependencies(deps []types.ProjectDependency, tree *types.DependencyTree) {
 	m.State.ProjectState.Dependencies = deps
 	m.State.ProjectState.DependencyTree = tree
+
+	// Collapsed subtrees are kept across reloads; the cursor stays in range.
+	view := &m.State.ProjectState.Tree
+	view.Cursor = clampIndex(view.Cursor, len(panels.VisibleTreeRows(tree, view.Collapsed)))
 }
 
 // ToggleTreeView toggles between dependency list and tree view.
@@ -156,6 +255,270 @@ func (m *Model) ToggleTreeView() {
 	m.State.ProjectState.ShowTree = !m.State.ProjectState.ShowTree
 }
 
+// GetSelectedTreeRow returns the dependency tree row under the cursor.
+func (m *Model) GetSelectedTreeRow() *panels.TreeRow {
+	view := m.State.ProjectState.Tree
+	rows := panels.VisibleTreeRows(m.State.ProjectState.DependencyTree, view.Collapsed)
+	if view.Cursor < 0 || view.Cursor >= len(rows) {
+		return nil
+	}
+	return &rows[view.Cursor]
+}
+
+// MoveTreeCursor moves the dependency tree cursor by delta rows.
+func (m *Model) MoveTreeCursor(delta int) {
+	view := &m.State.ProjectState.Tree
+	rows := panels.VisibleTreeRows(m.State.ProjectState.DependencyTree, view.Collapsed)
+	view.Cursor = clampIndex(view.Cursor+delta, len(rows))
+}
+
+// SetTreeNodeExpanded expands or collapses the subtree under the cursor.
+// Collapsing a leaf or an already collapsed node moves the cursor to its
+// parent instead.
+func (m *Model) SetTreeNodeExpanded(expanded bool) {
+	row := m.GetSelectedTreeRow()
+	if row == nil {
+		return
+	}
+	view := &m.State.ProjectState.Tree
+
+	if expanded {
+		delete(view.Collapsed, row.Path)
+		return
+	}
+	if len(row.Node.Children) > 0 && !view.Collapsed[row.Path] {
+		if view.Collapsed == nil {
+			view.Collapsed = make(map[string]bool)
+		}
+		view.Collapsed[row.Path] = true
+		return
+	}
+	if parent := panels.ParentTreePath(row.Path); parent != "" {
+		m.selectTreePath(parent)
+	}
+}
+
+// ToggleTreeNode expands or collapses the subtree under the cursor.
+func (m *Model) ToggleTreeNode() {
+	row := m.GetSelectedTreeRow()
+	if row == nil || len(row.Node.Children) == 0 {
+		return
+	}
+	m.SetTreeNodeExpanded(m.State.ProjectState.Tree.Collapsed[row.Path])
+}
+
+// JumpToTreeOccurrence moves the cursor to the next (direction 1) or previous
+// (direction -1) node of the same package, expanding the subtrees that hide
+// it. It reports whether the package occurs more than once.
+func (m *Model) JumpToTreeOccurrence(direction int) bool {
+	row := m.GetSelectedTreeRow()
+	if row == nil {
+		return false
+	}
+	occurrences := panels.TreeOccurrences(m.State.ProjectState.DependencyTree, row.Node.Name)
+	if len(occurrences) < 2 {
+		return false
+	}
+
+	current := 0
+	for i, path := range occurrences {
+		if path == row.Path {
+			current = i
+		}
+	}
+	target := occurrences[(current+direction+len(occurrences))%len(occurrences)]
+
+	view := &m.State.ProjectState.Tree
+	for parent := panels.ParentTreePath(target); parent != ""; parent = panels.ParentTreePath(parent) {
+		delete(view.Collapsed, parent)
+	}
+	m.selectTreePath(target)
+	return true
+}
+
+// selectTreePath moves the cursor to the visible row at path.
+func (m *Model) selectTreePath(path string) {
+	view := &m.State.ProjectState.Tree
+	for i, row := range panels.VisibleTreeRows(m.State.ProjectState.DependencyTree, view.Collapsed) {
+		if row.Path == path {
+			view.Cursor = i
+			return
+		}
+	}
+}
+
+// UpdateEnvironments updates the virtual environments in the state.
+func (m *Model) UpdateEnvironments(envs []types.VirtualEnv) {
+	m.State.Environments.Environments = envs
+	m.State.Environments.Loading = false
+	m.State.Environments.Selected = clampIndex(m.State.Environments.Selected, len(envs))
+}
+
+// GetSelectedEnvironment returns the selected virtual environment.
+func (m *Model) GetSelectedEnvironment() *types.VirtualEnv {
+	envs := m.State.Environments.Environments
+	if m.State.Environments.Selected < 0 || m.State.Environments.Selected >= len(envs) {
+		return nil
+	}
+	return &envs[m.State.Environments.Selected]
+}
+
+// GetSelectedInstalledPackage returns the selected package of the package
+// inspector.
+func (m *Model) GetSelectedInstalledPackage() *types.InstalledPackage {
+	view := &m.State.Environments.Packages
+	if view.Selected < 0 || view.Selected >= len(view.Packages) {
+		return nil
+	}
+	return &view.Packages[view.Selected]
+}
+
+// GetSelectedTool returns the selected tool.
+func (m *Model) GetSelectedTool() *types.Tool {
+	tools := m.State.Tools.Tools
+	if m.State.Tools.Selected < 0 || m.State.Tools.Selected >= len(tools) {
+		return nil
+	}
+	return &tools[m.State.Tools.Selected]
+}
+
+// GetSelectedRunTarget returns the selected run target.
+func (m *Model) GetSelectedRunTarget() *types.RunTarget {
+	targets := m.State.Run.Targets
+	if m.State.Run.Selected < 0 || m.State.Run.Selected >= len(targets) {
+		return nil
+	}
+	return &targets[m.State.Run.Selected]
+}
+
+// GetSelectedScriptRun returns the selected run in the run history.
+func (m *Model) GetSelectedScriptRun() *types.ScriptRun {
+	history := m.State.Run.History
+	if m.State.Run.RunSelected < 0 || m.State.Run.RunSelected >= len(history) {
+		return nil
+	}
+	return &history[m.State.Run.RunSelected]
+}
+
+// GetSelectedScriptDependency returns the selected dependency in the script
+// view.
+func (m *Model) GetSelectedScriptDependency() *types.ProjectDependency {
+	view := m.State.Run.Script
+	if view.Metadata == nil || view.Selected < 0 || view.Selected >= len(view.Metadata.Dependencies) {
+		return nil
+	}
+	return &view.Metadata.Dependencies[view.Selected]
+}
+
+// GetSelectedTask returns the selected task.
+func (m *Model) GetSelectedTask() *types.Task {
+	tasks := m.State.Tasks.Tasks
+	if m.State.Tasks.Selected < 0 || m.State.Tasks.Selected >= len(tasks) {
+		return nil
+	}
+	return &tasks[m.State.Tasks.Selected]
+}
+
+// GetSelectedTestCase returns the selected test case.
+func (m *Model) GetSelectedTestCase() *types.TestCase {
+	cases := m.State.Tests.VisibleCases()
+	if m.State.Tests.Selected < 0 || m.State.Tests.Selected >= len(cases) {
+		return nil
+	}
+	return &cases[m.State.Tests.Selected]
+}
+
+// GetSelectedDiagnostic returns the selected diagnostic.
+func (m *Model) GetSelectedDiagnostic() *types.Diagnostic {
+	diagnostics := m.State.Diagnostics.Visible()
+	if m.State.Diagnostics.Selected < 0 || m.State.Diagnostics.Selected >= len(diagnostics) {
+		return nil
+	}
+	return &diagnostics[m.State.Diagnostics.Selected]
+}
+
+// GetSelectedCachePackage returns the selected cached package.
+func (m *Model) GetSelectedCachePackage() *types.CachePackage {
+	info := m.State.Cache.Info
+	if info == nil || m.State.Cache.Selected < 0 || m.State.Cache.Selected >= len(info.Packages) {
+		return nil
+	}
+	return &info.Packages[m.State.Cache.Selected]
+}
+
+// GetSelectedToolRun returns the selected run in the tool run history.
+func (m *Model) GetSelectedToolRun() *types.ToolRun {
+	history := m.State.Tools.History
+	if m.State.Tools.RunSelected < 0 || m.State.Tools.RunSelected >= len(history) {
+		return nil
+	}
+	return &history[m.State.Tools.RunSelected]
+}
+
+// SetProjectRoot points the project-scoped services at dir and applies the
+// project's uvui settings.
+func (m *Model) SetProjectRoot(dir string) {
+	m.ProjectManager.SetProjectRoot(dir)
+	m.PythonManager.SetProjectRoot(dir)
+	m.UVLocator.SetProjectRoot(dir)
+	m.EnvironmentManager.SetProjectRoot(dir)
+	m.ScriptManager.SetProjectRoot(dir)
+	m.TaskManager.SetProjectRoot(dir)
+	m.TestRunner.SetProjectRoot(dir)
+	m.CodeChecker.SetProjectRoot(dir)
+	m.ToolManager.SetProjectRoot(dir)
+	m.CacheManager.SetProjectRoot(dir)
+	m.State.Environments = panels.EnvironmentState{}
+	m.State.Run = panels.RunState{}
+	m.State.Tasks = panels.TasksState{}
+	m.State.Tests = panels.TestsState{}
+	m.State.Diagnostics = panels.DiagnosticsState{}
+	m.State.Cache = panels.CacheState{}
+	m.State.ProjectState.Lock = panels.LockBrowserState{}
+	m.State.ProjectState.Tree = panels.TreeViewState{}
+	m.State.ProjectState.DependencyForm = panels.FormState{}
+
+	trusted := m.trustedProjects[dir]
+	m.UVLocator.SetProjectTrusted(trusted)
+	m.State.UVBinaries.ProjectTrusted = trusted
+
+	settings, err := LoadProjectSettings(dir)
+	if err != nil {
+		m.AddMessage(fmt.Sprintf("Failed to read %s: %v", projectSettingsFile, err))
+	}
+	previous := m.State.UVBinaries.ProjectPath
+	m.State.UVBinaries.ProjectPath = settings.UVPath
+	switch {
+	case settings.UVPath != "" && trusted:
+		m.SelectUV(settings.UVPath)
+	case previous != "" && m.CommandExecutor.UVPath() == previous:
+		// The previous project's uv does not apply here.
+		m.SelectUV("")
+	}
+	if settings.UVPath != "" && !trusted {
+		m.AddMessage(fmt.Sprintf("%s asks for the uv at %s. Press 'T' on the Status panel to trust the project and use it.", projectSettingsFile, settings.UVPath))
+	}
+}
+
+// TrustProject lets uvui run the uv binaries of the current project and use
+// the one its settings ask for, for the rest of the session.
+func (m *Model) TrustProject() {
+	root := m.ProjectManager.ProjectRoot()
+	m.trustedProjects[root] = true
+	m.UVLocator.SetProjectTrusted(true)
+	m.State.UVBinaries.ProjectTrusted = true
+	if path := m.State.UVBinaries.ProjectPath; path != "" {
+		m.SelectUV(path)
+	}
+}
+
+// SelectUV makes the services run the uv binary at path. An empty path
+// selects the first uv on PATH.
+func (m *Model) SelectUV(path string) {
+	m.CommandExecutor.SetUVPath(path)
+	m.State.UVBinaries.Active = path
+}
+
 // SetProjectLoading sets the project loading state.
 func (m *Model) SetProjectLoading(loading bool) {
 	m.State.ProjectState.Loading = loading
@@ -168,11 +531,206 @@ func (m *Model) SetOperation(operation, target string, inProgress bool) {
 		Operation:  operation,
 		Target:     target,
 	}
+
+	if inProgress {
+		m.StartOutput(strings.TrimSpace(operation + " " + target))
+	}
 }
 
 // CompleteOperation completes the current operation.
 func (m *Model) CompleteOperation(success bool, err error) {
 	m.State.Operation.InProgress = false
 	m.State.Operation.Success = success
+	m.State.Operation.Cancelled = errors.Is(err, context.Canceled)
+	m.State.Operation.TimedOut = errors.Is(err, context.DeadlineExceeded)
 	m.State.Operation.Error = err
+	m.State.Output.Running = false
+}
+
+// finishOperation updates the operation status once job has finished: it
+// follows the newest job that is still running, and shows the result of job
+// once none is.
+func (m *Model) finishOperation(job types.Job, err error) {
+	if running, ok := m.Jobs.LatestRunning(); ok {
+		m.State.Operation = types.OperationStatus{
+			InProgress: true,
+			Operation:  running.Operation,
+			Target:     running.Target,
+			JobID:      running.ID,
+		}
+		return
+	}
+	m.State.Operation = types.OperationStatus{Operation: job.Operation, Target: job.Target, JobID: job.ID}
+	m.CompleteOperation(job.State == types.JobSucceeded, err)
+}
+
+// newContext returns a context for background work identified by key, bounded
+// by the timeout configured for operation. Any earlier work with the same key
+// is cancelled.
+func (m *Model) newContext(key, operation string) context.Context {
+	m.releaseContext(key)
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if timeout := m.Config.Timeout(operation); timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	m.cancels[key] = cancel
+	return ctx
+}
+
+// loadContext returns the context for a background load identified by key.
+func (m *Model) loadContext(key string) context.Context {
+	return m.newContext(key, loadTimeoutOperation)
+}
+
+// releaseContext releases the resources of the context identified by key.
+func (m *Model) releaseContext(key string) {
+	if cancel, ok := m.cancels[key]; ok {
+		cancel()
+		delete(m.cancels, key)
+	}
+}
+
+// CancelOperation cancels the most recently started job, if any. It reports
+// whether there was a job to cancel.
+func (m *Model) CancelOperation() bool {
+	job, ok := m.Jobs.LatestRunning()
+	if !ok {
+		return false
+	}
+	return m.Jobs.Cancel(job.ID, time.Now())
+}
+
+// EnqueueJob queues an operation as a background job and starts every job
+// that may run now. Resources name what the job changes; jobs sharing a
+// resource run one after another.
+func (m *Model) EnqueueJob(operation, target string, resources []string, run JobFunc) tea.Cmd {
+	if _, ok := m.Jobs.Enqueue(operation, target, resources, run); !ok {
+		m.AddMessage(fmt.Sprintf("%s is already queued", strings.TrimSpace(operation+" "+target)))
+		return nil
+	}
+	cmd := m.startJobs()
+	m.refreshJobs()
+	return cmd
+}
+
+// startJobs starts the queued jobs that may run now and makes the output pane
+// follow the newest of them.
+func (m *Model) startJobs() tea.Cmd {
+	started := m.Jobs.Start(time.Now())
+	if len(started) == 0 {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0, len(started)+1)
+	for _, job := range started {
+		m.SetOperation(job.Operation, job.Target, true)
+		m.State.Operation.JobID = job.ID
+		m.State.Output.JobID = job.ID
+		cmds = append(cmds, m.Jobs.Command(job.ID))
+	}
+	if !m.jobTicking {
+		m.jobTicking = true
+		cmds = append(cmds, tickJobs())
+	}
+	m.refreshJobs()
+	return tea.Batch(cmds...)
+}
+
+// refreshJobs copies the job queue into the UI state.
+func (m *Model) refreshJobs() {
+	m.State.Jobs.Jobs = m.Jobs.Jobs()
+	m.State.Jobs.Limit = m.Jobs.Limit()
+	m.State.Jobs.Selected = clampIndex(m.State.Jobs.Selected, len(m.State.Jobs.Jobs))
+}
+
+// GetSelectedJob returns the job selected on the Jobs panel.
+func (m *Model) GetSelectedJob() *types.Job {
+	jobs := m.State.Jobs.Jobs
+	if m.State.Jobs.Selected < 0 || m.State.Jobs.Selected >= len(jobs) {
+		return nil
+	}
+	return &jobs[m.State.Jobs.Selected]
+}
+
+// ShowJobOutput shows a job's log in the output pane and follows it while
+// the job runs.
+func (m *Model) ShowJobOutput(id int) {
+	job, ok := m.Jobs.Job(id)
+	if !ok {
+		return
+	}
+	m.State.Output = panels.OutputState{
+		Title:   fmt.Sprintf("#%d %s", job.ID, strings.TrimSpace(job.Operation+" "+job.Target)),
+		Lines:   append([]types.OutputLine{}, job.Output...),
+		Visible: true,
+		Running: job.State == types.JobRunning,
+		JobID:   job.ID,
+	}
+}
+
+// StartOutput clears the output pane and shows it for a new operation.
+func (m *Model) StartOutput(title string) {
+	m.State.Output = panels.OutputState{
+		Title:   title,
+		Lines:   []types.OutputLine{},
+		Visible: true,
+		Running: true,
+	}
+}
+
+// AppendOutput adds a line to the output pane.
+func (m *Model) AppendOutput(line types.OutputLine) {
+	m.State.Output.Lines = append(m.State.Output.Lines, line)
+	trimmed := len(m.State.Output.Lines) > panels.MaxOutputLines
+	if trimmed {
+		m.State.Output.Lines = m.State.Output.Lines[len(m.State.Output.Lines)-panels.MaxOutputLines:]
+	}
+	// Keep the view anchored on the same lines while the user is scrolled up.
+	// Once the buffer is full the oldest line is dropped, and the offset must
+	// not run past the lines that are left.
+	if m.State.Output.Scroll > 0 {
+		m.State.Output.Scroll++
+		if trimmed {
+			m.State.Output.Scroll = panels.ClampOutputScroll(m.State.Output, m.outputHeight())
+		}
+	}
+}
+
+// ToggleOutput shows or hides the output pane.
+func (m *Model) ToggleOutput() {
+	m.State.Output.Visible = !m.State.Output.Visible
+}
+
+// ScrollOutput scrolls the output pane by delta lines; positive values scroll up.
+func (m *Model) ScrollOutput(delta int) {
+	m.State.Output.Scroll += delta
+	m.State.Output.Scroll = panels.ClampOutputScroll(m.State.Output, m.outputHeight())
+}
+
+// Confirm asks the user to confirm prompt before running action.
+func (m *Model) Confirm(prompt string, action func() tea.Cmd) {
+	m.confirm = &confirmation{prompt: prompt, action: action}
+	m.InputMode = InputModeConfirm
+}
+
+// clampIndex keeps a selection index within a list of n items.
+func clampIndex(index, n int) int {
+	if index >= n {
+		index = n - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	return index
+}
+
+// outputHeight returns the number of output lines shown in the output pane.
+func (m *Model) outputHeight() int {
+	return maxInt(3, m.State.Height/4)
 }
@@ -2,6 +2,9 @@
 package app
 
 import (
+	"context"
+	"strings"
+
 	"uvui/internal/services"
 	"uvui/internal/types"
 	"uvui/internal/ui"
@@ -10,9 +13,9 @@ import (
 )
 
 // LoadProjectStatus loads the current project status.
-func LoadProjectStatus(projectManager services.ProjectManagerInterface) tea.Cmd {
+func LoadProjectStatus(ctx context.Context, projectManager services.ProjectManagerInterface) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		status, err := projectManager.GetProjectStatus()
+		status, err := projectManager.GetProjectStatus(ctx)
 		return ui.ProjectStatusLoadedMsg{
 			Status: status,
 			Error:  err,
@@ -21,10 +24,10 @@ func LoadProjectStatus(projectManager services.ProjectManagerInterface) tea.Cmd
 }
 
 // LoadProjectDependencies loads project dependencies and dependency tree.
-func LoadProjectDependencies(projectManager services.ProjectManagerInterface) tea.Cmd {
+func LoadProjectDependencies(ctx context.Context, projectManager services.ProjectManagerInterface) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		deps, err1 := projectManager.GetProjectDependencies()
-		tree, err2 := projectManager.GetDependencyTree()
+		deps, err1 := projectManager.GetProjectDependencies(ctx)
+		tree, err2 := projectManager.GetDependencyTree(ctx)
 
 		var err error
 		if err1 != nil {
@@ -41,39 +44,126 @@ func LoadProjectDependencies(projectManager services.ProjectManagerInterface) te
 	})
 }
 
-// InitProject initializes a new project.
-func InitProject(projectManager services.ProjectManagerInterface, name string, options types.InitOptions) tea.Cmd {
+// LoadLockFile loads the project's uv.lock.
+func LoadLockFile(ctx context.Context, projectManager services.ProjectManagerInterface) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		projectDir, err := projectManager.InitProject(name, options)
+		lock, err := projectManager.GetLockFile(ctx)
+		return ui.LockFileLoadedMsg{
+			Lock:  lock,
+			Error: err,
+		}
+	})
+}
+
+// InitProject returns a job that initializes a new project.
+func InitProject(projectManager services.ProjectManagerInterface, name string, options types.InitOptions) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		projectDir, err := projectManager.InitProject(ctx, name, options, output)
 		return ui.ProjectOperationMsg{
 			Operation:  "init",
 			Success:    err == nil,
 			Error:      err,
 			ProjectDir: projectDir,
-		}
-	})
+		}, err
+	}
 }
 
-// SyncProject syncs project dependencies.
-func SyncProject(projectManager services.ProjectManagerInterface) tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		err := projectManager.SyncProject()
+// SyncProject returns a job that syncs project dependencies.
+func SyncProject(projectManager services.ProjectManagerInterface) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := projectManager.SyncProject(ctx, output)
 		return ui.ProjectOperationMsg{
 			Operation: "sync",
 			Success:   err == nil,
 			Error:     err,
-		}
-	})
+		}, err
+	}
 }
 
-// LockProject locks project dependencies.
-func LockProject(projectManager services.ProjectManagerInterface) tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		err := projectManager.LockProject()
+// LockProject returns a job that locks project dependencies.
+func LockProject(projectManager services.ProjectManagerInterface) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := projectManager.LockProject(ctx, output)
 		return ui.ProjectOperationMsg{
 			Operation: "lock",
 			Success:   err == nil,
 			Error:     err,
+		}, err
+	}
+}
+
+// maxFailureDetails limits how many lines of a uv error are shown inline.
+const maxFailureDetails = 12
+
+// AddDependency returns a job that adds a dependency to the project.
+func AddDependency(projectManager services.ProjectManagerInterface, requirement string, options types.DependencyOptions) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		var stderr []string
+		err := projectManager.AddDependency(ctx, requirement, options, collectStderr(output, &stderr))
+		return dependencyOperationMsg("add", requirement, stderr, err), err
+	}
+}
+
+// RemoveDependency returns a job that removes a dependency from the project.
+func RemoveDependency(projectManager services.ProjectManagerInterface, name string, options types.DependencyOptions) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		var stderr []string
+		err := projectManager.RemoveDependency(ctx, name, options, collectStderr(output, &stderr))
+		return dependencyOperationMsg("remove", name, stderr, err), err
+	}
+}
+
+// dependencyOperationMsg reports the result of uv add or uv remove.
+func dependencyOperationMsg(operation, pkg string, stderr []string, err error) ui.DependencyOperationMsg {
+	msg := ui.DependencyOperationMsg{
+		Operation: operation,
+		Package:   pkg,
+		Success:   err == nil,
+		Error:     err,
+	}
+	if err != nil {
+		msg.Details = failureDetails(stderr)
+	}
+	return msg
+}
+
+// collectStderr returns an output handler that forwards to output and records
+// stderr lines in lines.
+func collectStderr(output services.OutputHandler, lines *[]string) services.OutputHandler {
+	return func(line types.OutputLine) {
+		if line.Stream == types.StreamStderr {
+			*lines = append(*lines, line.Text)
 		}
-	})
+		if output != nil {
+			output(line)
+		}
+	}
+}
+
+// failureDetails picks the explanation out of uv's stderr: everything from the
+// first "error:" or "×" line on, or else the last lines written.
+func failureDetails(stderr []string) []string {
+	start := len(stderr) - maxFailureDetails
+	for i, line := range stderr {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "error:") || strings.HasPrefix(trimmed, "×") {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	var details []string
+	for _, line := range stderr[start:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		details = append(details, strings.TrimRight(line, " "))
+		if len(details) == maxFailureDetails {
+			break
+		}
+	}
+	return details
 }
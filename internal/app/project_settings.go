@@ -0,0 +1,58 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// projectSettingsFile holds uvui settings that belong to a single project.
+const projectSettingsFile = ".uvui.json"
+
+// ProjectSettings holds the uvui settings of a project.
+type ProjectSettings struct {
+	// UVPath is the uv binary used for the project. Paths inside the project
+	// are stored relative to it so the file can be shared.
+	UVPath string `json:"uv_path,omitempty"`
+}
+
+// LoadProjectSettings loads the settings of the project in root. A missing
+// settings file yields empty settings. The file comes with the checkout, so
+// its uv_path is only used once the user trusts the project.
+func LoadProjectSettings(root string) (ProjectSettings, error) {
+	var settings ProjectSettings
+
+	file, err := os.ReadFile(filepath.Join(root, projectSettingsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, err
+	}
+
+	if err := json.Unmarshal(file, &settings); err != nil {
+		return settings, err
+	}
+	if settings.UVPath != "" && !filepath.IsAbs(settings.UVPath) {
+		settings.UVPath = filepath.Join(root, settings.UVPath)
+	}
+	return settings, nil
+}
+
+// SaveProjectSettings writes the settings of the project in root.
+func SaveProjectSettings(root string, settings ProjectSettings) error {
+	if settings.UVPath != "" {
+		if rel, err := filepath.Rel(root, settings.UVPath); err == nil && !strings.HasPrefix(rel, "..") {
+			settings.UVPath = rel
+		}
+	}
+
+	file, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(root, projectSettingsFile), append(file, '\n'), 0644)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectSettings_RoundTrip(t *testing.T) {
+	root := t.TempDir()
+
+	settings, err := LoadProjectSettings(root)
+	assert.NoError(t, err)
+	assert.Empty(t, settings.UVPath)
+
+	uv := filepath.Join(root, "tools", "uv")
+	assert.NoError(t, SaveProjectSettings(root, ProjectSettings{UVPath: uv}))
+
+	file, err := os.ReadFile(filepath.Join(root, projectSettingsFile))
+	assert.NoError(t, err)
+	assert.Contains(t, string(file), `"uv_path": "tools/uv"`, "paths inside the project are stored relative to it")
+
+	settings, err = LoadProjectSettings(root)
+	assert.NoError(t, err)
+	assert.Equal(t, uv, settings.UVPath)
+}
+
+func TestProjectSettings_AbsolutePathOutsideProject(t *testing.T) {
+	root := t.TempDir()
+	uv := filepath.Join(t.TempDir(), "uv")
+
+	assert.NoError(t, SaveProjectSettings(root, ProjectSettings{UVPath: uv}))
+
+	settings, err := LoadProjectSettings(root)
+	assert.NoError(t, err)
+	assert.Equal(t, uv, settings.UVPath)
+}
+
+func TestLoadProjectSettings_Invalid(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, projectSettingsFile), []byte("{"), 0600))
+
+	_, err := LoadProjectSettings(root)
+	assert.Error(t, err)
+}
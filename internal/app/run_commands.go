@@ -0,0 +1,89 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+	"time"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadRunTargets loads the entry points and Python files of the project.
+func LoadRunTargets(ctx context.Context, scriptManager services.ScriptManagerInterface) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		targets, err := scriptManager.ListTargets(ctx)
+		return ui.RunTargetsLoadedMsg{Targets: targets, Error: err}
+	})
+}
+
+// RunScript returns a job that runs an entry point or file with uv run.
+func RunScript(scriptManager services.ScriptManagerInterface, options types.RunOptions) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		started := time.Now()
+		err := scriptManager.RunTarget(ctx, options, output)
+		return ui.ScriptRunMsg{Run: types.ScriptRun{
+			Options:   options,
+			StartedAt: started,
+			Duration:  time.Since(started),
+			ExitCode:  exitCode(err),
+			Error:     err,
+		}}, err
+	}
+}
+
+// LoadScriptMetadata reads the inline metadata of a script.
+func LoadScriptMetadata(ctx context.Context, scriptManager services.ScriptManagerInterface, script string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		metadata, err := scriptManager.ScriptMetadata(ctx, script)
+		return ui.ScriptMetadataLoadedMsg{Path: script, Metadata: metadata, Error: err}
+	})
+}
+
+// AddScriptDependency returns a job that adds a dependency to the inline
+// metadata of a script.
+func AddScriptDependency(scriptManager services.ScriptManagerInterface, script, requirement string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		var stderr []string
+		err := scriptManager.AddScriptDependency(ctx, script, requirement, collectStderr(output, &stderr))
+		return scriptOperationMsg("add", script, requirement, stderr, err), err
+	}
+}
+
+// RemoveScriptDependency returns a job that removes a dependency from the
+// inline metadata of a script.
+func RemoveScriptDependency(scriptManager services.ScriptManagerInterface, script, name string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		var stderr []string
+		err := scriptManager.RemoveScriptDependency(ctx, script, name, collectStderr(output, &stderr))
+		return scriptOperationMsg("remove", script, name, stderr, err), err
+	}
+}
+
+// LockScript returns a job that locks the dependencies of a script.
+func LockScript(scriptManager services.ScriptManagerInterface, script string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		var stderr []string
+		err := scriptManager.LockScript(ctx, script, collectStderr(output, &stderr))
+		return scriptOperationMsg("lock", script, "", stderr, err), err
+	}
+}
+
+// scriptOperationMsg reports the result of a change to the inline metadata of
+// a script.
+func scriptOperationMsg(operation, script, pkg string, stderr []string, err error) ui.ScriptOperationMsg {
+	msg := ui.ScriptOperationMsg{
+		Operation: operation,
+		Script:    script,
+		Package:   pkg,
+		Success:   err == nil,
+		Error:     err,
+	}
+	if err != nil {
+		msg.Details = failureDetails(stderr)
+	}
+	return msg
+}
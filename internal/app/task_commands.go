@@ -0,0 +1,30 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+
+	"uvui/internal/services"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadTasks loads the tasks declared in pyproject.toml.
+func LoadTasks(ctx context.Context, taskManager services.TaskManagerInterface) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := taskManager.ListTasks(ctx)
+		return ui.TasksLoadedMsg{Tasks: tasks, Error: err}
+	})
+}
+
+// RunTask returns a job that runs a task after the tasks it depends on.
+func RunTask(taskManager services.TaskManagerInterface, name string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		results, err := taskManager.RunTask(ctx, name, output)
+		for i := range results {
+			results[i].ExitCode = exitCode(results[i].Error)
+		}
+		return ui.TaskRunMsg{Task: name, Results: results, Error: err}, err
+	}
+}
@@ -0,0 +1,62 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunTests returns a job that runs pytest. A rerun of some tests is merged
+// into the results of the last run when it finishes.
+func RunTests(testRunner services.TestRunnerInterface, options types.PytestOptions, rerun bool) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		report, err := testRunner.RunTests(ctx, options, output)
+		return ui.TestRunMsg{Options: options, Rerun: rerun, Report: report, Error: err}, err
+	}
+}
+
+// LoadTestMatrix loads the test matrix of the project. A matrix that declares
+// no Pythons runs with every installed one.
+func LoadTestMatrix(ctx context.Context, testRunner services.TestRunnerInterface, pythonManager services.PythonManagerInterface) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		matrix, err := testRunner.LoadMatrix(ctx)
+		if err == nil && len(matrix.Python) == 0 {
+			var installed []types.PythonVersion
+			installed, err = pythonManager.ListInstalled(ctx)
+			matrix.Python = installedPythonRequests(installed)
+		}
+		return ui.TestMatrixLoadedMsg{Matrix: matrix, Error: err}
+	})
+}
+
+// RunTestMatrix returns a job that runs the test matrix with pythons.
+func RunTestMatrix(testRunner services.TestRunnerInterface, matrix types.TestMatrix, pythons []string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		results, err := testRunner.RunMatrix(ctx, matrix, pythons, output)
+		for i := range results {
+			results[i].ExitCode = exitCode(results[i].Error)
+		}
+		return ui.MatrixRunMsg{Pythons: pythons, Results: results, Error: err}, err
+	}
+}
+
+// installedPythonRequests returns the requests naming the installed Pythons,
+// each once, in the order uv lists them.
+func installedPythonRequests(installed []types.PythonVersion) []string {
+	var requests []string
+	seen := make(map[string]bool)
+	for _, python := range installed {
+		request := python.PinRequest()
+		if request == "" || seen[request] {
+			continue
+		}
+		seen[request] = true
+		requests = append(requests, request)
+	}
+	return requests
+}
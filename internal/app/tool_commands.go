@@ -0,0 +1,122 @@
+// Package app provides the core application logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+
+	"uvui/internal/services"
+	"uvui/internal/types"
+	"uvui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadTools loads the installed tools and the directory of their executables.
+func LoadTools(ctx context.Context, toolManager services.ToolManagerInterface) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tools, err := toolManager.ListTools(ctx)
+		if err != nil {
+			return ui.ToolsLoadedMsg{Error: err}
+		}
+		binDir, err := toolManager.BinDir(ctx)
+		return ui.ToolsLoadedMsg{
+			Tools:  tools,
+			BinDir: binDir,
+			Error:  err,
+		}
+	})
+}
+
+// InstallTool returns a job that installs a tool.
+func InstallTool(toolManager services.ToolManagerInterface, options types.ToolInstallOptions) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := toolManager.InstallTool(ctx, options, output)
+		return toolOperationMsg("install", options.Package, err), err
+	}
+}
+
+// UpgradeTool returns a job that upgrades a tool, or every tool if name is
+// empty.
+func UpgradeTool(toolManager services.ToolManagerInterface, name string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := toolManager.UpgradeTool(ctx, name, output)
+		return toolOperationMsg("upgrade", name, err), err
+	}
+}
+
+// UninstallTool returns a job that uninstalls a tool.
+func UninstallTool(toolManager services.ToolManagerInterface, name string) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := toolManager.UninstallTool(ctx, name, output)
+		return toolOperationMsg("uninstall", name, err), err
+	}
+}
+
+// UpdateShell returns a job that adds the tool directory to PATH in the shell
+// configuration.
+func UpdateShell(toolManager services.ToolManagerInterface) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		err := toolManager.UpdateShell(ctx, output)
+		return toolOperationMsg("update-shell", "", err), err
+	}
+}
+
+// RunTool returns a job that runs a tool with uvx, capturing its output.
+func RunTool(toolManager services.ToolManagerInterface, options types.ToolRunOptions) JobFunc {
+	return func(ctx context.Context, output services.OutputHandler) (tea.Msg, error) {
+		started := time.Now()
+		err := toolManager.RunTool(ctx, options, output)
+		return toolRunMsg(options, started, err), err
+	}
+}
+
+// ExecTool suspends the program and runs a tool with uvx in the terminal.
+func ExecTool(toolManager services.ToolManagerInterface, options types.ToolRunOptions) tea.Cmd {
+	cmd, err := toolManager.ToolCommand(options)
+	if err != nil {
+		return func() tea.Msg {
+			return toolRunMsg(options, time.Now(), err)
+		}
+	}
+	started := time.Now()
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return toolRunMsg(options, started, err)
+	})
+}
+
+// toolRunMsg reports a finished tool run.
+func toolRunMsg(options types.ToolRunOptions, started time.Time, err error) ui.ToolRunMsg {
+	return ui.ToolRunMsg{Run: types.ToolRun{
+		Options:   options,
+		StartedAt: started,
+		Duration:  time.Since(started),
+		ExitCode:  exitCode(err),
+		Error:     err,
+	}}
+}
+
+// exitCode returns the exit status of a command that failed with err, 0 if it
+// succeeded, or -1 if it did not run to completion.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// toolOperationMsg reports the result of a uv tool operation.
+func toolOperationMsg(operation, target string, err error) ui.ToolOperationMsg {
+	return ui.ToolOperationMsg{
+		Operation: operation,
+		Target:    target,
+		Success:   err == nil,
+		Error:     err,
+	}
+}
@@ -0,0 +1,238 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+// cacheVersionSuffix matches the "-v5" suffix of a cache bucket directory.
+var cacheVersionSuffix = regexp.MustCompile(`-v\d+$`)
+
+// cacheBucketDirs maps cache bucket directories, without their version
+// suffix, to buckets.
+var cacheBucketDirs = map[string]string{
+	"wheels":       types.CacheBucketWheels,
+	"sdists":       types.CacheBucketSdists,
+	"git":          types.CacheBucketGit,
+	"interpreter":  types.CacheBucketInterpreters,
+	"archive":      types.CacheBucketArchives,
+	"simple":       types.CacheBucketIndex,
+	"flat-index":   types.CacheBucketIndex,
+	"builds":       types.CacheBucketBuilds,
+	"environments": types.CacheBucketEnvironments,
+}
+
+// cacheBucketOrder is the order buckets are reported in.
+var cacheBucketOrder = []string{
+	types.CacheBucketWheels,
+	types.CacheBucketSdists,
+	types.CacheBucketGit,
+	types.CacheBucketInterpreters,
+	types.CacheBucketArchives,
+	types.CacheBucketIndex,
+	types.CacheBucketBuilds,
+	types.CacheBucketEnvironments,
+	types.CacheBucketOther,
+}
+
+// cacheRemovedSummary matches the summary uv cache clean and prune print,
+// e.g. "Removed 1234 files (56.7MiB)".
+var cacheRemovedSummary = regexp.MustCompile(`Removed \d+ files? \(([\d.]+)\s?(B|KiB|MiB|GiB|TiB)\)`)
+
+// cacheSizeUnits are the multipliers of the units in uv's size summaries.
+var cacheSizeUnits = map[string]float64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// CacheManager implements uv cache management. It runs uv in the project
+// directory, so that a cache-dir set in the project's pyproject.toml or
+// uv.toml is the cache shown and cleaned.
+type CacheManager struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewCacheManager creates a new cache manager.
+func NewCacheManager(executor CommandExecutorInterface) *CacheManager {
+	return &CacheManager{executor: executor}
+}
+
+// CacheDir returns the uv cache directory.
+func (c *CacheManager) CacheDir(ctx context.Context) (string, error) {
+	if !c.executor.IsUVAvailable() {
+		return "", fmt.Errorf("UV is not available")
+	}
+
+	output, err := c.exec(c.executor).Execute(ctx, "uv", "cache", "dir")
+	if err != nil {
+		return "", fmt.Errorf("failed to find the cache directory: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Inspect returns the cache directory with its size by bucket and by package.
+func (c *CacheManager) Inspect(ctx context.Context) (*types.CacheInfo, error) {
+	dir, err := c.CacheDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inspectCache(ctx, dir)
+}
+
+// Clean removes the given packages from the cache with uv cache clean, or the
+// whole cache if there are none. It returns the number of bytes reclaimed.
+func (c *CacheManager) Clean(ctx context.Context, packages []string, output OutputHandler) (int64, error) {
+	args := append([]string{"cache", "clean"}, packages...)
+	reclaimed, err := c.runSummarized(ctx, output, args...)
+	if err != nil {
+		return reclaimed, fmt.Errorf("failed to clean the cache: %w", err)
+	}
+	return reclaimed, nil
+}
+
+// Prune removes unused entries from the cache with uv cache prune. With ci it
+// also removes pre-built wheels and unzipped source distributions, keeping
+// only what is expensive to rebuild, as suits caches saved between CI runs.
+// It returns the number of bytes reclaimed.
+func (c *CacheManager) Prune(ctx context.Context, ci bool, output OutputHandler) (int64, error) {
+	args := []string{"cache", "prune"}
+	if ci {
+		args = append(args, "--ci")
+	}
+	reclaimed, err := c.runSummarized(ctx, output, args...)
+	if err != nil {
+		return reclaimed, fmt.Errorf("failed to prune the cache: %w", err)
+	}
+	return reclaimed, nil
+}
+
+// runSummarized runs a uv cache command and returns the bytes it reports
+// having removed. uv releases that do not report sizes yield zero.
+func (c *CacheManager) runSummarized(ctx context.Context, output OutputHandler, args ...string) (int64, error) {
+	if !c.executor.IsUVAvailable() {
+		return 0, fmt.Errorf("UV is not available")
+	}
+
+	var reclaimed int64
+	handler := func(line types.OutputLine) {
+		reclaimed += parseCacheRemoved(line.Text)
+		if output != nil {
+			output(line)
+		}
+	}
+	if _, err := c.exec(c.executor).ExecuteStream(ctx, handler, "uv", args...); err != nil {
+		return 0, err
+	}
+	return reclaimed, nil
+}
+
+// parseCacheRemoved returns the bytes in a "Removed N files (X)" summary line,
+// or zero for any other line.
+func parseCacheRemoved(line string) int64 {
+	match := cacheRemovedSummary.FindStringSubmatch(line)
+	if match == nil {
+		return 0
+	}
+	size, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0
+	}
+	return int64(size * cacheSizeUnits[match[2]])
+}
+
+// inspectCache measures the buckets of the cache in dir and the packages in
+// its wheel and sdist buckets. A missing cache directory is an empty cache.
+func inspectCache(ctx context.Context, dir string) (*types.CacheInfo, error) {
+	info := &types.CacheInfo{Dir: dir}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return info, nil
+		}
+		return nil, fmt.Errorf("failed to read the cache directory: %w", err)
+	}
+
+	buckets := make(map[string]int64)
+	packages := make(map[string]int64)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		size, err := dirSize(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		bucket, ok := cacheBucketDirs[cacheVersionSuffix.ReplaceAllString(entry.Name(), "")]
+		if !ok {
+			bucket = types.CacheBucketOther
+		}
+		buckets[bucket] += size
+		info.Size += size
+
+		if bucket == types.CacheBucketWheels || bucket == types.CacheBucketSdists {
+			if err := measureCachedPackages(ctx, path, packages); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, name := range cacheBucketOrder {
+		if size, ok := buckets[name]; ok {
+			info.Buckets = append(info.Buckets, types.CacheBucket{Name: name, Size: size})
+		}
+	}
+	for name, size := range packages {
+		info.Packages = append(info.Packages, types.CachePackage{Name: name, Size: size})
+	}
+	sort.Slice(info.Packages, func(i, j int) bool {
+		return info.Packages[i].Name < info.Packages[j].Name
+	})
+	return info, nil
+}
+
+// measureCachedPackages adds the size of each package in a wheel or sdist
+// bucket to packages. Packages from PyPI live in pypi/<name>, those from
+// other indexes in index/<hash>/<name>.
+func measureCachedPackages(ctx context.Context, bucket string, packages map[string]int64) error {
+	dirs := []string{filepath.Join(bucket, "pypi")}
+	if indexes, err := os.ReadDir(filepath.Join(bucket, "index")); err == nil {
+		for _, index := range indexes {
+			if index.IsDir() {
+				dirs = append(dirs, filepath.Join(bucket, "index", index.Name()))
+			}
+		}
+	}
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			size, err := dirSize(ctx, filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return err
+			}
+			packages[entry.Name()] += size
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+// writeCache creates a uv cache in a temporary directory with files of the
+// given sizes, keyed by their path within the cache.
+func writeCache(t *testing.T, files map[string]int) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, size := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestNewCacheManager(t *testing.T) {
+	if cm := NewCacheManager(&mockCommandExecutor{}); cm == nil {
+		t.Error("NewCacheManager() should not return nil")
+	}
+}
+
+func TestCacheManager_Inspect(t *testing.T) {
+	dir := writeCache(t, map[string]int{
+		"CACHEDIR.TAG":                                 10,
+		"wheels-v5/pypi/httpx/0.27.0.msgpack":          100,
+		"wheels-v5/index/abc123/httpx/0.28.0.msgpack":  50,
+		"wheels-v4/pypi/idna/3.7.msgpack":              30,
+		"sdists-v9/pypi/numpy/2.0.0/src.tar.gz":        400,
+		"git-v0/checkouts/abc/README":                  20,
+		"interpreter-v4/abc.msgpack":                   5,
+		"archive-v0/xyz/httpx/__init__.py":             200,
+		"simple-v15/pypi/httpx.rkyv":                   7,
+		"flat-index-v2/a":                              3,
+		"new-bucket-v1/file":                           1,
+		"environments-v1/ruff/pyvenv.cfg":              2,
+		"builds-v0/.tmpabc/lib":                        4,
+		"wheels-v5/pypi/certifi/2024.7.4/METADATA":     60,
+		"wheels-v5/index/abc123/certifi/2024.7.4.http": 6,
+	})
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			if !reflect.DeepEqual(args, []string{"cache", "dir"}) {
+				t.Errorf("ran uv %v, want uv cache dir", args)
+			}
+			return []byte(dir + "\n"), nil
+		},
+	}
+
+	info, err := NewCacheManager(executor).Inspect(context.Background())
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if info.Dir != dir || info.Size != 888 {
+		t.Errorf("Inspect() = %s with %d bytes, want %s with 888 bytes", info.Dir, info.Size, dir)
+	}
+
+	expectedBuckets := []types.CacheBucket{
+		{Name: types.CacheBucketWheels, Size: 246},
+		{Name: types.CacheBucketSdists, Size: 400},
+		{Name: types.CacheBucketGit, Size: 20},
+		{Name: types.CacheBucketInterpreters, Size: 5},
+		{Name: types.CacheBucketArchives, Size: 200},
+		{Name: types.CacheBucketIndex, Size: 10},
+		{Name: types.CacheBucketBuilds, Size: 4},
+		{Name: types.CacheBucketEnvironments, Size: 2},
+		{Name: types.CacheBucketOther, Size: 1},
+	}
+	if !reflect.DeepEqual(info.Buckets, expectedBuckets) {
+		t.Errorf("Inspect() buckets = %+v, want %+v", info.Buckets, expectedBuckets)
+	}
+
+	expectedPackages := []types.CachePackage{
+		{Name: "certifi", Size: 66},
+		{Name: "httpx", Size: 150},
+		{Name: "idna", Size: 30},
+		{Name: "numpy", Size: 400},
+	}
+	if !reflect.DeepEqual(info.Packages, expectedPackages) {
+		t.Errorf("Inspect() packages = %+v, want %+v", info.Packages, expectedPackages)
+	}
+}
+
+func TestCacheManager_InspectMissingCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			return []byte(dir), nil
+		},
+	}
+
+	info, err := NewCacheManager(executor).Inspect(context.Background())
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if info.Size != 0 || len(info.Buckets) != 0 || len(info.Packages) != 0 {
+		t.Errorf("Inspect() of a missing cache = %+v, want an empty cache", info)
+	}
+}
+
+func TestCacheManager_CleanAndPrune(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(cm *CacheManager) (int64, error)
+		expected  []string
+		reclaimed int64
+	}{
+		{"clean packages", func(cm *CacheManager) (int64, error) {
+			return cm.Clean(context.Background(), []string{"httpx", "idna"}, nil)
+		}, []string{"cache", "clean", "httpx", "idna"}, 1536},
+		{"clean all", func(cm *CacheManager) (int64, error) {
+			return cm.Clean(context.Background(), nil, nil)
+		}, []string{"cache", "clean"}, 1536},
+		{"prune", func(cm *CacheManager) (int64, error) {
+			return cm.Prune(context.Background(), false, nil)
+		}, []string{"cache", "prune"}, 1536},
+		{"prune for CI", func(cm *CacheManager) (int64, error) {
+			return cm.Prune(context.Background(), true, nil)
+		}, []string{"cache", "prune", "--ci"}, 1536},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			executor := &mockCommandExecutor{
+				ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+					got = args
+					handler(types.OutputLine{Stream: types.StreamStderr, Text: "Clearing cache at: /home/me/.cache/uv"})
+					handler(types.OutputLine{Stream: types.StreamStderr, Text: "Removed 3 files (1.5KiB)"})
+					return nil, nil
+				},
+			}
+			cm := NewCacheManager(executor)
+			cm.SetProjectRoot("/work/project")
+
+			reclaimed, err := tt.run(cm)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ran uv %v, want %v", got, tt.expected)
+			}
+			if reclaimed != tt.reclaimed {
+				t.Errorf("reclaimed %d bytes, want %d", reclaimed, tt.reclaimed)
+			}
+			if executor.Dir != "/work/project" {
+				t.Errorf("ran uv in %q, want the project root for its cache-dir setting", executor.Dir)
+			}
+		})
+	}
+}
+
+func TestParseCacheRemoved(t *testing.T) {
+	tests := []struct {
+		line string
+		want int64
+	}{
+		{"Removed 1234 files (56.7MiB)", 59454259},
+		{"Removed 1 file (512.0B)", 512},
+		{"Removed 10 files (2.0GiB)", 2 << 30},
+		{"Removed 12 directories", 0},
+		{"Removed 1234 files", 0},
+		{"No unused entries found", 0},
+	}
+	for _, tt := range tests {
+		if got := parseCacheRemoved(tt.line); got != tt.want {
+			t.Errorf("parseCacheRemoved(%q) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestCacheManager_Errors(t *testing.T) {
+	cm := NewCacheManager(&mockCommandExecutor{IsUVAvailableFunc: func() bool { return false }})
+
+	if _, err := cm.Inspect(context.Background()); err == nil {
+		t.Error("Inspect() should fail when UV is not available")
+	}
+	if _, err := cm.Clean(context.Background(), nil, nil); err == nil {
+		t.Error("Clean() should fail when UV is not available")
+	}
+	if _, err := cm.Prune(context.Background(), false, nil); err == nil {
+		t.Error("Prune() should fail when UV is not available")
+	}
+}
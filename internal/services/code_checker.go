@@ -0,0 +1,189 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/pkg/version"
+)
+
+// CodeChecker implements running ruff and mypy on the project and reading
+// their diagnostics. A tool the project depends on runs in the project
+// environment with uv run; any other runs with uv tool run (uvx).
+type CodeChecker struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewCodeChecker creates a new code checker.
+func NewCodeChecker(executor CommandExecutorInterface) *CodeChecker {
+	return &CodeChecker{executor: executor}
+}
+
+// checkArgs are the arguments of each tool that make it report diagnostics
+// in a form uvui parses. mypy is given no files, so that it checks those its
+// configuration names.
+var checkArgs = map[string][]string{
+	types.DiagnosticsRuff: {"check", "--output-format", "json", "--exit-zero"},
+	types.DiagnosticsMypy: {"-O", "json", "--no-error-summary"},
+}
+
+// mypyTextArgs make mypy releases before 1.11, which have no JSON output,
+// report diagnostics in the form parseMypyOutput parses.
+var mypyTextArgs = []string{"--show-column-numbers", "--show-error-codes", "--no-error-summary", "--no-pretty", "--no-color-output"}
+
+// Check runs tool on the project, streaming its output, and returns its
+// diagnostics.
+func (c *CodeChecker) Check(ctx context.Context, tool string, output OutputHandler) ([]types.Diagnostic, error) {
+	if !c.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+	args, ok := checkArgs[tool]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %q", tool)
+	}
+	root, err := c.absProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, runErr := c.run(ctx, tool, args, output)
+	switch tool {
+	case types.DiagnosticsRuff:
+		if runErr != nil {
+			return nil, fmt.Errorf("failed to run ruff: %w", runErr)
+		}
+		return parseRuffJSON([]byte(stdout), root)
+	default:
+		var diagnostics []types.Diagnostic
+		if runErr != nil && ctx.Err() == nil && rejectsFlag(runErr, "-O") {
+			stdout, runErr = c.run(ctx, tool, mypyTextArgs, output)
+			diagnostics = parseMypyOutput([]byte(stdout))
+		} else {
+			var err error
+			diagnostics, err = parseMypyJSON([]byte(stdout), root)
+			if err != nil && runErr == nil {
+				return nil, err
+			}
+		}
+		// mypy exits with status 1 when it reports errors.
+		if runErr != nil && len(diagnostics) == 0 {
+			return nil, fmt.Errorf("failed to run mypy: %w", runErr)
+		}
+		return diagnostics, nil
+	}
+}
+
+// FixRuff applies ruff's safe fixes to the project with ruff check --fix.
+func (c *CodeChecker) FixRuff(ctx context.Context, output OutputHandler) error {
+	if !c.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	// --exit-zero: the violations ruff cannot fix are not a failure of the fix.
+	if _, err := c.run(ctx, types.DiagnosticsRuff, []string{"check", "--fix", "--exit-zero"}, output); err != nil {
+		return fmt.Errorf("failed to run ruff --fix: %w", err)
+	}
+	return nil
+}
+
+// run runs tool with args, streaming its output, and returns what it wrote
+// to standard output.
+func (c *CodeChecker) run(ctx context.Context, tool string, args []string, output OutputHandler) (string, error) {
+	uvArgs := append(c.toolArgs(tool), args...)
+	if output != nil {
+		output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("▶ uv %s", strings.Join(uvArgs, " "))})
+	}
+
+	var stdout strings.Builder
+	_, err := c.exec(c.executor).ExecuteStream(ctx, func(line types.OutputLine) {
+		if line.Stream == types.StreamStdout {
+			stdout.WriteString(line.Text)
+			stdout.WriteString("\n")
+		}
+		if output != nil {
+			output(line)
+		}
+	}, "uv", uvArgs...)
+	return stdout.String(), err
+}
+
+// toolArgs returns the uv arguments that run tool: uv run if the project
+// depends on it, so that mypy sees the project's packages and the pinned
+// version is used, or else uv tool run.
+func (c *CodeChecker) toolArgs(tool string) []string {
+	if c.projectDependsOn(tool) {
+		return []string{"run", tool}
+	}
+	return []string{"tool", "run", tool}
+}
+
+// projectDependsOn reports whether pyproject.toml declares name in any of its
+// dependency lists.
+func (c *CodeChecker) projectDependsOn(name string) bool {
+	root, err := c.absProjectRoot()
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if err != nil {
+		return false
+	}
+	dependencies, err := parsePyprojectDependencies(data)
+	if err != nil {
+		return false
+	}
+	for _, dependency := range dependencies {
+		if version.NormalizeName(dependency.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EditorCommand returns the command that opens the editor at a diagnostic:
+// $VISUAL, or else $EDITOR, or else vi, run in the project directory.
+func (c *CodeChecker) EditorCommand(diagnostic types.Diagnostic) (*exec.Cmd, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 || diagnostic.File == "" {
+		return nil, fmt.Errorf("no file to open")
+	}
+	args := append(fields[1:], editorArgs(fields[0], diagnostic)...)
+	return c.exec(c.executor).Command(fields[0], args...), nil
+}
+
+// editorArgs returns the arguments that make editor open the location of a
+// diagnostic. Editors that are not known take vi's +LINE argument.
+func editorArgs(editor string, diagnostic types.Diagnostic) []string {
+	file := filepath.FromSlash(diagnostic.File)
+	location := fmt.Sprintf("%s:%d", file, diagnostic.Line)
+	if diagnostic.Column > 0 {
+		location += fmt.Sprintf(":%d", diagnostic.Column)
+	}
+
+	switch strings.TrimSuffix(strings.ToLower(filepath.Base(editor)), ".exe") {
+	case "code", "code-insiders", "codium", "cursor":
+		return []string{"--goto", location}
+	case "subl", "zed", "hx", "helix":
+		return []string{location}
+	case "idea", "pycharm", "charm":
+		return []string{"--line", fmt.Sprint(diagnostic.Line), file}
+	default:
+		if diagnostic.Line <= 0 {
+			return []string{file}
+		}
+		return []string{fmt.Sprintf("+%d", diagnostic.Line), file}
+	}
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestNewCodeChecker(t *testing.T) {
+	if c := NewCodeChecker(&mockCommandExecutor{}); c == nil {
+		t.Error("NewCodeChecker() should not return nil")
+	}
+}
+
+func TestCodeChecker_Check(t *testing.T) {
+	root := t.TempDir()
+	pyproject := "[project]\nname = \"demo\"\n\n[dependency-groups]\ndev = [\"MyPy>=1.11\"]\n"
+	if err := os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var ran [][]string
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			ran = append(ran, args)
+			handler(types.OutputLine{Stream: types.StreamStderr, Text: "Resolved 12 packages in 3ms"})
+			if args[0] == "tool" {
+				handler(types.OutputLine{Stream: types.StreamStdout, Text: `[{"code": "E501", "filename": "` + filepath.ToSlash(filepath.Join(root, "app.py")) + `", "location": {"row": 3, "column": 89}, "message": "Line too long"}]`})
+				return nil, nil
+			}
+			handler(types.OutputLine{Stream: types.StreamStdout, Text: `{"file": "app.py", "line": 5, "column": 0, "message": "Missing return statement", "hint": null, "code": "return", "severity": "error"}`})
+			return nil, errors.New("exit status 1")
+		},
+	}
+	c := NewCodeChecker(executor)
+	c.SetProjectRoot(root)
+
+	var lines []string
+	output := func(line types.OutputLine) { lines = append(lines, line.Text) }
+	ruff, err := c.Check(context.Background(), types.DiagnosticsRuff, output)
+	if err != nil || len(ruff) != 1 || ruff[0].File != "app.py" || ruff[0].Rule != "E501" {
+		t.Errorf("Check(ruff) = %+v, %v", ruff, err)
+	}
+	mypy, err := c.Check(context.Background(), types.DiagnosticsMypy, output)
+	if err != nil || len(mypy) != 1 || mypy[0].Rule != "return" {
+		t.Errorf("Check(mypy) = %+v, %v", mypy, err)
+	}
+
+	if !reflect.DeepEqual(ran[0][:7], []string{"tool", "run", "ruff", "check", "--output-format", "json", "--exit-zero"}) {
+		t.Errorf("ran uv %v, want ruff with uv tool run", ran[0])
+	}
+	if !reflect.DeepEqual(ran[1], []string{"run", "mypy", "-O", "json", "--no-error-summary"}) {
+		t.Errorf("ran uv %v, want mypy in the project environment", ran[1])
+	}
+	if executor.Dir != root {
+		t.Errorf("ran in %q, want %q", executor.Dir, root)
+	}
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "▶ uv tool run ruff check") {
+		t.Errorf("output = %q", lines)
+	}
+}
+
+func TestCodeChecker_CheckMypyWithoutJSON(t *testing.T) {
+	var ran [][]string
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			ran = append(ran, args)
+			if args[3] == "-O" {
+				return nil, errors.New("exit status 2: mypy: error: unrecognized arguments: -O")
+			}
+			handler(types.OutputLine{Stream: types.StreamStdout, Text: "app.py:5:1: error: Missing return statement  [return]"})
+			return nil, errors.New("exit status 1")
+		},
+	}
+	c := NewCodeChecker(executor)
+	c.SetProjectRoot(t.TempDir())
+
+	mypy, err := c.Check(context.Background(), types.DiagnosticsMypy, nil)
+	if err != nil || len(mypy) != 1 || mypy[0].Rule != "return" || mypy[0].Column != 1 {
+		t.Errorf("Check(mypy) = %+v, %v", mypy, err)
+	}
+	if len(ran) != 2 || !reflect.DeepEqual(ran[1][3:], mypyTextArgs) {
+		t.Errorf("ran uv %v, want a retry with text output", ran)
+	}
+}
+
+func TestCodeChecker_CheckFailures(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			handler(types.OutputLine{Stream: types.StreamStderr, Text: "mypy: can't read file"})
+			return nil, errors.New("exit status 2")
+		},
+	}
+	c := NewCodeChecker(executor)
+	c.SetProjectRoot(t.TempDir())
+
+	for _, tool := range []string{types.DiagnosticsRuff, types.DiagnosticsMypy} {
+		if _, err := c.Check(context.Background(), tool, nil); err == nil || !strings.Contains(err.Error(), "failed to run "+tool) {
+			t.Errorf("Check(%s) error = %v", tool, err)
+		}
+	}
+	if _, err := c.Check(context.Background(), "pylint", nil); err == nil {
+		t.Error("Check() should fail for an unknown tool")
+	}
+	if err := c.FixRuff(context.Background(), nil); err == nil {
+		t.Error("FixRuff() should fail when ruff fails")
+	}
+
+	executor.IsUVAvailableFunc = func() bool { return false }
+	if _, err := c.Check(context.Background(), types.DiagnosticsRuff, nil); err == nil {
+		t.Error("Check() should fail without uv")
+	}
+}
+
+func TestCodeChecker_FixRuff(t *testing.T) {
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = args
+			return nil, nil
+		},
+	}
+	c := NewCodeChecker(executor)
+	c.SetProjectRoot(t.TempDir())
+	if err := c.FixRuff(context.Background(), nil); err != nil {
+		t.Fatalf("FixRuff() error = %v", err)
+	}
+	if want := []string{"tool", "run", "ruff", "check", "--fix", "--exit-zero"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ran uv %v, want %v", got, want)
+	}
+}
+
+func TestCodeChecker_EditorCommand(t *testing.T) {
+	root := t.TempDir()
+	c := NewCodeChecker(&mockCommandExecutor{})
+	c.SetProjectRoot(root)
+	diagnostic := types.Diagnostic{File: "src/app.py", Line: 12, Column: 5}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nvim -p")
+	cmd, err := c.EditorCommand(diagnostic)
+	if err != nil {
+		t.Fatalf("EditorCommand() error = %v", err)
+	}
+	if want := []string{"nvim", "-p", "+12", filepath.FromSlash("src/app.py")}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("EditorCommand() args = %q, want %q", cmd.Args, want)
+	}
+	if cmd.Dir != root {
+		t.Errorf("EditorCommand() dir = %q, want %q", cmd.Dir, root)
+	}
+
+	t.Setenv("VISUAL", "code --wait")
+	cmd, _ = c.EditorCommand(diagnostic)
+	if want := []string{"code", "--wait", "--goto", filepath.FromSlash("src/app.py") + ":12:5"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("EditorCommand() args = %q, want %q", cmd.Args, want)
+	}
+
+	if _, err := c.EditorCommand(types.Diagnostic{}); err == nil {
+		t.Error("EditorCommand() should fail without a file")
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	diagnostic := types.Diagnostic{File: "app.py", Line: 3}
+	tests := map[string][]string{
+		"vim":             {"+3", "app.py"},
+		"/usr/bin/hx":     {"app.py:3"},
+		"pycharm":         {"--line", "3", "app.py"},
+		"/opt/Cursor.exe": {"--goto", "app.py:3"},
+	}
+	for editor, want := range tests {
+		if got := editorArgs(editor, diagnostic); !reflect.DeepEqual(got, want) {
+			t.Errorf("editorArgs(%q) = %q, want %q", editor, got, want)
+		}
+	}
+}
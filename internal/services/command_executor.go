@@ -2,25 +2,234 @@
 package services
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"uvui/internal/types"
 )
 
+// commandWaitDelay bounds how long a cancelled command may keep its output
+// pipes open before they are forcibly closed.
+const commandWaitDelay = 5 * time.Second
+
 // CommandExecutor implements command execution functionality.
-type CommandExecutor struct{}
+type CommandExecutor struct {
+	dir string
+	env []string // KEY=VALUE overrides of the process environment
+	uv  *uvBinary
+}
+
+// uvBinary is the uv executable selected for a session. It is shared by an
+// executor and every executor derived from it with InDir.
+type uvBinary struct {
+	mu   sync.RWMutex
+	path string
+}
 
 // NewCommandExecutor creates a new command executor.
 func NewCommandExecutor() *CommandExecutor {
-	return &CommandExecutor{}
+	return &CommandExecutor{uv: &uvBinary{}}
+}
+
+// InDir returns an executor that runs commands in dir. An empty dir means the
+// process working directory.
+func (c *CommandExecutor) InDir(dir string) CommandExecutorInterface {
+	return &CommandExecutor{dir: dir, env: c.env, uv: c.uv}
+}
+
+// WithEnv returns an executor that runs commands with env, a list of
+// KEY=VALUE entries, added to the process environment.
+func (c *CommandExecutor) WithEnv(env []string) CommandExecutorInterface {
+	return &CommandExecutor{dir: c.dir, env: append(slices.Clone(c.env), env...), uv: c.uv}
+}
+
+// SetUVPath selects the uv binary run for the "uv" command. An empty path
+// means the first uv on PATH.
+func (c *CommandExecutor) SetUVPath(path string) {
+	if c.uv == nil {
+		c.uv = &uvBinary{}
+	}
+	c.uv.mu.Lock()
+	defer c.uv.mu.Unlock()
+	c.uv.path = path
+}
+
+// UVPath returns the selected uv binary, or "" if the one on PATH is used.
+func (c *CommandExecutor) UVPath() string {
+	if c.uv == nil {
+		return ""
+	}
+	c.uv.mu.RLock()
+	defer c.uv.mu.RUnlock()
+	return c.uv.path
+}
+
+// resolve maps the "uv" command to the selected uv binary.
+func (c *CommandExecutor) resolve(command string) string {
+	if command == "uv" {
+		if path := c.UVPath(); path != "" {
+			return path
+		}
+	}
+	return command
+}
+
+// Execute runs a command and returns its output. Cancelling ctx kills the
+// command together with any processes it started.
+func (c *CommandExecutor) Execute(ctx context.Context, command string, args ...string) ([]byte, error) {
+	cmd := c.newCommand(ctx, command, args...)
+	output, err := cmd.Output()
+	if err != nil && ctx.Err() != nil {
+		return output, ctx.Err()
+	}
+	return output, err
 }
 
-// Execute runs a command and returns its output.
-func (c *CommandExecutor) Execute(command string, args ...string) ([]byte, error) {
-	cmd := exec.Command(command, args...)
-	return cmd.Output()
+// ExecuteStream runs a command, delivering stdout and stderr to handler line by
+// line while the process runs. It returns the collected stdout once the process
+// exits. On failure the last stderr line is appended to the returned error; if
+// ctx was cancelled or timed out, ctx.Err() is returned instead.
+func (c *CommandExecutor) ExecuteStream(ctx context.Context, handler OutputHandler, command string, args ...string) ([]byte, error) {
+	cmd := c.newCommand(ctx, command, args...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var (
+		output     bytes.Buffer
+		lastStderr string
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+	)
+
+	emit := func(stream, text string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stream == types.StreamStdout {
+			output.WriteString(text)
+			output.WriteByte('\n')
+		} else if strings.TrimSpace(text) != "" {
+			lastStderr = text
+		}
+		if handler != nil {
+			handler(types.OutputLine{Stream: stream, Text: text})
+		}
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		scanLines(stdout, func(text string) { emit(types.StreamStdout, text) })
+	}()
+	go func() {
+		defer wg.Done()
+		scanLines(stderr, func(text string) { emit(types.StreamStderr, text) })
+	}()
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return output.Bytes(), ctx.Err()
+		}
+		if lastStderr != "" {
+			return output.Bytes(), fmt.Errorf("%w: %s", err, lastStderr)
+		}
+		return output.Bytes(), err
+	}
+
+	return output.Bytes(), nil
+}
+
+// Command returns an unstarted command for programs that need the terminal,
+// such as interactive tools run with tea.ExecProcess. Unlike Execute it is not
+// placed in its own process group, so it can read from the terminal.
+func (c *CommandExecutor) Command(command string, args ...string) *exec.Cmd {
+	cmd := exec.Command(c.resolve(command), args...)
+	cmd.Dir = c.dir
+	cmd.Env = c.environ()
+	return cmd
 }
 
-// IsUVAvailable checks if UV is available in PATH.
+// IsUVAvailable checks if the selected uv binary, or else a uv on PATH, is
+// available.
 func (c *CommandExecutor) IsUVAvailable() bool {
+	if path := c.UVPath(); path != "" {
+		return isExecutable(path)
+	}
 	_, err := exec.LookPath("uv")
 	return err == nil
 }
+
+// environ returns the environment of commands, or nil for the process
+// environment when there are no overrides.
+func (c *CommandExecutor) environ() []string {
+	if len(c.env) == 0 {
+		return nil
+	}
+	return append(os.Environ(), c.env...)
+}
+
+// newCommand creates a command bound to ctx that runs in the executor's
+// directory and in its own process group, so that cancellation also stops any
+// child processes it spawns.
+func (c *CommandExecutor) newCommand(ctx context.Context, command string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, c.resolve(command), args...)
+	cmd.Dir = c.dir
+	cmd.Env = c.environ()
+	configureProcessGroup(cmd)
+	cmd.WaitDelay = commandWaitDelay
+	return cmd
+}
+
+// scanLines reads r line by line, treating carriage returns as line breaks so
+// that progress bars redrawn in place still produce output.
+func scanLines(r io.Reader, fn func(string)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(splitLines)
+	for scanner.Scan() {
+		fn(scanner.Text())
+	}
+	// Drain anything left so the process never blocks on a full pipe.
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// splitLines is a bufio.SplitFunc that splits on '\n', '\r' or "\r\n".
+func splitLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		advance := i + 1
+		if data[i] == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+			advance++
+		} else if data[i] == '\r' && i+1 == len(data) && !atEOF {
+			// Need more data to know whether this is a CRLF pair.
+			return 0, nil, nil
+		}
+		return advance, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -1,7 +1,18 @@
 package services
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"uvui/internal/types"
 )
 
 func TestNewCommandExecutor(t *testing.T) {
@@ -13,7 +24,7 @@ func TestNewCommandExecutor(t *testing.T) {
 
 func TestCommandExecutor_Execute(t *testing.T) {
 	var executor CommandExecutorInterface = NewCommandExecutor()
-	output, err := executor.Execute("echo", "hello")
+	output, err := executor.Execute(context.Background(), "echo", "hello")
 	if err != nil {
 		t.Errorf("Execute() error = %v, wantErr %v", err, false)
 	}
@@ -24,8 +35,172 @@ func TestCommandExecutor_Execute(t *testing.T) {
 
 func TestCommandExecutor_Execute_Error(t *testing.T) {
 	var executor CommandExecutorInterface = NewCommandExecutor()
-	_, err := executor.Execute("non-existent-command")
+	_, err := executor.Execute(context.Background(), "non-existent-command")
 	if err == nil {
 		t.Error("Execute() error = nil, wantErr true")
 	}
 }
+
+func TestCommandExecutor_ExecuteStream(t *testing.T) {
+	var executor CommandExecutorInterface = NewCommandExecutor()
+
+	var lines []types.OutputLine
+	output, err := executor.ExecuteStream(context.Background(), func(line types.OutputLine) {
+		lines = append(lines, line)
+	}, "sh", "-c", "echo out; echo err >&2")
+	if err != nil {
+		t.Fatalf("ExecuteStream() error = %v, wantErr %v", err, false)
+	}
+
+	if string(output) != "out\n" {
+		t.Errorf("ExecuteStream() output = %q, want %q", string(output), "out\n")
+	}
+
+	want := map[types.OutputLine]bool{
+		{Stream: types.StreamStdout, Text: "out"}: true,
+		{Stream: types.StreamStderr, Text: "err"}: true,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("ExecuteStream() delivered %d lines, want %d", len(lines), len(want))
+	}
+	for _, line := range lines {
+		if !want[line] {
+			t.Errorf("ExecuteStream() delivered unexpected line %+v", line)
+		}
+	}
+}
+
+func TestCommandExecutor_ExecuteStream_ErrorIncludesStderr(t *testing.T) {
+	var executor CommandExecutorInterface = NewCommandExecutor()
+
+	_, err := executor.ExecuteStream(context.Background(), nil, "sh", "-c", "echo 'No solution found' >&2; exit 1")
+	if err == nil {
+		t.Fatal("ExecuteStream() error = nil, wantErr true")
+	}
+	if !strings.Contains(err.Error(), "No solution found") {
+		t.Errorf("ExecuteStream() error = %q, want it to contain stderr", err.Error())
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("a\nb\r\nc\rd"))
+	scanner.Split(splitLines)
+
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+
+	want := []string{"a", "b", "c", "d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitLines() = %q, want %q", got, want)
+	}
+}
+
+func TestCommandExecutor_ExecuteStream_Cancelled(t *testing.T) {
+	var executor CommandExecutorInterface = NewCommandExecutor()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	// The child sleep keeps the pipes open, so this only returns promptly if
+	// the whole process group is killed.
+	_, err := executor.ExecuteStream(ctx, nil, "sh", "-c", "sleep 10 & wait")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ExecuteStream() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("ExecuteStream() took %v after cancellation", elapsed)
+	}
+}
+
+func TestCommandExecutor_Execute_Cancelled(t *testing.T) {
+	var executor CommandExecutorInterface = NewCommandExecutor()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := executor.Execute(ctx, "sleep", "10")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCommandExecutor_InDir(t *testing.T) {
+	dir := t.TempDir()
+	executor := NewCommandExecutor().InDir(dir)
+
+	output, err := executor.Execute(context.Background(), "pwd")
+	if err != nil {
+		t.Fatalf("Execute() error = %v, wantErr %v", err, false)
+	}
+
+	want, _ := filepath.EvalSymlinks(dir)
+	got, _ := filepath.EvalSymlinks(strings.TrimSpace(string(output)))
+	if got != want {
+		t.Errorf("Execute() ran in %q, want %q", got, want)
+	}
+}
+
+func TestCommandExecutor_SetUVPath(t *testing.T) {
+	echo, err := exec.LookPath("echo")
+	if err != nil {
+		t.Skip("echo not available")
+	}
+	executor := NewCommandExecutor()
+	executor.SetUVPath(echo)
+
+	// Executors derived with InDir share the selected binary.
+	output, err := executor.InDir(t.TempDir()).Execute(context.Background(), "uv", "selected")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if string(output) != "selected\n" {
+		t.Errorf("Execute() output = %q, want the selected binary to run", string(output))
+	}
+	if !executor.IsUVAvailable() {
+		t.Error("IsUVAvailable() = false for an existing selected binary")
+	}
+
+	executor.SetUVPath(filepath.Join(t.TempDir(), "missing-uv"))
+	if executor.IsUVAvailable() {
+		t.Error("IsUVAvailable() = true for a missing selected binary")
+	}
+}
+
+func TestCommandExecutor_Command(t *testing.T) {
+	executor := NewCommandExecutor()
+	executor.SetUVPath("/opt/uv/bin/uv")
+	dir := t.TempDir()
+
+	cmd := executor.InDir(dir).Command("uv", "tool", "run", "ipython")
+	if cmd.Path != "/opt/uv/bin/uv" || cmd.Dir != dir {
+		t.Errorf("Command() runs %s in %q, want the selected uv in %q", cmd.Path, cmd.Dir, dir)
+	}
+	if cmd.Process != nil {
+		t.Error("Command() should not start the command")
+	}
+}
+
+func TestCommandExecutor_WithEnv(t *testing.T) {
+	if _, err := exec.LookPath("env"); err != nil {
+		t.Skip("env not available")
+	}
+	executor := NewCommandExecutor().WithEnv([]string{"UVUI_TEST=one"}).InDir(t.TempDir()).WithEnv([]string{"UVUI_OTHER=two"})
+
+	output, err := executor.Execute(context.Background(), "env")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"UVUI_TEST=one", "UVUI_OTHER=two", "PATH="} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Execute() environment is missing %s", want)
+		}
+	}
+
+	cmd := executor.Command("env")
+	if !slices.Contains(cmd.Env, "UVUI_OTHER=two") {
+		t.Error("Command() should carry the environment overrides")
+	}
+}
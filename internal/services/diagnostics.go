@@ -0,0 +1,150 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+// ruffDiagnostic is an entry of ruff check --output-format json.
+type ruffDiagnostic struct {
+	Code     *string `json:"code"` // null for syntax errors
+	Message  string  `json:"message"`
+	Filename string  `json:"filename"`
+	Location struct {
+		Row    int `json:"row"`
+		Column int `json:"column"`
+	} `json:"location"`
+	Fix *struct {
+		Applicability string `json:"applicability"`
+	} `json:"fix"`
+}
+
+// parseRuffJSON parses the JSON output of ruff check. ruff reports absolute
+// file names; those inside root are made relative to it.
+func parseRuffJSON(data []byte, root string) ([]types.Diagnostic, error) {
+	var raw []ruffDiagnostic
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse ruff output: %w", err)
+	}
+
+	diagnostics := make([]types.Diagnostic, 0, len(raw))
+	for _, r := range raw {
+		d := types.Diagnostic{
+			Tool:     types.DiagnosticsRuff,
+			File:     projectRelative(r.Filename, root),
+			Line:     r.Location.Row,
+			Column:   r.Location.Column,
+			Severity: types.SeverityError,
+			Message:  r.Message,
+			// ruff check --fix applies safe fixes only; releases before 0.1
+			// do not report the applicability.
+			Fixable: r.Fix != nil && (r.Fix.Applicability == "" || r.Fix.Applicability == "safe"),
+		}
+		if r.Code != nil {
+			d.Rule = *r.Code
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics, nil
+}
+
+// mypyDiagnostic is a line of mypy -O json.
+type mypyDiagnostic struct {
+	File     string  `json:"file"`
+	Line     int     `json:"line"`
+	Column   int     `json:"column"` // zero-based, -1 if unknown
+	Message  string  `json:"message"`
+	Hint     *string `json:"hint"` // the notes on the error, one per line
+	Code     *string `json:"code"`
+	Severity string  `json:"severity"`
+}
+
+// parseMypyJSON parses the output of mypy -O json, one JSON object per line.
+// The notes mypy attaches to an error follow it as diagnostics of their own,
+// as in its text output. Lines that are not JSON objects are skipped.
+func parseMypyJSON(data []byte, root string) ([]types.Diagnostic, error) {
+	diagnostics := []types.Diagnostic{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var r mypyDiagnostic
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return nil, fmt.Errorf("failed to parse mypy output: %w", err)
+		}
+		d := types.Diagnostic{
+			Tool:     types.DiagnosticsMypy,
+			File:     projectRelative(r.File, root),
+			Line:     max(r.Line, 0),
+			Column:   max(r.Column+1, 0),
+			Severity: r.Severity,
+			Message:  r.Message,
+		}
+		if r.Code != nil {
+			d.Rule = *r.Code
+		}
+		diagnostics = append(diagnostics, d)
+		if r.Hint != nil {
+			for _, hint := range strings.Split(*r.Hint, "\n") {
+				diagnostics = append(diagnostics, types.Diagnostic{
+					Tool:     types.DiagnosticsMypy,
+					File:     d.File,
+					Line:     d.Line,
+					Column:   d.Column,
+					Severity: types.SeverityNote,
+					Message:  hint,
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// mypyLine matches a line of mypy output with column numbers and error codes,
+// e.g. "src/app.py:12:5: error: Incompatible types  [assignment]".
+var mypyLine = regexp.MustCompile(`^(.+?):(\d+):(?:(\d+):)? (error|warning|note): (.*?)(?:  \[([a-z0-9-]+)\])?$`)
+
+// parseMypyOutput parses the text output of mypy --show-column-numbers
+// --show-error-codes, for releases without -O json. Lines that are not diagnostics, such as the summary, are
+// skipped.
+func parseMypyOutput(data []byte) []types.Diagnostic {
+	diagnostics := []types.Diagnostic{}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		match := mypyLine.FindStringSubmatch(strings.TrimRight(scanner.Text(), "\r"))
+		if match == nil {
+			continue
+		}
+		d := types.Diagnostic{
+			Tool:     types.DiagnosticsMypy,
+			File:     filepath.ToSlash(match[1]),
+			Severity: match[4],
+			Message:  match[5],
+			Rule:     match[6],
+		}
+		d.Line, _ = strconv.Atoi(match[2])
+		d.Column, _ = strconv.Atoi(match[3])
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// projectRelative returns path relative to root if it lies inside it,
+// slash-separated.
+func projectRelative(path, root string) string {
+	if root != "" && filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestParseRuffJSON(t *testing.T) {
+	root := filepath.FromSlash("/work/demo")
+	data := []byte(`[
+  {
+    "cell": null,
+    "code": "F401",
+    "end_location": {"column": 10, "row": 1},
+    "filename": "` + filepath.ToSlash(filepath.Join(root, "src", "app.py")) + `",
+    "fix": {"applicability": "safe", "edits": [], "message": "Remove unused import: ` + "`os`" + `"},
+    "location": {"column": 8, "row": 1},
+    "message": "` + "`os`" + ` imported but unused",
+    "noqa_row": 1,
+    "url": "https://docs.astral.sh/ruff/rules/unused-import"
+  },
+  {
+    "code": "B006",
+    "filename": "/elsewhere/lib.py",
+    "fix": {"applicability": "unsafe", "edits": []},
+    "location": {"column": 21, "row": 4},
+    "message": "Do not use mutable data structures for argument defaults"
+  },
+  {
+    "code": null,
+    "filename": "` + filepath.ToSlash(filepath.Join(root, "broken.py")) + `",
+    "fix": null,
+    "location": {"column": 1, "row": 2},
+    "message": "SyntaxError: Expected an expression"
+  }
+]`)
+
+	diagnostics, err := parseRuffJSON(data, root)
+	if err != nil {
+		t.Fatalf("parseRuffJSON() error = %v", err)
+	}
+	expected := []types.Diagnostic{
+		{Tool: "ruff", File: "src/app.py", Line: 1, Column: 8, Severity: "error", Rule: "F401", Message: "`os` imported but unused", Fixable: true},
+		{Tool: "ruff", File: "/elsewhere/lib.py", Line: 4, Column: 21, Severity: "error", Rule: "B006", Message: "Do not use mutable data structures for argument defaults"},
+		{Tool: "ruff", File: "broken.py", Line: 2, Column: 1, Severity: "error", Message: "SyntaxError: Expected an expression"},
+	}
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("parseRuffJSON() = %+v, want %+v", diagnostics, expected)
+	}
+
+	if diagnostics, err := parseRuffJSON([]byte("[]"), root); err != nil || len(diagnostics) != 0 {
+		t.Errorf("parseRuffJSON() of a clean project = %v, %v", diagnostics, err)
+	}
+	if _, err := parseRuffJSON([]byte("error: unexpected argument"), root); err == nil {
+		t.Error("parseRuffJSON() should fail on text output")
+	}
+}
+
+func TestParseMypyJSON(t *testing.T) {
+	root := filepath.FromSlash("/work/demo")
+	data := []byte(`{"file": "src/app.py", "line": 12, "column": 4, "message": "Incompatible types in assignment (expression has type \"int\", variable has type \"str\")", "hint": null, "code": "assignment", "severity": "error"}
+{"file": "` + filepath.ToSlash(filepath.Join(root, "src", "util.py")) + `", "line": 3, "column": 0, "message": "Name \"foo\" is not defined", "hint": "Did you forget to import it?\nSee https://mypy.rtfd.io", "code": "name-defined", "severity": "error"}
+{"file": "setup.py", "line": -1, "column": -1, "message": "Duplicate module named \"setup\"\n(also at \"./setup.py\")", "hint": null, "code": null, "severity": "error"}
+`)
+
+	diagnostics, err := parseMypyJSON(data, root)
+	if err != nil {
+		t.Fatalf("parseMypyJSON() error = %v", err)
+	}
+	expected := []types.Diagnostic{
+		{Tool: "mypy", File: "src/app.py", Line: 12, Column: 5, Severity: "error", Rule: "assignment", Message: "Incompatible types in assignment (expression has type \"int\", variable has type \"str\")"},
+		{Tool: "mypy", File: "src/util.py", Line: 3, Column: 1, Severity: "error", Rule: "name-defined", Message: "Name \"foo\" is not defined"},
+		{Tool: "mypy", File: "src/util.py", Line: 3, Column: 1, Severity: "note", Message: "Did you forget to import it?"},
+		{Tool: "mypy", File: "src/util.py", Line: 3, Column: 1, Severity: "note", Message: "See https://mypy.rtfd.io"},
+		{Tool: "mypy", File: "setup.py", Severity: "error", Message: "Duplicate module named \"setup\"\n(also at \"./setup.py\")"},
+	}
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("parseMypyJSON() = %+v, want %+v", diagnostics, expected)
+	}
+
+	if diagnostics, err := parseMypyJSON([]byte("Success: no issues found in 4 source files\n"), root); err != nil || len(diagnostics) != 0 {
+		t.Errorf("parseMypyJSON() of a clean run = %v, %v", diagnostics, err)
+	}
+	if _, err := parseMypyJSON([]byte("{\"file\": "), root); err == nil {
+		t.Error("parseMypyJSON() should fail on a truncated line")
+	}
+}
+
+func TestParseMypyOutput(t *testing.T) {
+	data := []byte("src/app.py:12:5: error: Incompatible types in assignment (expression has type \"int\", variable has type \"str\")  [assignment]\n" +
+		"src/app.py:12: note: See https://mypy.rtfd.io\r\n" +
+		"src/util.py:3:1: error: Name \"foo\" is not defined  [name-defined]\n" +
+		"src/util.py:7:9: warning: unused \"type: ignore\" comment\n" +
+		"Found 2 errors in 2 files (checked 4 source files)\n")
+
+	expected := []types.Diagnostic{
+		{Tool: "mypy", File: "src/app.py", Line: 12, Column: 5, Severity: "error", Rule: "assignment", Message: "Incompatible types in assignment (expression has type \"int\", variable has type \"str\")"},
+		{Tool: "mypy", File: "src/app.py", Line: 12, Severity: "note", Message: "See https://mypy.rtfd.io"},
+		{Tool: "mypy", File: "src/util.py", Line: 3, Column: 1, Severity: "error", Rule: "name-defined", Message: "Name \"foo\" is not defined"},
+		{Tool: "mypy", File: "src/util.py", Line: 7, Column: 9, Severity: "warning", Message: "unused \"type: ignore\" comment"},
+	}
+	if diagnostics := parseMypyOutput(data); !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("parseMypyOutput() = %+v, want %+v", diagnostics, expected)
+	}
+	if diagnostics := parseMypyOutput([]byte("Success: no issues found in 4 source files\n")); len(diagnostics) != 0 {
+		t.Errorf("parseMypyOutput() of a clean run = %+v", diagnostics)
+	}
+}
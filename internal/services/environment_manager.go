@@ -0,0 +1,437 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+// defaultVenvDir is where uv creates the project environment.
+const defaultVenvDir = ".venv"
+
+// skippedVenvSearchDirs are never searched for environments.
+var skippedVenvSearchDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"__pycache__":  true,
+}
+
+// EnvironmentManager implements virtual environment management.
+type EnvironmentManager struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewEnvironmentManager creates a new environment manager.
+func NewEnvironmentManager(executor CommandExecutorInterface) *EnvironmentManager {
+	return &EnvironmentManager{executor: executor}
+}
+
+// ProjectEnvironment returns the absolute path of the environment uv uses for
+// the project: $UV_PROJECT_ENVIRONMENT, or .venv in the project root.
+func (e *EnvironmentManager) ProjectEnvironment() (string, error) {
+	root, err := e.absProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	if dir := os.Getenv("UV_PROJECT_ENVIRONMENT"); dir != "" {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		return filepath.Clean(dir), nil
+	}
+	return filepath.Join(root, defaultVenvDir), nil
+}
+
+// ListEnvironments returns the virtual environments in the project root and
+// up to one directory below it, the project environment and the activated
+// environment. The project environment is listed first, then the others in
+// the order they were found.
+func (e *EnvironmentManager) ListEnvironments(ctx context.Context) ([]types.VirtualEnv, error) {
+	root, err := e.absProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+	projectEnv, err := e.ProjectEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	activeEnv := os.Getenv("VIRTUAL_ENV")
+	if activeEnv != "" {
+		activeEnv, _ = filepath.Abs(activeEnv)
+	}
+
+	candidates := findVenvDirs(root, 2)
+	candidates = append(candidates, projectEnv)
+	if activeEnv != "" {
+		candidates = append(candidates, activeEnv)
+	}
+
+	seen := make(map[string]bool)
+	var envs []types.VirtualEnv
+	for _, dir := range candidates {
+		if seen[dir] || !isVenv(dir) {
+			continue
+		}
+		seen[dir] = true
+
+		env, err := readVenv(ctx, dir)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// List the environment anyway, so that it can still be deleted.
+			env.Error = err
+		}
+		env.Name = dir
+		if rel, err := filepath.Rel(root, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			env.Name = rel
+		}
+		env.Project = dir == projectEnv
+		env.Active = dir == activeEnv
+		envs = append(envs, env)
+	}
+
+	sort.SliceStable(envs, func(i, j int) bool {
+		return envs[i].Project && !envs[j].Project
+	})
+	return envs, nil
+}
+
+// CreateEnvironment creates a virtual environment with uv venv, streaming uv's
+// output to output.
+func (e *EnvironmentManager) CreateEnvironment(ctx context.Context, options types.VenvOptions, output OutputHandler) error {
+	if !e.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+
+	args := []string{"venv"}
+	if options.Path != "" {
+		args = append(args, options.Path)
+	}
+	if options.PythonVersion != "" {
+		args = append(args, "--python", options.PythonVersion)
+	}
+	if options.Prompt != "" {
+		args = append(args, "--prompt", options.Prompt)
+	}
+	if options.Seed {
+		args = append(args, "--seed")
+	}
+	if options.SystemSitePackages {
+		args = append(args, "--system-site-packages")
+	}
+
+	_, err := e.exec(e.executor).ExecuteStream(ctx, output, "uv", args...)
+	return err
+}
+
+// RecreateEnvironment creates an environment again with the same interpreter
+// and options. The old environment is moved aside while uv venv runs and put
+// back if uv fails, so that a bad interpreter request or a network error does
+// not leave the project without an environment.
+func (e *EnvironmentManager) RecreateEnvironment(ctx context.Context, env types.VirtualEnv, output OutputHandler) error {
+	if !e.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	if env.Error != nil {
+		return fmt.Errorf("cannot recreate %s: %w", env.Path, env.Error)
+	}
+	path, err := e.venvPath(env.Path)
+	if err != nil {
+		return err
+	}
+
+	// The backup stays next to the environment, on the same file system, so
+	// that moving it is a rename. Environments hold absolute paths, so it is
+	// only ever moved back to where it was.
+	backupDir, err := os.MkdirTemp(filepath.Dir(path), "."+filepath.Base(path)+".uvui-")
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	backup := filepath.Join(backupDir, filepath.Base(path))
+	if err := os.Rename(path, backup); err != nil {
+		_ = os.Remove(backupDir)
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	env.Path = path
+	if err := e.CreateEnvironment(ctx, venvOptions(env), output); err != nil {
+		if restoreErr := restoreVenv(backup, path); restoreErr != nil {
+			// Keep the backup for the user to recover.
+			return fmt.Errorf("%w; the old environment could not be restored from %s: %v", err, backup, restoreErr)
+		}
+		_ = os.Remove(backupDir)
+		return err
+	}
+	return os.RemoveAll(backupDir)
+}
+
+// DeleteEnvironment removes the virtual environment at path. Relative paths
+// are resolved against the project root; directories that are not virtual
+// environments are refused.
+func (e *EnvironmentManager) DeleteEnvironment(_ context.Context, path string) error {
+	path, err := e.venvPath(path)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// venvPath returns the absolute path of the virtual environment at path,
+// relative paths being resolved against the project root. Anything without a
+// pyvenv.cfg, and the project root itself, is refused.
+func (e *EnvironmentManager) venvPath(path string) (string, error) {
+	root, err := e.absProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+	if path == root || !isVenv(path) {
+		return "", fmt.Errorf("%s is not a virtual environment", path)
+	}
+	return path, nil
+}
+
+// restoreVenv puts the environment moved to backup back at path, replacing
+// whatever a failed uv venv left there.
+func restoreVenv(backup, path string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+	return os.Rename(backup, path)
+}
+
+// ListPackages returns the packages installed in the environment of the
+// python interpreter, with their locations and dependency edges.
+func (e *EnvironmentManager) ListPackages(ctx context.Context, python string) ([]types.InstalledPackage, error) {
+	if !e.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+
+	output, err := e.exec(e.executor).Execute(ctx, "uv", "pip", "list", "--format", "json", "--python", python)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+	packages, err := parsePipList(output)
+	if err != nil || len(packages) == 0 {
+		return packages, err
+	}
+
+	args := []string{"pip", "show", "--python", python}
+	for _, pkg := range packages {
+		args = append(args, pkg.Name)
+	}
+	output, err = e.exec(e.executor).Execute(ctx, "uv", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show packages: %w", err)
+	}
+	mergePackageDetails(packages, parsePipShow(output))
+	return packages, nil
+}
+
+// ShowPackage returns the metadata and files of a package installed in the
+// environment of the python interpreter.
+func (e *EnvironmentManager) ShowPackage(ctx context.Context, python, name string) (*types.PackageDetails, error) {
+	if !e.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+
+	output, err := e.exec(e.executor).Execute(ctx, "uv", "pip", "show", "--files", "--python", python, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show %s: %w", name, err)
+	}
+	packages := parsePipShow(output)
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("package %s is not installed", name)
+	}
+
+	details := packages[0]
+	for _, file := range details.Files {
+		if strings.HasSuffix(file, ".dist-info/METADATA") {
+			if data, err := os.ReadFile(filepath.Join(details.Location, file)); err == nil {
+				details.Metadata = parseCoreMetadata(data)
+			}
+			break
+		}
+	}
+	return &details, nil
+}
+
+// FreezePackages returns the environment's packages in requirements format,
+// as printed by uv pip freeze.
+func (e *EnvironmentManager) FreezePackages(ctx context.Context, python string) ([]string, error) {
+	if !e.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+
+	output, err := e.exec(e.executor).Execute(ctx, "uv", "pip", "freeze", "--python", python)
+	if err != nil {
+		return nil, fmt.Errorf("failed to freeze packages: %w", err)
+	}
+	return parsePipFreeze(output), nil
+}
+
+// CheckPackages returns the broken requirements in the environment of the
+// python interpreter, as reported by uv pip check.
+func (e *EnvironmentManager) CheckPackages(ctx context.Context, python string) ([]types.PackageIssue, error) {
+	if !e.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+
+	// uv pip check reports problems on stderr and exits with an error when
+	// it finds any, so stderr is collected along with stdout.
+	var lines []string
+	collect := func(line types.OutputLine) {
+		if line.Stream == types.StreamStderr {
+			lines = append(lines, line.Text)
+		}
+	}
+	output, err := e.exec(e.executor).ExecuteStream(ctx, collect, "uv", "pip", "check", "--python", python)
+	lines = append(lines, strings.Split(string(output), "\n")...)
+
+	issues := parsePipCheck(lines)
+	if err != nil && (len(issues) == 0 || ctx.Err() != nil) {
+		return nil, fmt.Errorf("failed to check packages: %w", err)
+	}
+	return issues, nil
+}
+
+// venvOptions returns the options that recreate env.
+func venvOptions(env types.VirtualEnv) types.VenvOptions {
+	python := env.PythonVersion
+	if python != "" && env.Implementation != "" {
+		python = strings.ToLower(env.Implementation) + "@" + python
+	}
+	return types.VenvOptions{
+		Path:               env.Path,
+		PythonVersion:      python,
+		Prompt:             env.Prompt,
+		Seed:               env.Seeded,
+		SystemSitePackages: env.SystemSitePackages,
+	}
+}
+
+// isVenv reports whether dir holds a virtual environment.
+func isVenv(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, "pyvenv.cfg"))
+	return err == nil && !info.IsDir()
+}
+
+// findVenvDirs returns the virtual environments in root and up to depth
+// levels below it, without looking inside environments.
+func findVenvDirs(root string, depth int) []string {
+	var dirs []string
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || skippedVenvSearchDirs[entry.Name()] {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		if isVenv(dir) {
+			dirs = append(dirs, dir)
+		} else if depth > 1 {
+			dirs = append(dirs, findVenvDirs(dir, depth-1)...)
+		}
+	}
+	return dirs
+}
+
+// readVenv describes the environment in dir from its pyvenv.cfg and files.
+func readVenv(ctx context.Context, dir string) (types.VirtualEnv, error) {
+	env := types.VirtualEnv{Path: dir, Interpreter: venvInterpreter(dir)}
+
+	data, err := os.ReadFile(filepath.Join(dir, "pyvenv.cfg"))
+	if err != nil {
+		return env, err
+	}
+	cfg := parsePyvenvCfg(data)
+	env.BaseInterpreter = cfg["home"]
+	env.Implementation = cfg["implementation"]
+	env.PythonVersion = cfg["version_info"]
+	if env.PythonVersion == "" {
+		env.PythonVersion = cfg["version"]
+	}
+	env.Prompt = cfg["prompt"]
+	env.SystemSitePackages = strings.EqualFold(cfg["include-system-site-packages"], "true")
+	env.UVVersion = cfg["uv"]
+	env.Seeded = hasPip(dir)
+
+	env.Size, err = dirSize(ctx, dir)
+	return env, err
+}
+
+// parsePyvenvCfg parses the "key = value" lines of a pyvenv.cfg file.
+func parsePyvenvCfg(data []byte) map[string]string {
+	cfg := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		cfg[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return cfg
+}
+
+// venvInterpreter returns the path of the python executable of the
+// environment in dir.
+func venvInterpreter(dir string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "Scripts", "python.exe")
+	}
+	return filepath.Join(dir, "bin", "python")
+}
+
+// hasPip reports whether pip is installed in the environment in dir.
+func hasPip(dir string) bool {
+	patterns := []string{
+		filepath.Join(dir, "lib", "python*", "site-packages", "pip"),
+		filepath.Join(dir, "lib", "pypy*", "site-packages", "pip"),
+		filepath.Join(dir, "Lib", "site-packages", "pip"),
+	}
+	for _, pattern := range patterns {
+		if matches, _ := filepath.Glob(pattern); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// dirSize returns the total size of the regular files below dir. Symbolic
+// links are not followed.
+func dirSize(ctx context.Context, dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.Type().IsRegular() {
+			if info, err := entry.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size, err
+}
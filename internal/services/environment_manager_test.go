@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+// writeVenv creates a minimal virtual environment with the given pyvenv.cfg.
+func writeVenv(t *testing.T, dir, cfg string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pyvenv.cfg"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bin", "python"), []byte("#!python"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewEnvironmentManager(t *testing.T) {
+	executor := &mockCommandExecutor{}
+	em := NewEnvironmentManager(executor)
+	if em == nil {
+		t.Error("NewEnvironmentManager() should not return nil")
+	}
+}
+
+func TestParsePyvenvCfg(t *testing.T) {
+	data := []byte("home = /usr/local/bin\nimplementation = CPython\nuv = 0.5.4\nversion_info = 3.12.7\ninclude-system-site-packages = false\nprompt = demo\n")
+	cfg := parsePyvenvCfg(data)
+
+	expected := map[string]string{
+		"home":                         "/usr/local/bin",
+		"implementation":               "CPython",
+		"uv":                           "0.5.4",
+		"version_info":                 "3.12.7",
+		"include-system-site-packages": "false",
+		"prompt":                       "demo",
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("parsePyvenvCfg() = %v, want %v", cfg, expected)
+	}
+}
+
+func TestListEnvironments(t *testing.T) {
+	t.Setenv("UV_PROJECT_ENVIRONMENT", "")
+	root := t.TempDir()
+	active := t.TempDir()
+	t.Setenv("VIRTUAL_ENV", active)
+
+	writeVenv(t, filepath.Join(root, ".venv"), "home = /usr/bin\nimplementation = CPython\nversion_info = 3.12.7\nuv = 0.5.4\n")
+	writeVenv(t, filepath.Join(root, "envs", "py311"), "home = /usr/bin\nversion = 3.11.9\ninclude-system-site-packages = true\nprompt = legacy\n")
+	writeVenv(t, filepath.Join(root, ".git", "hooks-env"), "version = 3.10.0\n")
+	writeVenv(t, active, "version = 3.13.0\n")
+	if err := os.MkdirAll(filepath.Join(root, "envs", "py311", "lib", "python3.11", "site-packages", "pip"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	em := NewEnvironmentManager(&mockCommandExecutor{})
+	em.SetProjectRoot(root)
+
+	envs, err := em.ListEnvironments(context.Background())
+	if err != nil {
+		t.Fatalf("ListEnvironments() error = %v", err)
+	}
+	if len(envs) != 3 {
+		t.Fatalf("ListEnvironments() returned %d environments, want 3: %+v", len(envs), envs)
+	}
+
+	project := envs[0]
+	if project.Name != ".venv" || !project.Project || project.Active {
+		t.Errorf("first environment = %+v, want the project .venv", project)
+	}
+	if project.PythonVersion != "3.12.7" || project.Implementation != "CPython" || project.UVVersion != "0.5.4" {
+		t.Errorf("project environment = %+v, want CPython 3.12.7 created by uv 0.5.4", project)
+	}
+	if project.Interpreter != filepath.Join(root, ".venv", "bin", "python") {
+		t.Errorf("project interpreter = %q", project.Interpreter)
+	}
+	if project.Size == 0 {
+		t.Error("project environment size = 0, want the size of its files")
+	}
+
+	legacy := envs[1]
+	if legacy.Name != filepath.Join("envs", "py311") || legacy.PythonVersion != "3.11.9" {
+		t.Errorf("second environment = %+v, want envs/py311 with Python 3.11.9", legacy)
+	}
+	if !legacy.SystemSitePackages || !legacy.Seeded || legacy.Prompt != "legacy" {
+		t.Errorf("second environment = %+v, want seeded with system site packages and prompt legacy", legacy)
+	}
+
+	if !envs[2].Active || envs[2].Path != active {
+		t.Errorf("third environment = %+v, want the active environment", envs[2])
+	}
+}
+
+func TestProjectEnvironment(t *testing.T) {
+	root := t.TempDir()
+	em := NewEnvironmentManager(&mockCommandExecutor{})
+	em.SetProjectRoot(root)
+
+	t.Setenv("UV_PROJECT_ENVIRONMENT", "")
+	if dir, _ := em.ProjectEnvironment(); dir != filepath.Join(root, ".venv") {
+		t.Errorf("ProjectEnvironment() = %q, want .venv in the project root", dir)
+	}
+
+	t.Setenv("UV_PROJECT_ENVIRONMENT", "build/env")
+	if dir, _ := em.ProjectEnvironment(); dir != filepath.Join(root, "build", "env") {
+		t.Errorf("ProjectEnvironment() = %q, want build/env in the project root", dir)
+	}
+}
+
+func TestCreateEnvironment(t *testing.T) {
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = args
+			return nil, nil
+		},
+	}
+	em := NewEnvironmentManager(executor)
+	em.SetProjectRoot("/project")
+
+	options := types.VenvOptions{Path: "envs/test", PythonVersion: "3.12", Prompt: "test", Seed: true, SystemSitePackages: true}
+	if err := em.CreateEnvironment(context.Background(), options, nil); err != nil {
+		t.Fatalf("CreateEnvironment() error = %v", err)
+	}
+	expected := []string{"venv", "envs/test", "--python", "3.12", "--prompt", "test", "--seed", "--system-site-packages"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("CreateEnvironment() ran uv %v, want %v", got, expected)
+	}
+	if executor.Dir != "/project" {
+		t.Errorf("CreateEnvironment() ran in %q, want /project", executor.Dir)
+	}
+
+	if err := em.CreateEnvironment(context.Background(), types.VenvOptions{}, nil); err != nil {
+		t.Fatalf("CreateEnvironment() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"venv"}) {
+		t.Errorf("CreateEnvironment() with defaults ran uv %v, want [venv]", got)
+	}
+}
+
+func TestCreateEnvironment_Error(t *testing.T) {
+	executor := &mockCommandExecutor{
+		IsUVAvailableFunc: func() bool { return false },
+	}
+	em := NewEnvironmentManager(executor)
+
+	if err := em.CreateEnvironment(context.Background(), types.VenvOptions{}, nil); err == nil {
+		t.Error("CreateEnvironment() should fail when UV is not available")
+	}
+}
+
+func TestRecreateEnvironment(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".venv")
+	writeVenv(t, dir, "implementation = CPython\nversion_info = 3.12.7\n")
+
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = args
+			if _, err := os.Stat(dir); !os.IsNotExist(err) {
+				return nil, fmt.Errorf("environment was not deleted first")
+			}
+			return nil, nil
+		},
+	}
+	em := NewEnvironmentManager(executor)
+	em.SetProjectRoot(root)
+
+	env := types.VirtualEnv{Path: dir, PythonVersion: "3.12.7", Implementation: "CPython", Prompt: "demo", Seeded: true}
+	if err := em.RecreateEnvironment(context.Background(), env, nil); err != nil {
+		t.Fatalf("RecreateEnvironment() error = %v", err)
+	}
+	expected := []string{"venv", dir, "--python", "cpython@3.12.7", "--prompt", "demo", "--seed"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("RecreateEnvironment() ran uv %v, want %v", got, expected)
+	}
+}
+
+func TestRecreateEnvironment_RestoresOnFailure(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".venv")
+	writeVenv(t, dir, "version_info = 3.12.7\n")
+
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			// uv may leave a partial environment behind.
+			if err := os.MkdirAll(filepath.Join(dir, "bin"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			return nil, fmt.Errorf("error: No interpreter found for Python 3.99")
+		},
+	}
+	em := NewEnvironmentManager(executor)
+	em.SetProjectRoot(root)
+
+	env := types.VirtualEnv{Path: ".venv", PythonVersion: "3.99"}
+	if err := em.RecreateEnvironment(context.Background(), env, nil); err == nil {
+		t.Fatal("RecreateEnvironment() error = nil, want uv's error")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "pyvenv.cfg"))
+	if err != nil || string(data) != "version_info = 3.12.7\n" {
+		t.Errorf("RecreateEnvironment() did not restore the old environment: %q, %v", data, err)
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 1 {
+		t.Errorf("RecreateEnvironment() left %d entries in the project, want only .venv", len(entries))
+	}
+
+	env.Error = fmt.Errorf("permission denied")
+	if err := em.RecreateEnvironment(context.Background(), env, nil); err == nil {
+		t.Error("RecreateEnvironment() should refuse an environment that could not be read")
+	}
+}
+
+func TestDeleteEnvironment(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".venv")
+	writeVenv(t, dir, "version = 3.12.7\n")
+	plain := filepath.Join(root, "src")
+	if err := os.MkdirAll(plain, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	em := NewEnvironmentManager(&mockCommandExecutor{})
+	em.SetProjectRoot(root)
+
+	if err := em.DeleteEnvironment(context.Background(), plain); err == nil {
+		t.Error("DeleteEnvironment() should refuse a directory that is not an environment")
+	}
+	// Relative paths are resolved against the project, not the working
+	// directory.
+	if err := em.DeleteEnvironment(context.Background(), "."); err == nil {
+		t.Error("DeleteEnvironment() should refuse the project root")
+	}
+	if err := em.DeleteEnvironment(context.Background(), ".venv"); err != nil {
+		t.Fatalf("DeleteEnvironment() error = %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("DeleteEnvironment() did not remove the environment")
+	}
+	if _, err := os.Stat(plain); err != nil {
+		t.Error("DeleteEnvironment() removed another directory")
+	}
+}
+
+func TestListPackages(t *testing.T) {
+	var calls [][]string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			calls = append(calls, args)
+			switch args[1] {
+			case "list":
+				return []byte(`[{"name":"anyio","version":"4.4.0"},{"name":"demo","version":"0.1.0"}]`), nil
+			case "show":
+				return []byte(testPipShowOutput), nil
+			}
+			return nil, fmt.Errorf("unexpected command: %s %v", command, args)
+		},
+	}
+	em := NewEnvironmentManager(executor)
+
+	packages, err := em.ListPackages(context.Background(), "/project/.venv/bin/python")
+	if err != nil {
+		t.Fatalf("ListPackages() error = %v", err)
+	}
+	expected := [][]string{
+		{"pip", "list", "--format", "json", "--python", "/project/.venv/bin/python"},
+		{"pip", "show", "--python", "/project/.venv/bin/python", "anyio", "demo"},
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("ListPackages() ran uv %v, want %v", calls, expected)
+	}
+	if len(packages) != 2 || packages[0].Location == "" || packages[1].EditableLocation != "/project" {
+		t.Errorf("ListPackages() = %+v, want locations from uv pip show", packages)
+	}
+	if !reflect.DeepEqual(packages[0].RequiredBy, []string{"httpx"}) {
+		t.Errorf("ListPackages() anyio required by %v, want [httpx]", packages[0].RequiredBy)
+	}
+}
+
+func TestShowPackage(t *testing.T) {
+	location := t.TempDir()
+	distInfo := filepath.Join(location, "anyio-4.4.0.dist-info")
+	if err := os.MkdirAll(distInfo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(distInfo, "METADATA"), []byte("Name: anyio\nSummary: High level compatibility layer\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = args
+			return []byte("Name: anyio\nVersion: 4.4.0\nLocation: " + location + "\nFiles:\n  anyio-4.4.0.dist-info/METADATA\n"), nil
+		},
+	}
+	em := NewEnvironmentManager(executor)
+
+	details, err := em.ShowPackage(context.Background(), "python", "anyio")
+	if err != nil {
+		t.Fatalf("ShowPackage() error = %v", err)
+	}
+	if expected := []string{"pip", "show", "--files", "--python", "python", "anyio"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("ShowPackage() ran uv %v, want %v", got, expected)
+	}
+	expected := []types.PackageMetadata{{Key: "Summary", Value: "High level compatibility layer"}}
+	if !reflect.DeepEqual(details.Metadata, expected) {
+		t.Errorf("ShowPackage() metadata = %+v, want %+v", details.Metadata, expected)
+	}
+}
+
+func TestFreezePackages(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			return []byte("anyio==4.4.0\nidna==3.7\n"), nil
+		},
+	}
+	em := NewEnvironmentManager(executor)
+
+	lines, err := em.FreezePackages(context.Background(), "python")
+	if err != nil {
+		t.Fatalf("FreezePackages() error = %v", err)
+	}
+	if !reflect.DeepEqual(lines, []string{"anyio==4.4.0", "idna==3.7"}) {
+		t.Errorf("FreezePackages() = %v", lines)
+	}
+}
+
+func TestCheckPackages(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			handler(types.OutputLine{Stream: types.StreamStderr, Text: "Found 1 incompatibility"})
+			handler(types.OutputLine{Stream: types.StreamStderr, Text: "The package `httpx` requires `idna`, but it's not installed"})
+			return nil, fmt.Errorf("exit status 1")
+		},
+	}
+	em := NewEnvironmentManager(executor)
+
+	issues, err := em.CheckPackages(context.Background(), "python")
+	if err != nil {
+		t.Fatalf("CheckPackages() error = %v, want the problems found", err)
+	}
+	if len(issues) != 1 || issues[0].Package != "httpx" || issues[0].Requirement != "idna" {
+		t.Errorf("CheckPackages() = %+v", issues)
+	}
+
+	executor.ExecuteStreamFunc = func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+		return nil, fmt.Errorf("no interpreter found")
+	}
+	if _, err := em.CheckPackages(context.Background(), "python"); err == nil {
+		t.Error("CheckPackages() should fail when uv reports no problems but fails")
+	}
+}
@@ -0,0 +1,80 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"uvui/internal/types"
+)
+
+// inlineScriptBlock is the type of the PEP 723 block holding a script's
+// metadata.
+const inlineScriptBlock = "script"
+
+// inlineScriptTOML is the subset of a "# /// script" block read by uvui.
+type inlineScriptTOML struct {
+	RequiresPython string   `toml:"requires-python"`
+	Dependencies   []string `toml:"dependencies"`
+}
+
+// parseInlineScriptMetadata parses the PEP 723 metadata of a Python script.
+// A script without a "# /// script" block has empty metadata.
+func parseInlineScriptMetadata(data []byte) (*types.ScriptMetadata, error) {
+	block, found, err := extractInlineBlock(data, inlineScriptBlock)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &types.ScriptMetadata{HasBlock: found, Dependencies: []types.ProjectDependency{}}
+	if !found {
+		return metadata, nil
+	}
+
+	var raw inlineScriptTOML
+	if _, err := toml.Decode(block, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse script metadata: %w", err)
+	}
+	metadata.RequiresPython = raw.RequiresPython
+	for _, requirement := range raw.Dependencies {
+		metadata.Dependencies = append(metadata.Dependencies, newProjectDependency(requirement, DependencyMain, ""))
+	}
+	return metadata, nil
+}
+
+// extractInlineBlock returns the content of the PEP 723 block of the given
+// type, without its comment markers. As in the reference implementation, a
+// block opens with "# /// TYPE", continues with lines that are "#" or start
+// with "# ", and closes at the last "# ///" line of that run. A type may only
+// appear once.
+func extractInlineBlock(data []byte, blockType string) (string, bool, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	var content []string
+	found := false
+	for i := 0; i < len(lines); i++ {
+		if lines[i] != "# /// "+blockType {
+			continue
+		}
+		end := -1
+		for j := i + 1; j < len(lines) && (lines[j] == "#" || strings.HasPrefix(lines[j], "# ")); j++ {
+			if lines[j] == "# ///" {
+				end = j
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		if found {
+			return "", false, fmt.Errorf("multiple %q blocks found", blockType)
+		}
+
+		found = true
+		for _, line := range lines[i+1 : end] {
+			content = append(content, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+		}
+		i = end
+	}
+	return strings.Join(content, "\n"), found, nil
+}
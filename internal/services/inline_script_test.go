@@ -0,0 +1,75 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sampleInlineScript = `#!/usr/bin/env -S uv run --script
+# /// script
+# requires-python = ">=3.11"
+# dependencies = [
+#   "requests<3",
+#   "rich; python_version >= '3.12'",
+# ]
+#
+# [tool.uv]
+# exclude-newer = "2024-06-01T00:00:00Z"
+# ///
+
+import requests
+`
+
+func TestParseInlineScriptMetadata(t *testing.T) {
+	metadata, err := parseInlineScriptMetadata([]byte(sampleInlineScript))
+	if err != nil {
+		t.Fatalf("parseInlineScriptMetadata() error = %v", err)
+	}
+	if !metadata.HasBlock || metadata.RequiresPython != ">=3.11" {
+		t.Errorf("parseInlineScriptMetadata() = %+v", metadata)
+	}
+	var got []string
+	for _, dep := range metadata.Dependencies {
+		got = append(got, dep.Name+" "+dep.Version+" "+dep.Markers)
+	}
+	if expected := []string{"requests <3 ", "rich  python_version >= '3.12'"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("parseInlineScriptMetadata() dependencies = %q, want %q", got, expected)
+	}
+}
+
+func TestParseInlineScriptMetadata_NoBlock(t *testing.T) {
+	for name, script := range map[string]string{
+		"plain":      "print('hi')\n",
+		"other type": "# /// pyproject\n# [project]\n# ///\n",
+		"unclosed":   "# /// script\n# dependencies = []\nimport os\n",
+	} {
+		metadata, err := parseInlineScriptMetadata([]byte(script))
+		if err != nil || metadata.HasBlock || len(metadata.Dependencies) != 0 {
+			t.Errorf("%s: parseInlineScriptMetadata() = %+v, %v, want no metadata", name, metadata, err)
+		}
+	}
+}
+
+func TestParseInlineScriptMetadata_Errors(t *testing.T) {
+	for name, script := range map[string]string{
+		"duplicate": "# /// script\n# dependencies = []\n# ///\n\n# /// script\n# dependencies = []\n# ///\n",
+		"bad toml":  "# /// script\n# dependencies = [\n# ///\n",
+	} {
+		if _, err := parseInlineScriptMetadata([]byte(script)); err == nil {
+			t.Errorf("%s: parseInlineScriptMetadata() should fail", name)
+		}
+	}
+}
+
+func TestExtractInlineBlock(t *testing.T) {
+	// The block ends at the last "# ///" line of the comment run, so a
+	// closing marker inside the content does not end it early.
+	script := "# /// script\r\n# a = 1\r\n# ///\r\n#\r\n# b = 2\r\n# ///\r\nx = 1\r\n"
+	block, found, err := extractInlineBlock([]byte(script), "script")
+	if err != nil || !found {
+		t.Fatalf("extractInlineBlock() = %q, %v, %v", block, found, err)
+	}
+	if expected := "a = 1\n///\n\nb = 2"; block != expected {
+		t.Errorf("extractInlineBlock() = %q, want %q", block, expected)
+	}
+}
@@ -1,37 +1,147 @@
 // Package services provides services for the application.
 package services
 
-import "uvui/internal/types"
+import (
+	"context"
+	"os/exec"
+
+	"uvui/internal/types"
+)
+
+// OutputHandler receives command output line by line while a command runs.
+type OutputHandler func(line types.OutputLine)
 
 // CommandExecutorInterface defines the contract for command execution.
 type CommandExecutorInterface interface {
-	Execute(command string, args ...string) ([]byte, error)
+	Execute(ctx context.Context, command string, args ...string) ([]byte, error)
+	ExecuteStream(ctx context.Context, handler OutputHandler, command string, args ...string) ([]byte, error)
+	Command(command string, args ...string) *exec.Cmd
+	InDir(dir string) CommandExecutorInterface
+	WithEnv(env []string) CommandExecutorInterface
+	SetUVPath(path string)
+	UVPath() string
 	IsUVAvailable() bool
 }
 
+// ProjectScoped is implemented by services whose commands operate on a
+// project directory.
+type ProjectScoped interface {
+	SetProjectRoot(dir string)
+	ProjectRoot() string
+}
+
 // PythonManagerInterface defines the contract for Python version management.
 type PythonManagerInterface interface {
-	ListAvailable() ([]types.PythonVersion, error)
-	ListInstalled() ([]types.PythonVersion, error)
-	Install(version string) error
-	Uninstall(version string) error
-	Pin(version string) error
-	Find(version string) (*types.PythonVersion, error)
+	ProjectScoped
+	ListAvailable(ctx context.Context) ([]types.PythonVersion, error)
+	ListInstalled(ctx context.Context) ([]types.PythonVersion, error)
+	Install(ctx context.Context, version string, output OutputHandler) error
+	Uninstall(ctx context.Context, version string, output OutputHandler) error
+	Pin(ctx context.Context, version string) error
+	Find(ctx context.Context, version string) (*types.PythonVersion, error)
 }
 
 // ProjectManagerInterface defines the contract for project management.
 type ProjectManagerInterface interface {
-	GetProjectStatus() (*types.ProjectStatus, error)
-	InitProject(name string, options types.InitOptions) (string, error)
-	SyncProject() error
-	LockProject() error
-	GetDependencyTree() (*types.DependencyTree, error)
-	GetProjectDependencies() ([]types.ProjectDependency, error)
+	ProjectScoped
+	GetProjectStatus(ctx context.Context) (*types.ProjectStatus, error)
+	InitProject(ctx context.Context, name string, options types.InitOptions, output OutputHandler) (string, error)
+	SyncProject(ctx context.Context, output OutputHandler) error
+	LockProject(ctx context.Context, output OutputHandler) error
+	AddDependency(ctx context.Context, requirement string, options types.DependencyOptions, output OutputHandler) error
+	RemoveDependency(ctx context.Context, name string, options types.DependencyOptions, output OutputHandler) error
+	GetDependencyTree(ctx context.Context) (*types.DependencyTree, error)
+	GetProjectDependencies(ctx context.Context) ([]types.ProjectDependency, error)
+	GetLockFile(ctx context.Context) (*types.LockFile, error)
+}
+
+// EnvironmentManagerInterface defines the contract for virtual environment
+// management.
+type EnvironmentManagerInterface interface {
+	ProjectScoped
+	ProjectEnvironment() (string, error)
+	ListEnvironments(ctx context.Context) ([]types.VirtualEnv, error)
+	CreateEnvironment(ctx context.Context, options types.VenvOptions, output OutputHandler) error
+	RecreateEnvironment(ctx context.Context, env types.VirtualEnv, output OutputHandler) error
+	DeleteEnvironment(ctx context.Context, path string) error
+	ListPackages(ctx context.Context, python string) ([]types.InstalledPackage, error)
+	ShowPackage(ctx context.Context, python, name string) (*types.PackageDetails, error)
+	FreezePackages(ctx context.Context, python string) ([]string, error)
+	CheckPackages(ctx context.Context, python string) ([]types.PackageIssue, error)
+}
+
+// ToolManagerInterface defines the contract for tool management.
+type ToolManagerInterface interface {
+	ProjectScoped
+	ListTools(ctx context.Context) ([]types.Tool, error)
+	BinDir(ctx context.Context) (string, error)
+	InstallTool(ctx context.Context, options types.ToolInstallOptions, output OutputHandler) error
+	UpgradeTool(ctx context.Context, name string, output OutputHandler) error
+	UninstallTool(ctx context.Context, name string, output OutputHandler) error
+	UpdateShell(ctx context.Context, output OutputHandler) error
+	RunTool(ctx context.Context, options types.ToolRunOptions, output OutputHandler) error
+	ToolCommand(options types.ToolRunOptions) (*exec.Cmd, error)
+}
+
+// ScriptManagerInterface defines the contract for running project entry
+// points and scripts, and for editing the inline metadata of scripts.
+type ScriptManagerInterface interface {
+	ProjectScoped
+	ListTargets(ctx context.Context) ([]types.RunTarget, error)
+	RunTarget(ctx context.Context, options types.RunOptions, output OutputHandler) error
+	ScriptMetadata(ctx context.Context, script string) (*types.ScriptMetadata, error)
+	AddScriptDependency(ctx context.Context, script, requirement string, output OutputHandler) error
+	RemoveScriptDependency(ctx context.Context, script, name string, output OutputHandler) error
+	LockScript(ctx context.Context, script string, output OutputHandler) error
+}
+
+// TaskManagerInterface defines the contract for the project task runner.
+type TaskManagerInterface interface {
+	ProjectScoped
+	ListTasks(ctx context.Context) ([]types.Task, error)
+	RunTask(ctx context.Context, name string, output OutputHandler) ([]types.TaskResult, error)
+}
+
+// TestRunnerInterface defines the contract for running tests with pytest.
+type TestRunnerInterface interface {
+	ProjectScoped
+	RunTests(ctx context.Context, options types.PytestOptions, output OutputHandler) (*types.TestReport, error)
+	LoadMatrix(ctx context.Context) (types.TestMatrix, error)
+	RunMatrix(ctx context.Context, matrix types.TestMatrix, pythons []string, output OutputHandler) ([]types.MatrixResult, error)
+}
+
+// CodeCheckerInterface defines the contract for running ruff and mypy.
+type CodeCheckerInterface interface {
+	ProjectScoped
+	Check(ctx context.Context, tool string, output OutputHandler) ([]types.Diagnostic, error)
+	FixRuff(ctx context.Context, output OutputHandler) error
+	EditorCommand(diagnostic types.Diagnostic) (*exec.Cmd, error)
+}
+
+// CacheManagerInterface defines the contract for uv cache management.
+type CacheManagerInterface interface {
+	ProjectScoped
+	CacheDir(ctx context.Context) (string, error)
+	Inspect(ctx context.Context) (*types.CacheInfo, error)
+	Clean(ctx context.Context, packages []string, output OutputHandler) (int64, error)
+	Prune(ctx context.Context, ci bool, output OutputHandler) (int64, error)
 }
 
 // UVInstallerInterface defines the contract for UV installation.
 type UVInstallerInterface interface {
-	IsInstalled() (bool, string, error)
-	Install() error
+	IsInstalled(ctx context.Context) (bool, string, error)
+	Install(ctx context.Context, output OutputHandler) (types.UVStatus, error)
 	GetInstallCommand() (string, error)
+	SetInstallOptions(options types.UVInstallOptions)
+	SelfUpdate(ctx context.Context, version string, output OutputHandler) error
+	Uninstall(ctx context.Context, output OutputHandler) error
+	ManagedPath() (string, bool)
+}
+
+// UVLocatorInterface defines the contract for discovering uv binaries.
+type UVLocatorInterface interface {
+	ProjectScoped
+	SetSearchPaths(paths []string)
+	SetProjectTrusted(trusted bool)
+	Discover(ctx context.Context) ([]types.UVBinary, error)
 }
@@ -0,0 +1,142 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+)
+
+// junitReport mirrors a JUnit XML report as written by pytest: a
+// <testsuites> root or, with older pytest releases, a single <testsuite>.
+type junitReport struct {
+	XMLName xml.Name
+	Time    string       `xml:"time,attr"`
+	Suites  []junitSuite `xml:"testsuite"`
+	Cases   []junitCase  `xml:"testcase"`
+}
+
+type junitSuite struct {
+	Time  string      `xml:"time,attr"`
+	Cases []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string       `xml:"classname,attr"`
+	Name      string       `xml:"name,attr"`
+	File      string       `xml:"file,attr"`
+	Line      string       `xml:"line,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *junitResult `xml:"skipped"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// parseJUnitXML parses a pytest JUnit XML report. exists reports whether a
+// slash-separated path relative to the project is a file; it locates the
+// module of tests whose report does not record their file.
+func parseJUnitXML(data []byte, exists func(path string) bool) (*types.TestReport, error) {
+	var raw junitReport
+	if err := xml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit XML report: %w", err)
+	}
+
+	report := &types.TestReport{Cases: []types.TestCase{}}
+	switch raw.XMLName.Local {
+	case "testsuites":
+		for _, suite := range raw.Suites {
+			report.Duration += junitDuration(suite.Time)
+			for _, c := range suite.Cases {
+				report.Cases = append(report.Cases, junitTestCase(c, exists))
+			}
+		}
+	case "testsuite":
+		report.Duration = junitDuration(raw.Time)
+		for _, c := range raw.Cases {
+			report.Cases = append(report.Cases, junitTestCase(c, exists))
+		}
+	default:
+		return nil, fmt.Errorf("not a JUnit XML report: unexpected <%s> element", raw.XMLName.Local)
+	}
+	return report, nil
+}
+
+// junitTestCase converts a <testcase>. A failure takes precedence over an
+// error reported for the same test, e.g. in a teardown fixture.
+func junitTestCase(c junitCase, exists func(string) bool) types.TestCase {
+	tc := types.TestCase{
+		ClassName: c.ClassName,
+		Name:      c.Name,
+		Duration:  junitDuration(c.Time),
+		Outcome:   types.TestPassed,
+	}
+	tc.NodeID, tc.File = testNodeID(c, exists)
+	// pytest records the 0-based line of the test function.
+	if line, err := strconv.Atoi(c.Line); err == nil {
+		tc.Line = line + 1
+	}
+
+	var result *junitResult
+	switch {
+	case c.Failure != nil:
+		tc.Outcome, result = types.TestFailed, c.Failure
+	case c.Error != nil:
+		tc.Outcome, result = types.TestError, c.Error
+	case c.Skipped != nil:
+		tc.Outcome, result = types.TestSkipped, c.Skipped
+	}
+	if result != nil {
+		tc.Message = result.Message
+		tc.Details = strings.TrimRight(result.Text, "\n")
+	}
+	return tc
+}
+
+// testNodeID rebuilds the pytest node ID of a test case, such as
+// "tests/test_app.py::TestApp::test_run", and returns it with the test file.
+// The classname is the dotted module path followed by any classes; without a
+// file attribute the longest module path that exists is taken as the file.
+func testNodeID(c junitCase, exists func(string) bool) (string, string) {
+	if c.ClassName == "" {
+		// Collection errors are reported against the module itself.
+		return c.Name, ""
+	}
+	parts := strings.Split(c.ClassName, ".")
+
+	file := strings.ReplaceAll(c.File, "\\", "/")
+	var classes []string
+	if file != "" {
+		module := strings.Split(strings.TrimSuffix(file, ".py"), "/")
+		if len(module) <= len(parts) {
+			classes = parts[len(module):]
+		}
+	} else {
+		file = strings.Join(parts, "/") + ".py"
+		for i := len(parts); i > 0; i-- {
+			candidate := strings.Join(parts[:i], "/") + ".py"
+			if exists != nil && exists(candidate) {
+				file, classes = candidate, parts[i:]
+				break
+			}
+		}
+	}
+	return strings.Join(append(append([]string{file}, classes...), c.Name), "::"), file
+}
+
+// junitDuration converts a time attribute in seconds.
+func junitDuration(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+const sampleJUnit = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites>
+  <testsuite name="pytest" errors="1" failures="1" skipped="1" tests="4" time="1.250">
+    <testcase classname="tests.test_app.TestApp" name="test_ok[fast]" file="tests/test_app.py" line="11" time="0.010"/>
+    <testcase classname="tests.test_app" name="test_fail" file="tests/test_app.py" line="20" time="0.020">
+      <failure message="assert 1 == 2">def test_fail():
+&gt;       assert 1 == 2
+E       assert 1 == 2
+
+tests/test_app.py:22: AssertionError
+</failure>
+    </testcase>
+    <testcase classname="tests.test_app" name="test_skip" file="tests/test_app.py" line="24" time="0.000">
+      <skipped type="pytest.skip" message="not on CI">tests/test_app.py:25: not on CI</skipped>
+    </testcase>
+    <testcase classname="tests.test_db" name="test_query" time="0.001">
+      <error message="failed on setup with &quot;RuntimeError: no db&quot;">RuntimeError: no db</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+func TestParseJUnitXML(t *testing.T) {
+	report, err := parseJUnitXML([]byte(sampleJUnit), func(path string) bool {
+		return path == "tests/test_db.py"
+	})
+	if err != nil {
+		t.Fatalf("parseJUnitXML() error = %v", err)
+	}
+	if report.Duration != 1250*time.Millisecond {
+		t.Errorf("parseJUnitXML() duration = %v, want 1.25s", report.Duration)
+	}
+	if len(report.Cases) != 4 {
+		t.Fatalf("parseJUnitXML() = %d cases, want 4", len(report.Cases))
+	}
+
+	expected := []struct {
+		nodeID, file, outcome, message string
+		line                           int
+	}{
+		{"tests/test_app.py::TestApp::test_ok[fast]", "tests/test_app.py", types.TestPassed, "", 12},
+		{"tests/test_app.py::test_fail", "tests/test_app.py", types.TestFailed, "assert 1 == 2", 21},
+		{"tests/test_app.py::test_skip", "tests/test_app.py", types.TestSkipped, "not on CI", 25},
+		{"tests/test_db.py::test_query", "tests/test_db.py", types.TestError, `failed on setup with "RuntimeError: no db"`, 0},
+	}
+	for i, want := range expected {
+		c := report.Cases[i]
+		if c.NodeID != want.nodeID || c.File != want.file || c.Outcome != want.outcome || c.Message != want.message || c.Line != want.line {
+			t.Errorf("case %d = %+v, want %+v", i, c, want)
+		}
+	}
+	if details := report.Cases[1].Details; !strings.HasPrefix(details, "def test_fail():\n>       assert 1 == 2") || !strings.HasSuffix(details, "AssertionError") {
+		t.Errorf("parseJUnitXML() traceback = %q", details)
+	}
+}
+
+func TestParseJUnitXML_SingleSuite(t *testing.T) {
+	data := `<testsuite time="0.5"><testcase classname="tests.unit.test_x.TestX" name="test_y" time="0.5"/><testcase classname="" name="tests.test_broken"><error message="collection failure"/></testcase></testsuite>`
+	report, err := parseJUnitXML([]byte(data), func(path string) bool {
+		return path == "tests/unit/test_x.py"
+	})
+	if err != nil {
+		t.Fatalf("parseJUnitXML() error = %v", err)
+	}
+	if report.Duration != 500*time.Millisecond || len(report.Cases) != 2 {
+		t.Fatalf("parseJUnitXML() = %+v", report)
+	}
+	if id := report.Cases[0].NodeID; id != "tests/unit/test_x.py::TestX::test_y" {
+		t.Errorf("parseJUnitXML() node ID = %q", id)
+	}
+	if c := report.Cases[1]; c.NodeID != "tests.test_broken" || c.Outcome != types.TestError {
+		t.Errorf("parseJUnitXML() collection error = %+v", c)
+	}
+}
+
+func TestParseJUnitXML_Errors(t *testing.T) {
+	for name, data := range map[string]string{
+		"invalid":   "<testsuites>",
+		"not junit": "<html></html>",
+	} {
+		if _, err := parseJUnitXML([]byte(data), nil); err == nil {
+			t.Errorf("%s: parseJUnitXML() should fail", name)
+		}
+	}
+}
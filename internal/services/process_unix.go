@@ -0,0 +1,21 @@
+//go:build !windows
+
+// Package services provides services for the application.
+package services
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureProcessGroup starts cmd in a new process group and makes
+// cancellation kill the whole group rather than only the direct child.
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+// Package services provides services for the application.
+package services
+
+import "os/exec"
+
+// configureProcessGroup keeps the default cancellation behaviour on Windows,
+// where killing the process also terminates uv's worker processes.
+func configureProcessGroup(_ *exec.Cmd) {}
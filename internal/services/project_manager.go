@@ -2,6 +2,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 
 // ProjectManager implements project management functionality.
 type ProjectManager struct {
+	projectRoot
 	executor CommandExecutorInterface
 }
 
@@ -20,47 +22,48 @@ func NewProjectManager(executor CommandExecutorInterface) *ProjectManager {
 	return &ProjectManager{executor: executor}
 }
 
-// GetProjectStatus returns the current project status.
-func (p *ProjectManager) GetProjectStatus() (*types.ProjectStatus, error) {
+// GetProjectStatus returns the status of the project in the project root.
+func (p *ProjectManager) GetProjectStatus(_ context.Context) (*types.ProjectStatus, error) {
 	status := &types.ProjectStatus{
 		IsProject: false,
 	}
 
-	// Check for pyproject.toml file
-	currentDir, err := os.Getwd()
+	root, err := p.absProjectRoot()
 	if err != nil {
 		return status, err
 	}
+	status.Path = root
 
-	pyprojectPath := "pyproject.toml"
+	// Check for pyproject.toml file
+	pyprojectPath := filepath.Join(root, "pyproject.toml")
 	if _, err := os.Stat(pyprojectPath); os.IsNotExist(err) {
-		status.Path = currentDir
 		return status, nil
+	} else if err != nil {
+		return status, err
 	}
 
 	// Found pyproject.toml, this is a project
 	status.IsProject = true
-	status.Path = currentDir
-	status.Name = filepath.Base(currentDir)
+	status.Name = filepath.Base(root)
 	status.ConfigFile = pyprojectPath
 
 	// Try to get more project info
 	if p.executor.IsUVAvailable() {
 		// Check if project is synced
-		lockFilePath := "uv.lock"
+		lockFilePath := filepath.Join(root, "uv.lock")
 		if _, err := os.Stat(lockFilePath); err == nil {
 			status.HasLockFile = true
 			status.LockFile = lockFilePath
 		}
 
 		// Get Python version if pinned
-		pythonVersionPath := ".python-version"
+		pythonVersionPath := filepath.Join(root, ".python-version")
 		if content, err := os.ReadFile(pythonVersionPath); err == nil {
 			status.PythonVersion = strings.TrimSpace(string(content))
 		}
 
 		// Check virtual environment
-		venvPath := ".venv"
+		venvPath := filepath.Join(root, ".venv")
 		if _, err := os.Stat(venvPath); err == nil {
 			status.HasVirtualEnv = true
 			status.VenvPath = venvPath
@@ -70,8 +73,9 @@ func (p *ProjectManager) GetProjectStatus() (*types.ProjectStatus, error) {
 	return status, nil
 }
 
-// InitProject creates a new UV project.
-func (p *ProjectManager) InitProject(name string, options types.InitOptions) (string, error) {
+// InitProject creates a new UV project inside the project root, streaming uv's
+// output to output. It returns the absolute directory of the new project.
+func (p *ProjectManager) InitProject(ctx context.Context, name string, options types.InitOptions, output OutputHandler) (string, error) {
 	if !p.executor.IsUVAvailable() {
 		return "", fmt.Errorf("UV is not available")
 	}
@@ -94,134 +98,160 @@ func (p *ProjectManager) InitProject(name string, options types.InitOptions) (st
 		args = append(args, "--python", options.PythonVersion)
 	}
 
-	_, err := p.executor.Execute("uv", args...)
+	root, err := p.absProjectRoot()
 	if err != nil {
 		return "", err
 	}
 
-	if name != "" {
-		return name, nil
+	_, err = p.executor.InDir(root).ExecuteStream(ctx, output, "uv", args...)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(root, name), nil
+}
+
+// SyncProject syncs project dependencies, streaming uv's output to output.
+func (p *ProjectManager) SyncProject(ctx context.Context, output OutputHandler) error {
+	if !p.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+
+	_, err := p.exec(p.executor).ExecuteStream(ctx, output, "uv", "sync")
+	return err
+}
+
+// LockProject locks project dependencies, streaming uv's output to output.
+func (p *ProjectManager) LockProject(ctx context.Context, output OutputHandler) error {
+	if !p.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
 	}
 
-	return ".", nil
+	_, err := p.exec(p.executor).ExecuteStream(ctx, output, "uv", "lock")
+	return err
 }
 
-// SyncProject syncs project dependencies.
-func (p *ProjectManager) SyncProject() error {
+// AddDependency adds a requirement (a PEP 508 requirement, local path or VCS
+// URL) to the project with uv add, streaming uv's output to output.
+func (p *ProjectManager) AddDependency(ctx context.Context, requirement string, options types.DependencyOptions, output OutputHandler) error {
 	if !p.executor.IsUVAvailable() {
 		return fmt.Errorf("UV is not available")
 	}
 
-	_, err := p.executor.Execute("uv", "sync")
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" {
+		return fmt.Errorf("no dependency given")
+	}
+	args, err := dependencyArgs("add", requirement, options)
+	if err != nil {
+		return err
+	}
+	if options.Editable {
+		args = append(args, "--editable")
+	}
+
+	_, err = p.exec(p.executor).ExecuteStream(ctx, output, "uv", args...)
 	return err
 }
 
-// LockProject locks project dependencies.
-func (p *ProjectManager) LockProject() error {
+// RemoveDependency removes a dependency from the project with uv remove,
+// streaming uv's output to output.
+func (p *ProjectManager) RemoveDependency(ctx context.Context, name string, options types.DependencyOptions, output OutputHandler) error {
 	if !p.executor.IsUVAvailable() {
 		return fmt.Errorf("UV is not available")
 	}
 
-	_, err := p.executor.Execute("uv", "lock")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("no dependency given")
+	}
+	args, err := dependencyArgs("remove", name, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.exec(p.executor).ExecuteStream(ctx, output, "uv", args...)
 	return err
 }
 
+// dependencyArgs returns the uv add or uv remove arguments shared by both
+// commands.
+func dependencyArgs(command, dependency string, options types.DependencyOptions) ([]string, error) {
+	args := []string{command, dependency}
+
+	switch options.Target {
+	case "", DependencyMain:
+	case DependencyDev:
+		args = append(args, "--dev")
+	case DependencyOptional:
+		if strings.TrimSpace(options.Group) == "" {
+			return nil, fmt.Errorf("no extra name given")
+		}
+		args = append(args, "--optional", strings.TrimSpace(options.Group))
+	case DependencyGroup:
+		if strings.TrimSpace(options.Group) == "" {
+			return nil, fmt.Errorf("no group name given")
+		}
+		args = append(args, "--group", strings.TrimSpace(options.Group))
+	default:
+		return nil, fmt.Errorf("unknown dependency target %q", options.Target)
+	}
+
+	if options.Frozen {
+		args = append(args, "--frozen")
+	} else if options.NoSync {
+		args = append(args, "--no-sync")
+	}
+	return args, nil
+}
+
 // GetDependencyTree returns the project dependency tree.
-func (p *ProjectManager) GetDependencyTree() (*types.DependencyTree, error) {
+func (p *ProjectManager) GetDependencyTree(ctx context.Context) (*types.DependencyTree, error) {
 	if !p.executor.IsUVAvailable() {
 		return nil, fmt.Errorf("UV is not available")
 	}
 
-	output, err := p.executor.Execute("uv", "tree")
+	output, err := p.exec(p.executor).Execute(ctx, "uv", "tree")
 	if err != nil {
 		return nil, err
 	}
 
-	return p.parseDependencyTree(string(output)), nil
+	return parseDependencyTree(string(output)), nil
 }
 
 // GetProjectDependencies returns project dependencies.
-func (p *ProjectManager) GetProjectDependencies() ([]types.ProjectDependency, error) {
+func (p *ProjectManager) GetProjectDependencies(_ context.Context) ([]types.ProjectDependency, error) {
 	if !p.executor.IsUVAvailable() {
 		return nil, fmt.Errorf("UV is not available")
 	}
 
-	// Try to read from pyproject.toml
-	pyprojectPath := "pyproject.toml"
-	if _, err := os.Stat(pyprojectPath); os.IsNotExist(err) {
+	root, err := p.absProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if os.IsNotExist(err) {
 		return []types.ProjectDependency{}, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	// For now, return mock dependencies
-	// In a real implementation, you'd parse the pyproject.toml file
-	return p.getMockDependencies(), nil
+	return parsePyprojectDependencies(data)
 }
 
-// parseDependencyTree parses the output of uv tree.
-func (p *ProjectManager) parseDependencyTree(output string) *types.DependencyTree {
-	tree := &types.DependencyTree{
-		Dependencies: []types.TreeNode{},
+// GetLockFile reads the project's uv.lock.
+func (p *ProjectManager) GetLockFile(_ context.Context) (*types.LockFile, error) {
+	root, err := p.absProjectRoot()
+	if err != nil {
+		return nil, err
 	}
 
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		// Parse tree structure
-		level := 0
-		trimmed := line
-		for strings.HasPrefix(trimmed, "  ") || strings.HasPrefix(trimmed, "├─") || strings.HasPrefix(trimmed, "└─") {
-			level++
-			if strings.HasPrefix(trimmed, "├─") || strings.HasPrefix(trimmed, "└─") {
-				trimmed = trimmed[2:]
-			} else {
-				trimmed = trimmed[2:]
-			}
-		}
-
-		// Extract package name and version
-		parts := strings.Split(trimmed, "==")
-		if len(parts) > 0 {
-			node := types.TreeNode{
-				Name:  parts[0],
-				Level: level,
-			}
-			if len(parts) > 1 {
-				node.Version = parts[1]
-			}
-			tree.Dependencies = append(tree.Dependencies, node)
-		}
+	data, err := os.ReadFile(filepath.Join(root, "uv.lock"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no uv.lock found in %s", root)
+	} else if err != nil {
+		return nil, err
 	}
 
-	return tree
-}
-
-// getMockDependencyTree returns mock dependency tree for demo.
-// func (p *ProjectManager) getMockDependencyTree() *types.DependencyTree {
-// 	return &types.DependencyTree{
-// 		Dependencies: []types.TreeNode{
-// 			{Name: "requests", Version: "2.31.0", Level: 0},
-// 			{Name: "urllib3", Version: "2.0.4", Level: 1},
-// 			{Name: "certifi", Version: "2023.7.22", Level: 1},
-// 			{Name: "charset-normalizer", Version: "3.2.0", Level: 1},
-// 			{Name: "idna", Version: "3.4", Level: 1},
-// 			{Name: "fastapi", Version: "0.104.1", Level: 0},
-// 			{Name: "starlette", Version: "0.27.0", Level: 1},
-// 			{Name: "pydantic", Version: "2.4.2", Level: 1},
-// 			{Name: "typing-extensions", Version: "4.8.0", Level: 2},
-// 		},
-// 	}
-// }
-
-// getMockDependencies returns mock dependencies for demo.
-func (p *ProjectManager) getMockDependencies() []types.ProjectDependency {
-	return []types.ProjectDependency{
-		{Name: "requests", Version: "^2.31.0", Type: "main"},
-		{Name: "fastapi", Version: "^0.104.1", Type: "main"},
-		{Name: "pytest", Version: "^7.4.0", Type: "dev"},
-		{Name: "black", Version: "^23.9.0", Type: "dev"},
-		{Name: "mypy", Version: "^1.5.0", Type: "dev"},
-	}
+	return parseLockFile(data)
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,25 +20,14 @@ func TestNewProjectManager(t *testing.T) {
 }
 
 func TestGetProjectStatus_NoProject(t *testing.T) {
+	t.Parallel()
 	executor := &mockCommandExecutor{}
 	pm := NewProjectManager(executor)
 
-	// Create a temporary directory for the test
-	tmpDir, err := os.MkdirTemp("", "uvui-test-")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.RemoveAll(tmpDir) }()
-
-	// Change the current working directory to the temporary directory
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.Chdir(oldWd) }()
-	_ = os.Chdir(tmpDir)
+	tmpDir := t.TempDir()
+	pm.SetProjectRoot(tmpDir)
 
-	status, err := pm.GetProjectStatus()
+	status, err := pm.GetProjectStatus(context.Background())
 	if err != nil {
 		t.Errorf("GetProjectStatus() error = %v, wantErr %v", err, false)
 	}
@@ -48,30 +38,19 @@ func TestGetProjectStatus_NoProject(t *testing.T) {
 }
 
 func TestGetProjectStatus_Project(t *testing.T) {
+	t.Parallel()
 	executor := &mockCommandExecutor{}
 	pm := NewProjectManager(executor)
 
-	// Create a temporary directory and a pyproject.toml file
-	tmpDir, err := os.MkdirTemp("", "uvui-test-")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.RemoveAll(tmpDir) }()
+	tmpDir := t.TempDir()
+	pm.SetProjectRoot(tmpDir)
 
 	pyprojectPath := filepath.Join(tmpDir, "pyproject.toml")
 	if _, err := os.Create(pyprojectPath); err != nil {
 		t.Fatal(err)
 	}
 
-	// Change the current working directory to the temporary directory
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.Chdir(oldWd) }()
-	_ = os.Chdir(tmpDir)
-
-	status, err := pm.GetProjectStatus()
+	status, err := pm.GetProjectStatus(context.Background())
 	if err != nil {
 		t.Errorf("GetProjectStatus() error = %v, wantErr %v", err, false)
 	}
@@ -92,13 +71,21 @@ func TestInitProject(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	projectName, err := pm.InitProject("test-project", types.InitOptions{})
+	root := t.TempDir()
+	pm.SetProjectRoot(root)
+
+	projectDir, err := pm.InitProject(context.Background(), "test-project", types.InitOptions{}, nil)
 	if err != nil {
 		t.Errorf("InitProject() error = %v, wantErr %v", err, false)
 	}
 
-	if projectName != "test-project" {
-		t.Errorf("InitProject() projectName = %q, want %q", projectName, "test-project")
+	want := filepath.Join(root, "test-project")
+	if projectDir != want {
+		t.Errorf("InitProject() projectDir = %q, want %q", projectDir, want)
+	}
+
+	if executor.Dir != root {
+		t.Errorf("InitProject() ran in %q, want %q", executor.Dir, root)
 	}
 }
 
@@ -113,7 +100,7 @@ func TestSyncProject(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	err := pm.SyncProject()
+	err := pm.SyncProject(context.Background(), nil)
 	if err != nil {
 		t.Errorf("SyncProject() error = %v, wantErr %v", err, false)
 	}
@@ -130,12 +117,98 @@ func TestLockProject(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	err := pm.LockProject()
+	err := pm.LockProject(context.Background(), nil)
 	if err != nil {
 		t.Errorf("LockProject() error = %v, wantErr %v", err, false)
 	}
 }
 
+func TestAddDependency(t *testing.T) {
+	tests := []struct {
+		name        string
+		requirement string
+		options     types.DependencyOptions
+		expected    []string
+	}{
+		{"main", "requests>=2.31", types.DependencyOptions{}, []string{"add", "requests>=2.31"}},
+		{"dev", "pytest", types.DependencyOptions{Target: DependencyDev}, []string{"add", "pytest", "--dev"}},
+		{"optional", "httpx", types.DependencyOptions{Target: DependencyOptional, Group: "net"}, []string{"add", "httpx", "--optional", "net"}},
+		{"group", "ruff", types.DependencyOptions{Target: DependencyGroup, Group: "lint", NoSync: true}, []string{"add", "ruff", "--group", "lint", "--no-sync"}},
+		{"editable path", "./libs/core", types.DependencyOptions{Editable: true, Frozen: true}, []string{"add", "./libs/core", "--frozen", "--editable"}},
+		{"git", "git+https://github.com/encode/httpx@0.27.0", types.DependencyOptions{}, []string{"add", "git+https://github.com/encode/httpx@0.27.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			executor := &mockCommandExecutor{
+				ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+					got = args
+					return nil, nil
+				},
+			}
+			pm := NewProjectManager(executor)
+
+			if err := pm.AddDependency(context.Background(), tt.requirement, tt.options, nil); err != nil {
+				t.Fatalf("AddDependency() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("AddDependency() ran uv %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveDependency(t *testing.T) {
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = args
+			return nil, nil
+		},
+	}
+	pm := NewProjectManager(executor)
+
+	options := types.DependencyOptions{Target: DependencyGroup, Group: "lint", Editable: true, Frozen: true}
+	if err := pm.RemoveDependency(context.Background(), "ruff", options, nil); err != nil {
+		t.Fatalf("RemoveDependency() error = %v", err)
+	}
+	expected := []string{"remove", "ruff", "--group", "lint", "--frozen"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("RemoveDependency() ran uv %v, want %v", got, expected)
+	}
+}
+
+func TestAddDependency_Errors(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			t.Errorf("unexpected command: %s %v", command, args)
+			return nil, nil
+		},
+	}
+	pm := NewProjectManager(executor)
+
+	invalid := []struct {
+		requirement string
+		options     types.DependencyOptions
+	}{
+		{"  ", types.DependencyOptions{}},
+		{"httpx", types.DependencyOptions{Target: DependencyOptional}},
+		{"ruff", types.DependencyOptions{Target: DependencyGroup, Group: " "}},
+		{"ruff", types.DependencyOptions{Target: "test"}},
+	}
+	for _, tt := range invalid {
+		if err := pm.AddDependency(context.Background(), tt.requirement, tt.options, nil); err == nil {
+			t.Errorf("AddDependency(%q, %+v) error = nil, want an error", tt.requirement, tt.options)
+		}
+	}
+
+	executor.IsUVAvailableFunc = func() bool { return false }
+	if err := pm.RemoveDependency(context.Background(), "ruff", types.DependencyOptions{}, nil); err == nil {
+		t.Error("RemoveDependency() error = nil, want an error without uv")
+	}
+}
+
 func TestGetDependencyTree(t *testing.T) {
 	executor := &mockCommandExecutor{
 		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
@@ -147,7 +220,7 @@ func TestGetDependencyTree(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	tree, err := pm.GetDependencyTree()
+	tree, err := pm.GetDependencyTree(context.Background())
 	if err != nil {
 		t.Errorf("GetDependencyTree() error = %v, wantErr %v", err, false)
 	}
@@ -164,36 +237,53 @@ func TestGetDependencyTree(t *testing.T) {
 }
 
 func TestGetProjectDependencies(t *testing.T) {
+	t.Parallel()
 	executor := &mockCommandExecutor{}
 	pm := NewProjectManager(executor)
 
-	// Create a temporary directory and a pyproject.toml file
-	tmpDir, err := os.MkdirTemp("", "uvui-test-")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.RemoveAll(tmpDir) }()
+	tmpDir := t.TempDir()
+	pm.SetProjectRoot(tmpDir)
 
 	pyprojectPath := filepath.Join(tmpDir, "pyproject.toml")
-	if _, err := os.Create(pyprojectPath); err != nil {
+	pyproject := "[project]\nname = \"demo\"\ndependencies = [\"requests>=2.31\"]\n"
+	if err := os.WriteFile(pyprojectPath, []byte(pyproject), 0o644); err != nil {
 		t.Fatal(err)
 	}
 
-	// Change the current working directory to the temporary directory
-	oldWd, err := os.Getwd()
+	deps, err := pm.GetProjectDependencies(context.Background())
 	if err != nil {
-		t.Fatal(err)
+		t.Errorf("GetProjectDependencies() error = %v, wantErr %v", err, false)
+	}
+
+	if len(deps) != 1 || deps[0].Name != "requests" || deps[0].Version != ">=2.31" {
+		t.Errorf("GetProjectDependencies() deps = %+v, want requests>=2.31", deps)
 	}
-	defer func() { _ = os.Chdir(oldWd) }()
-	_ = os.Chdir(tmpDir)
+}
 
-	deps, err := pm.GetProjectDependencies()
+func TestGetProjectDependencies_NoPyproject(t *testing.T) {
+	pm := NewProjectManager(&mockCommandExecutor{})
+	pm.SetProjectRoot(t.TempDir())
+
+	deps, err := pm.GetProjectDependencies(context.Background())
 	if err != nil {
-		t.Errorf("GetProjectDependencies() error = %v, wantErr %v", err, false)
+		t.Errorf("GetProjectDependencies() error = %v, want nil", err)
+	}
+	if len(deps) != 0 {
+		t.Errorf("GetProjectDependencies() deps = %+v, want none", deps)
+	}
+}
+
+func TestGetProjectDependencies_InvalidTOML(t *testing.T) {
+	pm := NewProjectManager(&mockCommandExecutor{})
+	tmpDir := t.TempDir()
+	pm.SetProjectRoot(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "pyproject.toml"), []byte("[project\n"), 0o644); err != nil {
+		t.Fatal(err)
 	}
 
-	if len(deps) == 0 {
-		t.Error("GetProjectDependencies() deps is empty, want not empty")
+	if _, err := pm.GetProjectDependencies(context.Background()); err == nil {
+		t.Error("GetProjectDependencies() error = nil, want a parse error")
 	}
 }
 
@@ -205,7 +295,7 @@ func TestInitProject_Error(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	_, err := pm.InitProject("test-project", types.InitOptions{})
+	_, err := pm.InitProject(context.Background(), "test-project", types.InitOptions{}, nil)
 	if err == nil {
 		t.Error("InitProject() error = nil, wantErr true")
 	}
@@ -219,7 +309,7 @@ func TestSyncProject_Error(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	err := pm.SyncProject()
+	err := pm.SyncProject(context.Background(), nil)
 	if err == nil {
 		t.Error("SyncProject() error = nil, wantErr true")
 	}
@@ -233,7 +323,7 @@ func TestLockProject_Error(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	err := pm.LockProject()
+	err := pm.LockProject(context.Background(), nil)
 	if err == nil {
 		t.Error("LockProject() error = nil, wantErr true")
 	}
@@ -247,7 +337,7 @@ func TestGetDependencyTree_Error(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	_, err := pm.GetDependencyTree()
+	_, err := pm.GetDependencyTree(context.Background())
 	if err == nil {
 		t.Error("GetDependencyTree() error = nil, wantErr true")
 	}
@@ -261,76 +351,45 @@ func TestGetProjectDependencies_Error(t *testing.T) {
 	}
 	pm := NewProjectManager(executor)
 
-	_, err := pm.GetProjectDependencies()
+	_, err := pm.GetProjectDependencies(context.Background())
 	if err == nil {
 		t.Error("GetProjectDependencies() error = nil, wantErr true")
 	}
 }
 
 func TestGetProjectStatus_Error(t *testing.T) {
+	t.Parallel()
 	executor := &mockCommandExecutor{}
 	pm := NewProjectManager(executor)
 
-	// Create a temporary directory for the test
-	tmpDir, err := os.MkdirTemp("", "uvui-test-")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.RemoveAll(tmpDir) }()
-
-	// Change the current working directory to the temporary directory
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.Chdir(oldWd) }()
-	_ = os.Chdir(tmpDir)
-
-	// Create a pyproject.toml file
-	pyprojectPath := filepath.Join(tmpDir, "pyproject.toml")
-	if _, err := os.Create(pyprojectPath); err != nil {
+	// A project root that is a regular file cannot contain pyproject.toml,
+	// and the resulting error must not be mistaken for "no project".
+	rootFile := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(rootFile, nil, 0600); err != nil {
 		t.Fatal(err)
 	}
+	pm.SetProjectRoot(rootFile)
 
-	// Make the directory unreadable
-	if err := os.Chmod(tmpDir, 0000); err != nil {
-		t.Fatal(err)
-	}
-
-	_, err = pm.GetProjectStatus()
+	_, err := pm.GetProjectStatus(context.Background())
 	if err == nil {
 		t.Error("GetProjectStatus() error = nil, wantErr true")
 	}
-
-	// Restore permissions so the directory can be cleaned up
-	_ = os.Chmod(tmpDir, 0755)
 }
 
 func TestGetProjectStatus_Project_NoLockOrVersion(t *testing.T) {
+	t.Parallel()
 	executor := &mockCommandExecutor{}
 	pm := NewProjectManager(executor)
 
-	// Create a temporary directory and a pyproject.toml file
-	tmpDir, err := os.MkdirTemp("", "uvui-test-")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.RemoveAll(tmpDir) }()
+	tmpDir := t.TempDir()
+	pm.SetProjectRoot(tmpDir)
 
 	pyprojectPath := filepath.Join(tmpDir, "pyproject.toml")
 	if _, err := os.Create(pyprojectPath); err != nil {
 		t.Fatal(err)
 	}
 
-	// Change the current working directory to the temporary directory
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = os.Chdir(oldWd) }()
-	_ = os.Chdir(tmpDir)
-
-	status, err := pm.GetProjectStatus()
+	status, err := pm.GetProjectStatus(context.Background())
 	if err != nil {
 		t.Errorf("GetProjectStatus() error = %v, wantErr %v", err, false)
 	}
@@ -347,3 +406,80 @@ func TestGetProjectStatus_Project_NoLockOrVersion(t *testing.T) {
 		t.Errorf("GetProjectStatus() PythonVersion = %q, want %q", status.PythonVersion, "")
 	}
 }
+
+func TestGetProjectStatus_ProjectFiles(t *testing.T) {
+	t.Parallel()
+	executor := &mockCommandExecutor{}
+	pm := NewProjectManager(executor)
+
+	root := t.TempDir()
+	pm.SetProjectRoot(root)
+	for name, content := range map[string]string{
+		"pyproject.toml":  "",
+		"uv.lock":         "",
+		".python-version": "3.12\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(root, ".venv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := pm.GetProjectStatus(context.Background())
+	if err != nil {
+		t.Fatalf("GetProjectStatus() error = %v, wantErr %v", err, false)
+	}
+
+	want := &types.ProjectStatus{
+		IsProject:     true,
+		Name:          filepath.Base(root),
+		Path:          root,
+		ConfigFile:    filepath.Join(root, "pyproject.toml"),
+		HasLockFile:   true,
+		LockFile:      filepath.Join(root, "uv.lock"),
+		PythonVersion: "3.12",
+		HasVirtualEnv: true,
+		VenvPath:      filepath.Join(root, ".venv"),
+	}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("GetProjectStatus() = %+v, want %+v", status, want)
+	}
+}
+
+func TestSyncProject_RunsInProjectRoot(t *testing.T) {
+	t.Parallel()
+	executor := &mockCommandExecutor{}
+	pm := NewProjectManager(executor)
+	pm.SetProjectRoot("/path/to/project")
+
+	if err := pm.SyncProject(context.Background(), nil); err != nil {
+		t.Fatalf("SyncProject() error = %v, wantErr %v", err, false)
+	}
+
+	if executor.Dir != "/path/to/project" {
+		t.Errorf("SyncProject() ran in %q, want %q", executor.Dir, "/path/to/project")
+	}
+}
+
+func TestGetLockFile(t *testing.T) {
+	pm := NewProjectManager(&mockCommandExecutor{})
+	tmpDir := t.TempDir()
+	pm.SetProjectRoot(tmpDir)
+
+	if _, err := pm.GetLockFile(context.Background()); err == nil {
+		t.Error("GetLockFile() error = nil, want an error without uv.lock")
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "uv.lock"), []byte(sampleLock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := pm.GetLockFile(context.Background())
+	if err != nil {
+		t.Fatalf("GetLockFile() error = %v", err)
+	}
+	if len(lock.Packages) != 5 {
+		t.Errorf("GetLockFile() returned %d packages, want 5", len(lock.Packages))
+	}
+}
@@ -0,0 +1,46 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// projectRoot holds the directory project-scoped commands run in and project
+// files are read from. It is safe for concurrent use and is embedded by the
+// services that operate on a project.
+type projectRoot struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// SetProjectRoot sets the project directory. An empty dir means the process
+// working directory.
+func (r *projectRoot) SetProjectRoot(dir string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dir = dir
+}
+
+// ProjectRoot returns the project directory as configured.
+func (r *projectRoot) ProjectRoot() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.dir
+}
+
+// absProjectRoot returns the project directory as an absolute path.
+func (r *projectRoot) absProjectRoot() (string, error) {
+	dir := r.ProjectRoot()
+	if dir == "" {
+		return os.Getwd()
+	}
+	return filepath.Abs(dir)
+}
+
+// exec returns executor running commands in the project directory, so that uv
+// finds the project's pyproject.toml, lock file and .python-version.
+func (r *projectRoot) exec(executor CommandExecutorInterface) CommandExecutorInterface {
+	return executor.InDir(r.ProjectRoot())
+}
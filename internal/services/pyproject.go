@@ -0,0 +1,323 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"uvui/internal/types"
+	"uvui/pkg/version"
+)
+
+// Dependency types reported for the sections of pyproject.toml.
+const (
+	DependencyMain     = "main"     // [project.dependencies]
+	DependencyOptional = "optional" // [project.optional-dependencies], Group is the extra
+	DependencyGroup    = "group"    // [dependency-groups], Group is the group name
+	DependencyDev      = "dev"      // the "dev" group and [tool.uv.dev-dependencies]
+)
+
+// pyprojectFile is the subset of pyproject.toml read by uvui.
+type pyprojectFile struct {
+	Project struct {
+		Name                 string              `toml:"name"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		Scripts              map[string]string   `toml:"scripts"`
+		GUIScripts           map[string]string   `toml:"gui-scripts"`
+	} `toml:"project"`
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+	Tool             struct {
+		UV struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+		UVUI struct {
+			Tasks  map[string]interface{} `toml:"tasks"`
+			Matrix map[string]interface{} `toml:"matrix"`
+		} `toml:"uvui"`
+	} `toml:"tool"`
+}
+
+// parsePyprojectDependencies returns the dependencies declared in a
+// pyproject.toml: project dependencies, then extras and dependency groups in
+// order of their extra or group name, then legacy uv dev-dependencies.
+// Requirements keep their file order within each of these.
+func parsePyprojectDependencies(data []byte) ([]types.ProjectDependency, error) {
+	var file pyprojectFile
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	dependencies := []types.ProjectDependency{}
+	for _, raw := range file.Project.Dependencies {
+		dependencies = append(dependencies, newProjectDependency(raw, DependencyMain, ""))
+	}
+
+	for _, extra := range slices.Sorted(maps.Keys(file.Project.OptionalDependencies)) {
+		for _, raw := range file.Project.OptionalDependencies[extra] {
+			dependencies = append(dependencies, newProjectDependency(raw, DependencyOptional, extra))
+		}
+	}
+
+	for _, group := range slices.Sorted(maps.Keys(file.DependencyGroups)) {
+		requirements, err := expandDependencyGroup(file.DependencyGroups, group, nil)
+		if err != nil {
+			return nil, err
+		}
+		kind := DependencyGroup
+		if version.NormalizeName(group) == "dev" {
+			kind = DependencyDev
+		}
+		for _, raw := range requirements {
+			dependencies = append(dependencies, newProjectDependency(raw, kind, group))
+		}
+	}
+
+	for _, raw := range file.Tool.UV.DevDependencies {
+		dependencies = append(dependencies, newProjectDependency(raw, DependencyDev, ""))
+	}
+
+	return dependencies, nil
+}
+
+// parsePyprojectScripts returns the [project.scripts] and then the
+// [project.gui-scripts] entry points of a pyproject.toml, each sorted by name.
+func parsePyprojectScripts(data []byte) ([]types.RunTarget, error) {
+	var file pyprojectFile
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	var targets []types.RunTarget
+	for _, name := range slices.Sorted(maps.Keys(file.Project.Scripts)) {
+		targets = append(targets, types.RunTarget{Name: name, Kind: types.RunTargetScript, Spec: file.Project.Scripts[name]})
+	}
+	for _, name := range slices.Sorted(maps.Keys(file.Project.GUIScripts)) {
+		targets = append(targets, types.RunTarget{Name: name, Kind: types.RunTargetGUIScript, Spec: file.Project.GUIScripts[name]})
+	}
+	return targets, nil
+}
+
+// parsePyprojectTasks returns the tasks of the [tool.uvui.tasks] table of a
+// pyproject.toml, sorted by name. A task is either a command line or a table
+// with cmd, env, depends, python and description keys; cmd may be a list to
+// keep arguments with spaces together.
+func parsePyprojectTasks(data []byte) ([]types.Task, error) {
+	var file pyprojectFile
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	tasks := []types.Task{}
+	for _, name := range slices.Sorted(maps.Keys(file.Tool.UVUI.Tasks)) {
+		task, err := parseTask(name, file.Tool.UVUI.Tasks[name])
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// parseTask converts one entry of the [tool.uvui.tasks] table.
+func parseTask(name string, value interface{}) (types.Task, error) {
+	task := types.Task{Name: name}
+	table, ok := value.(map[string]interface{})
+	if !ok {
+		table = map[string]interface{}{"cmd": value}
+	}
+
+	for key, field := range table {
+		var err error
+		switch key {
+		case "cmd":
+			task.Command, err = taskCommand(field)
+		case "env":
+			task.Env, err = taskEnv(field)
+		case "depends":
+			task.Depends, err = stringList(field)
+		case "python":
+			task.Python, ok = field.(string)
+			if !ok {
+				err = fmt.Errorf("must be a string")
+			}
+		case "description":
+			task.Description, ok = field.(string)
+			if !ok {
+				err = fmt.Errorf("must be a string")
+			}
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			return types.Task{}, fmt.Errorf("task %q: %s: %w", name, key, err)
+		}
+	}
+	if len(task.Command) == 0 {
+		return types.Task{}, fmt.Errorf("task %q has no command", name)
+	}
+	return task, nil
+}
+
+// defaultMatrixParallel is how many Pythons a test matrix runs at once unless
+// pyproject.toml says otherwise.
+const defaultMatrixParallel = 2
+
+// parsePyprojectMatrix returns the test matrix of the [tool.uvui.matrix] table
+// of a pyproject.toml: cmd, a command line or list run with each Python
+// (pytest by default), python, the list of Pythons, and parallel, how many run
+// at once.
+func parsePyprojectMatrix(data []byte) (types.TestMatrix, error) {
+	var file pyprojectFile
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return types.TestMatrix{}, fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	matrix := types.TestMatrix{Command: []string{"pytest"}, Parallel: defaultMatrixParallel}
+	for key, field := range file.Tool.UVUI.Matrix {
+		var err error
+		switch key {
+		case "cmd":
+			matrix.Command, err = taskCommand(field)
+			if err == nil && len(matrix.Command) == 0 {
+				err = fmt.Errorf("must not be empty")
+			}
+		case "python":
+			matrix.Python, err = stringList(field)
+		case "parallel":
+			n, ok := field.(int64)
+			if !ok || n < 1 {
+				err = fmt.Errorf("must be a positive integer")
+			}
+			matrix.Parallel = int(n)
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			return types.TestMatrix{}, fmt.Errorf("[tool.uvui.matrix] %s: %w", key, err)
+		}
+	}
+	return matrix, nil
+}
+
+// taskCommand converts a task command, given as a command line split on
+// whitespace or as a list of arguments. A command line with quotes or
+// backslashes is rejected rather than split in the middle of an argument.
+func taskCommand(value interface{}) ([]string, error) {
+	if line, ok := value.(string); ok {
+		if strings.ContainsAny(line, "\"'\\") {
+			return nil, fmt.Errorf("quoting is not supported in a command line; use a list of arguments instead")
+		}
+		return strings.Fields(line), nil
+	}
+	return stringList(value)
+}
+
+// taskEnv converts the env table of a task into KEY=VALUE entries sorted by
+// key.
+func taskEnv(value interface{}) ([]string, error) {
+	table, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a table")
+	}
+	env := make([]string, 0, len(table))
+	for key, raw := range table {
+		switch v := raw.(type) {
+		case string:
+			env = append(env, key+"="+v)
+		case int64, float64, bool:
+			env = append(env, fmt.Sprintf("%s=%v", key, v))
+		default:
+			return nil, fmt.Errorf("%s must be a string", key)
+		}
+	}
+	sort.Strings(env)
+	return env, nil
+}
+
+// stringList converts a TOML array of strings.
+func stringList(value interface{}) ([]string, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a list of strings")
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a list of strings")
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+// expandDependencyGroup returns the requirements of a PEP 735 dependency
+// group, following {include-group = "..."} entries.
+func expandDependencyGroup(groups map[string][]interface{}, name string, seen []string) ([]string, error) {
+	for _, visited := range seen {
+		if version.NormalizeName(visited) == version.NormalizeName(name) {
+			return nil, fmt.Errorf("dependency group %q includes itself", name)
+		}
+	}
+
+	var entries []interface{}
+	found := false
+	for group, list := range groups {
+		if version.NormalizeName(group) == version.NormalizeName(name) {
+			entries, found = list, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("dependency group %q is not defined", name)
+	}
+
+	var requirements []string
+	for _, entry := range entries {
+		switch value := entry.(type) {
+		case string:
+			requirements = append(requirements, value)
+		case map[string]interface{}:
+			include, ok := value["include-group"].(string)
+			if !ok {
+				return nil, fmt.Errorf("dependency group %q has an invalid entry", name)
+			}
+			included, err := expandDependencyGroup(groups, include, append(seen, name))
+			if err != nil {
+				return nil, err
+			}
+			requirements = append(requirements, included...)
+		default:
+			return nil, fmt.Errorf("dependency group %q has an invalid entry", name)
+		}
+	}
+	return requirements, nil
+}
+
+// newProjectDependency converts a PEP 508 requirement string. Requirements
+// that cannot be parsed are kept verbatim as the name.
+func newProjectDependency(raw, kind, group string) types.ProjectDependency {
+	dep := types.ProjectDependency{Name: strings.TrimSpace(raw), Type: kind, Group: group}
+
+	req, err := version.ParseRequirement(raw)
+	if err != nil {
+		return dep
+	}
+	dep.Name = req.Name
+	dep.Extras = req.Extras
+	if req.URL != "" {
+		dep.Version = "@ " + req.URL
+	} else {
+		dep.Version = req.Specifier.String()
+	}
+	if req.Marker != nil {
+		dep.Markers = req.Marker.String()
+	}
+	return dep
+}
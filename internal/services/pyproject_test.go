@@ -0,0 +1,224 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+const samplePyproject = `
+[project]
+name = "demo"
+version = "0.1.0"
+dependencies = [
+    "requests[socks]>=2.31,<3",
+    "tomli>=2.0; python_version < '3.11'",
+    "mylib @ https://example.com/mylib-1.0.tar.gz",
+]
+
+[project.optional-dependencies]
+server = ["uvicorn>=0.30"]
+cli = ["rich", "click>=8; extra == 'cli'"]
+
+[dependency-groups]
+test = ["pytest>=8", {include-group = "typing"}]
+typing = ["mypy==1.11.*"]
+dev = [{include-group = "test"}, "ruff"]
+
+[tool.uv]
+dev-dependencies = ["black~=24.4"]
+`
+
+func TestParsePyprojectDependencies(t *testing.T) {
+	deps, err := parsePyprojectDependencies([]byte(samplePyproject))
+	if err != nil {
+		t.Fatalf("parsePyprojectDependencies() error = %v", err)
+	}
+
+	want := []types.ProjectDependency{
+		{Name: "requests", Version: ">=2.31,<3", Type: DependencyMain, Extras: []string{"socks"}},
+		{Name: "tomli", Version: ">=2.0", Type: DependencyMain, Markers: "python_version < '3.11'"},
+		{Name: "mylib", Version: "@ https://example.com/mylib-1.0.tar.gz", Type: DependencyMain},
+		{Name: "rich", Type: DependencyOptional, Group: "cli"},
+		{Name: "click", Version: ">=8", Type: DependencyOptional, Group: "cli", Markers: "extra == 'cli'"},
+		{Name: "uvicorn", Version: ">=0.30", Type: DependencyOptional, Group: "server"},
+		{Name: "pytest", Version: ">=8", Type: DependencyDev, Group: "dev"},
+		{Name: "mypy", Version: "==1.11.*", Type: DependencyDev, Group: "dev"},
+		{Name: "ruff", Type: DependencyDev, Group: "dev"},
+		{Name: "pytest", Version: ">=8", Type: DependencyGroup, Group: "test"},
+		{Name: "mypy", Version: "==1.11.*", Type: DependencyGroup, Group: "test"},
+		{Name: "mypy", Version: "==1.11.*", Type: DependencyGroup, Group: "typing"},
+		{Name: "black", Version: "~=24.4", Type: DependencyDev},
+	}
+
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("parsePyprojectDependencies() =\n%+v\nwant\n%+v", deps, want)
+	}
+}
+
+func TestParsePyprojectDependencies_Empty(t *testing.T) {
+	deps, err := parsePyprojectDependencies([]byte("[project]\nname = \"demo\"\n"))
+	if err != nil {
+		t.Fatalf("parsePyprojectDependencies() error = %v", err)
+	}
+	if len(deps) != 0 {
+		t.Errorf("parsePyprojectDependencies() = %+v, want none", deps)
+	}
+}
+
+func TestParsePyprojectDependencies_InvalidRequirement(t *testing.T) {
+	deps, err := parsePyprojectDependencies([]byte("[project]\ndependencies = [\"not a requirement!\"]\n"))
+	if err != nil {
+		t.Fatalf("parsePyprojectDependencies() error = %v", err)
+	}
+	if len(deps) != 1 || deps[0].Name != "not a requirement!" || deps[0].Type != DependencyMain {
+		t.Errorf("parsePyprojectDependencies() = %+v, want the raw requirement kept", deps)
+	}
+}
+
+func TestParsePyprojectDependencies_GroupErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"cycle", "[dependency-groups]\na = [{include-group = \"b\"}]\nb = [{include-group = \"a\"}]\n", "includes itself"},
+		{"missing", "[dependency-groups]\na = [{include-group = \"nope\"}]\n", "not defined"},
+		{"invalid entry", "[dependency-groups]\na = [42]\n", "invalid entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePyprojectDependencies([]byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parsePyprojectDependencies() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePyprojectScripts(t *testing.T) {
+	data := []byte(`
+[project]
+name = "demo"
+
+[project.scripts]
+serve = "demo.server:main"
+demo = "demo.cli:app"
+
+[project.gui-scripts]
+demo-gui = "demo.gui:run"
+`)
+	targets, err := parsePyprojectScripts(data)
+	if err != nil {
+		t.Fatalf("parsePyprojectScripts() error = %v", err)
+	}
+	expected := []types.RunTarget{
+		{Name: "demo", Kind: types.RunTargetScript, Spec: "demo.cli:app"},
+		{Name: "serve", Kind: types.RunTargetScript, Spec: "demo.server:main"},
+		{Name: "demo-gui", Kind: types.RunTargetGUIScript, Spec: "demo.gui:run"},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("parsePyprojectScripts() = %+v, want %+v", targets, expected)
+	}
+
+	if _, err := parsePyprojectScripts([]byte("[project")); err == nil {
+		t.Error("parsePyprojectScripts() should fail on invalid TOML")
+	}
+}
+
+func TestParsePyprojectTasks(t *testing.T) {
+	data := []byte(`
+[project]
+name = "demo"
+
+[tool.uvui.tasks]
+lint = "ruff check ."
+fmt = ["ruff", "format", "src dir"]
+
+[tool.uvui.tasks.test]
+cmd = "pytest -q"
+description = "Run the test suite"
+depends = ["lint", "fmt"]
+python = "3.12"
+env = { PYTHONWARNINGS = "error", DEBUG = 1 }
+`)
+	tasks, err := parsePyprojectTasks(data)
+	if err != nil {
+		t.Fatalf("parsePyprojectTasks() error = %v", err)
+	}
+	expected := []types.Task{
+		{Name: "fmt", Command: []string{"ruff", "format", "src dir"}},
+		{Name: "lint", Command: []string{"ruff", "check", "."}},
+		{
+			Name:        "test",
+			Description: "Run the test suite",
+			Command:     []string{"pytest", "-q"},
+			Env:         []string{"DEBUG=1", "PYTHONWARNINGS=error"},
+			Depends:     []string{"lint", "fmt"},
+			Python:      "3.12",
+		},
+	}
+	if !reflect.DeepEqual(tasks, expected) {
+		t.Errorf("parsePyprojectTasks() = %+v, want %+v", tasks, expected)
+	}
+
+	if tasks, err := parsePyprojectTasks([]byte("[project]\nname = \"demo\"\n")); err != nil || len(tasks) != 0 {
+		t.Errorf("parsePyprojectTasks() without tasks = %v, %v", tasks, err)
+	}
+}
+
+func TestParsePyprojectTasks_Errors(t *testing.T) {
+	for name, data := range map[string]string{
+		"no command":     "[tool.uvui.tasks.test]\ndepends = [\"lint\"]\n",
+		"unknown key":    "[tool.uvui.tasks.test]\ncmd = \"pytest\"\nrun = \"x\"\n",
+		"bad depends":    "[tool.uvui.tasks.test]\ncmd = \"pytest\"\ndepends = \"lint\"\n",
+		"bad env":        "[tool.uvui.tasks.test]\ncmd = \"pytest\"\nenv = [\"A=1\"]\n",
+		"bad command":    "[tool.uvui.tasks]\ntest = 3\n",
+		"invalid TOML":   "[tool.uvui.tasks",
+		"empty command":  "[tool.uvui.tasks]\ntest = \"\"\n",
+		"quoted command": "[tool.uvui.tasks]\ntest = \"pytest -k 'a and b'\"\n",
+	} {
+		if _, err := parsePyprojectTasks([]byte(data)); err == nil {
+			t.Errorf("%s: parsePyprojectTasks() should fail", name)
+		}
+	}
+}
+
+func TestParsePyprojectMatrix(t *testing.T) {
+	data := []byte(`
+[tool.uvui.matrix]
+cmd = "pytest -q -x"
+python = ["3.10", "3.11", "pypy@3.10"]
+parallel = 3
+`)
+	matrix, err := parsePyprojectMatrix(data)
+	if err != nil {
+		t.Fatalf("parsePyprojectMatrix() error = %v", err)
+	}
+	expected := types.TestMatrix{Command: []string{"pytest", "-q", "-x"}, Python: []string{"3.10", "3.11", "pypy@3.10"}, Parallel: 3}
+	if !reflect.DeepEqual(matrix, expected) {
+		t.Errorf("parsePyprojectMatrix() = %+v, want %+v", matrix, expected)
+	}
+
+	matrix, err = parsePyprojectMatrix([]byte("[project]\nname = \"demo\"\n"))
+	expected = types.TestMatrix{Command: []string{"pytest"}, Parallel: defaultMatrixParallel}
+	if err != nil || !reflect.DeepEqual(matrix, expected) {
+		t.Errorf("parsePyprojectMatrix() without a matrix = %+v, %v", matrix, err)
+	}
+}
+
+func TestParsePyprojectMatrix_Errors(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty command":  "[tool.uvui.matrix]\ncmd = \"\"\n",
+		"bad python":     "[tool.uvui.matrix]\npython = \"3.12\"\n",
+		"bad parallel":   "[tool.uvui.matrix]\nparallel = 0\n",
+		"unknown key":    "[tool.uvui.matrix]\nenv = {}\n",
+		"quoted command": "[tool.uvui.matrix]\ncmd = \"pytest -k \\\"a and b\\\"\"\n",
+	} {
+		if _, err := parsePyprojectMatrix([]byte(data)); err == nil {
+			t.Errorf("%s: parsePyprojectMatrix() should fail", name)
+		}
+	}
+}
@@ -2,8 +2,13 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os/exec"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -13,6 +18,7 @@ import (
 
 // PythonManager implements Python version management functionality.
 type PythonManager struct {
+	projectRoot
 	executor CommandExecutorInterface
 }
 
@@ -22,12 +28,22 @@ func NewPythonManager(executor CommandExecutorInterface) *PythonManager {
 }
 
 // ListAvailable lists all available Python versions.
-func (p *PythonManager) ListAvailable() ([]types.PythonVersion, error) {
+func (p *PythonManager) ListAvailable(ctx context.Context) ([]types.PythonVersion, error) {
 	if !p.executor.IsUVAvailable() {
 		return nil, fmt.Errorf("UV is not available")
 	}
 
-	output, err := p.executor.Execute("uv", "python", "list", "--only-downloads")
+	versions, ok, err := p.listJSON(ctx, "--only-downloads")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		sortPythonVersions(versions)
+		return versions, nil
+	}
+
+	// uv releases without --output-format only offer human-readable output.
+	output, err := p.exec(p.executor).Execute(ctx, "uv", "python", "list", "--only-downloads")
 	if err != nil {
 		return nil, err
 	}
@@ -36,12 +52,25 @@ func (p *PythonManager) ListAvailable() ([]types.PythonVersion, error) {
 }
 
 // ListInstalled lists all installed Python versions.
-func (p *PythonManager) ListInstalled() ([]types.PythonVersion, error) {
+func (p *PythonManager) ListInstalled(ctx context.Context) ([]types.PythonVersion, error) {
 	if !p.executor.IsUVAvailable() {
 		return nil, fmt.Errorf("UV is not available")
 	}
 
-	output, err := p.executor.Execute("uv", "python", "list", "--only-installed")
+	versions, ok, err := p.listJSON(ctx, "--only-installed")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		for i := range versions {
+			versions[i].Installed = true
+		}
+		p.markCurrent(ctx, versions)
+		return versions, nil
+	}
+
+	// uv releases without --output-format only offer human-readable output.
+	output, err := p.exec(p.executor).Execute(ctx, "uv", "python", "list", "--only-installed")
 	if err != nil {
 		return nil, err
 	}
@@ -49,43 +78,97 @@ func (p *PythonManager) ListInstalled() ([]types.PythonVersion, error) {
 	return p.parseInstalledVersions(string(output)), nil
 }
 
-// Install installs a Python version.
-func (p *PythonManager) Install(version string) error {
+// listJSON runs `uv python list` with filter and JSON output. It reports
+// false if uv rejects --output-format or does not produce JSON, as older
+// releases do; any other failure of uv is returned.
+func (p *PythonManager) listJSON(ctx context.Context, filter string) ([]types.PythonVersion, bool, error) {
+	output, err := p.exec(p.executor).Execute(ctx, "uv", "python", "list", filter, "--output-format", "json")
+	if err != nil {
+		if ctx.Err() == nil && rejectsFlag(err, "--output-format") {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	versions, err := parsePythonListJSON(output)
+	if err != nil {
+		return nil, false, nil
+	}
+	return versions, true, nil
+}
+
+// rejectsFlag reports whether err is uv, or a Python tool parsing its
+// arguments with argparse such as mypy, refusing flag as an unknown argument.
+func rejectsFlag(err error, flag string) bool {
+	message := err.Error()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		message += "\n" + string(exitErr.Stderr)
+	}
+	for _, line := range strings.Split(message, "\n") {
+		if _, unknown, ok := strings.Cut(line, "unrecognized arguments: "); ok && slices.Contains(strings.Fields(unknown), flag) {
+			return true
+		}
+	}
+	return strings.Contains(message, "unexpected argument '"+flag+"'") ||
+		strings.Contains(message, "unrecognized argument '"+flag+"'") ||
+		strings.Contains(message, "Found argument '"+flag+"' which wasn't expected")
+}
+
+// markCurrent marks the interpreter uv selects by default for the project
+// root as current.
+func (p *PythonManager) markCurrent(ctx context.Context, versions []types.PythonVersion) {
+	output, err := p.exec(p.executor).Execute(ctx, "uv", "python", "find")
+	if err != nil {
+		return
+	}
+
+	current := strings.TrimSpace(string(output))
+	for i := range versions {
+		if versions[i].Path == current || (versions[i].Symlink != "" && versions[i].Symlink == current) {
+			versions[i].Current = true
+			return
+		}
+	}
+}
+
+// Install installs a Python version, streaming uv's progress to output.
+func (p *PythonManager) Install(ctx context.Context, version string, output OutputHandler) error {
 	if !p.executor.IsUVAvailable() {
 		return fmt.Errorf("UV is not available")
 	}
 
-	_, err := p.executor.Execute("uv", "python", "install", version)
+	_, err := p.exec(p.executor).ExecuteStream(ctx, output, "uv", "python", "install", version)
 	return err
 }
 
-// Uninstall removes a Python version.
-func (p *PythonManager) Uninstall(version string) error {
+// Uninstall removes a Python version, streaming uv's progress to output.
+func (p *PythonManager) Uninstall(ctx context.Context, version string, output OutputHandler) error {
 	if !p.executor.IsUVAvailable() {
 		return fmt.Errorf("UV is not available")
 	}
 
-	_, err := p.executor.Execute("uv", "python", "uninstall", version)
+	_, err := p.exec(p.executor).ExecuteStream(ctx, output, "uv", "python", "uninstall", version)
 	return err
 }
 
-// Pin pins a Python version for the current project.
-func (p *PythonManager) Pin(version string) error {
+// Pin pins a Python version for the project root.
+func (p *PythonManager) Pin(ctx context.Context, version string) error {
 	if !p.executor.IsUVAvailable() {
 		return fmt.Errorf("UV is not available")
 	}
 
-	_, err := p.executor.Execute("uv", "python", "pin", version)
+	_, err := p.exec(p.executor).Execute(ctx, "uv", "python", "pin", version)
 	return err
 }
 
 // Find finds a specific Python version.
-func (p *PythonManager) Find(version string) (*types.PythonVersion, error) {
+func (p *PythonManager) Find(ctx context.Context, version string) (*types.PythonVersion, error) {
 	if !p.executor.IsUVAvailable() {
 		return nil, fmt.Errorf("UV is not available")
 	}
 
-	output, err := p.executor.Execute("uv", "python", "find", version)
+	output, err := p.exec(p.executor).Execute(ctx, "uv", "python", "find", version)
 	if err != nil {
 		return nil, err
 	}
@@ -116,12 +199,68 @@ func (p *PythonManager) parseAvailableVersions(output string) []types.PythonVers
 		}
 	}
 
-	// Sort versions in descending order
-	sort.Slice(versions, func(i, j int) bool {
-		return version.CompareVersions(versions[i].Version, versions[j].Version) > 0
+	sortPythonVersions(versions)
+	return versions
+}
+
+// pythonListEntry is an entry of `uv python list --output-format json`.
+type pythonListEntry struct {
+	Key            string  `json:"key"`
+	Version        string  `json:"version"`
+	Path           *string `json:"path"`
+	Symlink        *string `json:"symlink"`
+	URL            *string `json:"url"`
+	OS             string  `json:"os"`
+	Variant        string  `json:"variant"`
+	Implementation string  `json:"implementation"`
+	Arch           string  `json:"arch"`
+	Libc           string  `json:"libc"`
+}
+
+// parsePythonListJSON parses the output of `uv python list --output-format json`.
+func parsePythonListJSON(output []byte) ([]types.PythonVersion, error) {
+	var entries []pythonListEntry
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, err
+	}
+
+	versions := make([]types.PythonVersion, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, types.PythonVersion{
+			Version:        entry.Version,
+			Installed:      entry.Path != nil,
+			Path:           stringValue(entry.Path),
+			Key:            entry.Key,
+			Implementation: entry.Implementation,
+			Arch:           entry.Arch,
+			OS:             entry.OS,
+			Libc:           entry.Libc,
+			Variant:        entry.Variant,
+			Symlink:        stringValue(entry.Symlink),
+			URL:            stringValue(entry.URL),
+		})
+	}
+	return versions, nil
+}
+
+// sortPythonVersions sorts versions newest first under PEP 440 ordering, so
+// pre-releases come before their final release, keeping versions that are
+// equal in a stable order by key.
+func sortPythonVersions(versions []types.PythonVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		if cmp := version.CompareVersions(versions[i].Version, versions[j].Version); cmp != 0 {
+			return cmp > 0
+		}
+		return versions[i].Key < versions[j].Key
 	})
+}
 
-	return versions
+// stringValue returns the string s points to, or "" for nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 // parseInstalledVersions parses the output of uv python list --only-installed.
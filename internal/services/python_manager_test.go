@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"os/exec"
 	"reflect"
+	"strings"
 	"testing"
 
 	"uvui/internal/types"
@@ -27,7 +30,7 @@ func TestPythonManager_ListAvailable(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	versions, err := pm.ListAvailable()
+	versions, err := pm.ListAvailable(context.Background())
 	if err != nil {
 		t.Errorf("ListAvailable() error = %v, wantErr %v", err, false)
 	}
@@ -53,7 +56,7 @@ func TestPythonManager_ListInstalled(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	versions, err := pm.ListInstalled()
+	versions, err := pm.ListInstalled(context.Background())
 	if err != nil {
 		t.Errorf("ListInstalled() error = %v, wantErr %v", err, false)
 	}
@@ -79,7 +82,7 @@ func TestPythonManager_Install(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	err := pm.Install("3.12.1")
+	err := pm.Install(context.Background(), "3.12.1", nil)
 	if err != nil {
 		t.Errorf("Install() error = %v, wantErr %v", err, false)
 	}
@@ -96,7 +99,7 @@ func TestPythonManager_Uninstall(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	err := pm.Uninstall("3.12.1")
+	err := pm.Uninstall(context.Background(), "3.12.1", nil)
 	if err != nil {
 		t.Errorf("Uninstall() error = %v, wantErr %v", err, false)
 	}
@@ -113,7 +116,7 @@ func TestPythonManager_Pin(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	err := pm.Pin("3.12.1")
+	err := pm.Pin(context.Background(), "3.12.1")
 	if err != nil {
 		t.Errorf("Pin() error = %v, wantErr %v", err, false)
 	}
@@ -130,7 +133,7 @@ func TestPythonManager_Find(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	version, err := pm.Find("3.12.1")
+	version, err := pm.Find(context.Background(), "3.12.1")
 	if err != nil {
 		t.Errorf("Find() error = %v, wantErr %v", err, false)
 	}
@@ -154,7 +157,7 @@ func TestPythonManager_ListAvailable_Error(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	_, err := pm.ListAvailable()
+	_, err := pm.ListAvailable(context.Background())
 	if err == nil {
 		t.Error("ListAvailable() error = nil, wantErr true")
 	}
@@ -168,7 +171,7 @@ func TestPythonManager_ListInstalled_Error(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	_, err := pm.ListInstalled()
+	_, err := pm.ListInstalled(context.Background())
 	if err == nil {
 		t.Error("ListInstalled() error = nil, wantErr true")
 	}
@@ -182,7 +185,7 @@ func TestPythonManager_Install_Error(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	err := pm.Install("3.12.1")
+	err := pm.Install(context.Background(), "3.12.1", nil)
 	if err == nil {
 		t.Error("Install() error = nil, wantErr true")
 	}
@@ -196,7 +199,7 @@ func TestPythonManager_Uninstall_Error(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	err := pm.Uninstall("3.12.1")
+	err := pm.Uninstall(context.Background(), "3.12.1", nil)
 	if err == nil {
 		t.Error("Uninstall() error = nil, wantErr true")
 	}
@@ -210,7 +213,7 @@ func TestPythonManager_Pin_Error(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	err := pm.Pin("3.12.1")
+	err := pm.Pin(context.Background(), "3.12.1")
 	if err == nil {
 		t.Error("Pin() error = nil, wantErr true")
 	}
@@ -224,8 +227,182 @@ func TestPythonManager_Find_Error(t *testing.T) {
 	}
 	pm := NewPythonManager(executor)
 
-	_, err := pm.Find("3.12.1")
+	_, err := pm.Find(context.Background(), "3.12.1")
 	if err == nil {
 		t.Error("Find() error = nil, wantErr true")
 	}
 }
+
+const pythonListDownloadsJSON = `[
+  {"key": "cpython-3.13.0+freethreaded-linux-x86_64-gnu", "version": "3.13.0", "version_parts": {"major": 3, "minor": 13, "patch": 0}, "path": null, "symlink": null, "url": "https://example.com/cpython-3.13.0t.tar.gz", "os": "linux", "variant": "freethreaded", "implementation": "cpython", "arch": "x86_64", "libc": "gnu"},
+  {"key": "pypy-3.10.14-linux-x86_64-gnu", "version": "3.10.14", "version_parts": {"major": 3, "minor": 10, "patch": 14}, "path": null, "symlink": null, "url": "https://example.com/pypy.tar.bz2", "os": "linux", "variant": "default", "implementation": "pypy", "arch": "x86_64", "libc": "gnu"},
+  {"key": "cpython-3.13.0-linux-x86_64-gnu", "version": "3.13.0", "version_parts": {"major": 3, "minor": 13, "patch": 0}, "path": null, "symlink": null, "url": "https://example.com/cpython-3.13.0.tar.gz", "os": "linux", "variant": "default", "implementation": "cpython", "arch": "x86_64", "libc": "gnu"}
+]`
+
+const pythonListInstalledJSON = `[
+  {"key": "cpython-3.12.7-linux-x86_64-gnu", "version": "3.12.7", "path": "/home/me/.local/share/uv/python/cpython-3.12.7-linux-x86_64-gnu/bin/python3.12", "symlink": null, "url": null, "os": "linux", "variant": "default", "implementation": "cpython", "arch": "x86_64", "libc": "gnu"},
+  {"key": "cpython-3.11.2-linux-x86_64-gnu", "version": "3.11.2", "path": "/usr/bin/python3", "symlink": "/usr/bin/python3.11", "url": null, "os": "linux", "variant": "default", "implementation": "cpython", "arch": "x86_64", "libc": "gnu"}
+]`
+
+func TestPythonManager_ListAvailable_JSON(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			if reflect.DeepEqual(args, []string{"python", "list", "--only-downloads", "--output-format", "json"}) {
+				return []byte(pythonListDownloadsJSON), nil
+			}
+			return nil, fmt.Errorf("unexpected command: %s %v", command, args)
+		},
+	}
+	pm := NewPythonManager(executor)
+
+	versions, err := pm.ListAvailable(context.Background())
+	if err != nil {
+		t.Fatalf("ListAvailable() error = %v", err)
+	}
+
+	if len(versions) != 3 {
+		t.Fatalf("ListAvailable() returned %d versions, want 3", len(versions))
+	}
+	// Newest first; equal versions are ordered by key.
+	wantKeys := []string{"cpython-3.13.0+freethreaded-linux-x86_64-gnu", "cpython-3.13.0-linux-x86_64-gnu", "pypy-3.10.14-linux-x86_64-gnu"}
+	for i, key := range wantKeys {
+		if versions[i].Key != key {
+			t.Errorf("versions[%d].Key = %q, want %q", i, versions[i].Key, key)
+		}
+	}
+
+	want := types.PythonVersion{
+		Version:        "3.10.14",
+		Key:            "pypy-3.10.14-linux-x86_64-gnu",
+		Implementation: "pypy",
+		Arch:           "x86_64",
+		OS:             "linux",
+		Libc:           "gnu",
+		Variant:        "default",
+		URL:            "https://example.com/pypy.tar.bz2",
+	}
+	if !reflect.DeepEqual(versions[2], want) {
+		t.Errorf("ListAvailable() pypy = %+v, want %+v", versions[2], want)
+	}
+	if versions[0].Variant != "freethreaded" {
+		t.Errorf("ListAvailable() variant = %q, want freethreaded", versions[0].Variant)
+	}
+}
+
+func TestPythonManager_ListInstalled_JSON(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			switch {
+			case reflect.DeepEqual(args, []string{"python", "list", "--only-installed", "--output-format", "json"}):
+				return []byte(pythonListInstalledJSON), nil
+			case reflect.DeepEqual(args, []string{"python", "find"}):
+				return []byte("/usr/bin/python3.11\n"), nil
+			}
+			return nil, fmt.Errorf("unexpected command: %s %v", command, args)
+		},
+	}
+	pm := NewPythonManager(executor)
+
+	versions, err := pm.ListInstalled(context.Background())
+	if err != nil {
+		t.Fatalf("ListInstalled() error = %v", err)
+	}
+
+	if len(versions) != 2 {
+		t.Fatalf("ListInstalled() returned %d versions, want 2", len(versions))
+	}
+	if !versions[0].Installed || versions[0].Current {
+		t.Errorf("versions[0] = %+v, want installed and not current", versions[0])
+	}
+	// The current interpreter is matched through its symlink target.
+	if !versions[1].Current || versions[1].Symlink != "/usr/bin/python3.11" || versions[1].Path != "/usr/bin/python3" {
+		t.Errorf("versions[1] = %+v, want the current system Python", versions[1])
+	}
+}
+
+func TestPythonManager_ListAvailable_TextFallback(t *testing.T) {
+	var calls [][]string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			calls = append(calls, args)
+			for _, arg := range args {
+				if arg == "--output-format" {
+					return nil, fmt.Errorf("error: unexpected argument '--output-format' found")
+				}
+			}
+			return []byte("cpython-3.12.1-linux-x86_64-gnu    <download available>\n"), nil
+		},
+	}
+	pm := NewPythonManager(executor)
+
+	versions, err := pm.ListAvailable(context.Background())
+	if err != nil {
+		t.Fatalf("ListAvailable() error = %v", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("ListAvailable() ran %d commands, want the JSON attempt and the text fallback", len(calls))
+	}
+	if len(versions) != 1 || versions[0].Version != "3.12.1" {
+		t.Errorf("ListAvailable() versions = %+v, want 3.12.1 from the text output", versions)
+	}
+}
+
+func TestPythonManager_ListAvailable_ReturnsOtherErrors(t *testing.T) {
+	var calls int
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			calls++
+			return nil, fmt.Errorf("error: Failed to fetch: `https://github.com/astral-sh/python-build-standalone`")
+		},
+	}
+	pm := NewPythonManager(executor)
+
+	if _, err := pm.ListAvailable(context.Background()); err == nil || !strings.Contains(err.Error(), "Failed to fetch") {
+		t.Errorf("ListAvailable() error = %v, want uv's error", err)
+	}
+	if calls != 1 {
+		t.Errorf("ListAvailable() ran %d commands, want no text fallback", calls)
+	}
+	if _, err := pm.ListInstalled(context.Background()); err == nil {
+		t.Error("ListInstalled() error = nil, want uv's error")
+	}
+}
+
+func TestRejectsFlag(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("error: unexpected argument '--output-format' found"), true},
+		{fmt.Errorf("error: Found argument '--output-format' which wasn't expected, or isn't valid in this context"), true},
+		{&exec.ExitError{Stderr: []byte("error: unexpected argument '--output-format' found\n")}, true},
+		{fmt.Errorf("error: unexpected argument '--only-downloads' found"), false},
+		{fmt.Errorf("exit status 2: main.py: error: unrecognized arguments: --output-format json"), true},
+		{fmt.Errorf("exit status 2: main.py: error: unrecognized arguments: --output-formats"), false},
+		{fmt.Errorf("error: Failed to fetch"), false},
+	}
+	for _, tt := range tests {
+		if got := rejectsFlag(tt.err, "--output-format"); got != tt.want {
+			t.Errorf("rejectsFlag(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestSortPythonVersions_PreReleases(t *testing.T) {
+	versions := []types.PythonVersion{
+		{Version: "3.13.0rc1"},
+		{Version: "3.12.10"},
+		{Version: "3.14.0a3"},
+		{Version: "3.13.0"},
+		{Version: "3.12.9"},
+	}
+
+	sortPythonVersions(versions)
+
+	want := []string{"3.14.0a3", "3.13.0", "3.13.0rc1", "3.12.10", "3.12.9"}
+	for i, v := range versions {
+		if v.Version != want[i] {
+			t.Errorf("versions[%d] = %q, want %q", i, v.Version, want[i])
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+const (
+	// maxScriptDepth is how many directories below the project root are
+	// searched for Python files.
+	maxScriptDepth = 4
+	// maxScriptFiles caps the number of Python files listed.
+	maxScriptFiles = 500
+)
+
+// skippedScriptDirs are directories that never hold project scripts.
+var skippedScriptDirs = map[string]bool{
+	"__pycache__":   true,
+	"node_modules":  true,
+	"site-packages": true,
+	"build":         true,
+	"dist":          true,
+}
+
+// ScriptManager implements running project entry points and scripts with
+// uv run.
+type ScriptManager struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewScriptManager creates a new script manager.
+func NewScriptManager(executor CommandExecutorInterface) *ScriptManager {
+	return &ScriptManager{executor: executor}
+}
+
+// ListTargets returns the entry points declared in pyproject.toml followed by
+// the Python files in the project, outside virtual environments and hidden or
+// build directories. Files with PEP 723 inline metadata are marked Inline.
+func (s *ScriptManager) ListTargets(ctx context.Context) ([]types.RunTarget, error) {
+	root, err := s.absProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []types.RunTarget{}
+	data, err := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		scripts, err := parsePyprojectScripts(data)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, scripts...)
+	}
+
+	files, err := findPythonFiles(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		targets = append(targets, types.RunTarget{
+			Name:   file,
+			Kind:   types.RunTargetFile,
+			Inline: hasInlineMetadata(filepath.Join(root, filepath.FromSlash(file))),
+		})
+	}
+	return targets, nil
+}
+
+// RunTarget runs an entry point or file with uv run in the project directory,
+// streaming its output.
+func (s *ScriptManager) RunTarget(ctx context.Context, options types.RunOptions, output OutputHandler) error {
+	if !s.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	if strings.TrimSpace(options.Target) == "" {
+		return fmt.Errorf("nothing to run")
+	}
+
+	executor := s.exec(s.executor)
+	if len(options.Env) > 0 {
+		executor = executor.WithEnv(options.Env)
+	}
+	args := append([]string{"run"}, options.RunArgs()...)
+	if _, err := executor.ExecuteStream(ctx, output, "uv", args...); err != nil {
+		return fmt.Errorf("failed to run %s: %w", options.Target, err)
+	}
+	return nil
+}
+
+// ScriptMetadata reads the PEP 723 inline metadata of a script in the
+// project, and whether uv lock --script has locked it.
+func (s *ScriptManager) ScriptMetadata(_ context.Context, script string) (*types.ScriptMetadata, error) {
+	path, err := s.scriptPath(script)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := parseInlineScriptMetadata(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", script, err)
+	}
+	metadata.Path = script
+	if _, err := os.Stat(path + ".lock"); err == nil {
+		metadata.Locked = true
+	}
+	return metadata, nil
+}
+
+// AddScriptDependency adds a requirement to the inline metadata of a script
+// with uv add --script, which creates the metadata block if needed.
+func (s *ScriptManager) AddScriptDependency(ctx context.Context, script, requirement string, output OutputHandler) error {
+	if !s.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" {
+		return fmt.Errorf("no dependency given")
+	}
+	_, err := s.exec(s.executor).ExecuteStream(ctx, output, "uv", "add", "--script", script, requirement)
+	return err
+}
+
+// RemoveScriptDependency removes a dependency from the inline metadata of a
+// script with uv remove --script.
+func (s *ScriptManager) RemoveScriptDependency(ctx context.Context, script, name string, output OutputHandler) error {
+	if !s.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("no dependency given")
+	}
+	_, err := s.exec(s.executor).ExecuteStream(ctx, output, "uv", "remove", "--script", script, name)
+	return err
+}
+
+// LockScript writes the <script>.lock file of a script with
+// uv lock --script.
+func (s *ScriptManager) LockScript(ctx context.Context, script string, output OutputHandler) error {
+	if !s.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	_, err := s.exec(s.executor).ExecuteStream(ctx, output, "uv", "lock", "--script", script)
+	return err
+}
+
+// scriptPath returns the absolute path of a script given relative to the
+// project.
+func (s *ScriptManager) scriptPath(script string) (string, error) {
+	if strings.TrimSpace(script) == "" {
+		return "", fmt.Errorf("no script given")
+	}
+	if filepath.IsAbs(script) {
+		return script, nil
+	}
+	root, err := s.absProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(script)), nil
+}
+
+// findPythonFiles returns the .py files below root as slash-separated paths
+// relative to it, in walk order.
+func findPythonFiles(ctx context.Context, root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			if strings.HasPrefix(entry.Name(), ".") || skippedScriptDirs[entry.Name()] ||
+				strings.Count(filepath.ToSlash(rel), "/") >= maxScriptDepth {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "pyvenv.cfg")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(entry.Name(), ".py") {
+			files = append(files, filepath.ToSlash(rel))
+			if len(files) >= maxScriptFiles {
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// hasInlineMetadata reports whether the file at path declares a PEP 723
+// "# /// script" block.
+func hasInlineMetadata(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	_, found, err := extractInlineBlock(data, inlineScriptBlock)
+	return found || err != nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestNewScriptManager(t *testing.T) {
+	if sm := NewScriptManager(&mockCommandExecutor{}); sm == nil {
+		t.Error("NewScriptManager() should not return nil")
+	}
+}
+
+func TestScriptManager_ListTargets(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"pyproject.toml":              "[project]\nname = \"demo\"\n\n[project.scripts]\ndemo = \"demo.cli:main\"\n",
+		"main.py":                     "print('hi')\n",
+		"fetch.py":                    "# /// script\n# dependencies = [\"httpx\"]\n# ///\nimport httpx\n",
+		"README.md":                   "# demo\n",
+		"src/demo/cli.py":             "",
+		"scripts/tools/a/b/deep.py":   "",
+		"scripts/tools/a/deeper.py":   "",
+		".venv/lib/site.py":           "",
+		"venv/pyvenv.cfg":             "home = /usr/bin\n",
+		"venv/lib/python3.12/os.py":   "",
+		"src/demo/__pycache__/cli.py": "",
+		".hidden/secret.py":           "",
+		"build/lib/demo/cli.py":       "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sm := NewScriptManager(&mockCommandExecutor{})
+	sm.SetProjectRoot(root)
+	targets, err := sm.ListTargets(context.Background())
+	if err != nil {
+		t.Fatalf("ListTargets() error = %v", err)
+	}
+	expected := []types.RunTarget{
+		{Name: "demo", Kind: types.RunTargetScript, Spec: "demo.cli:main"},
+		{Name: "fetch.py", Kind: types.RunTargetFile, Inline: true},
+		{Name: "main.py", Kind: types.RunTargetFile},
+		{Name: "scripts/tools/a/b/deep.py", Kind: types.RunTargetFile},
+		{Name: "scripts/tools/a/deeper.py", Kind: types.RunTargetFile},
+		{Name: "src/demo/cli.py", Kind: types.RunTargetFile},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("ListTargets() = %+v, want %+v", targets, expected)
+	}
+}
+
+func TestScriptManager_ListTargetsWithoutProject(t *testing.T) {
+	sm := NewScriptManager(&mockCommandExecutor{})
+	sm.SetProjectRoot(t.TempDir())
+	targets, err := sm.ListTargets(context.Background())
+	if err != nil || len(targets) != 0 {
+		t.Errorf("ListTargets() = %v, %v, want no targets", targets, err)
+	}
+}
+
+func TestScriptManager_RunTarget(t *testing.T) {
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = args
+			return nil, nil
+		},
+	}
+	sm := NewScriptManager(executor)
+	sm.SetProjectRoot("/project")
+
+	options := types.RunOptions{Target: "main.py", Args: []string{"--port", "8000"}, Env: []string{"DEBUG=1"}, With: []string{"rich"}}
+	if err := sm.RunTarget(context.Background(), options, nil); err != nil {
+		t.Fatalf("RunTarget() error = %v", err)
+	}
+	if expected := []string{"run", "--with", "rich", "main.py", "--port", "8000"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("ran uv %v, want %v", got, expected)
+	}
+	if executor.Dir != "/project" || !reflect.DeepEqual(executor.Env, []string{"DEBUG=1"}) {
+		t.Errorf("ran in %q with %v, want /project with DEBUG=1", executor.Dir, executor.Env)
+	}
+
+	if err := sm.RunTarget(context.Background(), types.RunOptions{}, nil); err == nil {
+		t.Error("RunTarget() should fail without a target")
+	}
+	executor.IsUVAvailableFunc = func() bool { return false }
+	if err := sm.RunTarget(context.Background(), options, nil); err == nil {
+		t.Error("RunTarget() should fail when UV is not available")
+	}
+}
+
+func TestScriptManager_ScriptMetadata(t *testing.T) {
+	root := t.TempDir()
+	script := "# /// script\n# requires-python = \">=3.12\"\n# dependencies = [\"rich>=13\"]\n# ///\n"
+	if err := os.MkdirAll(filepath.Join(root, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "scripts", "report.py"), []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sm := NewScriptManager(&mockCommandExecutor{})
+	sm.SetProjectRoot(root)
+	metadata, err := sm.ScriptMetadata(context.Background(), "scripts/report.py")
+	if err != nil {
+		t.Fatalf("ScriptMetadata() error = %v", err)
+	}
+	if metadata.Path != "scripts/report.py" || !metadata.HasBlock || metadata.RequiresPython != ">=3.12" || metadata.Locked {
+		t.Errorf("ScriptMetadata() = %+v", metadata)
+	}
+	if len(metadata.Dependencies) != 1 || metadata.Dependencies[0].Name != "rich" {
+		t.Errorf("ScriptMetadata() dependencies = %+v", metadata.Dependencies)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "scripts", "report.py.lock"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if metadata, err := sm.ScriptMetadata(context.Background(), "scripts/report.py"); err != nil || !metadata.Locked {
+		t.Errorf("ScriptMetadata() = %+v, %v, want a locked script", metadata, err)
+	}
+
+	if _, err := sm.ScriptMetadata(context.Background(), "missing.py"); err == nil {
+		t.Error("ScriptMetadata() should fail for a missing script")
+	}
+}
+
+func TestScriptManager_ScriptCommands(t *testing.T) {
+	var got [][]string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			got = append(got, args)
+			return nil, nil
+		},
+	}
+	sm := NewScriptManager(executor)
+	sm.SetProjectRoot("/project")
+	ctx := context.Background()
+
+	if err := sm.AddScriptDependency(ctx, "fetch.py", " httpx>=0.27 ", nil); err != nil {
+		t.Fatalf("AddScriptDependency() error = %v", err)
+	}
+	if err := sm.RemoveScriptDependency(ctx, "fetch.py", "httpx", nil); err != nil {
+		t.Fatalf("RemoveScriptDependency() error = %v", err)
+	}
+	if err := sm.LockScript(ctx, "fetch.py", nil); err != nil {
+		t.Fatalf("LockScript() error = %v", err)
+	}
+	expected := [][]string{
+		{"add", "--script", "fetch.py", "httpx>=0.27"},
+		{"remove", "--script", "fetch.py", "httpx"},
+		{"lock", "--script", "fetch.py"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ran uv %v, want %v", got, expected)
+	}
+	if executor.Dir != "/project" {
+		t.Errorf("ran in %q, want /project", executor.Dir)
+	}
+
+	if err := sm.AddScriptDependency(ctx, "fetch.py", " ", nil); err == nil {
+		t.Error("AddScriptDependency() should fail without a requirement")
+	}
+	executor.IsUVAvailableFunc = func() bool { return false }
+	if err := sm.LockScript(ctx, "fetch.py", nil); err == nil {
+		t.Error("LockScript() should fail when UV is not available")
+	}
+}
@@ -1,10 +1,19 @@
 package services
 
+import (
+	"context"
+	"os/exec"
+)
+
 // mockCommandExecutor is a mock implementation of the CommandExecutorInterface.
 type mockCommandExecutor struct {
 	IsUVAvailableFunc func() bool
 	ExecuteFunc       func(command string, args ...string) ([]byte, error)
+	ExecuteStreamFunc func(handler OutputHandler, command string, args ...string) ([]byte, error)
 	RunCommandFunc    func(command string, args ...string) ([]byte, error)
+	Dir               string
+	Env               []string
+	UV                string
 }
 
 func (m *mockCommandExecutor) IsUVAvailable() bool {
@@ -14,13 +23,54 @@ func (m *mockCommandExecutor) IsUVAvailable() bool {
 	return true
 }
 
-func (m *mockCommandExecutor) Execute(command string, args ...string) ([]byte, error) {
+func (m *mockCommandExecutor) Execute(_ context.Context, command string, args ...string) ([]byte, error) {
 	if m.ExecuteFunc != nil {
 		return m.ExecuteFunc(command, args...)
 	}
 	return nil, nil
 }
 
+// ExecuteStream falls back to ExecuteFunc so tests that only care about the
+// command line do not need to distinguish streaming calls.
+func (m *mockCommandExecutor) ExecuteStream(ctx context.Context, handler OutputHandler, command string, args ...string) ([]byte, error) {
+	if m.ExecuteStreamFunc != nil {
+		return m.ExecuteStreamFunc(handler, command, args...)
+	}
+	return m.Execute(ctx, command, args...)
+}
+
+// InDir records dir and returns the same mock, so expectations set on it still
+// apply to commands run in a project directory.
+func (m *mockCommandExecutor) InDir(dir string) CommandExecutorInterface {
+	m.Dir = dir
+	return m
+}
+
+// Command returns a command that records nothing; tests inspect its Path, Args
+// and Dir.
+func (m *mockCommandExecutor) Command(command string, args ...string) *exec.Cmd {
+	if m.UV != "" && command == "uv" {
+		command = m.UV
+	}
+	cmd := exec.Command(command, args...)
+	cmd.Dir = m.Dir
+	return cmd
+}
+
+// WithEnv records env and returns the same mock, like InDir.
+func (m *mockCommandExecutor) WithEnv(env []string) CommandExecutorInterface {
+	m.Env = env
+	return m
+}
+
+func (m *mockCommandExecutor) SetUVPath(path string) {
+	m.UV = path
+}
+
+func (m *mockCommandExecutor) UVPath() string {
+	return m.UV
+}
+
 func (m *mockCommandExecutor) RunCommand(command string, args ...string) ([]byte, error) {
 	if m.RunCommandFunc != nil {
 		return m.RunCommandFunc(command, args...)
@@ -0,0 +1,134 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+)
+
+// TaskManager implements the project task runner: the commands declared in
+// the [tool.uvui.tasks] table of pyproject.toml, run with uv run.
+type TaskManager struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewTaskManager creates a new task manager.
+func NewTaskManager(executor CommandExecutorInterface) *TaskManager {
+	return &TaskManager{executor: executor}
+}
+
+// ListTasks returns the tasks declared in pyproject.toml, sorted by name. A
+// directory without a pyproject.toml has no tasks.
+func (t *TaskManager) ListTasks(_ context.Context) ([]types.Task, error) {
+	root, err := t.absProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if os.IsNotExist(err) {
+		return []types.Task{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parsePyprojectTasks(data)
+}
+
+// RunTask runs a task after the tasks it depends on, each with uv run in the
+// project directory, and stops at the first failure. The tasks are read from
+// pyproject.toml again so edits apply without a refresh. Output is streamed
+// to output with a heading per task and kept in the task's result.
+func (t *TaskManager) RunTask(ctx context.Context, name string, output OutputHandler) ([]types.TaskResult, error) {
+	if !t.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+	tasks, err := t.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planTasks(tasks, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.TaskResult
+	for _, task := range plan {
+		result := t.runOne(ctx, task, output)
+		results = append(results, result)
+		if result.Error != nil {
+			return results, fmt.Errorf("task %s failed: %w", task.Name, result.Error)
+		}
+	}
+	return results, nil
+}
+
+// runOne runs a single task.
+func (t *TaskManager) runOne(ctx context.Context, task types.Task, output OutputHandler) types.TaskResult {
+	result := types.TaskResult{Task: task.Name, StartedAt: time.Now()}
+	args := append([]string{"run"}, task.RunArgs()...)
+	if output != nil {
+		output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("▶ %s: uv %s", task.Name, strings.Join(args, " "))})
+	}
+
+	executor := t.exec(t.executor)
+	if len(task.Env) > 0 {
+		executor = executor.WithEnv(task.Env)
+	}
+	_, result.Error = executor.ExecuteStream(ctx, func(line types.OutputLine) {
+		result.Log = append(result.Log, line)
+		if output != nil {
+			output(line)
+		}
+	}, "uv", args...)
+	result.Duration = time.Since(result.StartedAt)
+	return result
+}
+
+// planTasks returns the tasks to run for name in order: every dependency
+// before the tasks that need it, each task once.
+func planTasks(tasks []types.Task, name string) ([]types.Task, error) {
+	byName := make(map[string]types.Task, len(tasks))
+	for _, task := range tasks {
+		byName[task.Name] = task
+	}
+
+	var plan []types.Task
+	done := make(map[string]bool)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		for i, visiting := range path {
+			if visiting == name {
+				return fmt.Errorf("tasks depend on each other: %s", strings.Join(append(path[i:], name), " → "))
+			}
+		}
+		if done[name] {
+			return nil
+		}
+		task, ok := byName[name]
+		if !ok {
+			if len(path) == 0 {
+				return fmt.Errorf("task %q is not defined", name)
+			}
+			return fmt.Errorf("task %q depends on %q, which is not defined", path[len(path)-1], name)
+		}
+		for _, dependency := range task.Depends {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		done[name] = true
+		plan = append(plan, task)
+		return nil
+	}
+	if err := visit(name, nil); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
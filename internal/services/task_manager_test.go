@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+const sampleTasks = `[project]
+name = "demo"
+
+[tool.uvui.tasks]
+lint = "ruff check ."
+typecheck = { cmd = "mypy src", depends = ["lint"] }
+
+[tool.uvui.tasks.test]
+cmd = "pytest -q"
+depends = ["lint", "typecheck"]
+python = "3.12"
+env = { DEBUG = "1" }
+`
+
+func newTaskTestManager(t *testing.T, pyproject string, executor *mockCommandExecutor) *TaskManager {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tm := NewTaskManager(executor)
+	tm.SetProjectRoot(root)
+	return tm
+}
+
+func TestNewTaskManager(t *testing.T) {
+	if tm := NewTaskManager(&mockCommandExecutor{}); tm == nil {
+		t.Error("NewTaskManager() should not return nil")
+	}
+}
+
+func TestTaskManager_ListTasks(t *testing.T) {
+	tm := newTaskTestManager(t, sampleTasks, &mockCommandExecutor{})
+	tasks, err := tm.ListTasks(context.Background())
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	var names []string
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+	if expected := []string{"lint", "test", "typecheck"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("ListTasks() = %v, want %v", names, expected)
+	}
+
+	tm.SetProjectRoot(t.TempDir())
+	if tasks, err := tm.ListTasks(context.Background()); err != nil || len(tasks) != 0 {
+		t.Errorf("ListTasks() without pyproject.toml = %v, %v", tasks, err)
+	}
+}
+
+func TestTaskManager_RunTask(t *testing.T) {
+	var ran []string
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			ran = append(ran, strings.Join(args, " "))
+			handler(types.OutputLine{Stream: types.StreamStdout, Text: "output of " + args[len(args)-1]})
+			return nil, nil
+		},
+	}
+	tm := newTaskTestManager(t, sampleTasks, executor)
+
+	var lines []string
+	results, err := tm.RunTask(context.Background(), "test", func(line types.OutputLine) {
+		lines = append(lines, line.Text)
+	})
+	if err != nil {
+		t.Fatalf("RunTask() error = %v", err)
+	}
+	expected := []string{"run ruff check .", "run mypy src", "run --python 3.12 pytest -q"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Errorf("ran uv %q, want %q", ran, expected)
+	}
+	if len(results) != 3 || results[2].Task != "test" || len(results[2].Log) != 1 || results[2].Log[0].Text != "output of -q" {
+		t.Errorf("RunTask() results = %+v", results)
+	}
+	if len(lines) != 6 || lines[0] != "▶ lint: uv run ruff check ." {
+		t.Errorf("RunTask() output = %q", lines)
+	}
+	if !reflect.DeepEqual(executor.Env, []string{"DEBUG=1"}) {
+		t.Errorf("RunTask() env = %v, want DEBUG=1", executor.Env)
+	}
+}
+
+func TestTaskManager_RunTaskStopsAtFailure(t *testing.T) {
+	var ran []string
+	failure := errors.New("exit status 1")
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			ran = append(ran, args[1])
+			if args[1] == "mypy" {
+				return nil, failure
+			}
+			return nil, nil
+		},
+	}
+	tm := newTaskTestManager(t, sampleTasks, executor)
+
+	results, err := tm.RunTask(context.Background(), "test", nil)
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "typecheck") {
+		t.Errorf("RunTask() error = %v, want the typecheck failure", err)
+	}
+	if !reflect.DeepEqual(ran, []string{"ruff", "mypy"}) || len(results) != 2 || results[1].Error == nil {
+		t.Errorf("RunTask() ran %v with results %+v", ran, results)
+	}
+
+	executor.IsUVAvailableFunc = func() bool { return false }
+	if _, err := tm.RunTask(context.Background(), "lint", nil); err == nil {
+		t.Error("RunTask() should fail when UV is not available")
+	}
+}
+
+func TestPlanTasks(t *testing.T) {
+	tasks := []types.Task{
+		{Name: "a", Depends: []string{"b", "c"}},
+		{Name: "b", Depends: []string{"c"}},
+		{Name: "c"},
+		{Name: "loop", Depends: []string{"again"}},
+		{Name: "again", Depends: []string{"loop"}},
+		{Name: "broken", Depends: []string{"missing"}},
+	}
+
+	plan, err := planTasks(tasks, "a")
+	if err != nil {
+		t.Fatalf("planTasks() error = %v", err)
+	}
+	var names []string
+	for _, task := range plan {
+		names = append(names, task.Name)
+	}
+	if expected := []string{"c", "b", "a"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("planTasks() = %v, want %v", names, expected)
+	}
+
+	for name, want := range map[string]string{
+		"loop":    "loop → again → loop",
+		"broken":  `"broken" depends on "missing"`,
+		"unknown": `task "unknown" is not defined`,
+	} {
+		if _, err := planTasks(tasks, name); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("planTasks(%q) error = %v, want %q", name, err, want)
+		}
+	}
+}
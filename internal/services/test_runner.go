@@ -0,0 +1,156 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"uvui/internal/types"
+)
+
+// TestRunner implements running the project's tests with pytest through uv
+// run and reading their results from a JUnit XML report.
+type TestRunner struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewTestRunner creates a new test runner.
+func NewTestRunner(executor CommandExecutorInterface) *TestRunner {
+	return &TestRunner{executor: executor}
+}
+
+// RunTests runs pytest in the project directory, streaming its output, and
+// returns the parsed report. pytest exits with an error when tests fail, so a
+// report may come with an error; the report is nil only if pytest wrote none,
+// for instance because it is not installed.
+func (t *TestRunner) RunTests(ctx context.Context, options types.PytestOptions, output OutputHandler) (*types.TestReport, error) {
+	if !t.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+	root, err := t.absProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "uvui-junit-*.xml")
+	if err != nil {
+		return nil, err
+	}
+	path := file.Name()
+	_ = file.Close()
+	defer func() { _ = os.Remove(path) }()
+
+	args := append([]string{"run"}, options.PytestArgs(path)...)
+	_, runErr := t.exec(t.executor).ExecuteStream(ctx, output, "uv", args...)
+
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		if runErr != nil {
+			return nil, fmt.Errorf("failed to run pytest: %w", runErr)
+		}
+		return nil, fmt.Errorf("pytest wrote no report")
+	}
+	report, err := parseJUnitXML(data, func(rel string) bool {
+		info, err := os.Stat(filepath.Join(root, filepath.FromSlash(rel)))
+		return err == nil && !info.IsDir()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, runErr
+}
+
+// LoadMatrix returns the test matrix declared in pyproject.toml. Without one,
+// the matrix runs pytest; its Pythons are left empty unless declared.
+func (t *TestRunner) LoadMatrix(_ context.Context) (types.TestMatrix, error) {
+	root, err := t.absProjectRoot()
+	if err != nil {
+		return types.TestMatrix{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if os.IsNotExist(err) {
+		data, err = nil, nil
+	}
+	if err != nil {
+		return types.TestMatrix{}, err
+	}
+	return parsePyprojectMatrix(data)
+}
+
+// RunMatrix runs the matrix command with each of pythons, up to
+// matrix.Parallel at a time, and returns the results in the order of pythons.
+// Output is streamed to output with the Python in front of each line and kept
+// in the Python's result. Pythons still waiting when ctx is done are not run.
+func (t *TestRunner) RunMatrix(ctx context.Context, matrix types.TestMatrix, pythons []string, output OutputHandler) ([]types.MatrixResult, error) {
+	if !t.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+	if len(pythons) == 0 {
+		return nil, fmt.Errorf("no Python to run the matrix with")
+	}
+
+	parallel := matrix.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	executor := t.exec(t.executor)
+	slots := make(chan struct{}, parallel)
+	results := make([]types.MatrixResult, len(pythons))
+	var wg sync.WaitGroup
+	for i, python := range pythons {
+		wg.Add(1)
+		go func(i int, python string) {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				results[i] = runMatrixCell(ctx, executor, matrix, python, output)
+			case <-ctx.Done():
+				results[i] = types.MatrixResult{Python: python, Error: ctx.Err()}
+			}
+		}(i, python)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, result := range results {
+		if result.Error != nil {
+			failed = append(failed, result.Python)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("failed with Python %s", strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+// runMatrixCell runs the matrix command with a single Python.
+func runMatrixCell(ctx context.Context, executor CommandExecutorInterface, matrix types.TestMatrix, python string, output OutputHandler) types.MatrixResult {
+	result := types.MatrixResult{Python: python, StartedAt: time.Now()}
+	if ctx.Err() != nil {
+		result.Error = ctx.Err()
+		return result
+	}
+	args := append([]string{"run"}, matrix.RunArgs(python)...)
+	if output != nil {
+		output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("[%s] ▶ uv %s", python, strings.Join(args, " "))})
+	}
+
+	_, result.Error = executor.ExecuteStream(ctx, func(line types.OutputLine) {
+		result.Log = append(result.Log, line)
+		if output != nil {
+			output(types.OutputLine{Stream: line.Stream, Text: fmt.Sprintf("[%s] %s", python, line.Text)})
+		}
+	}, "uv", args...)
+	result.Duration = time.Since(result.StartedAt)
+	return result
+}
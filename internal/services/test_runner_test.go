@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func TestNewTestRunner(t *testing.T) {
+	if tr := NewTestRunner(&mockCommandExecutor{}); tr == nil {
+		t.Error("NewTestRunner() should not return nil")
+	}
+}
+
+func TestTestRunner_RunTests(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "tests"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "tests", "test_db.py"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	testsFailed := errors.New("exit status 1")
+	var got []string
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			got = args
+			report := strings.TrimPrefix(args[2], "--junitxml=")
+			return nil, errors.Join(os.WriteFile(report, []byte(sampleJUnit), 0o644), testsFailed)
+		},
+	}
+	tr := NewTestRunner(executor)
+	tr.SetProjectRoot(root)
+
+	options := types.PytestOptions{Tests: []string{"tests"}, Args: []string{"-x"}}
+	report, err := tr.RunTests(context.Background(), options, nil)
+	if !errors.Is(err, testsFailed) {
+		t.Errorf("RunTests() error = %v, want the pytest exit status", err)
+	}
+	if report == nil || len(report.Cases) != 4 || report.Cases[3].NodeID != "tests/test_db.py::test_query" {
+		t.Fatalf("RunTests() report = %+v", report)
+	}
+	if got[0] != "run" || got[1] != "pytest" || !reflect.DeepEqual(got[3:], []string{"-o", "junit_family=xunit1", "-x", "tests"}) {
+		t.Errorf("ran uv %v", got)
+	}
+	if executor.Dir != root {
+		t.Errorf("ran in %q, want %q", executor.Dir, root)
+	}
+	if _, err := os.Stat(strings.TrimPrefix(got[2], "--junitxml=")); !os.IsNotExist(err) {
+		t.Error("RunTests() should remove the report file")
+	}
+}
+
+func TestTestRunner_RunTestsWithoutReport(t *testing.T) {
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			return nil, errors.New("exit status 2")
+		},
+	}
+	tr := NewTestRunner(executor)
+	tr.SetProjectRoot(t.TempDir())
+	if report, err := tr.RunTests(context.Background(), types.PytestOptions{}, nil); report != nil || err == nil || !strings.Contains(err.Error(), "failed to run pytest") {
+		t.Errorf("RunTests() = %v, %v, want a failure without a report", report, err)
+	}
+
+	executor.ExecuteStreamFunc = func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+		return nil, nil
+	}
+	if _, err := tr.RunTests(context.Background(), types.PytestOptions{}, nil); err == nil {
+		t.Error("RunTests() should fail when pytest wrote no report")
+	}
+
+	executor.IsUVAvailableFunc = func() bool { return false }
+	if _, err := tr.RunTests(context.Background(), types.PytestOptions{}, nil); err == nil {
+		t.Error("RunTests() should fail when UV is not available")
+	}
+}
+
+func TestTestRunner_LoadMatrix(t *testing.T) {
+	root := t.TempDir()
+	tr := NewTestRunner(&mockCommandExecutor{})
+	tr.SetProjectRoot(root)
+
+	matrix, err := tr.LoadMatrix(context.Background())
+	if err != nil || !reflect.DeepEqual(matrix.Command, []string{"pytest"}) || len(matrix.Python) != 0 {
+		t.Errorf("LoadMatrix() without pyproject.toml = %+v, %v", matrix, err)
+	}
+
+	pyproject := "[tool.uvui.matrix]\ncmd = [\"pytest\", \"-q\"]\npython = [\"3.11\", \"3.12\"]\n"
+	if err := os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	matrix, err = tr.LoadMatrix(context.Background())
+	if err != nil || !reflect.DeepEqual(matrix.Python, []string{"3.11", "3.12"}) {
+		t.Errorf("LoadMatrix() = %+v, %v", matrix, err)
+	}
+}
+
+func TestTestRunner_RunMatrix(t *testing.T) {
+	var mu sync.Mutex
+	var running, most int
+	var lines []string
+	executor := &mockCommandExecutor{
+		ExecuteStreamFunc: func(handler OutputHandler, command string, args ...string) ([]byte, error) {
+			mu.Lock()
+			running++
+			most = max(most, running)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			if !reflect.DeepEqual(args[3:], []string{"--isolated", "pytest", "-q"}) {
+				t.Errorf("ran uv %v", args)
+			}
+			handler(types.OutputLine{Stream: types.StreamStdout, Text: "python " + args[2]})
+			if args[2] == "3.10" {
+				return nil, errors.New("exit status 1")
+			}
+			return nil, nil
+		},
+	}
+	tr := NewTestRunner(executor)
+	tr.SetProjectRoot(t.TempDir())
+
+	matrix := types.TestMatrix{Command: []string{"pytest", "-q"}, Parallel: 2}
+	output := func(line types.OutputLine) {
+		mu.Lock()
+		lines = append(lines, line.Text)
+		mu.Unlock()
+	}
+	results, err := tr.RunMatrix(context.Background(), matrix, []string{"3.10", "3.11", "3.12"}, output)
+	if err == nil || !strings.Contains(err.Error(), "failed with Python 3.10") {
+		t.Errorf("RunMatrix() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("RunMatrix() results = %+v", results)
+	}
+	for i, python := range []string{"3.10", "3.11", "3.12"} {
+		if results[i].Python != python || len(results[i].Log) != 1 || results[i].Log[0].Text != "python "+python {
+			t.Errorf("RunMatrix() result %d = %+v", i, results[i])
+		}
+	}
+	if results[0].Error == nil || results[1].Error != nil {
+		t.Errorf("RunMatrix() errors = %v, %v", results[0].Error, results[1].Error)
+	}
+	if most != 2 {
+		t.Errorf("RunMatrix() ran %d Pythons at once, want 2", most)
+	}
+	for _, want := range []string{"[3.11] ▶ uv run --python 3.11 --isolated pytest -q", "[3.12] python 3.12"} {
+		found := false
+		for _, line := range lines {
+			found = found || line == want
+		}
+		if !found {
+			t.Errorf("RunMatrix() output missing %q: %q", want, lines)
+		}
+	}
+}
+
+func TestTestRunner_RunMatrixCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tr := NewTestRunner(&mockCommandExecutor{})
+	results, err := tr.RunMatrix(ctx, types.TestMatrix{Command: []string{"pytest"}, Parallel: 1}, []string{"3.11", "3.12"}, nil)
+	if !errors.Is(err, context.Canceled) || len(results) != 2 || !errors.Is(results[1].Error, context.Canceled) {
+		t.Errorf("RunMatrix() cancelled = %+v, %v", results, err)
+	}
+
+	if _, err := tr.RunMatrix(context.Background(), types.TestMatrix{}, nil, nil); err == nil {
+		t.Error("RunMatrix() without Pythons should fail")
+	}
+}
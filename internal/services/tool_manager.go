@@ -0,0 +1,190 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+var (
+	// toolLinePattern matches a tool in `uv tool list` output, for example
+	// "ruff v0.5.0 [required: >=0.5] [with: rich] (/home/u/.local/share/uv/tools/ruff)".
+	toolLinePattern = regexp.MustCompile(`^(\S+) v(\S+)(.*)$`)
+	// toolAnnotationPattern matches a "[key: value]" annotation of a tool.
+	toolAnnotationPattern = regexp.MustCompile(`\[(\w+): ([^\]]*)\]`)
+	// toolPathPattern matches the trailing "(path)" of a tool or entry point.
+	toolPathPattern = regexp.MustCompile(`\(([^()]*)\)\s*$`)
+)
+
+// ToolManager implements tool management with uv tool.
+type ToolManager struct {
+	projectRoot
+	executor CommandExecutorInterface
+}
+
+// NewToolManager creates a new tool manager.
+func NewToolManager(executor CommandExecutorInterface) *ToolManager {
+	return &ToolManager{executor: executor}
+}
+
+// ListTools returns the installed tools with their entry points.
+func (t *ToolManager) ListTools(ctx context.Context) ([]types.Tool, error) {
+	if !t.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+
+	output, err := t.exec(t.executor).Execute(ctx, "uv", "tool", "list", "--show-paths", "--show-version-specifiers", "--show-with")
+	if err != nil && ctx.Err() == nil &&
+		(rejectsFlag(err, "--show-version-specifiers") || rejectsFlag(err, "--show-with")) {
+		// uv releases before 0.5.x do not know the --show-* flags besides
+		// --show-paths.
+		output, err = t.exec(t.executor).Execute(ctx, "uv", "tool", "list", "--show-paths")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+	return parseToolList(string(output)), nil
+}
+
+// BinDir returns the directory uv installs tool executables into.
+func (t *ToolManager) BinDir(ctx context.Context) (string, error) {
+	if !t.executor.IsUVAvailable() {
+		return "", fmt.Errorf("UV is not available")
+	}
+
+	output, err := t.exec(t.executor).Execute(ctx, "uv", "tool", "dir", "--bin")
+	if err != nil {
+		return "", fmt.Errorf("failed to find the tool directory: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// InstallTool installs a tool with uv tool install.
+func (t *ToolManager) InstallTool(ctx context.Context, options types.ToolInstallOptions, output OutputHandler) error {
+	if !t.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	if strings.TrimSpace(options.Package) == "" {
+		return fmt.Errorf("no package to install")
+	}
+
+	args := append([]string{"tool", "install"}, options.InstallArgs()...)
+	_, err := t.exec(t.executor).ExecuteStream(ctx, output, "uv", args...)
+	return err
+}
+
+// UpgradeTool upgrades a tool, or every tool if name is empty.
+func (t *ToolManager) UpgradeTool(ctx context.Context, name string, output OutputHandler) error {
+	if !t.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+
+	args := []string{"tool", "upgrade", "--all"}
+	if name != "" {
+		args = []string{"tool", "upgrade", name}
+	}
+	_, err := t.exec(t.executor).ExecuteStream(ctx, output, "uv", args...)
+	return err
+}
+
+// UninstallTool uninstalls a tool.
+func (t *ToolManager) UninstallTool(ctx context.Context, name string, output OutputHandler) error {
+	if !t.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+
+	_, err := t.exec(t.executor).ExecuteStream(ctx, output, "uv", "tool", "uninstall", name)
+	return err
+}
+
+// UpdateShell adds the tool directory to PATH in the shell configuration
+// files with uv tool update-shell.
+func (t *ToolManager) UpdateShell(ctx context.Context, output OutputHandler) error {
+	if !t.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+
+	_, err := t.exec(t.executor).ExecuteStream(ctx, output, "uv", "tool", "update-shell")
+	return err
+}
+
+// parseToolList parses the output of uv tool list. Tools are listed on lines
+// of their own, followed by their entry points as "- name (path)" lines.
+func parseToolList(output string) []types.Tool {
+	var tools []types.Tool
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "- "); ok {
+			if len(tools) == 0 {
+				continue
+			}
+			entry := types.ToolEntryPoint{Name: strings.TrimSpace(rest)}
+			if match := toolPathPattern.FindStringSubmatchIndex(rest); match != nil {
+				entry.Name = strings.TrimSpace(rest[:match[0]])
+				entry.Path = rest[match[2]:match[3]]
+			}
+			current := &tools[len(tools)-1]
+			current.EntryPoints = append(current.EntryPoints, entry)
+			continue
+		}
+
+		match := toolLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		tool := types.Tool{Name: match[1], Version: match[2]}
+		annotations := match[3]
+		if path := toolPathPattern.FindStringSubmatch(annotations); path != nil {
+			tool.Path = path[1]
+		}
+		for _, annotation := range toolAnnotationPattern.FindAllStringSubmatch(annotations, -1) {
+			switch annotation[1] {
+			case "required":
+				tool.Specifier = strings.TrimSpace(annotation[2])
+			case "with":
+				tool.With = splitPackageNames(annotation[2])
+			case "extras":
+				tool.Extras = splitPackageNames(annotation[2])
+			}
+		}
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// RunTool runs a tool with uv tool run in the project directory, streaming
+// its output.
+func (t *ToolManager) RunTool(ctx context.Context, options types.ToolRunOptions, output OutputHandler) error {
+	if !t.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+	if strings.TrimSpace(options.Package) == "" {
+		return fmt.Errorf("no tool to run")
+	}
+
+	if _, err := t.exec(t.executor).ExecuteStream(ctx, output, "uv", append([]string{"tool", "run"}, options.RunArgs()...)...); err != nil {
+		return fmt.Errorf("failed to run %s: %w", options.Executable(), err)
+	}
+	return nil
+}
+
+// ToolCommand returns the uv tool run command for a tool that needs the
+// terminal. It runs in the project directory.
+func (t *ToolManager) ToolCommand(options types.ToolRunOptions) (*exec.Cmd, error) {
+	if !t.executor.IsUVAvailable() {
+		return nil, fmt.Errorf("UV is not available")
+	}
+	if strings.TrimSpace(options.Package) == "" {
+		return nil, fmt.Errorf("no tool to run")
+	}
+	return t.exec(t.executor).Command("uv", append([]string{"tool", "run"}, options.RunArgs()...)...), nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+const testToolListOutput = `black v24.2.0 [required: >=24] (/home/u/.local/share/uv/tools/black)
+- black (/home/u/.local/bin/black)
+- blackd (/home/u/.local/bin/blackd)
+ruff v0.5.0 [with: rich, httpx] (/home/u/.local/share/uv/tools/ruff)
+- ruff (/home/u/.local/bin/ruff)
+`
+
+func TestNewToolManager(t *testing.T) {
+	if tm := NewToolManager(&mockCommandExecutor{}); tm == nil {
+		t.Error("NewToolManager() should not return nil")
+	}
+}
+
+func TestParseToolList(t *testing.T) {
+	tools := parseToolList(testToolListOutput)
+	expected := []types.Tool{
+		{
+			Name:      "black",
+			Version:   "24.2.0",
+			Specifier: ">=24",
+			Path:      "/home/u/.local/share/uv/tools/black",
+			EntryPoints: []types.ToolEntryPoint{
+				{Name: "black", Path: "/home/u/.local/bin/black"},
+				{Name: "blackd", Path: "/home/u/.local/bin/blackd"},
+			},
+		},
+		{
+			Name:        "ruff",
+			Version:     "0.5.0",
+			With:        []string{"rich", "httpx"},
+			Path:        "/home/u/.local/share/uv/tools/ruff",
+			EntryPoints: []types.ToolEntryPoint{{Name: "ruff", Path: "/home/u/.local/bin/ruff"}},
+		},
+	}
+	if !reflect.DeepEqual(tools, expected) {
+		t.Errorf("parseToolList() = %+v, want %+v", tools, expected)
+	}
+
+	// Without --show-paths entry points have no path.
+	tools = parseToolList("httpie v3.2.2\n- http\n- https\n")
+	if len(tools) != 1 || len(tools[0].EntryPoints) != 2 || tools[0].EntryPoints[1] != (types.ToolEntryPoint{Name: "https"}) {
+		t.Errorf("parseToolList() without paths = %+v", tools)
+	}
+
+	if tools := parseToolList(""); tools != nil {
+		t.Errorf("parseToolList(\"\") = %+v, want none", tools)
+	}
+}
+
+func TestListTools_FallsBackWithoutShowFlags(t *testing.T) {
+	var calls [][]string
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			calls = append(calls, args)
+			if len(args) > 3 {
+				return nil, fmt.Errorf("error: unexpected argument '--show-version-specifiers' found")
+			}
+			return []byte(testToolListOutput), nil
+		},
+	}
+	tm := NewToolManager(executor)
+
+	tools, err := tm.ListTools(context.Background())
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(tools) != 2 || len(calls) != 2 {
+		t.Errorf("ListTools() = %+v after %v", tools, calls)
+	}
+	if want := []string{"tool", "list", "--show-paths"}; !reflect.DeepEqual(calls[1], want) {
+		t.Errorf("ListTools() fallback ran uv %v, want %v", calls[1], want)
+	}
+}
+
+func TestListTools_ReportsOtherErrors(t *testing.T) {
+	calls := 0
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			calls++
+			return nil, fmt.Errorf("failed to read receipt for tool `ruff`")
+		},
+	}
+	tm := NewToolManager(executor)
+
+	_, err := tm.ListTools(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "receipt") {
+		t.Errorf("ListTools() error = %v, want the receipt error", err)
+	}
+	if calls != 1 {
+		t.Errorf("ListTools() ran uv %d times, want 1", calls)
+	}
+}
+
+func TestToolManager_Commands(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(tm *ToolManager) error
+		expected []string
+	}{
+		{"install", func(tm *ToolManager) error {
+			return tm.InstallTool(context.Background(), types.ToolInstallOptions{Package: "ruff>=0.5"}, nil)
+		}, []string{"tool", "install", "ruff>=0.5"}},
+		{"install with options", func(tm *ToolManager) error {
+			options := types.ToolInstallOptions{Package: "./tools/lint", With: []string{"rich", "httpx"}, PythonVersion: "3.12", Editable: true, Force: true}
+			return tm.InstallTool(context.Background(), options, nil)
+		}, []string{"tool", "install", "./tools/lint", "--with", "rich", "--with", "httpx", "--python", "3.12", "--editable", "--force"}},
+		{"upgrade", func(tm *ToolManager) error {
+			return tm.UpgradeTool(context.Background(), "ruff", nil)
+		}, []string{"tool", "upgrade", "ruff"}},
+		{"upgrade all", func(tm *ToolManager) error {
+			return tm.UpgradeTool(context.Background(), "", nil)
+		}, []string{"tool", "upgrade", "--all"}},
+		{"uninstall", func(tm *ToolManager) error {
+			return tm.UninstallTool(context.Background(), "ruff", nil)
+		}, []string{"tool", "uninstall", "ruff"}},
+		{"update shell", func(tm *ToolManager) error {
+			return tm.UpdateShell(context.Background(), nil)
+		}, []string{"tool", "update-shell"}},
+		{"run", func(tm *ToolManager) error {
+			return tm.RunTool(context.Background(), types.ToolRunOptions{Package: "ruff@0.6.9", Args: []string{"check", "."}}, nil)
+		}, []string{"tool", "run", "ruff@0.6.9", "check", "."}},
+		{"run from package", func(tm *ToolManager) error {
+			options := types.ToolRunOptions{Package: "httpie", Command: "http", With: []string{"rich"}, PythonVersion: "3.12", Args: []string{"GET", "example.org"}}
+			return tm.RunTool(context.Background(), options, nil)
+		}, []string{"tool", "run", "--from", "httpie", "--with", "rich", "--python", "3.12", "http", "GET", "example.org"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			executor := &mockCommandExecutor{
+				ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+					got = args
+					return nil, nil
+				},
+			}
+			tm := NewToolManager(executor)
+			tm.SetProjectRoot("/work/project")
+			if err := tt.run(tm); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ran uv %v, want %v", got, tt.expected)
+			}
+			// Local paths such as ./tools/lint are relative to the project.
+			if executor.Dir != "/work/project" {
+				t.Errorf("ran uv in %q, want the project root", executor.Dir)
+			}
+		})
+	}
+}
+
+func TestToolManager_Errors(t *testing.T) {
+	executor := &mockCommandExecutor{IsUVAvailableFunc: func() bool { return false }}
+	tm := NewToolManager(executor)
+
+	if _, err := tm.ListTools(context.Background()); err == nil {
+		t.Error("ListTools() should fail when UV is not available")
+	}
+	if err := tm.UpdateShell(context.Background(), nil); err == nil {
+		t.Error("UpdateShell() should fail when UV is not available")
+	}
+
+	if _, err := tm.ToolCommand(types.ToolRunOptions{Package: "ruff"}); err == nil {
+		t.Error("ToolCommand() should fail when UV is not available")
+	}
+
+	executor.IsUVAvailableFunc = nil
+	if err := tm.InstallTool(context.Background(), types.ToolInstallOptions{Package: " "}, nil); err == nil {
+		t.Error("InstallTool() should fail without a package")
+	}
+	if err := tm.RunTool(context.Background(), types.ToolRunOptions{}, nil); err == nil {
+		t.Error("RunTool() should fail without a package")
+	}
+}
+
+func TestToolCommand(t *testing.T) {
+	executor := &mockCommandExecutor{UV: "/opt/uv"}
+	tm := NewToolManager(executor)
+	tm.SetProjectRoot("/project")
+
+	cmd, err := tm.ToolCommand(types.ToolRunOptions{Package: "ipython", With: []string{"numpy"}, Interactive: true})
+	if err != nil {
+		t.Fatalf("ToolCommand() error = %v", err)
+	}
+	if cmd.Path != "/opt/uv" || cmd.Dir != "/project" {
+		t.Errorf("ToolCommand() runs %s in %q, want /opt/uv in /project", cmd.Path, cmd.Dir)
+	}
+	if want := []string{"/opt/uv", "tool", "run", "--with", "numpy", "ipython"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("ToolCommand() args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestBinDirAndDirOnPath(t *testing.T) {
+	dir := t.TempDir()
+	executor := &mockCommandExecutor{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			return []byte(dir + "\n"), nil
+		},
+	}
+	binDir, err := NewToolManager(executor).BinDir(context.Background())
+	if err != nil || binDir != dir {
+		t.Fatalf("BinDir() = %q, %v, want %q", binDir, err, dir)
+	}
+
+	t.Setenv("PATH", strings.Join([]string{"/usr/bin", dir + string(filepath.Separator)}, string(filepath.ListSeparator)))
+	if !DirOnPath(dir) {
+		t.Error("DirOnPath() = false for a directory on PATH")
+	}
+	t.Setenv("PATH", "/usr/bin")
+	if DirOnPath(dir) {
+		t.Error("DirOnPath() = true for a directory not on PATH")
+	}
+}
@@ -0,0 +1,241 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+// uvArchiveBinaries are the executables installed from a uv release archive.
+var uvArchiveBinaries = []string{"uv", "uvx", "uvw"}
+
+// installArchive downloads a uv release archive, verifies its SHA-256
+// checksum and extracts the uv executables into dir.
+func installArchive(ctx context.Context, archive, checksum, dir string, output OutputHandler) error {
+	if checksum == "" {
+		output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("Fetching checksum %s.sha256", archive)})
+		data, err := readLocation(ctx, archive+".sha256")
+		if err != nil {
+			return fmt.Errorf("failed to fetch archive checksum: %w", err)
+		}
+		checksum = parseChecksum(string(data))
+	}
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if checksum == "" {
+		return fmt.Errorf("no checksum available for %s", archive)
+	}
+
+	output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("Downloading %s", archive)})
+	file, err := os.CreateTemp("", "uv-archive-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	sum, err := copyLocation(ctx, archive, file)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	if sum != checksum {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archive, checksum, sum)
+	}
+	output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("Verified sha256 %s", sum)})
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	var installed []string
+	if strings.HasSuffix(strings.ToLower(archive), ".zip") {
+		installed, err = extractZip(file, dir)
+	} else {
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		installed, err = extractTarGz(file, dir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract archive: %w", err)
+	}
+	if len(installed) == 0 {
+		return fmt.Errorf("archive %s does not contain a uv binary", archive)
+	}
+
+	for _, name := range installed {
+		output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("Extracted %s", filepath.Join(dir, name))})
+	}
+	return nil
+}
+
+// parseChecksum returns the hash from a checksum file in "<hash>  <file>" or
+// bare "<hash>" form.
+func parseChecksum(data string) string {
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// readLocation reads a URL or local file.
+func readLocation(ctx context.Context, location string) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := copyLocationTo(ctx, location, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// copyLocation copies a URL or local file to w and returns its SHA-256 as hex.
+func copyLocation(ctx context.Context, location string, w io.Writer) (string, error) {
+	hash := sha256.New()
+	if _, err := copyLocationTo(ctx, location, io.MultiWriter(w, hash)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// copyLocationTo copies the contents of a URL or local file to w.
+func copyLocationTo(ctx context.Context, location string, w io.Writer) (int64, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		file, err := os.Open(strings.TrimPrefix(location, "file://"))
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = file.Close() }()
+		return io.Copy(w, file)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("GET %s: %s", location, resp.Status)
+	}
+	return io.Copy(w, resp.Body)
+}
+
+// extractTarGz extracts the uv executables from a .tar.gz archive into dir
+// and returns their file names.
+func extractTarGz(r io.Reader, dir string) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+
+	var installed []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return installed, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name, ok := archiveBinaryName(header.Name)
+		if !ok {
+			continue
+		}
+		if err := writeExecutable(filepath.Join(dir, name), tr); err != nil {
+			return nil, err
+		}
+		installed = append(installed, name)
+	}
+}
+
+// extractZip extracts the uv executables from a .zip archive into dir and
+// returns their file names.
+func extractZip(file *os.File, dir string) ([]string, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	var installed []string
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		name, ok := archiveBinaryName(entry.Name)
+		if !ok {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = writeExecutable(filepath.Join(dir, name), rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		installed = append(installed, name)
+	}
+	return installed, nil
+}
+
+// archiveBinaryName returns the base name of an archive entry if it is one of
+// the uv executables.
+func archiveBinaryName(entry string) (string, bool) {
+	name := path.Base(strings.ReplaceAll(entry, "\\", "/"))
+	for _, binary := range uvArchiveBinaries {
+		if name == binary || name == binary+".exe" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// writeExecutable atomically replaces target with the contents of r.
+func writeExecutable(target string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
@@ -2,14 +2,30 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+
+	"uvui/internal/types"
+)
+
+// Default locations of the official uv installer scripts.
+const (
+	defaultInstallScriptURL   = "https://astral.sh/uv/install.sh"
+	defaultInstallScriptURLPS = "https://astral.sh/uv/install.ps1"
 )
 
+// managedMarker is written next to the uv binaries that uvui installed, so
+// that only those are ever uninstalled by uvui.
+const managedMarker = ".uvui-managed"
+
 // UVInstaller implements UV installation functionality.
 type UVInstaller struct {
 	executor CommandExecutorInterface
+	options  types.UVInstallOptions
 }
 
 // NewUVInstaller creates a new UV installer.
@@ -17,13 +33,18 @@ func NewUVInstaller(executor CommandExecutorInterface) *UVInstaller {
 	return &UVInstaller{executor: executor}
 }
 
+// SetInstallOptions configures where and from what UV is installed.
+func (u *UVInstaller) SetInstallOptions(options types.UVInstallOptions) {
+	u.options = options
+}
+
 // IsInstalled checks if UV is installed and returns version info.
-func (u *UVInstaller) IsInstalled() (bool, string, error) {
+func (u *UVInstaller) IsInstalled(ctx context.Context) (bool, string, error) {
 	if !u.executor.IsUVAvailable() {
 		return false, "", nil
 	}
 
-	output, err := u.executor.Execute("uv", "--version")
+	output, err := u.executor.Execute(ctx, "uv", "--version")
 	if err != nil {
 		return false, "", err
 	}
@@ -32,26 +53,223 @@ func (u *UVInstaller) IsInstalled() (bool, string, error) {
 	return true, version, nil
 }
 
-// Install installs UV based on the detected OS.
-func (u *UVInstaller) Install() error {
-	// This would execute the appropriate installation command
-	// Implementation depends on OS detection
-	return nil
+// Install installs UV, either from the configured release archive or by
+// running the official installer script, and reports the installed binary.
+func (u *UVInstaller) Install(ctx context.Context, output OutputHandler) (types.UVStatus, error) {
+	if output == nil {
+		output = func(types.OutputLine) {}
+	}
+
+	dir := u.options.InstallDir
+	if u.options.Archive != "" {
+		if dir == "" {
+			dir = defaultUVInstallDir()
+		}
+		if err := installArchive(ctx, u.options.Archive, u.options.Checksum, dir, output); err != nil {
+			return types.UVStatus{}, err
+		}
+	} else {
+		command, args, err := u.installCommand()
+		if err != nil {
+			return types.UVStatus{}, err
+		}
+		if _, err := u.executor.ExecuteStream(ctx, output, command, args...); err != nil {
+			return types.UVStatus{}, fmt.Errorf("uv installer failed: %w", err)
+		}
+		if dir == "" {
+			dir = defaultUVInstallDir()
+		}
+	}
+
+	status, err := u.detect(ctx, dir, output)
+	if err != nil {
+		return types.UVStatus{}, err
+	}
+	if filepath.Dir(status.Path) == filepath.Clean(dir) {
+		if err := os.WriteFile(filepath.Join(dir, managedMarker), []byte(status.Version+"\n"), 0o644); err != nil {
+			return types.UVStatus{}, err
+		}
+		status.Managed = true
+	}
+	return status, nil
+}
+
+// SelfUpdate runs `uv self update`, to version if given or else to the latest
+// release.
+func (u *UVInstaller) SelfUpdate(ctx context.Context, version string, output OutputHandler) error {
+	if !u.executor.IsUVAvailable() {
+		return fmt.Errorf("UV is not available")
+	}
+
+	args := []string{"self", "update"}
+	if version != "" {
+		args = append(args, version)
+	}
+	_, err := u.executor.ExecuteStream(ctx, output, "uv", args...)
+	return err
+}
+
+// ManagedPath returns the uv binary installed by uvui, if there is one.
+func (u *UVInstaller) ManagedPath() (string, bool) {
+	dir := u.options.InstallDir
+	if dir == "" {
+		dir = defaultUVInstallDir()
+	}
+	path := filepath.Join(dir, uvBinaryName("uv"))
+	if dir == "" || !fileExists(filepath.Join(dir, managedMarker)) || !fileExists(path) {
+		return "", false
+	}
+	return path, true
+}
+
+// Uninstall removes the uv binaries installed by uvui. A uv installed by other
+// means is left alone.
+func (u *UVInstaller) Uninstall(_ context.Context, output OutputHandler) error {
+	if output == nil {
+		output = func(types.OutputLine) {}
+	}
+
+	path, ok := u.ManagedPath()
+	if !ok {
+		return fmt.Errorf("no uv installed by uvui was found")
+	}
+
+	dir := filepath.Dir(path)
+	for _, name := range uvArchiveBinaries {
+		binary := filepath.Join(dir, uvBinaryName(name))
+		if err := os.Remove(binary); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("Removed %s", binary)})
+	}
+	return os.Remove(filepath.Join(dir, managedMarker))
+}
+
+// detect finds the installed uv binary, preferring the install directory
+// over PATH, and reads its version.
+func (u *UVInstaller) detect(ctx context.Context, dir string, output OutputHandler) (types.UVStatus, error) {
+	path := filepath.Join(dir, uvBinaryName("uv"))
+	if !fileExists(path) {
+		found, err := exec.LookPath("uv")
+		if err != nil {
+			return types.UVStatus{}, fmt.Errorf("uv binary not found in %s or on PATH", dir)
+		}
+		path = found
+	}
+
+	version, err := u.executor.Execute(ctx, path, "--version")
+	if err != nil {
+		return types.UVStatus{}, fmt.Errorf("failed to run installed uv: %w", err)
+	}
+
+	status := types.UVStatus{
+		Installed: true,
+		Version:   strings.TrimSpace(string(version)),
+		Path:      path,
+	}
+	output(types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("Installed %s at %s", status.Version, path)})
+	if !DirOnPath(filepath.Dir(path)) {
+		output(types.OutputLine{Stream: types.StreamStderr, Text: fmt.Sprintf("warning: %s is not on PATH", filepath.Dir(path))})
+	}
+	return status, nil
 }
 
 // GetInstallCommand returns the installation command for the current OS.
 func (u *UVInstaller) GetInstallCommand() (string, error) {
+	command, args, err := u.installCommand()
+	if err != nil {
+		return "", err
+	}
+	script := args[len(args)-1]
+	if command == "powershell" {
+		return fmt.Sprintf("powershell -ExecutionPolicy ByPass -c %q", script), nil
+	}
+	return script, nil
+}
+
+// installCommand returns the command and arguments that run the installer
+// script for the current OS.
+func (u *UVInstaller) installCommand() (string, []string, error) {
+	dir := u.options.InstallDir
+
 	// Detect OS and return appropriate command
 	switch getOS() {
 	case "windows":
-		return "powershell -ExecutionPolicy ByPass -c \"irm https://astral.sh/uv/install.ps1 | iex\"", nil
+		url := u.options.ScriptURL
+		if url == "" {
+			url = defaultInstallScriptURLPS
+		}
+		script := fmt.Sprintf("irm %s | iex", url)
+		if dir != "" {
+			script = fmt.Sprintf("$env:UV_INSTALL_DIR=%s; %s", powershellQuote(dir), script)
+		}
+		return "powershell", []string{"-ExecutionPolicy", "ByPass", "-c", script}, nil
 	case "macos", "linux":
-		return "curl -LsSf https://astral.sh/uv/install.sh | sh", nil
+		url := u.options.ScriptURL
+		if url == "" {
+			url = defaultInstallScriptURL
+		}
+		script := fmt.Sprintf("curl -LsSf %s | sh", url)
+		if dir != "" {
+			script = fmt.Sprintf("curl -LsSf %s | env UV_INSTALL_DIR=%s sh", url, shellQuote(dir))
+		}
+		return "sh", []string{"-c", script}, nil
 	default:
-		return "", fmt.Errorf("unsupported operating system")
+		return "", nil, fmt.Errorf("unsupported operating system")
 	}
 }
 
+// defaultUVInstallDir returns the directory the official installer uses when
+// no install directory is configured.
+func defaultUVInstallDir() string {
+	if dir := os.Getenv("UV_INSTALL_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_BIN_HOME"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "bin")
+}
+
+// uvBinaryName returns the file name of a uv executable on the current OS.
+func uvBinaryName(name string) string {
+	if getOS() == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// DirOnPath reports whether dir is one of the directories in $PATH.
+func DirOnPath(dir string) bool {
+	if dir == "" {
+		return false
+	}
+	dir = filepath.Clean(dir)
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry != "" && filepath.Clean(entry) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// powershellQuote quotes s as a PowerShell string literal.
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
 // getOS detects the current operating system.
 func getOS() string {
 	switch {
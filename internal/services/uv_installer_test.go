@@ -1,8 +1,24 @@
 package services
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+
+	"uvui/internal/types"
 )
 
 func TestNewUVInstaller(t *testing.T) {
@@ -21,7 +37,7 @@ func TestUVInstaller_IsInstalled_NotInstalled(t *testing.T) {
 	}
 	installer := NewUVInstaller(executor)
 
-	installed, version, err := installer.IsInstalled()
+	installed, version, err := installer.IsInstalled(context.Background())
 	if err != nil {
 		t.Errorf("IsInstalled() error = %v, wantErr %v", err, false)
 	}
@@ -49,7 +65,7 @@ func TestUVInstaller_IsInstalled_Installed(t *testing.T) {
 	}
 	installer := NewUVInstaller(executor)
 
-	installed, version, err := installer.IsInstalled()
+	installed, version, err := installer.IsInstalled(context.Background())
 	if err != nil {
 		t.Errorf("IsInstalled() error = %v, wantErr %v", err, false)
 	}
@@ -63,13 +79,179 @@ func TestUVInstaller_IsInstalled_Installed(t *testing.T) {
 	}
 }
 
-func TestUVInstaller_Install(t *testing.T) {
-	executor := &mockCommandExecutor{}
-	installer := NewUVInstaller(executor)
+// fakeUV is a stand-in uv executable that reports a version.
+const fakeUV = "#!/bin/sh\necho 'uv 0.5.0 (abc123 2024-11-20)'\n"
+
+// uvTarGz builds a uv release archive laid out like the official ones.
+func uvTarGz(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"uv-x86_64-unknown-linux-gnu/uv", "uv-x86_64-unknown-linux-gnu/uvx", "uv-x86_64-unknown-linux-gnu/README"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(fakeUV)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(fakeUV)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// serveFiles serves the given path -> content map over HTTP.
+func serveFiles(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestUVInstaller_Install_Archive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv binary is a shell script")
+	}
+	archive := uvTarGz(t)
+	server := serveFiles(t, map[string][]byte{
+		"/uv.tar.gz":        archive,
+		"/uv.tar.gz.sha256": []byte(sha256Hex(archive) + "  uv-x86_64-unknown-linux-gnu.tar.gz\n"),
+	})
+	dir := filepath.Join(t.TempDir(), "bin")
+
+	installer := NewUVInstaller(NewCommandExecutor())
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: dir, Archive: server.URL + "/uv.tar.gz"})
+
+	var lines []string
+	status, err := installer.Install(context.Background(), func(line types.OutputLine) {
+		lines = append(lines, line.Text)
+	})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	if status.Path != filepath.Join(dir, "uv") {
+		t.Errorf("Install() path = %q, want %q", status.Path, filepath.Join(dir, "uv"))
+	}
+	if status.Version != "uv 0.5.0 (abc123 2024-11-20)" {
+		t.Errorf("Install() version = %q", status.Version)
+	}
+	if !fileExists(filepath.Join(dir, "uvx")) {
+		t.Error("Install() did not extract uvx")
+	}
+	if fileExists(filepath.Join(dir, "README")) {
+		t.Error("Install() extracted a file that is not a uv binary")
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), "Verified sha256 "+sha256Hex(archive)) {
+		t.Errorf("Install() output missing checksum verification: %v", lines)
+	}
+}
+
+func TestUVInstaller_Install_ChecksumMismatch(t *testing.T) {
+	archive := uvTarGz(t)
+	server := serveFiles(t, map[string][]byte{"/uv.tar.gz": archive})
+	dir := t.TempDir()
+
+	installer := NewUVInstaller(NewCommandExecutor())
+	installer.SetInstallOptions(types.UVInstallOptions{
+		InstallDir: dir,
+		Archive:    server.URL + "/uv.tar.gz",
+		Checksum:   strings.Repeat("0", 64),
+	})
+
+	_, err := installer.Install(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Install() error = %v, want checksum mismatch", err)
+	}
+	if fileExists(filepath.Join(dir, "uv")) {
+		t.Error("Install() installed uv despite a checksum mismatch")
+	}
+}
+
+func TestUVInstaller_Install_MissingChecksum(t *testing.T) {
+	server := serveFiles(t, map[string][]byte{"/uv.tar.gz": uvTarGz(t)})
+
+	installer := NewUVInstaller(NewCommandExecutor())
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: t.TempDir(), Archive: server.URL + "/uv.tar.gz"})
+
+	if _, err := installer.Install(context.Background(), nil); err == nil {
+		t.Error("Install() error = nil, want an error when no checksum is published")
+	}
+}
+
+func TestUVInstaller_Install_LocalZip(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv binary is a shell script")
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("uv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte(fakeUV))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(t.TempDir(), "uv.zip")
+	if err := os.WriteFile(archive, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	installer := NewUVInstaller(NewCommandExecutor())
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: dir, Archive: archive, Checksum: sha256Hex(buf.Bytes())})
+
+	status, err := installer.Install(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if status.Path != filepath.Join(dir, "uv") || !status.Installed {
+		t.Errorf("Install() status = %+v", status)
+	}
+}
+
+func TestUVInstaller_Install_Script(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil || runtime.GOOS == "windows" {
+		t.Skip("installer script needs curl and sh")
+	}
+	script := "#!/bin/sh\nmkdir -p \"$UV_INSTALL_DIR\"\nprintf '%s' \"$FAKE_UV\" > \"$UV_INSTALL_DIR/uv\"\nchmod +x \"$UV_INSTALL_DIR/uv\"\necho 'everything is installed!'\n"
+	server := serveFiles(t, map[string][]byte{"/install.sh": []byte(script)})
+	t.Setenv("FAKE_UV", fakeUV)
+	dir := filepath.Join(t.TempDir(), "uv bin")
+
+	installer := NewUVInstaller(NewCommandExecutor())
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: dir, ScriptURL: server.URL + "/install.sh"})
 
-	err := installer.Install()
+	var lines []string
+	status, err := installer.Install(context.Background(), func(line types.OutputLine) {
+		lines = append(lines, line.Text)
+	})
 	if err != nil {
-		t.Errorf("Install() error = %v, wantErr %v", err, false)
+		t.Fatalf("Install() error = %v", err)
+	}
+	if status.Path != filepath.Join(dir, "uv") || status.Version != "uv 0.5.0 (abc123 2024-11-20)" {
+		t.Errorf("Install() status = %+v", status)
+	}
+	if len(lines) == 0 || lines[0] != "everything is installed!" {
+		t.Errorf("Install() did not stream installer output: %v", lines)
 	}
 }
 
@@ -82,3 +264,117 @@ func TestUVInstaller_GetInstallCommand(t *testing.T) {
 		t.Errorf("GetInstallCommand() error = %v, wantErr %v", err, false)
 	}
 }
+
+func TestUVInstaller_GetInstallCommand_InstallDir(t *testing.T) {
+	if getOS() == "windows" {
+		t.Skip("POSIX installer command")
+	}
+	installer := NewUVInstaller(&mockCommandExecutor{})
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: "/opt/it's uv", ScriptURL: "http://localhost/install.sh"})
+
+	command, err := installer.GetInstallCommand()
+	if err != nil {
+		t.Fatalf("GetInstallCommand() error = %v", err)
+	}
+	want := `curl -LsSf http://localhost/install.sh | env UV_INSTALL_DIR='/opt/it'\''s uv' sh`
+	if command != want {
+		t.Errorf("GetInstallCommand() = %q, want %q", command, want)
+	}
+}
+
+func TestUVInstaller_SelfUpdate(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{"", []string{"self", "update"}},
+		{"0.5.4", []string{"self", "update", "0.5.4"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		executor := &mockCommandExecutor{
+			ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+				got = append([]string{}, args...)
+				return nil, nil
+			},
+		}
+		installer := NewUVInstaller(executor)
+
+		if err := installer.SelfUpdate(context.Background(), tt.version, nil); err != nil {
+			t.Errorf("SelfUpdate(%q) error = %v", tt.version, err)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("SelfUpdate(%q) args = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestUVInstaller_SelfUpdate_NotAvailable(t *testing.T) {
+	executor := &mockCommandExecutor{IsUVAvailableFunc: func() bool { return false }}
+	installer := NewUVInstaller(executor)
+
+	if err := installer.SelfUpdate(context.Background(), "", nil); err == nil {
+		t.Error("SelfUpdate() error = nil, want an error when uv is not available")
+	}
+}
+
+func TestUVInstaller_Uninstall_Managed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv binary is a shell script")
+	}
+	archive := uvTarGz(t)
+	dir := t.TempDir()
+	installer := NewUVInstaller(NewCommandExecutor())
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: dir, Archive: writeTemp(t, archive), Checksum: sha256Hex(archive)})
+
+	status, err := installer.Install(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if !status.Managed {
+		t.Error("Install() managed = false, want true")
+	}
+	if path, ok := installer.ManagedPath(); !ok || path != filepath.Join(dir, "uv") {
+		t.Errorf("ManagedPath() = %q, %v", path, ok)
+	}
+
+	if err := installer.Uninstall(context.Background(), nil); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	for _, name := range []string{"uv", "uvx", managedMarker} {
+		if fileExists(filepath.Join(dir, name)) {
+			t.Errorf("Uninstall() left %s behind", name)
+		}
+	}
+	if _, ok := installer.ManagedPath(); ok {
+		t.Error("ManagedPath() ok = true after uninstall")
+	}
+}
+
+func TestUVInstaller_Uninstall_NotManaged(t *testing.T) {
+	dir := t.TempDir()
+	uv := filepath.Join(dir, uvBinaryName("uv"))
+	if err := os.WriteFile(uv, []byte(fakeUV), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	installer := NewUVInstaller(&mockCommandExecutor{})
+	installer.SetInstallOptions(types.UVInstallOptions{InstallDir: dir})
+
+	if err := installer.Uninstall(context.Background(), nil); err == nil {
+		t.Error("Uninstall() error = nil, want an error for a uv not installed by uvui")
+	}
+	if !fileExists(uv) {
+		t.Error("Uninstall() removed a uv it did not install")
+	}
+}
+
+// writeTemp writes data to a temporary file and returns its path.
+func writeTemp(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "uv.tar.gz")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
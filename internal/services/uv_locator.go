@@ -0,0 +1,140 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"uvui/internal/types"
+)
+
+// UVLocator discovers the uv binaries available on this machine.
+type UVLocator struct {
+	projectRoot
+	executor CommandExecutorInterface
+	mu       sync.RWMutex
+	paths    []string
+	trusted  bool
+}
+
+// NewUVLocator creates a new uv locator.
+func NewUVLocator(executor CommandExecutorInterface) *UVLocator {
+	return &UVLocator{executor: executor}
+}
+
+// SetSearchPaths sets additional uv binaries to consider. Relative paths are
+// resolved against the project root.
+func (l *UVLocator) SetSearchPaths(paths []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.paths = append([]string{}, paths...)
+}
+
+// SetProjectTrusted sets whether the uv binaries inside the project may be run.
+// A checkout can ship any binary, so they are skipped until the user trusts
+// the project.
+func (l *UVLocator) SetProjectTrusted(trusted bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.trusted = trusted
+}
+
+// uvCandidate is a path that may hold a uv binary.
+type uvCandidate struct {
+	path   string
+	source string
+}
+
+// Discover returns every uv binary found in the configured paths, the project
+// (once trusted), PATH, ~/.local/bin and ~/.cargo/bin, in that order. A binary reachable
+// through several paths is listed once.
+func (l *UVLocator) Discover(ctx context.Context) ([]types.UVBinary, error) {
+	seen := make(map[string]bool)
+	var binaries []types.UVBinary
+
+	for _, candidate := range l.candidates() {
+		if !isExecutable(candidate.path) {
+			continue
+		}
+		key := candidate.path
+		if resolved, err := filepath.EvalSymlinks(candidate.path); err == nil {
+			key = resolved
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		binary := types.UVBinary{Path: candidate.path, Source: candidate.source}
+		output, err := l.executor.Execute(ctx, candidate.path, "--version")
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			binary.Error = err
+		} else {
+			binary.Version = strings.TrimSpace(string(output))
+			binary.Details, binary.Error = ParseUVVersion(binary.Version)
+		}
+		binaries = append(binaries, binary)
+	}
+
+	return binaries, nil
+}
+
+// candidates lists the paths to look for uv in, in order of preference.
+func (l *UVLocator) candidates() []uvCandidate {
+	name := uvBinaryName("uv")
+	root := l.ProjectRoot()
+	var candidates []uvCandidate
+
+	l.mu.RLock()
+	for _, path := range l.paths {
+		if !filepath.IsAbs(path) && root != "" {
+			path = filepath.Join(root, path)
+		}
+		candidates = append(candidates, uvCandidate{filepath.Clean(path), types.UVSourceConfigured})
+	}
+	trusted := l.trusted
+	l.mu.RUnlock()
+
+	if root != "" && trusted {
+		venvBin := filepath.Join(root, ".venv", "bin")
+		if runtime.GOOS == "windows" {
+			venvBin = filepath.Join(root, ".venv", "Scripts")
+		}
+		candidates = append(candidates,
+			uvCandidate{filepath.Join(venvBin, name), types.UVSourceProject},
+			uvCandidate{filepath.Join(root, "bin", name), types.UVSourceProject},
+		)
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		candidates = append(candidates, uvCandidate{filepath.Join(dir, name), types.UVSourcePath})
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates,
+			uvCandidate{filepath.Join(home, ".local", "bin", name), types.UVSourceLocal},
+			uvCandidate{filepath.Join(home, ".cargo", "bin", name), types.UVSourceCargo},
+		)
+	}
+
+	return candidates
+}
+
+// isExecutable reports whether path is a regular file that can be executed.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
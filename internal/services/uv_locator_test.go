@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+// writeFakeUV writes an executable uv reporting version into dir.
+func writeFakeUV(t *testing.T, dir, version string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "uv")
+	script := fmt.Sprintf("#!/bin/sh\necho 'uv %s (abc1234 2024-11-07)'\n", version)
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUVLocator_Discover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv binaries are shell scripts")
+	}
+	home := t.TempDir()
+	root := t.TempDir()
+	pathDir := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", pathDir+string(os.PathListSeparator)+filepath.Join(home, ".local", "bin"))
+
+	pathUV := writeFakeUV(t, pathDir, "0.5.0")
+	localUV := writeFakeUV(t, filepath.Join(home, ".local", "bin"), "0.4.30")
+	cargoUV := writeFakeUV(t, filepath.Join(home, ".cargo", "bin"), "0.3.0")
+	projectUV := writeFakeUV(t, filepath.Join(root, ".venv", "bin"), "0.5.2")
+	configuredUV := writeFakeUV(t, filepath.Join(root, "tools"), "0.5.4")
+
+	locator := NewUVLocator(NewCommandExecutor())
+	locator.SetProjectRoot(root)
+	locator.SetSearchPaths([]string{"tools/uv", filepath.Join(root, "missing", "uv")})
+
+	// The project's own uv is not run until the project is trusted.
+	binaries, err := locator.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	for _, binary := range binaries {
+		if binary.Path == projectUV {
+			t.Errorf("Discover() ran %s of an untrusted project", projectUV)
+		}
+	}
+
+	locator.SetProjectTrusted(true)
+	binaries, err = locator.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	want := []struct {
+		path, source, version string
+	}{
+		{configuredUV, types.UVSourceConfigured, "0.5.4"},
+		{projectUV, types.UVSourceProject, "0.5.2"},
+		{pathUV, types.UVSourcePath, "0.5.0"},
+		// ~/.local/bin is also on PATH; it is listed once.
+		{localUV, types.UVSourcePath, "0.4.30"},
+		{cargoUV, types.UVSourceCargo, "0.3.0"},
+	}
+	if len(binaries) != len(want) {
+		t.Fatalf("Discover() returned %d binaries, want %d: %+v", len(binaries), len(want), binaries)
+	}
+	for i, w := range want {
+		got := binaries[i]
+		if got.Path != w.path || got.Source != w.source || got.Details.Version != w.version {
+			t.Errorf("binary %d = {%s %s %s}, want {%s %s %s}", i, got.Path, got.Source, got.Details.Version, w.path, w.source, w.version)
+		}
+	}
+}
+
+func TestUVLocator_Discover_DeduplicatesSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv binaries are shell scripts")
+	}
+	t.Setenv("HOME", t.TempDir())
+	target := writeFakeUV(t, t.TempDir(), "0.5.0")
+	linkDir := t.TempDir()
+	if err := os.Symlink(target, filepath.Join(linkDir, "uv")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", filepath.Dir(target)+string(os.PathListSeparator)+linkDir)
+
+	binaries, err := NewUVLocator(NewCommandExecutor()).Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(binaries) != 1 || binaries[0].Path != target {
+		t.Errorf("Discover() = %+v, want only %s", binaries, target)
+	}
+}
+
+func TestUVLocator_Discover_VersionError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv binaries are shell scripts")
+	}
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	if err := os.WriteFile(filepath.Join(dir, "uv"), []byte("#!/bin/sh\nexit 3\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	binaries, err := NewUVLocator(NewCommandExecutor()).Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(binaries) != 1 || binaries[0].Error == nil {
+		t.Errorf("Discover() = %+v, want one binary with an error", binaries)
+	}
+}
@@ -0,0 +1,160 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"uvui/internal/types"
+)
+
+// maxLockVersion is the newest uv.lock format version uvui understands.
+const maxLockVersion = 1
+
+// lockSourceKinds are the keys of a package's source table, in the order they
+// are checked.
+var lockSourceKinds = []string{
+	types.LockSourceRegistry,
+	types.LockSourceGit,
+	types.LockSourceURL,
+	types.LockSourcePath,
+	types.LockSourceDirectory,
+	types.LockSourceEditable,
+	types.LockSourceVirtual,
+}
+
+// lockFileTOML mirrors the layout of uv.lock.
+type lockFileTOML struct {
+	Version           int               `toml:"version"`
+	Revision          int               `toml:"revision"`
+	RequiresPython    string            `toml:"requires-python"`
+	ResolutionMarkers []string          `toml:"resolution-markers"`
+	Packages          []lockPackageTOML `toml:"package"`
+}
+
+type lockPackageTOML struct {
+	Name                 string                          `toml:"name"`
+	Version              string                          `toml:"version"`
+	Source               map[string]interface{}          `toml:"source"`
+	Dependencies         []lockDependencyTOML            `toml:"dependencies"`
+	OptionalDependencies map[string][]lockDependencyTOML `toml:"optional-dependencies"`
+	DevDependencies      map[string][]lockDependencyTOML `toml:"dev-dependencies"`
+	ResolutionMarkers    []string                        `toml:"resolution-markers"`
+	Sdist                *lockArtifactTOML               `toml:"sdist"`
+	Wheels               []lockArtifactTOML              `toml:"wheels"`
+}
+
+type lockDependencyTOML struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+	Marker  string   `toml:"marker"`
+}
+
+type lockArtifactTOML struct {
+	URL      string `toml:"url"`
+	Path     string `toml:"path"`
+	Filename string `toml:"filename"`
+	Hash     string `toml:"hash"`
+	Size     int64  `toml:"size"`
+}
+
+// parseLockFile parses the content of a uv.lock file.
+func parseLockFile(data []byte) (*types.LockFile, error) {
+	var raw lockFileTOML
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse uv.lock: %w", err)
+	}
+	if raw.Version > maxLockVersion {
+		return nil, fmt.Errorf("unsupported uv.lock version %d", raw.Version)
+	}
+
+	lock := &types.LockFile{
+		Version:           raw.Version,
+		Revision:          raw.Revision,
+		RequiresPython:    raw.RequiresPython,
+		ResolutionMarkers: raw.ResolutionMarkers,
+		Packages:          make([]types.LockPackage, 0, len(raw.Packages)),
+	}
+	for _, pkg := range raw.Packages {
+		locked := types.LockPackage{
+			Name:                 pkg.Name,
+			Version:              pkg.Version,
+			Source:               lockSource(pkg.Source),
+			Dependencies:         lockDependencies(pkg.Dependencies),
+			OptionalDependencies: lockDependencyGroups(pkg.OptionalDependencies),
+			DevDependencies:      lockDependencyGroups(pkg.DevDependencies),
+			ResolutionMarkers:    pkg.ResolutionMarkers,
+		}
+		if pkg.Sdist != nil {
+			sdist := lockArtifact(*pkg.Sdist)
+			locked.Sdist = &sdist
+		}
+		for _, wheel := range pkg.Wheels {
+			locked.Wheels = append(locked.Wheels, lockArtifact(wheel))
+		}
+		lock.Packages = append(lock.Packages, locked)
+	}
+	return lock, nil
+}
+
+// lockSource converts a package's source table.
+func lockSource(source map[string]interface{}) types.LockSource {
+	for _, kind := range lockSourceKinds {
+		if location, ok := source[kind].(string); ok {
+			return types.LockSource{Kind: kind, Location: location}
+		}
+	}
+	return types.LockSource{}
+}
+
+func lockDependencies(deps []lockDependencyTOML) []types.LockDependency {
+	var converted []types.LockDependency
+	for _, dep := range deps {
+		converted = append(converted, types.LockDependency{
+			Name:    dep.Name,
+			Version: dep.Version,
+			Extras:  dep.Extra,
+			Marker:  dep.Marker,
+		})
+	}
+	return converted
+}
+
+func lockDependencyGroups(groups map[string][]lockDependencyTOML) map[string][]types.LockDependency {
+	if len(groups) == 0 {
+		return nil
+	}
+	converted := make(map[string][]types.LockDependency, len(groups))
+	for name, deps := range groups {
+		converted[name] = lockDependencies(deps)
+	}
+	return converted
+}
+
+// lockArtifact converts an sdist or wheel entry, deriving its file name from
+// the URL or path when uv.lock does not record one.
+func lockArtifact(artifact lockArtifactTOML) types.LockArtifact {
+	converted := types.LockArtifact{
+		URL:      artifact.URL,
+		Path:     artifact.Path,
+		Filename: artifact.Filename,
+		Hash:     artifact.Hash,
+		Size:     artifact.Size,
+	}
+	if converted.Filename == "" {
+		switch {
+		case artifact.URL != "":
+			if parsed, err := url.Parse(artifact.URL); err == nil {
+				converted.Filename = path.Base(parsed.Path)
+			}
+		case artifact.Path != "":
+			converted.Filename = path.Base(strings.ReplaceAll(artifact.Path, "\\", "/"))
+		}
+	}
+	return converted
+}
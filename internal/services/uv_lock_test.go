@@ -0,0 +1,169 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+const sampleLock = `version = 1
+revision = 2
+requires-python = ">=3.11"
+resolution-markers = [
+    "python_full_version >= '3.12'",
+    "python_full_version < '3.12'",
+]
+
+[[package]]
+name = "anyio"
+version = "4.4.0"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "idna" },
+    { name = "sniffio", marker = "python_full_version < '3.12'" },
+]
+sdist = { url = "https://files.pythonhosted.org/packages/anyio-4.4.0.tar.gz", hash = "sha256:aaaa", size = 163930 }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/anyio-4.4.0-py3-none-any.whl", hash = "sha256:bbbb", size = 86780 },
+]
+
+[[package]]
+name = "demo"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "anyio", extra = ["trio"] },
+    { name = "mylib" },
+]
+
+[package.optional-dependencies]
+server = [
+    { name = "uvicorn" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "pytest" },
+]
+
+[[package]]
+name = "idna"
+version = "3.7"
+source = { registry = "https://pypi.org/simple" }
+resolution-markers = [
+    "python_full_version >= '3.12'",
+]
+
+[[package]]
+name = "mylib"
+version = "1.0.0"
+source = { git = "https://github.com/example/mylib?rev=main#0123abcd" }
+
+[[package]]
+name = "localpkg"
+version = "0.2.0"
+source = { path = "vendor/localpkg-0.2.0-py3-none-any.whl" }
+wheels = [
+    { path = "vendor/localpkg-0.2.0-py3-none-any.whl" },
+]
+`
+
+func TestParseLockFile(t *testing.T) {
+	lock, err := parseLockFile([]byte(sampleLock))
+	if err != nil {
+		t.Fatalf("parseLockFile() error = %v", err)
+	}
+
+	if lock.Version != 1 || lock.Revision != 2 || lock.RequiresPython != ">=3.11" {
+		t.Errorf("parseLockFile() header = %d/%d/%q", lock.Version, lock.Revision, lock.RequiresPython)
+	}
+	if len(lock.ResolutionMarkers) != 2 {
+		t.Errorf("parseLockFile() resolution markers = %v, want 2", lock.ResolutionMarkers)
+	}
+	if len(lock.Packages) != 5 {
+		t.Fatalf("parseLockFile() returned %d packages, want 5", len(lock.Packages))
+	}
+
+	anyio := lock.Packages[0]
+	if anyio.Source != (types.LockSource{Kind: types.LockSourceRegistry, Location: "https://pypi.org/simple"}) {
+		t.Errorf("anyio source = %+v", anyio.Source)
+	}
+	wantDeps := []types.LockDependency{
+		{Name: "idna"},
+		{Name: "sniffio", Marker: "python_full_version < '3.12'"},
+	}
+	if !reflect.DeepEqual(anyio.Dependencies, wantDeps) {
+		t.Errorf("anyio dependencies = %+v, want %+v", anyio.Dependencies, wantDeps)
+	}
+	wantSdist := &types.LockArtifact{
+		URL:      "https://files.pythonhosted.org/packages/anyio-4.4.0.tar.gz",
+		Filename: "anyio-4.4.0.tar.gz",
+		Hash:     "sha256:aaaa",
+		Size:     163930,
+	}
+	if !reflect.DeepEqual(anyio.Sdist, wantSdist) {
+		t.Errorf("anyio sdist = %+v, want %+v", anyio.Sdist, wantSdist)
+	}
+	if len(anyio.Wheels) != 1 || anyio.Wheels[0].Filename != "anyio-4.4.0-py3-none-any.whl" || anyio.Wheels[0].Size != 86780 {
+		t.Errorf("anyio wheels = %+v", anyio.Wheels)
+	}
+
+	demo := lock.Packages[1]
+	if demo.Source.Kind != types.LockSourceEditable || demo.Source.Location != "." {
+		t.Errorf("demo source = %+v, want editable .", demo.Source)
+	}
+	if !reflect.DeepEqual(demo.Dependencies[0].Extras, []string{"trio"}) {
+		t.Errorf("demo dependency extras = %v, want [trio]", demo.Dependencies[0].Extras)
+	}
+	if deps := demo.OptionalDependencies["server"]; len(deps) != 1 || deps[0].Name != "uvicorn" {
+		t.Errorf("demo optional dependencies = %+v", demo.OptionalDependencies)
+	}
+	if deps := demo.DevDependencies["dev"]; len(deps) != 1 || deps[0].Name != "pytest" {
+		t.Errorf("demo dev dependencies = %+v", demo.DevDependencies)
+	}
+
+	if got := lock.Packages[2].ResolutionMarkers; !reflect.DeepEqual(got, []string{"python_full_version >= '3.12'"}) {
+		t.Errorf("idna resolution markers = %v", got)
+	}
+	if got := lock.Packages[3].Source; got.Kind != types.LockSourceGit || !strings.HasSuffix(got.Location, "#0123abcd") {
+		t.Errorf("mylib source = %+v, want git", got)
+	}
+	if got := lock.Packages[4]; got.Source.Kind != types.LockSourcePath || got.Wheels[0].Filename != "localpkg-0.2.0-py3-none-any.whl" {
+		t.Errorf("localpkg = %+v, want a path source with a named wheel", got)
+	}
+}
+
+func TestParseLockFile_Edges(t *testing.T) {
+	lock, err := parseLockFile([]byte(sampleLock))
+	if err != nil {
+		t.Fatalf("parseLockFile() error = %v", err)
+	}
+
+	var names []string
+	for _, pkg := range lock.Dependents("anyio") {
+		names = append(names, pkg.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"demo"}) {
+		t.Errorf("Dependents(anyio) = %v, want [demo]", names)
+	}
+	if len(lock.Dependents("pytest")) != 1 {
+		t.Error("Dependents(pytest) should include dev dependency edges")
+	}
+	if _, ok := lock.Package("idna"); !ok {
+		t.Error("Package(idna) not found")
+	}
+	if _, ok := lock.Package("missing"); ok {
+		t.Error("Package(missing) found")
+	}
+}
+
+func TestParseLockFile_Errors(t *testing.T) {
+	if _, err := parseLockFile([]byte("version = 2\n")); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("parseLockFile() error = %v, want unsupported version", err)
+	}
+	if _, err := parseLockFile([]byte("[[package]\n")); err == nil {
+		t.Error("parseLockFile() error = nil, want a parse error")
+	}
+}
@@ -0,0 +1,202 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/pkg/version"
+)
+
+// packageMetadataKeys are the core metadata fields shown for an installed
+// package, in display order.
+var packageMetadataKeys = []string{
+	"Summary",
+	"License",
+	"License-Expression",
+	"Author",
+	"Author-email",
+	"Home-page",
+	"Project-URL",
+	"Requires-Python",
+}
+
+// pipCheckPattern matches a problem reported by uv pip check, for example
+// "The package `httpx` requires `idna`, but it's not installed".
+var pipCheckPattern = regexp.MustCompile("The package `([^`]+)` (?:requires `([^`]+)`)?")
+
+// pipListEntry is an entry of `uv pip list --format json`.
+type pipListEntry struct {
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	EditableLocation string `json:"editable_project_location"`
+}
+
+// parsePipList parses the output of `uv pip list --format json`.
+func parsePipList(output []byte) ([]types.InstalledPackage, error) {
+	var entries []pipListEntry
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse uv pip list output: %w", err)
+	}
+
+	packages := make([]types.InstalledPackage, 0, len(entries))
+	for _, entry := range entries {
+		packages = append(packages, types.InstalledPackage{
+			Name:             entry.Name,
+			Version:          entry.Version,
+			EditableLocation: entry.EditableLocation,
+		})
+	}
+	return packages, nil
+}
+
+// parsePipShow parses the output of `uv pip show [--files]`, which describes
+// one or more packages separated by "---" lines.
+func parsePipShow(output []byte) []types.PackageDetails {
+	var (
+		packages []types.PackageDetails
+		current  *types.PackageDetails
+		inFiles  bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			current, inFiles = nil, false
+			continue
+		}
+		if inFiles && strings.HasPrefix(line, " ") {
+			if file := strings.TrimSpace(line); file != "" {
+				current.Files = append(current.Files, file)
+			}
+			continue
+		}
+		inFiles = false
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if current == nil {
+			packages = append(packages, types.PackageDetails{})
+			current = &packages[len(packages)-1]
+		}
+
+		switch key {
+		case "Name":
+			current.Name = value
+		case "Version":
+			current.Version = value
+		case "Location":
+			current.Location = value
+		case "Editable project location":
+			current.EditableLocation = value
+		case "Requires":
+			current.Requires = splitPackageNames(value)
+		case "Required-by":
+			current.RequiredBy = splitPackageNames(value)
+		case "Files":
+			inFiles = true
+		}
+	}
+	return packages
+}
+
+// splitPackageNames splits a comma-separated list of package names.
+func splitPackageNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseCoreMetadata returns the displayed fields of a METADATA file. Only the
+// header is read; the long description that follows it is skipped.
+func parseCoreMetadata(data []byte) []types.PackageMetadata {
+	fields := make(map[string][]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			fields[key] = append(fields[key], value)
+		}
+	}
+
+	var metadata []types.PackageMetadata
+	for _, key := range packageMetadataKeys {
+		for _, value := range fields[key] {
+			metadata = append(metadata, types.PackageMetadata{Key: key, Value: value})
+		}
+	}
+	return metadata
+}
+
+// parsePipFreeze returns the requirement lines of `uv pip freeze`.
+func parsePipFreeze(output []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parsePipCheck returns the problems in the output of uv pip check.
+func parsePipCheck(lines []string) []types.PackageIssue {
+	var issues []types.PackageIssue
+	for _, line := range lines {
+		match := pipCheckPattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		issue := types.PackageIssue{
+			Package: line[match[2]:match[3]],
+			Message: strings.TrimSpace(line[match[0]:]),
+		}
+		if match[4] >= 0 {
+			issue.Requirement = line[match[4]:match[5]]
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// mergePackageDetails fills in the location and dependency edges of listed
+// packages from uv pip show.
+func mergePackageDetails(packages []types.InstalledPackage, details []types.PackageDetails) {
+	byName := make(map[string]types.PackageDetails, len(details))
+	for _, detail := range details {
+		byName[version.NormalizeName(detail.Name)] = detail
+	}
+	for i := range packages {
+		detail, ok := byName[version.NormalizeName(packages[i].Name)]
+		if !ok {
+			continue
+		}
+		packages[i].Location = detail.Location
+		packages[i].Requires = detail.Requires
+		packages[i].RequiredBy = detail.RequiredBy
+		if packages[i].EditableLocation == "" {
+			packages[i].EditableLocation = detail.EditableLocation
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+const testPipShowOutput = `Name: anyio
+Version: 4.4.0
+Location: /project/.venv/lib/python3.12/site-packages
+Requires: idna, sniffio
+Required-by: httpx
+Files:
+  anyio-4.4.0.dist-info/INSTALLER
+  anyio-4.4.0.dist-info/METADATA
+  anyio/__init__.py
+---
+Name: demo
+Version: 0.1.0
+Location: /project/.venv/lib/python3.12/site-packages
+Editable project location: /project
+Requires: anyio
+Required-by:
+`
+
+func TestParsePipList(t *testing.T) {
+	output := []byte(`[{"name":"anyio","version":"4.4.0"},{"name":"demo","version":"0.1.0","editable_project_location":"/project"}]`)
+	packages, err := parsePipList(output)
+	if err != nil {
+		t.Fatalf("parsePipList() error = %v", err)
+	}
+	expected := []types.InstalledPackage{
+		{Name: "anyio", Version: "4.4.0"},
+		{Name: "demo", Version: "0.1.0", EditableLocation: "/project"},
+	}
+	if !reflect.DeepEqual(packages, expected) {
+		t.Errorf("parsePipList() = %+v, want %+v", packages, expected)
+	}
+
+	if _, err := parsePipList([]byte("anyio 4.4.0")); err == nil {
+		t.Error("parsePipList() should fail on text output")
+	}
+}
+
+func TestParsePipShow(t *testing.T) {
+	packages := parsePipShow([]byte(testPipShowOutput))
+	if len(packages) != 2 {
+		t.Fatalf("parsePipShow() returned %d packages, want 2", len(packages))
+	}
+
+	anyio := packages[0]
+	if anyio.Name != "anyio" || anyio.Version != "4.4.0" || anyio.Location != "/project/.venv/lib/python3.12/site-packages" {
+		t.Errorf("parsePipShow() first package = %+v", anyio)
+	}
+	if !reflect.DeepEqual(anyio.Requires, []string{"idna", "sniffio"}) || !reflect.DeepEqual(anyio.RequiredBy, []string{"httpx"}) {
+		t.Errorf("parsePipShow() anyio requires %v, required by %v", anyio.Requires, anyio.RequiredBy)
+	}
+	if len(anyio.Files) != 3 || anyio.Files[1] != "anyio-4.4.0.dist-info/METADATA" {
+		t.Errorf("parsePipShow() anyio files = %v", anyio.Files)
+	}
+
+	demo := packages[1]
+	if demo.EditableLocation != "/project" || demo.RequiredBy != nil || len(demo.Files) != 0 {
+		t.Errorf("parsePipShow() second package = %+v", demo)
+	}
+}
+
+func TestParseCoreMetadata(t *testing.T) {
+	data := []byte("Metadata-Version: 2.1\nName: anyio\nVersion: 4.4.0\nSummary: High level compatibility layer\nAuthor-email: Alex <alex@example.com>\nLicense: MIT\nProject-URL: Documentation, https://anyio.readthedocs.io/\nProject-URL: Changelog, https://anyio.readthedocs.io/en/stable/versionhistory.html\nRequires-Python: >=3.8\nClassifier: Framework :: AnyIO\n\nLicense: not a header\n")
+	expected := []types.PackageMetadata{
+		{Key: "Summary", Value: "High level compatibility layer"},
+		{Key: "License", Value: "MIT"},
+		{Key: "Author-email", Value: "Alex <alex@example.com>"},
+		{Key: "Project-URL", Value: "Documentation, https://anyio.readthedocs.io/"},
+		{Key: "Project-URL", Value: "Changelog, https://anyio.readthedocs.io/en/stable/versionhistory.html"},
+		{Key: "Requires-Python", Value: ">=3.8"},
+	}
+	if metadata := parseCoreMetadata(data); !reflect.DeepEqual(metadata, expected) {
+		t.Errorf("parseCoreMetadata() = %+v, want %+v", metadata, expected)
+	}
+}
+
+func TestParsePipFreeze(t *testing.T) {
+	output := []byte("anyio==4.4.0\n-e file:///project\n\nidna==3.7\n")
+	expected := []string{"anyio==4.4.0", "-e file:///project", "idna==3.7"}
+	if lines := parsePipFreeze(output); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("parsePipFreeze() = %v, want %v", lines, expected)
+	}
+}
+
+func TestParsePipCheck(t *testing.T) {
+	lines := []string{
+		"Checked 4 packages in 2ms",
+		"Found 2 incompatibilities",
+		"The package `httpx` requires `idna`, but it's not installed",
+		" - The package `demo` requires `anyio>=5`, but `4.4.0` is installed",
+		"The package `old` requires Python >=3.13, but `3.12.7` is installed",
+	}
+	expected := []types.PackageIssue{
+		{Package: "httpx", Requirement: "idna", Message: "The package `httpx` requires `idna`, but it's not installed"},
+		{Package: "demo", Requirement: "anyio>=5", Message: "The package `demo` requires `anyio>=5`, but `4.4.0` is installed"},
+		{Package: "old", Message: "The package `old` requires Python >=3.13, but `3.12.7` is installed"},
+	}
+	if issues := parsePipCheck(lines); !reflect.DeepEqual(issues, expected) {
+		t.Errorf("parsePipCheck() = %+v, want %+v", issues, expected)
+	}
+	if issues := parsePipCheck([]string{"All installed packages are compatible"}); issues != nil {
+		t.Errorf("parsePipCheck() = %+v, want none", issues)
+	}
+}
@@ -0,0 +1,109 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"uvui/internal/types"
+)
+
+// treeIndentWidth is the width of one level of `uv tree` guides ("├── ",
+// "│   ", ...).
+const treeIndentWidth = 4
+
+// treeGuideChars are the characters uv draws the tree with.
+const treeGuideChars = "│├└─ "
+
+// treeLinePattern matches a package line of `uv tree` once its guides are
+// removed: a name with optional extras, a version written as "v1.2.3" (or
+// "==1.2.3" by older releases) and any parenthesized annotations.
+var treeLinePattern = regexp.MustCompile(`^([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)(?:\[([^\]]*)\])?(?:(?: v|==)(\S+))?((?:\s+\([^)]*\))*)\s*$`)
+
+// treeAnnotationPattern matches one "(...)" annotation.
+var treeAnnotationPattern = regexp.MustCompile(`\(([^)]*)\)`)
+
+// treeLine is a package line of `uv tree` with its depth.
+type treeLine struct {
+	depth int
+	node  types.TreeNode
+}
+
+// parseDependencyTree parses the output of uv tree into nested nodes. Lines
+// that are not packages, such as the "(*) Package tree already displayed"
+// legend, are skipped.
+func parseDependencyTree(output string) *types.DependencyTree {
+	var lines []treeLine
+	for _, raw := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		line, ok := parseTreeLine(raw)
+		if !ok {
+			continue
+		}
+		// A line can be at most one level below the one before it.
+		if len(lines) == 0 {
+			line.depth = 0
+		} else if limit := lines[len(lines)-1].depth + 1; line.depth > limit {
+			line.depth = limit
+		}
+		lines = append(lines, line)
+	}
+
+	nodes, _ := buildTreeNodes(lines, 0, 0)
+	if nodes == nil {
+		nodes = []types.TreeNode{}
+	}
+	return &types.DependencyTree{Dependencies: nodes}
+}
+
+// parseTreeLine parses a single line of uv tree output.
+func parseTreeLine(raw string) (treeLine, bool) {
+	raw = strings.TrimRight(raw, " \t")
+	text := strings.TrimLeft(raw, treeGuideChars)
+	if text == "" || strings.HasPrefix(text, "(*)") {
+		return treeLine{}, false
+	}
+
+	m := treeLinePattern.FindStringSubmatch(text)
+	if m == nil {
+		return treeLine{}, false
+	}
+
+	guides := utf8.RuneCountInString(raw[:len(raw)-len(text)])
+	line := treeLine{
+		depth: (guides + treeIndentWidth - 1) / treeIndentWidth,
+		node:  types.TreeNode{Name: m[1], Version: m[3]},
+	}
+	if m[2] != "" {
+		for _, extra := range strings.Split(m[2], ",") {
+			if extra = strings.TrimSpace(extra); extra != "" {
+				line.node.Extras = append(line.node.Extras, extra)
+			}
+		}
+	}
+	for _, annotation := range treeAnnotationPattern.FindAllStringSubmatch(m[4], -1) {
+		key, value, _ := strings.Cut(annotation[1], ":")
+		switch strings.TrimSpace(key) {
+		case "*":
+			line.node.Repeated = true
+		case "extra":
+			line.node.Extra = strings.TrimSpace(value)
+		case "group":
+			line.node.Group = strings.TrimSpace(value)
+		}
+	}
+	return line, true
+}
+
+// buildTreeNodes builds the siblings at depth starting at lines[i], returning
+// them and the index of the first line that is not part of them.
+func buildTreeNodes(lines []treeLine, i, depth int) ([]types.TreeNode, int) {
+	var nodes []types.TreeNode
+	for i < len(lines) && lines[i].depth == depth {
+		node := lines[i].node
+		node.Level = depth
+		node.Children, i = buildTreeNodes(lines, i+1, depth+1)
+		nodes = append(nodes, node)
+	}
+	return nodes, i
+}
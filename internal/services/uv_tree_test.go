@@ -0,0 +1,95 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+const sampleTree = `Resolved 7 packages in 2ms
+demo v0.1.0
+├── anyio v4.4.0
+│   ├── idna v3.7
+│   └── sniffio v1.3.1
+├── requests[socks] v2.32.3 (extra: net)
+│   └── idna v3.7
+└── pytest v8.3.2 (group: dev)
+    └── anyio v4.4.0 (*)
+(*) Package tree already displayed
+`
+
+func TestParseDependencyTree(t *testing.T) {
+	tree := parseDependencyTree(sampleTree)
+
+	expected := &types.DependencyTree{Dependencies: []types.TreeNode{
+		{Name: "demo", Version: "0.1.0", Level: 0, Children: []types.TreeNode{
+			{Name: "anyio", Version: "4.4.0", Level: 1, Children: []types.TreeNode{
+				{Name: "idna", Version: "3.7", Level: 2},
+				{Name: "sniffio", Version: "1.3.1", Level: 2},
+			}},
+			{Name: "requests", Version: "2.32.3", Extras: []string{"socks"}, Extra: "net", Level: 1, Children: []types.TreeNode{
+				{Name: "idna", Version: "3.7", Level: 2},
+			}},
+			{Name: "pytest", Version: "8.3.2", Group: "dev", Level: 1, Children: []types.TreeNode{
+				{Name: "anyio", Version: "4.4.0", Level: 2, Repeated: true},
+			}},
+		}},
+	}}
+
+	if !reflect.DeepEqual(tree, expected) {
+		t.Errorf("parseDependencyTree() = %+v, want %+v", tree, expected)
+	}
+}
+
+func TestParseDependencyTree_Workspace(t *testing.T) {
+	output := "app v0.1.0\n└── lib v0.1.0\nlib v0.1.0\n└── idna v3.7\n"
+
+	tree := parseDependencyTree(output)
+
+	if len(tree.Dependencies) != 2 {
+		t.Fatalf("parseDependencyTree() returned %d roots, want 2", len(tree.Dependencies))
+	}
+	if got := tree.Dependencies[1].Children; len(got) != 1 || got[0].Name != "idna" {
+		t.Errorf("second root children = %+v, want idna", got)
+	}
+}
+
+func TestParseDependencyTree_Empty(t *testing.T) {
+	tree := parseDependencyTree("\n")
+	if tree == nil || len(tree.Dependencies) != 0 {
+		t.Errorf("parseDependencyTree(empty) = %+v, want no dependencies", tree)
+	}
+}
+
+func TestParseTreeLine(t *testing.T) {
+	tests := []struct {
+		line  string
+		depth int
+		node  types.TreeNode
+		ok    bool
+	}{
+		{"demo v0.1.0", 0, types.TreeNode{Name: "demo", Version: "0.1.0"}, true},
+		{"│   │   └── typing-extensions v4.12.2", 3, types.TreeNode{Name: "typing-extensions", Version: "4.12.2"}, true},
+		{"    └── zope.interface v7.0 (*)", 2, types.TreeNode{Name: "zope.interface", Version: "7.0", Repeated: true}, true},
+		{"├── httpx[http2,socks] v0.27.0 (extra: client) (*)", 1, types.TreeNode{Name: "httpx", Version: "0.27.0", Extras: []string{"http2", "socks"}, Extra: "client", Repeated: true}, true},
+		{"  ├─ urllib3==2.0.4", 2, types.TreeNode{Name: "urllib3", Version: "2.0.4"}, true},
+		{"(*) Package tree already displayed", 0, types.TreeNode{}, false},
+		{"Resolved 12 packages in 1ms", 0, types.TreeNode{}, false},
+		{"│", 0, types.TreeNode{}, false},
+	}
+
+	for _, tt := range tests {
+		line, ok := parseTreeLine(tt.line)
+		if ok != tt.ok {
+			t.Errorf("parseTreeLine(%q) ok = %v, want %v", tt.line, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if line.depth != tt.depth || !reflect.DeepEqual(line.node, tt.node) {
+			t.Errorf("parseTreeLine(%q) = %d %+v, want %d %+v", tt.line, line.depth, line.node, tt.depth, tt.node)
+		}
+	}
+}
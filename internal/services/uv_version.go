@@ -0,0 +1,43 @@
+// Package services provides services for the application.
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"uvui/internal/types"
+)
+
+var (
+	uvVersionRegex = regexp.MustCompile(`^uv\s+(\d+(?:\.\d+)*)([-.+]\S*)?(?:\s+\(([^)]*)\))?`)
+	uvDateRegex    = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	uvCommitRegex  = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+// ParseUVVersion parses the output of `uv --version`, such as
+// "uv 0.5.0 (8d4d9b0 2024-11-07)" or "uv 0.5.1+3 (Homebrew 2024-11-12)".
+func ParseUVVersion(raw string) (types.UVVersion, error) {
+	raw = strings.TrimSpace(raw)
+	matches := uvVersionRegex.FindStringSubmatch(raw)
+	if matches == nil {
+		return types.UVVersion{Raw: raw}, fmt.Errorf("unrecognized uv version %q", raw)
+	}
+
+	parsed := types.UVVersion{Raw: raw, Version: matches[1]}
+	if suffix := matches[2]; strings.HasPrefix(suffix, "+") {
+		parsed.Build = suffix[1:]
+	} else {
+		parsed.Version += suffix
+	}
+
+	for _, field := range strings.Fields(matches[3]) {
+		switch {
+		case uvDateRegex.MatchString(field):
+			parsed.Date = field
+		case uvCommitRegex.MatchString(field) && parsed.Commit == "":
+			parsed.Commit = field
+		}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestParseUVVersion(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want types.UVVersion
+	}{
+		{
+			raw:  "uv 0.5.0 (8d4d9b0 2024-11-07)",
+			want: types.UVVersion{Raw: "uv 0.5.0 (8d4d9b0 2024-11-07)", Version: "0.5.0", Commit: "8d4d9b0", Date: "2024-11-07"},
+		},
+		{
+			raw:  "uv 0.5.1+3 (Homebrew 2024-11-12)\n",
+			want: types.UVVersion{Raw: "uv 0.5.1+3 (Homebrew 2024-11-12)", Version: "0.5.1", Build: "3", Date: "2024-11-12"},
+		},
+		{
+			raw:  "uv 0.1.0",
+			want: types.UVVersion{Raw: "uv 0.1.0", Version: "0.1.0"},
+		},
+		{
+			raw:  "uv 0.6.0-rc.1 (abcdef123 2025-02-01)",
+			want: types.UVVersion{Raw: "uv 0.6.0-rc.1 (abcdef123 2025-02-01)", Version: "0.6.0-rc.1", Commit: "abcdef123", Date: "2025-02-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseUVVersion(tt.raw)
+		if err != nil {
+			t.Errorf("ParseUVVersion(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseUVVersion(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseUVVersion_Invalid(t *testing.T) {
+	got, err := ParseUVVersion("pip 24.0")
+	if err == nil {
+		t.Error("ParseUVVersion() error = nil, want an error")
+	}
+	if got.Raw != "pip 24.0" {
+		t.Errorf("ParseUVVersion() raw = %q, want the input", got.Raw)
+	}
+}
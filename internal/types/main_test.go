@@ -2,6 +2,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,6 +13,13 @@ func TestPanelConstants(t *testing.T) {
 	assert.Equal(t, 1, int(PythonPanel))
 	assert.Equal(t, 2, int(ProjectPanel))
 	assert.Equal(t, 3, int(EnvironmentPanel))
+	assert.Equal(t, 4, int(RunPanel))
+	assert.Equal(t, 5, int(TasksPanel))
+	assert.Equal(t, 6, int(TestsPanel))
+	assert.Equal(t, 7, int(DiagnosticsPanel))
+	assert.Equal(t, 8, int(ToolsPanel))
+	assert.Equal(t, 9, int(CachePanel))
+	assert.Equal(t, 10, int(JobsPanel))
 }
 
 func TestPythonVersion(t *testing.T) {
@@ -91,3 +99,97 @@ func TestOperationStatusEmpty(t *testing.T) {
 	assert.False(t, status.Success)
 	assert.Nil(t, status.Error)
 }
+
+func TestJobState(t *testing.T) {
+	assert.Equal(t, "queued", JobQueued.String())
+	assert.Equal(t, "timed out", JobTimedOut.String())
+
+	assert.False(t, JobQueued.Finished())
+	assert.False(t, JobRunning.Finished())
+	assert.True(t, JobSucceeded.Finished())
+	assert.True(t, JobCancelled.Finished())
+}
+
+func TestJobDuration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	job := Job{}
+
+	assert.Equal(t, time.Duration(0), job.Duration(start))
+
+	job.StartedAt = start
+	assert.Equal(t, 5*time.Second, job.Duration(start.Add(5*time.Second)))
+
+	job.FinishedAt = start.Add(2 * time.Second)
+	assert.Equal(t, 2*time.Second, job.Duration(start.Add(time.Minute)))
+}
+
+func TestUVVersionAtLeast(t *testing.T) {
+	assert.True(t, UVVersion{Version: "0.5.0"}.AtLeast("0.5.0"))
+	assert.True(t, UVVersion{Version: "0.10.2"}.AtLeast("0.5.0"))
+	assert.False(t, UVVersion{Version: "0.4.30"}.AtLeast("0.5.0"))
+
+	// Unknown versions and minimums never warn.
+	assert.True(t, UVVersion{}.AtLeast("0.5.0"))
+	assert.True(t, UVVersion{Version: "0.1.0"}.AtLeast(""))
+}
+
+func TestToolInstallOptions_InstallArgs(t *testing.T) {
+	assert.Equal(t, []string{"ruff"}, ToolInstallOptions{Package: "ruff"}.InstallArgs())
+	options := ToolInstallOptions{Package: "./mytool", With: []string{"rich"}, PythonVersion: "3.12", Editable: true, Force: true}
+	assert.Equal(t, []string{"./mytool", "--with", "rich", "--python", "3.12", "--editable", "--force"}, options.InstallArgs())
+}
+
+func TestToolRunOptions(t *testing.T) {
+	options := ToolRunOptions{Package: "ruff@0.6.9", Args: []string{"check", "."}}
+	assert.Equal(t, "ruff@0.6.9", options.Executable())
+	assert.Equal(t, []string{"ruff@0.6.9", "check", "."}, options.RunArgs())
+
+	options = ToolRunOptions{Package: "httpie", Command: "http", With: []string{"rich"}, PythonVersion: "3.12"}
+	assert.Equal(t, "http", options.Executable())
+	assert.Equal(t, []string{"--from", "httpie", "--with", "rich", "--python", "3.12", "http"}, options.RunArgs())
+}
+
+func TestRunOptions(t *testing.T) {
+	options := RunOptions{Target: "main.py", Args: []string{"--port", "8000"}, Env: []string{"DEBUG=1"}, With: []string{"rich", "httpx"}}
+	assert.Equal(t, []string{"--with", "rich", "--with", "httpx", "main.py", "--port", "8000"}, options.RunArgs())
+	assert.Equal(t, []string{"serve"}, RunOptions{Target: "serve"}.RunArgs())
+}
+
+func TestTask_RunArgs(t *testing.T) {
+	task := Task{Name: "test", Command: []string{"pytest", "-q"}, Python: "3.12"}
+	assert.Equal(t, []string{"--python", "3.12", "pytest", "-q"}, task.RunArgs())
+	assert.Equal(t, []string{"ruff", "check"}, Task{Command: []string{"ruff", "check"}}.RunArgs())
+}
+
+func TestTestReport(t *testing.T) {
+	report := &TestReport{Cases: []TestCase{
+		{NodeID: "a", Outcome: TestPassed},
+		{NodeID: "b", Outcome: TestFailed},
+		{NodeID: "c", Outcome: TestSkipped},
+		{NodeID: "d", Outcome: TestError},
+		{NodeID: "e", Outcome: TestPassed},
+	}}
+	assert.Equal(t, 2, report.Count(TestPassed))
+	assert.Equal(t, 1, report.Count(TestFailed))
+	failures := report.Failures()
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, "b", failures[0].NodeID)
+		assert.Equal(t, "d", failures[1].NodeID)
+	}
+}
+
+func TestPytestOptions_PytestArgs(t *testing.T) {
+	options := PytestOptions{Tests: []string{"tests/test_app.py::test_run"}, Args: []string{"-x"}}
+	assert.Equal(t, []string{"pytest", "--junitxml=/tmp/r.xml", "-o", "junit_family=xunit1", "-x", "tests/test_app.py::test_run"}, options.PytestArgs("/tmp/r.xml"))
+}
+
+func TestTestMatrix_RunArgs(t *testing.T) {
+	matrix := TestMatrix{Command: []string{"pytest", "-q"}, Python: []string{"3.11", "3.12"}}
+	assert.Equal(t, []string{"--python", "3.12", "--isolated", "pytest", "-q"}, matrix.RunArgs("3.12"))
+	assert.Equal(t, []string{"pytest", "-q"}, matrix.Command)
+}
+
+func TestDiagnostic_Location(t *testing.T) {
+	assert.Equal(t, "src/app.py:12:5", Diagnostic{File: "src/app.py", Line: 12, Column: 5}.Location())
+	assert.Equal(t, "src/app.py:3", Diagnostic{File: "src/app.py", Line: 3}.Location())
+}
@@ -1,6 +1,13 @@
 // Package types provides shared data types for the application.
 package types
 
+import (
+	"fmt"
+	"time"
+
+	"uvui/pkg/version"
+)
+
 // Panel represents a UI panel.
 type Panel int
 
@@ -13,6 +20,20 @@ const (
 	ProjectPanel
 	// EnvironmentPanel is the environment panel.
 	EnvironmentPanel
+	// RunPanel is the script and entry point panel.
+	RunPanel
+	// TasksPanel is the project task runner panel.
+	TasksPanel
+	// TestsPanel is the pytest results panel.
+	TestsPanel
+	// DiagnosticsPanel is the ruff and mypy diagnostics panel.
+	DiagnosticsPanel
+	// ToolsPanel is the uv tool panel.
+	ToolsPanel
+	// CachePanel is the uv cache panel.
+	CachePanel
+	// JobsPanel is the background jobs panel.
+	JobsPanel
 )
 
 // PythonVersion represents a Python version.
@@ -21,6 +42,36 @@ type PythonVersion struct {
 	Installed bool
 	Current   bool
 	Path      string
+
+	// The fields below are only known when uv reports JSON output.
+	Key            string // uv's unique name, e.g. "cpython-3.13.0-linux-x86_64-gnu"
+	Implementation string // "cpython", "pypy" or "graalpy"
+	Arch           string
+	OS             string
+	Libc           string
+	Variant        string // "default", "freethreaded", "debug", ...
+	Symlink        string // target of Path, if Path is a symlink
+	URL            string // download URL of a managed Python
+}
+
+// Request returns the request naming this Python in uv commands: its key when
+// known, so that implementations and variants are told apart, or else its
+// version.
+func (v PythonVersion) Request() string {
+	if v.Key != "" {
+		return v.Key
+	}
+	return v.Version
+}
+
+// PinRequest returns the request written to .python-version when pinning this
+// Python: the bare version for a default CPython build, which keeps the pin
+// portable across platforms, or else its key.
+func (v PythonVersion) PinRequest() string {
+	if (v.Implementation == "" || v.Implementation == "cpython") && (v.Variant == "" || v.Variant == "default") {
+		return v.Version
+	}
+	return v.Request()
 }
 
 // ProjectStatus represents the current project status.
@@ -43,18 +94,434 @@ type InitOptions struct {
 	PythonVersion string
 }
 
+// DependencyOptions represents options for adding or removing a dependency.
+type DependencyOptions struct {
+	Target   string // "main" (or empty), "dev", "optional" or "group"
+	Group    string // extra or dependency group for the "optional" and "group" targets
+	Editable bool   // install a local path dependency as editable (add only)
+	Frozen   bool   // update pyproject.toml without locking or syncing
+	NoSync   bool   // update pyproject.toml and uv.lock without syncing
+}
+
+// VenvOptions represents options for creating a virtual environment.
+type VenvOptions struct {
+	Path               string // relative to the project root; empty means .venv
+	PythonVersion      string
+	Prompt             string
+	Seed               bool // install pip, setuptools and wheel into the environment
+	SystemSitePackages bool
+}
+
+// VirtualEnv represents a virtual environment found on disk.
+type VirtualEnv struct {
+	Name               string // path relative to the project root, or absolute outside it
+	Path               string
+	Interpreter        string // the environment's python executable
+	PythonVersion      string
+	Implementation     string
+	BaseInterpreter    string // directory of the interpreter the environment was created from
+	Prompt             string
+	SystemSitePackages bool
+	Seeded             bool   // pip is installed
+	UVVersion          string // uv release that created the environment, if any
+	Size               int64
+	Project            bool  // the environment uv uses for the project
+	Active             bool  // the activated environment ($VIRTUAL_ENV)
+	Error              error // the environment could not be read
+}
+
+// InstalledPackage represents a package installed in a virtual environment,
+// as reported by uv pip list and uv pip show.
+type InstalledPackage struct {
+	Name             string
+	Version          string
+	Location         string   // the site-packages directory it is installed in
+	EditableLocation string   // the project directory of an editable install
+	Requires         []string // names of the packages it depends on
+	RequiredBy       []string // names of the installed packages that depend on it
+}
+
+// PackageMetadata is a field of an installed package's core metadata, such as
+// Summary or License.
+type PackageMetadata struct {
+	Key   string
+	Value string
+}
+
+// PackageDetails describes an installed package in full.
+type PackageDetails struct {
+	InstalledPackage
+	Metadata []PackageMetadata
+	Files    []string // paths relative to Location
+}
+
+// PackageIssue is a broken requirement reported by uv pip check.
+type PackageIssue struct {
+	Package     string // the package whose requirement is not met
+	Requirement string // the unmet requirement, when uv names one
+	Message     string
+}
+
+// Tool represents a tool installed with uv tool install.
+type Tool struct {
+	Name        string
+	Version     string
+	Specifier   string   // the version requirement it was installed with, if any
+	With        []string // additional requirements installed with --with
+	Extras      []string
+	Path        string // the tool's environment
+	EntryPoints []ToolEntryPoint
+}
+
+// ToolEntryPoint is an executable installed by a tool.
+type ToolEntryPoint struct {
+	Name string
+	Path string
+}
+
+// ToolInstallOptions represents options for installing a tool.
+type ToolInstallOptions struct {
+	Package       string // a requirement, local path or git URL
+	With          []string
+	PythonVersion string
+	Editable      bool
+	Force         bool // overwrite executables that belong to something else
+}
+
+// InstallArgs returns the arguments that follow "uv tool install".
+func (o ToolInstallOptions) InstallArgs() []string {
+	args := []string{o.Package}
+	for _, with := range o.With {
+		args = append(args, "--with", with)
+	}
+	if o.PythonVersion != "" {
+		args = append(args, "--python", o.PythonVersion)
+	}
+	if o.Editable {
+		args = append(args, "--editable")
+	}
+	if o.Force {
+		args = append(args, "--force")
+	}
+	return args
+}
+
+// ToolRunOptions represents an ephemeral tool invocation with uv tool run
+// (uvx).
+type ToolRunOptions struct {
+	Package       string // a requirement such as "ruff@0.6.9", local path or git URL
+	Command       string // the executable, when it is not named after the package
+	With          []string
+	PythonVersion string
+	Args          []string
+	Interactive   bool // hand the terminal to the tool instead of capturing its output
+}
+
+// Executable returns the name of the executable the invocation runs.
+func (o ToolRunOptions) Executable() string {
+	if o.Command != "" {
+		return o.Command
+	}
+	return o.Package
+}
+
+// RunArgs returns the arguments that follow "uv tool run" (or "uvx").
+func (o ToolRunOptions) RunArgs() []string {
+	var args []string
+	if o.Command != "" {
+		args = append(args, "--from", o.Package)
+	}
+	for _, with := range o.With {
+		args = append(args, "--with", with)
+	}
+	if o.PythonVersion != "" {
+		args = append(args, "--python", o.PythonVersion)
+	}
+	args = append(args, o.Executable())
+	return append(args, o.Args...)
+}
+
+// ToolRun is a finished uv tool run invocation.
+type ToolRun struct {
+	Options   ToolRunOptions
+	StartedAt time.Time
+	Duration  time.Duration
+	ExitCode  int // -1 if the tool could not be started or was stopped
+	Error     error
+}
+
+// Kinds of run targets.
+const (
+	RunTargetScript    = "script"     // a [project.scripts] entry point
+	RunTargetGUIScript = "gui-script" // a [project.gui-scripts] entry point
+	RunTargetFile      = "file"       // a Python file in the project
+)
+
+// RunTarget is something in the project that uv run can run.
+type RunTarget struct {
+	Name string // the entry point name, or the file path relative to the project
+	Kind string
+	Spec string // "module:function" for entry points
+	// Inline reports whether a file declares PEP 723 inline script metadata.
+	Inline bool
+}
+
+// RunOptions represents a uv run invocation.
+type RunOptions struct {
+	Target string   // an entry point name or a file path
+	Args   []string // passed to the target
+	Env    []string // KEY=VALUE overrides for the environment of the run
+	With   []string // extra requirements for the run
+}
+
+// RunArgs returns the arguments that follow "uv run".
+func (o RunOptions) RunArgs() []string {
+	var args []string
+	for _, with := range o.With {
+		args = append(args, "--with", with)
+	}
+	args = append(args, o.Target)
+	return append(args, o.Args...)
+}
+
+// ScriptRun is a finished uv run invocation.
+type ScriptRun struct {
+	Options   RunOptions
+	StartedAt time.Time
+	Duration  time.Duration
+	ExitCode  int // -1 if the target could not be started or was stopped
+	Error     error
+}
+
+// Task is a project command declared in the [tool.uvui.tasks] table of
+// pyproject.toml.
+type Task struct {
+	Name        string
+	Description string
+	Command     []string // run with uv run
+	Env         []string // KEY=VALUE overrides, sorted by key
+	Depends     []string // tasks that must succeed first, in order
+	Python      string   // passed to uv run --python
+}
+
+// RunArgs returns the arguments that follow "uv run".
+func (t Task) RunArgs() []string {
+	var args []string
+	if t.Python != "" {
+		args = append(args, "--python", t.Python)
+	}
+	return append(args, t.Command...)
+}
+
+// TaskResult is the outcome of one task of a task run.
+type TaskResult struct {
+	Task      string
+	StartedAt time.Time
+	Duration  time.Duration
+	ExitCode  int // -1 if the task could not be started or was stopped
+	Error     error
+	Log       []OutputLine // the output of the task
+}
+
+// Outcomes of a test case.
+const (
+	TestPassed  = "passed"
+	TestFailed  = "failed"
+	TestError   = "error" // failed outside the test itself, e.g. in a fixture
+	TestSkipped = "skipped"
+)
+
+// TestCase is a test case of a pytest JUnit XML report.
+type TestCase struct {
+	NodeID    string // e.g. "tests/test_app.py::TestApp::test_run[fast]"
+	ClassName string // the dotted module and class path reported by pytest
+	Name      string
+	File      string // relative to the project, if known
+	Line      int    // 1-based line of the test function, or 0
+	Duration  time.Duration
+	Outcome   string
+	Message   string // the failure, error or skip message
+	Details   string // the traceback, or the skip location
+}
+
+// TestReport is the result of a pytest run.
+type TestReport struct {
+	Cases    []TestCase
+	Duration time.Duration
+}
+
+// Count returns the number of test cases with the given outcome.
+func (r *TestReport) Count(outcome string) int {
+	n := 0
+	for _, c := range r.Cases {
+		if c.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// Failures returns the failed test cases and those with errors, in report
+// order.
+func (r *TestReport) Failures() []TestCase {
+	var failures []TestCase
+	for _, c := range r.Cases {
+		if c.Outcome == TestFailed || c.Outcome == TestError {
+			failures = append(failures, c)
+		}
+	}
+	return failures
+}
+
+// PytestOptions represents a pytest invocation.
+type PytestOptions struct {
+	Tests []string // node IDs or paths; all tests if empty
+	Args  []string // further pytest arguments, e.g. "-k", "slow"
+}
+
+// PytestArgs returns the arguments that follow "uv run" to run pytest and
+// write a JUnit XML report to report. The xunit1 format records the file and
+// line of each test.
+func (o PytestOptions) PytestArgs(report string) []string {
+	args := []string{"pytest", "--junitxml=" + report, "-o", "junit_family=xunit1"}
+	args = append(args, o.Args...)
+	return append(args, o.Tests...)
+}
+
+// TestMatrix is a command run against several Pythons, declared in the
+// [tool.uvui.matrix] table of pyproject.toml.
+type TestMatrix struct {
+	Command  []string // run with uv run
+	Python   []string // Python requests; the installed Pythons if empty
+	Parallel int      // how many Pythons run at once
+}
+
+// RunArgs returns the arguments that follow "uv run" to run the command with
+// python, in an isolated environment that leaves the project's alone.
+func (m TestMatrix) RunArgs(python string) []string {
+	return append([]string{"--python", python, "--isolated"}, m.Command...)
+}
+
+// MatrixResult is the outcome of the matrix command with one Python.
+type MatrixResult struct {
+	Python    string
+	StartedAt time.Time
+	Duration  time.Duration
+	ExitCode  int // -1 if the command could not be started or was stopped
+	Error     error
+	Log       []OutputLine // the output of the command
+}
+
+// Tools that report diagnostics.
+const (
+	DiagnosticsRuff = "ruff"
+	DiagnosticsMypy = "mypy"
+)
+
+// Severities of a diagnostic.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+)
+
+// Diagnostic is a problem reported by a linter or type checker.
+type Diagnostic struct {
+	Tool     string // DiagnosticsRuff or DiagnosticsMypy
+	File     string // relative to the project, slash-separated
+	Line     int    // 1-based
+	Column   int    // 1-based; 0 if unknown
+	Severity string
+	Rule     string // e.g. "F401" or "arg-type"; empty for syntax errors
+	Message  string
+	Fixable  bool // ruff check --fix can fix it
+}
+
+// Location returns the position of the diagnostic as file:line[:column].
+func (d Diagnostic) Location() string {
+	location := fmt.Sprintf("%s:%d", d.File, d.Line)
+	if d.Column > 0 {
+		location += fmt.Sprintf(":%d", d.Column)
+	}
+	return location
+}
+
+// ScriptMetadata is the PEP 723 inline metadata of a single-file script, the
+// "# /// script" block uv add --script and uv lock --script work on.
+type ScriptMetadata struct {
+	Path           string // relative to the project
+	HasBlock       bool   // false if the script declares no metadata yet
+	RequiresPython string
+	Dependencies   []ProjectDependency
+	Locked         bool // a <script>.lock file exists next to the script
+}
+
+// Cache buckets, the kinds of data uv keeps in its cache.
+const (
+	CacheBucketWheels       = "wheels"
+	CacheBucketSdists       = "sdists"
+	CacheBucketGit          = "git"
+	CacheBucketInterpreters = "interpreters"
+	CacheBucketArchives     = "archives"
+	CacheBucketIndex        = "index"
+	CacheBucketBuilds       = "builds"
+	CacheBucketEnvironments = "environments"
+	CacheBucketOther        = "other"
+)
+
+// CacheInfo describes the uv cache directory.
+type CacheInfo struct {
+	Dir      string
+	Size     int64
+	Buckets  []CacheBucket  // in the order of the CacheBucket constants, empty ones left out
+	Packages []CachePackage // packages with cached wheels or sdists, by name
+}
+
+// CacheBucket is the part of the cache holding one kind of data.
+type CacheBucket struct {
+	Name string
+	Size int64
+}
+
+// CachePackage is a package with cached wheels or source distributions.
+type CachePackage struct {
+	Name string
+	Size int64
+}
+
+// UVInstallOptions configures how UV is installed.
+type UVInstallOptions struct {
+	// InstallDir receives the uv binaries. Empty uses the installer default.
+	InstallDir string `json:"install_dir,omitempty"`
+	// ScriptURL overrides the URL of the official installer script.
+	ScriptURL string `json:"script_url,omitempty"`
+	// Archive is a uv release archive (.tar.gz or .zip), given as a URL or a
+	// local path. When set it is installed instead of running the script.
+	Archive string `json:"archive,omitempty"`
+	// Checksum is the expected SHA-256 of Archive. Empty reads it from the
+	// "<archive>.sha256" file published next to every uv release archive.
+	Checksum string `json:"checksum,omitempty"`
+}
+
 // ProjectDependency represents a project dependency.
 type ProjectDependency struct {
 	Name    string
-	Version string
-	Type    string // "main", "dev", etc.
+	Version string // version specifier, e.g. ">=2.31,<3", or "@ <url>"
+	Type    string // "main", "optional", "group" or "dev"
+	Group   string // extra or dependency group the dependency belongs to
+	Extras  []string
+	Markers string // PEP 508 environment markers, e.g. `python_version < "3.12"`
 }
 
 // TreeNode represents a node in the dependency tree.
 type TreeNode struct {
 	Name     string
 	Version  string
+	Extras   []string // extras requested of the package, e.g. requests[socks]
+	Extra    string   // extra of the parent that pulls the package in
+	Group    string   // dependency group of the parent that pulls the package in
 	Level    int
+	Repeated bool // the subtree is shown at an earlier occurrence ("(*)")
 	Children []TreeNode
 }
 
@@ -63,11 +530,156 @@ type DependencyTree struct {
 	Dependencies []TreeNode
 }
 
+// Lock source kinds, as written in uv.lock.
+const (
+	LockSourceRegistry  = "registry"
+	LockSourceGit       = "git"
+	LockSourcePath      = "path"
+	LockSourceDirectory = "directory"
+	LockSourceEditable  = "editable"
+	LockSourceVirtual   = "virtual"
+	LockSourceURL       = "url"
+)
+
+// LockFile is the content of a uv.lock file.
+type LockFile struct {
+	Version           int
+	Revision          int
+	RequiresPython    string
+	ResolutionMarkers []string
+	Packages          []LockPackage
+}
+
+// Package returns the locked package with the given name. When a package is
+// locked at several versions (forked resolution), the first is returned.
+func (l *LockFile) Package(name string) (LockPackage, bool) {
+	for _, pkg := range l.Packages {
+		if pkg.Name == name {
+			return pkg, true
+		}
+	}
+	return LockPackage{}, false
+}
+
+// Dependents returns the packages that depend on the named package, through
+// their dependencies, extras or dependency groups.
+func (l *LockFile) Dependents(name string) []LockPackage {
+	var dependents []LockPackage
+	for _, pkg := range l.Packages {
+		if pkg.DependsOn(name) {
+			dependents = append(dependents, pkg)
+		}
+	}
+	return dependents
+}
+
+// LockPackage is a [[package]] entry of uv.lock.
+type LockPackage struct {
+	Name                 string
+	Version              string
+	Source               LockSource
+	Dependencies         []LockDependency
+	OptionalDependencies map[string][]LockDependency // by extra
+	DevDependencies      map[string][]LockDependency // by dependency group
+	ResolutionMarkers    []string
+	Sdist                *LockArtifact
+	Wheels               []LockArtifact
+}
+
+// DependsOn reports whether the package has an edge to the named package.
+func (p LockPackage) DependsOn(name string) bool {
+	for _, dep := range p.Dependencies {
+		if dep.Name == name {
+			return true
+		}
+	}
+	for _, groups := range []map[string][]LockDependency{p.OptionalDependencies, p.DevDependencies} {
+		for _, deps := range groups {
+			for _, dep := range deps {
+				if dep.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// LockSource is where a locked package comes from.
+type LockSource struct {
+	Kind     string // one of the LockSource* constants
+	Location string // registry index URL, git URL, path or URL
+}
+
+// String returns the source as "kind location".
+func (s LockSource) String() string {
+	if s.Location == "" {
+		return s.Kind
+	}
+	return s.Kind + " " + s.Location
+}
+
+// LockDependency is an edge from a locked package to another.
+type LockDependency struct {
+	Name    string
+	Version string // set when the dependency is locked at several versions
+	Extras  []string
+	Marker  string
+}
+
+// LockArtifact is a source distribution or wheel of a locked package.
+type LockArtifact struct {
+	URL      string
+	Path     string
+	Filename string
+	Hash     string
+	Size     int64
+}
+
 // UVStatus represents the status of UV installation.
 type UVStatus struct {
 	Installed bool
 	Version   string
 	Path      string
+	Details   UVVersion
+	Managed   bool // installed by uvui into its install directory
+}
+
+// UVVersion is the parsed output of `uv --version`, for example
+// "uv 0.5.0 (8d4d9b0 2024-11-07)".
+type UVVersion struct {
+	Raw     string
+	Version string // semantic version without build metadata, e.g. "0.5.0"
+	Build   string // build metadata, e.g. "3" for "0.5.0+3"
+	Commit  string
+	Date    string
+}
+
+// Places a uv binary can be discovered in.
+const (
+	UVSourceConfigured = "configured"
+	UVSourceProject    = "project"
+	UVSourcePath       = "PATH"
+	UVSourceLocal      = "~/.local/bin"
+	UVSourceCargo      = "~/.cargo/bin"
+)
+
+// UVBinary is a uv executable found on this machine.
+type UVBinary struct {
+	Path    string
+	Source  string // one of the UVSource constants
+	Version string // raw `uv --version` output
+	Details UVVersion
+	Error   error // set if the binary could not report its version
+}
+
+// AtLeast reports whether the version is min or newer. Unknown versions are
+// assumed to be new enough.
+func (v UVVersion) AtLeast(min string) bool {
+	if v.Version == "" || min == "" {
+		return true
+	}
+	return version.CompareVersions(v.Version, min) >= 0
 }
 
 // OperationStatus represents the status of an ongoing operation.
@@ -76,5 +688,91 @@ type OperationStatus struct {
 	Operation  string
 	Target     string
 	Success    bool
+	Cancelled  bool // stopped by the user
+	TimedOut   bool // stopped after exceeding its configured timeout
+	Error      error
+	JobID      int // job running the operation, if any
+}
+
+// JobState represents the lifecycle state of a background job.
+type JobState int
+
+const (
+	// JobQueued is a job waiting for a free slot or for conflicting jobs.
+	JobQueued JobState = iota
+	// JobRunning is a job whose command is executing.
+	JobRunning
+	// JobSucceeded is a job that completed without error.
+	JobSucceeded
+	// JobFailed is a job that completed with an error.
+	JobFailed
+	// JobCancelled is a job stopped by the user.
+	JobCancelled
+	// JobTimedOut is a job stopped after exceeding its timeout.
+	JobTimedOut
+)
+
+// String returns the display name of the job state.
+func (s JobState) String() string {
+	switch s {
+	case JobQueued:
+		return "queued"
+	case JobRunning:
+		return "running"
+	case JobSucceeded:
+		return "succeeded"
+	case JobFailed:
+		return "failed"
+	case JobCancelled:
+		return "cancelled"
+	case JobTimedOut:
+		return "timed out"
+	default:
+		return "unknown"
+	}
+}
+
+// Finished reports whether the job has reached a final state.
+func (s JobState) Finished() bool {
+	return s >= JobSucceeded
+}
+
+// Job represents a queued or running background operation.
+type Job struct {
+	ID         int
+	Operation  string
+	Target     string
+	Resources  []string // jobs sharing a resource never run concurrently
+	State      JobState
+	QueuedAt   time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
 	Error      error
+	Output     []OutputLine
+}
+
+// Duration returns how long the job has been running, or ran for if finished.
+func (j Job) Duration(now time.Time) time.Duration {
+	switch {
+	case j.StartedAt.IsZero():
+		return 0
+	case j.FinishedAt.IsZero():
+		return now.Sub(j.StartedAt)
+	default:
+		return j.FinishedAt.Sub(j.StartedAt)
+	}
+}
+
+// Output stream names used in OutputLine.
+const (
+	// StreamStdout identifies a line written to standard output.
+	StreamStdout = "stdout"
+	// StreamStderr identifies a line written to standard error.
+	StreamStderr = "stderr"
+)
+
+// OutputLine represents a single line of output from a running command.
+type OutputLine struct {
+	Stream string // StreamStdout or StreamStderr
+	Text   string
 }
@@ -1,15 +1,29 @@
 // Package ui provides the user interface for the application.
 package ui
 
-import "uvui/internal/types"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"uvui/internal/types"
+)
 
 // UVInstalledMsg represents a message when UV installation is complete.
 type UVInstalledMsg struct {
 	Success bool
 	Version string
+	Path    string // set when UV was installed by uvui
+	Managed bool   // UV was installed by uvui and may be uninstalled by it
 	Error   error
 }
 
+// UVOperationMsg represents the result of a uv self-management operation.
+type UVOperationMsg struct {
+	Operation string // "update" or "uninstall"
+	Target    string
+	Success   bool
+	Error     error
+}
+
 // PythonVersionsLoadedMsg represents loaded Python versions.
 type PythonVersionsLoadedMsg struct {
 	Available []types.PythonVersion
@@ -63,6 +77,16 @@ type ProjectOperationMsg struct {
 	ProjectDir string
 }
 
+// DependencyOperationMsg represents the result of adding or removing a
+// dependency.
+type DependencyOperationMsg struct {
+	Operation string // "add" or "remove"
+	Package   string
+	Success   bool
+	Error     error
+	Details   []string // uv's explanation of a failure, such as a resolution error
+}
+
 // ProjectInitRequestMsg represents a project initialization request.
 type ProjectInitRequestMsg struct {
 	Name    string
@@ -74,3 +98,196 @@ type ToggleTreeViewMsg struct{}
 
 // RefreshProjectMsg represents a request to refresh project data.
 type RefreshProjectMsg struct{}
+
+// CommandOutputMsg represents a line of output from a running command.
+// Stream is the channel the remaining output and the final result arrive on.
+type CommandOutputMsg struct {
+	JobID  int
+	Line   types.OutputLine
+	Stream <-chan tea.Msg
+}
+
+// JobFinishedMsg represents the completion of a background job. Result is the
+// operation-specific message produced by the job.
+type JobFinishedMsg struct {
+	JobID  int
+	Result tea.Msg
+	Error  error
+}
+
+// JobTickMsg is sent periodically while jobs run to refresh their durations.
+type JobTickMsg struct{}
+
+// UVBinariesLoadedMsg represents the uv binaries found on this machine.
+type UVBinariesLoadedMsg struct {
+	Binaries []types.UVBinary
+	Error    error
+}
+
+// LockFileLoadedMsg represents the loaded uv.lock of the project.
+type LockFileLoadedMsg struct {
+	Lock  *types.LockFile
+	Error error
+}
+
+// EnvironmentsLoadedMsg represents the virtual environments found for the
+// project.
+type EnvironmentsLoadedMsg struct {
+	Environments []types.VirtualEnv
+	Error        error
+}
+
+// EnvironmentOperationMsg represents the result of creating, recreating or
+// deleting a virtual environment.
+type EnvironmentOperationMsg struct {
+	Operation string // "create", "recreate" or "delete"
+	Target    string
+	Project   bool // the environment is the project's
+	Success   bool
+	Error     error
+}
+
+// ToolsLoadedMsg represents the installed tools.
+type ToolsLoadedMsg struct {
+	Tools  []types.Tool
+	BinDir string
+	Error  error
+}
+
+// ToolOperationMsg represents the result of a uv tool operation.
+type ToolOperationMsg struct {
+	Operation string // "install", "upgrade", "uninstall" or "update-shell"
+	Target    string // the tool; empty when upgrading all tools
+	Success   bool
+	Error     error
+}
+
+// CacheLoadedMsg represents the measured uv cache.
+type CacheLoadedMsg struct {
+	Info  *types.CacheInfo
+	Error error
+}
+
+// CacheOperationMsg represents the result of cleaning or pruning the cache.
+type CacheOperationMsg struct {
+	Operation string // "clean" or "prune"
+	Target    string // the cleaned packages, or "--ci" for a CI prune
+	Reclaimed int64  // bytes freed
+	Success   bool
+	Error     error
+}
+
+// RunTargetsLoadedMsg represents the entry points and Python files of the
+// project.
+type RunTargetsLoadedMsg struct {
+	Targets []types.RunTarget
+	Error   error
+}
+
+// ScriptRunMsg represents a finished uv run invocation.
+type ScriptRunMsg struct {
+	Run types.ScriptRun
+}
+
+// ScriptMetadataLoadedMsg represents the inline metadata read from a script.
+type ScriptMetadataLoadedMsg struct {
+	Path     string
+	Metadata *types.ScriptMetadata
+	Error    error
+}
+
+// ScriptOperationMsg represents the result of changing or locking the inline
+// metadata of a script.
+type ScriptOperationMsg struct {
+	Operation string // "add", "remove" or "lock"
+	Script    string
+	Package   string // the added or removed requirement
+	Success   bool
+	Error     error
+	Details   []string // uv's explanation of a failure
+}
+
+// TasksLoadedMsg represents the tasks declared in pyproject.toml.
+type TasksLoadedMsg struct {
+	Tasks []types.Task
+	Error error
+}
+
+// TaskRunMsg represents a finished task run: the results of the task and of
+// the tasks it depends on, in the order they ran.
+type TaskRunMsg struct {
+	Task    string
+	Results []types.TaskResult
+	Error   error
+}
+
+// TestRunMsg represents a finished pytest run. Report is nil when pytest
+// wrote no report, e.g. because it is not installed.
+type TestRunMsg struct {
+	Options types.PytestOptions
+	Rerun   bool // only some tests ran; merge the report into the last one
+	Report  *types.TestReport
+	Error   error
+}
+
+// TestMatrixLoadedMsg represents the test matrix of the project, with the
+// installed Pythons if it declares none.
+type TestMatrixLoadedMsg struct {
+	Matrix types.TestMatrix
+	Error  error
+}
+
+// MatrixRunMsg represents a finished run of the test matrix with some of its
+// Pythons.
+type MatrixRunMsg struct {
+	Pythons []string
+	Results []types.MatrixResult
+	Error   error
+}
+
+// DiagnosticsMsg represents the diagnostics reported by a run of the checks.
+type DiagnosticsMsg struct {
+	Diagnostics []types.Diagnostic
+	Failures    map[string]error // tools that could not run
+}
+
+// RuffFixMsg represents a finished ruff check --fix run.
+type RuffFixMsg struct {
+	Error error
+}
+
+// EditorClosedMsg represents the editor exiting after opening a file.
+type EditorClosedMsg struct {
+	File  string
+	Error error
+}
+
+// ToolRunMsg represents a finished uv tool run (uvx) invocation.
+type ToolRunMsg struct {
+	Run types.ToolRun
+}
+
+// InstalledPackagesLoadedMsg represents the packages installed in the
+// environment of Python, and the broken requirements among them.
+type InstalledPackagesLoadedMsg struct {
+	Python     string
+	Packages   []types.InstalledPackage
+	Issues     []types.PackageIssue
+	Error      error
+	CheckError error // uv pip check failed; the package list is still valid
+}
+
+// PackageDetailsLoadedMsg represents the metadata and files of an installed
+// package.
+type PackageDetailsLoadedMsg struct {
+	Python  string
+	Details *types.PackageDetails
+	Error   error
+}
+
+// PackageFreezeLoadedMsg represents the output of uv pip freeze.
+type PackageFreezeLoadedMsg struct {
+	Python string
+	Lines  []string
+	Error  error
+}
@@ -0,0 +1,159 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// cachePackageRows is the number of cached packages shown at once.
+const cachePackageRows = 10
+
+// cacheBucketLabels are the names the cache panel shows for buckets.
+var cacheBucketLabels = map[string]string{
+	types.CacheBucketWheels:       "Wheels",
+	types.CacheBucketSdists:       "Source distributions",
+	types.CacheBucketGit:          "Git checkouts",
+	types.CacheBucketInterpreters: "Interpreters",
+	types.CacheBucketArchives:     "Unpacked archives",
+	types.CacheBucketIndex:        "Index metadata",
+	types.CacheBucketBuilds:       "Build environments",
+	types.CacheBucketEnvironments: "Ephemeral environments",
+	types.CacheBucketOther:        "Other",
+}
+
+// CacheState represents the state of the cache panel.
+type CacheState struct {
+	Info      *types.CacheInfo
+	Loading   bool
+	Selected  int             // selected cached package
+	Marked    map[string]bool // packages marked for cleaning
+	Reclaimed string          // summary of the last clean or prune
+}
+
+// MarkedPackages returns the names of the marked packages in cache order
+// (exported).
+func (s CacheState) MarkedPackages() []string {
+	if s.Info == nil {
+		return nil
+	}
+	var names []string
+	for _, pkg := range s.Info.Packages {
+		if s.Marked[pkg.Name] {
+			names = append(names, pkg.Name)
+		}
+	}
+	return names
+}
+
+// RenderCachePanel renders the cache management panel.
+func RenderCachePanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Cache Management\n\n")
+
+	if !state.Installed {
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to manage the cache."))
+		return content.String()
+	}
+
+	cache := state.Cache
+	if cache.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Measuring the cache..."))
+		return content.String()
+	}
+	if cache.Info == nil {
+		content.WriteString("Cache information not loaded.\n")
+		content.WriteString("Press 'r' to load it.")
+		return content.String()
+	}
+
+	info := cache.Info
+	content.WriteString(fmt.Sprintf("Directory: %s\n", info.Dir))
+	content.WriteString(fmt.Sprintf("Total size: %s\n", ui.InstalledVersionStyle.Render(FormatBytes(info.Size))))
+	if cache.Reclaimed != "" {
+		content.WriteString(ui.SuccessStyle.Render(cache.Reclaimed))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	if len(info.Buckets) == 0 {
+		content.WriteString("The cache is empty.\n")
+	} else {
+		content.WriteString(renderCacheBuckets(info))
+	}
+
+	if len(info.Packages) > 0 {
+		content.WriteString("\n")
+		content.WriteString(renderCachePackages(cache))
+	}
+
+	content.WriteString("\n\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetCachePanelHelp()))
+
+	return content.String()
+}
+
+// renderCacheBuckets renders the size of each bucket with its share of the
+// cache.
+func renderCacheBuckets(info *types.CacheInfo) string {
+	var content strings.Builder
+
+	content.WriteString("By bucket:\n")
+	for _, bucket := range info.Buckets {
+		label, ok := cacheBucketLabels[bucket.Name]
+		if !ok {
+			label = bucket.Name
+		}
+		share := 0.0
+		if info.Size > 0 {
+			share = float64(bucket.Size) * 100 / float64(info.Size)
+		}
+		content.WriteString(fmt.Sprintf("  %-24s %10s %s\n", label, FormatBytes(bucket.Size), ui.HelpStyle.Render(fmt.Sprintf("%5.1f%%", share))))
+	}
+	return content.String()
+}
+
+// renderCachePackages renders the cached packages with their marks.
+func renderCachePackages(cache CacheState) string {
+	var content strings.Builder
+
+	packages := cache.Info.Packages
+	header := fmt.Sprintf("Cached packages (%d", len(packages))
+	if marked := len(cache.MarkedPackages()); marked > 0 {
+		header += fmt.Sprintf(", %d marked", marked)
+	}
+	content.WriteString(header + "):\n")
+
+	start, end := visibleRange(cache.Selected, len(packages), cachePackageRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		pkg := packages[i]
+		if i == cache.Selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		mark := "[ ]"
+		if cache.Marked[pkg.Name] {
+			mark = "[x]"
+		}
+		content.WriteString(fmt.Sprintf("%s %s %s\n", mark, pkg.Name, ui.AvailableVersionStyle.Render(FormatBytes(pkg.Size))))
+	}
+	if end < len(packages) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(packages)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// GetCachePanelHelp returns help text for the cache panel.
+func GetCachePanelHelp() string {
+	return "↑↓: Navigate | Enter: Mark package | d/Del: Clean marked packages | C: Clean all | p: Prune | P: Prune for CI | r: Refresh"
+}
@@ -0,0 +1,75 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func testCacheInfo() *types.CacheInfo {
+	return &types.CacheInfo{
+		Dir:  "/home/user/.cache/uv",
+		Size: 4 << 20,
+		Buckets: []types.CacheBucket{
+			{Name: types.CacheBucketWheels, Size: 3 << 20},
+			{Name: types.CacheBucketGit, Size: 1 << 20},
+		},
+		Packages: []types.CachePackage{
+			{Name: "httpx", Size: 2 << 20},
+			{Name: "numpy", Size: 1 << 20},
+		},
+	}
+}
+
+func TestRenderCachePanel(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Cache = CacheState{
+		Info:      testCacheInfo(),
+		Selected:  1,
+		Marked:    map[string]bool{"httpx": true},
+		Reclaimed: "Reclaimed 1.0 MiB by pruning the cache",
+	}
+
+	output := RenderCachePanel(state)
+	for _, want := range []string{"Directory: /home/user/.cache/uv", "Total size: 4.0 MiB", "Reclaimed 1.0 MiB", "Wheels", "3.0 MiB", "75.0%", "Git checkouts", "Cached packages (2, 1 marked)", "[x] httpx"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderCachePanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "numpy") {
+		t.Errorf("RenderCachePanel() should select numpy:\n%s", output)
+	}
+	if strings.Contains(output, "Source distributions") {
+		t.Error("RenderCachePanel() should leave out empty buckets")
+	}
+}
+
+func TestRenderCachePanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderCachePanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderCachePanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	state.Cache.Loading = true
+	if output := RenderCachePanel(state); !strings.Contains(output, "Measuring the cache") {
+		t.Errorf("RenderCachePanel() while loading = %q", output)
+	}
+
+	state.Cache.Loading = false
+	state.Cache.Info = &types.CacheInfo{Dir: "/cache"}
+	if output := RenderCachePanel(state); !strings.Contains(output, "The cache is empty") {
+		t.Errorf("RenderCachePanel() for an empty cache = %q", output)
+	}
+}
+
+func TestCacheState_MarkedPackages(t *testing.T) {
+	state := CacheState{Info: testCacheInfo(), Marked: map[string]bool{"numpy": true, "httpx": true, "gone": true}}
+	if got := strings.Join(state.MarkedPackages(), ","); got != "httpx,numpy" {
+		t.Errorf("MarkedPackages() = %q, want httpx,numpy", got)
+	}
+	if got := (CacheState{}).MarkedPackages(); got != nil {
+		t.Errorf("MarkedPackages() without cache info = %v", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"strings"
+)
+
+// Form IDs of the dependency dialogs.
+const (
+	AddDependencyForm    = "add-dependency"
+	RemoveDependencyForm = "remove-dependency"
+)
+
+// Keys of the dependency dialog fields.
+const (
+	DependencyFieldPackage   = "package"
+	DependencyFieldSpecifier = "specifier"
+	DependencyFieldTarget    = "target"
+	DependencyFieldGroup     = "group"
+	DependencyFieldEditable  = "editable"
+	DependencyFieldFrozen    = "frozen"
+	DependencyFieldNoSync    = "no-sync"
+)
+
+// DependencyTargets are the sections a dependency can be added to or removed
+// from, in the order the dialog cycles through them.
+var DependencyTargets = []string{"main", "dev", "optional", "group"}
+
+// Sources of a dependency, as detected from what was typed.
+const (
+	DependencySourceRegistry = "registry"
+	DependencySourcePath     = "path"
+	DependencySourceGit      = "git"
+	DependencySourceURL      = "url"
+)
+
+// NewDependencyForm returns the add (or remove) dependency dialog.
+func NewDependencyForm(remove bool) FormState {
+	form := FormState{
+		ID:    AddDependencyForm,
+		Title: "Add Dependency",
+		Fields: []FormField{
+			{Key: DependencyFieldPackage, Label: "Package", Kind: FieldText, Placeholder: "name, path or git URL"},
+			{Key: DependencyFieldSpecifier, Label: "Version", Kind: FieldText, Placeholder: ">=1.0, or 1.2.3 to pin"},
+			{Key: DependencyFieldTarget, Label: "Section", Kind: FieldChoice, Value: DependencyTargets[0], Choices: DependencyTargets},
+			{Key: DependencyFieldGroup, Label: "Group", Kind: FieldText},
+			{Key: DependencyFieldEditable, Label: "Editable", Kind: FieldToggle},
+			{Key: DependencyFieldFrozen, Label: "Frozen", Kind: FieldToggle, Hint: "don't lock or sync"},
+			{Key: DependencyFieldNoSync, Label: "No sync", Kind: FieldToggle, Hint: "lock without syncing"},
+		},
+	}
+	if remove {
+		form.ID = RemoveDependencyForm
+		form.Title = "Remove Dependency"
+		form.SetHidden(DependencyFieldSpecifier, true)
+		form.Field(DependencyFieldPackage).Placeholder = "name"
+	}
+	UpdateDependencyForm(&form)
+	return form
+}
+
+// UpdateDependencyForm shows the fields that apply to what has been entered
+// and previews the uv command.
+func UpdateDependencyForm(form *FormState) {
+	remove := form.ID == RemoveDependencyForm
+	target := form.Value(DependencyFieldTarget)
+
+	group := form.Field(DependencyFieldGroup)
+	group.Hidden = target != "optional" && target != "group"
+	group.Label = "Group"
+	if target == "optional" {
+		group.Label = "Extra"
+	}
+	form.SetHidden(DependencyFieldEditable, remove || DependencySource(form.Value(DependencyFieldPackage)) != DependencySourcePath)
+
+	form.Preview = ""
+	if pkg := form.Value(DependencyFieldPackage); pkg != "" && !remove {
+		form.Preview = "uv add " + DependencyRequirement(form)
+		if source := DependencySource(pkg); source != DependencySourceRegistry {
+			form.Preview += "  (" + source + " source)"
+		}
+	} else if pkg != "" {
+		form.Preview = "uv remove " + pkg
+	}
+}
+
+// DependencySource detects whether pkg names a registry package, a local path
+// or a direct URL (exported).
+func DependencySource(pkg string) string {
+	pkg = strings.TrimSpace(pkg)
+	switch {
+	case strings.HasPrefix(pkg, "git+"):
+		return DependencySourceGit
+	case strings.Contains(pkg, "://"):
+		return DependencySourceURL
+	case strings.HasPrefix(pkg, "."), strings.HasPrefix(pkg, "/"), strings.HasPrefix(pkg, "~"),
+		strings.Contains(pkg, "\\"), strings.HasSuffix(pkg, ".whl"), strings.HasSuffix(pkg, ".tar.gz"):
+		return DependencySourcePath
+	default:
+		return DependencySourceRegistry
+	}
+}
+
+// DependencyRequirement combines the package and version of the dialog into
+// the argument passed to uv add. A bare version becomes an exact pin, and the
+// version is ignored for paths and URLs, which carry their own (exported).
+func DependencyRequirement(form *FormState) string {
+	pkg := form.Value(DependencyFieldPackage)
+	spec := form.Value(DependencyFieldSpecifier)
+	if spec == "" || DependencySource(pkg) != DependencySourceRegistry {
+		return pkg
+	}
+	if strings.ContainsAny(spec[:1], "<>=!~") {
+		return pkg + spec
+	}
+	return pkg + "==" + spec
+}
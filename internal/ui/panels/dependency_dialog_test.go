@@ -0,0 +1,94 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+)
+
+// visibleFieldKeys returns the keys of the fields a form shows.
+func visibleFieldKeys(form FormState) string {
+	var keys []string
+	for _, field := range form.Fields {
+		if !field.Hidden {
+			keys = append(keys, field.Key)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestNewDependencyForm(t *testing.T) {
+	add := NewDependencyForm(false)
+	if got := visibleFieldKeys(add); got != "package,specifier,target,frozen,no-sync" {
+		t.Errorf("add dialog fields = %s", got)
+	}
+
+	remove := NewDependencyForm(true)
+	if remove.ID != RemoveDependencyForm || remove.Title != "Remove Dependency" {
+		t.Errorf("remove dialog = %s %q", remove.ID, remove.Title)
+	}
+	if got := visibleFieldKeys(remove); got != "package,target,frozen,no-sync" {
+		t.Errorf("remove dialog fields = %s", got)
+	}
+}
+
+func TestUpdateDependencyForm(t *testing.T) {
+	form := NewDependencyForm(false)
+	form.Field(DependencyFieldPackage).Value = "./libs/core"
+	form.Field(DependencyFieldTarget).Value = "optional"
+
+	UpdateDependencyForm(&form)
+
+	if got := visibleFieldKeys(form); got != "package,specifier,target,group,editable,frozen,no-sync" {
+		t.Errorf("fields for a path added to an extra = %s", got)
+	}
+	if label := form.Field(DependencyFieldGroup).Label; label != "Extra" {
+		t.Errorf("group label = %q, want Extra", label)
+	}
+	if form.Preview != "uv add ./libs/core  (path source)" {
+		t.Errorf("preview = %q", form.Preview)
+	}
+
+	// Hidden fields do not contribute values.
+	form.Field(DependencyFieldEditable).Checked = true
+	form.Field(DependencyFieldPackage).Value = "requests"
+	UpdateDependencyForm(&form)
+	if form.Checked(DependencyFieldEditable) {
+		t.Error("editable applies only to local paths")
+	}
+}
+
+func TestDependencySource(t *testing.T) {
+	tests := map[string]string{
+		"requests":                              DependencySourceRegistry,
+		"requests[socks]":                       DependencySourceRegistry,
+		"./libs/core":                           DependencySourcePath,
+		"/opt/wheels/demo-1.0-py3-none-any.whl": DependencySourcePath,
+		"git+https://github.com/encode/httpx":   DependencySourceGit,
+		"https://example.com/demo-1.0.tar.gz":   DependencySourceURL,
+	}
+	for pkg, want := range tests {
+		if got := DependencySource(pkg); got != want {
+			t.Errorf("DependencySource(%q) = %q, want %q", pkg, got, want)
+		}
+	}
+}
+
+func TestDependencyRequirement(t *testing.T) {
+	tests := []struct {
+		pkg, spec, want string
+	}{
+		{"requests", "", "requests"},
+		{"requests", ">=2.31,<3", "requests>=2.31,<3"},
+		{"requests", "2.32.3", "requests==2.32.3"},
+		{" requests ", " ~=2.31 ", "requests~=2.31"},
+		{"./libs/core", ">=1", "./libs/core"},
+	}
+	for _, tt := range tests {
+		form := NewDependencyForm(false)
+		form.Field(DependencyFieldPackage).Value = tt.pkg
+		form.Field(DependencyFieldSpecifier).Value = tt.spec
+		if got := DependencyRequirement(&form); got != tt.want {
+			t.Errorf("DependencyRequirement(%q, %q) = %q, want %q", tt.pkg, tt.spec, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// DiagnosticsFilterForm is the form ID of the diagnostics filter dialog.
+const DiagnosticsFilterForm = "filter-diagnostics"
+
+// Keys of the diagnostics filter dialog fields.
+const (
+	DiagnosticsFieldTool = "tool"
+	DiagnosticsFieldRule = "rule"
+	DiagnosticsFieldFile = "file"
+)
+
+// DiagnosticsTools are the tools the Diagnostics panel runs, in order.
+var DiagnosticsTools = []string{types.DiagnosticsRuff, types.DiagnosticsMypy}
+
+// allTools is the tool filter choice that shows every tool.
+const allTools = "all"
+
+// diagnosticRows is the number of diagnostics shown at once.
+const diagnosticRows = 12
+
+// severityStyles render the severity of a diagnostic.
+var severityStyles = map[string]func(...string) string{
+	types.SeverityError:   ui.ErrorStyle.Render,
+	types.SeverityWarning: ui.WarningMessageStyle.Render,
+	types.SeverityNote:    ui.HelpStyle.Render,
+}
+
+// DiagnosticsFilter narrows the diagnostics listed.
+type DiagnosticsFilter struct {
+	Tool string // empty for every tool
+	Rule string // rule code prefix, e.g. "F4" or "E501", matched ignoring case
+	File string // part of the file path
+}
+
+// Active reports whether the filter hides anything (exported).
+func (f DiagnosticsFilter) Active() bool {
+	return f.Tool != "" || f.Rule != "" || f.File != ""
+}
+
+// Matches reports whether a diagnostic passes the filter (exported).
+func (f DiagnosticsFilter) Matches(d types.Diagnostic) bool {
+	if f.Tool != "" && d.Tool != f.Tool {
+		return false
+	}
+	if f.Rule != "" && !strings.HasPrefix(strings.ToLower(d.Rule), strings.ToLower(f.Rule)) {
+		return false
+	}
+	return f.File == "" || strings.Contains(d.File, f.File)
+}
+
+// String describes the filter, e.g. "ruff, rule F4, file src/".
+func (f DiagnosticsFilter) String() string {
+	var parts []string
+	if f.Tool != "" {
+		parts = append(parts, f.Tool)
+	}
+	if f.Rule != "" {
+		parts = append(parts, "rule "+f.Rule)
+	}
+	if f.File != "" {
+		parts = append(parts, "file "+f.File)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DiagnosticsState represents the state of the diagnostics panel.
+type DiagnosticsState struct {
+	Diagnostics []types.Diagnostic // nil until the checks have run
+	Failures    map[string]error   // tools that could not run
+	Filter      DiagnosticsFilter
+	Selected    int
+}
+
+// SetDiagnostics shows the diagnostics of a run of the checks, sorted by
+// location (exported).
+func (s *DiagnosticsState) SetDiagnostics(diagnostics []types.Diagnostic, failures map[string]error) {
+	sorted := append([]types.Diagnostic{}, diagnostics...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	s.Diagnostics = sorted
+	s.Failures = failures
+	s.Selected = clampSelection(s.Selected, len(s.Visible()))
+}
+
+// Visible returns the diagnostics that pass the filter (exported).
+func (s DiagnosticsState) Visible() []types.Diagnostic {
+	if !s.Filter.Active() {
+		return s.Diagnostics
+	}
+	var visible []types.Diagnostic
+	for _, d := range s.Diagnostics {
+		if s.Filter.Matches(d) {
+			visible = append(visible, d)
+		}
+	}
+	return visible
+}
+
+// countSeverity returns the number of diagnostics with the given severity.
+func countSeverity(diagnostics []types.Diagnostic, severity string) int {
+	n := 0
+	for _, d := range diagnostics {
+		if d.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// RenderDiagnosticsPanel renders the diagnostics panel.
+func RenderDiagnosticsPanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Diagnostics (ruff, mypy)\n\n")
+
+	if !state.Installed {
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to run the checks."))
+		return content.String()
+	}
+
+	diagnostics := state.Diagnostics
+	if diagnostics.Diagnostics == nil && len(diagnostics.Failures) == 0 {
+		content.WriteString("No checks have run yet.\n")
+		content.WriteString("Press 'r' to run ruff check and mypy on the project.")
+		content.WriteString("\n\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetDiagnosticsPanelHelp()))
+		return content.String()
+	}
+
+	for _, tool := range DiagnosticsTools {
+		if err, ok := diagnostics.Failures[tool]; ok {
+			content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("%s could not run: %v", tool, err)))
+			content.WriteString("\n")
+		}
+	}
+
+	all := diagnostics.Diagnostics
+	content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("✗ %d errors", countSeverity(all, types.SeverityError))))
+	content.WriteString("  " + ui.WarningMessageStyle.Render(fmt.Sprintf("⚠ %d warnings", countSeverity(all, types.SeverityWarning))))
+	content.WriteString("  " + ui.HelpStyle.Render(fmt.Sprintf("· %d notes", countSeverity(all, types.SeverityNote))))
+	content.WriteString("\n")
+
+	visible := diagnostics.Visible()
+	if diagnostics.Filter.Active() {
+		content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("Filter: %s", diagnostics.Filter)))
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf(" (%d of %d shown)", len(visible), len(all))))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	switch {
+	case len(all) == 0 && len(diagnostics.Failures) == 0:
+		content.WriteString(ui.SuccessStyle.Render("No problems found."))
+		content.WriteString("\n")
+	case len(visible) == 0:
+		content.WriteString(ui.UnselectedItemStyle.Render("No diagnostics match the filter."))
+		content.WriteString("\n")
+	default:
+		content.WriteString(renderDiagnosticList(visible, diagnostics.Selected))
+		if diagnostics.Selected >= 0 && diagnostics.Selected < len(visible) {
+			content.WriteString("\n")
+			content.WriteString(renderDiagnosticDetails(visible[diagnostics.Selected]))
+		}
+	}
+
+	content.WriteString("\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetDiagnosticsPanelHelp()))
+
+	return content.String()
+}
+
+// renderDiagnosticList renders the visible diagnostics, one per line as
+// file:line:column severity rule message.
+func renderDiagnosticList(diagnostics []types.Diagnostic, selected int) string {
+	var content strings.Builder
+
+	start, end := visibleRange(selected, len(diagnostics), diagnosticRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		d := diagnostics[i]
+		if i == selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(d.Location() + " " + renderSeverity(d.Severity))
+		if d.Rule != "" {
+			content.WriteString(ui.HelpStyle.Render(" " + d.Rule))
+		}
+		content.WriteString(" " + firstLine(d.Message))
+		content.WriteString("\n")
+	}
+	if end < len(diagnostics) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(diagnostics)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderDiagnosticDetails renders the tool, rule and full message of a
+// diagnostic.
+func renderDiagnosticDetails(d types.Diagnostic) string {
+	var content strings.Builder
+
+	source := d.Tool
+	if d.Rule != "" {
+		source += " " + d.Rule
+	}
+	content.WriteString(ui.InfoMessageStyle.Render("  " + d.Location()))
+	content.WriteString(ui.HelpStyle.Render(" (" + source + ")"))
+	content.WriteString("\n")
+	for _, line := range strings.Split(d.Message, "\n") {
+		content.WriteString("    " + line + "\n")
+	}
+	if d.Fixable {
+		content.WriteString(ui.SuccessStyle.Render("    Fixable with ruff check --fix"))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderSeverity renders the severity of a diagnostic.
+func renderSeverity(severity string) string {
+	if render, ok := severityStyles[severity]; ok {
+		return render(severity)
+	}
+	return severity
+}
+
+// NewDiagnosticsFilterForm returns the diagnostics filter dialog, filled in
+// from filter.
+func NewDiagnosticsFilterForm(filter DiagnosticsFilter, diagnostics []types.Diagnostic) FormState {
+	tool := filter.Tool
+	if tool == "" {
+		tool = allTools
+	}
+	form := FormState{
+		ID:    DiagnosticsFilterForm,
+		Title: "Filter Diagnostics",
+		Fields: []FormField{
+			{Key: DiagnosticsFieldTool, Label: "Tool", Kind: FieldChoice, Value: tool, Choices: append([]string{allTools}, DiagnosticsTools...)},
+			{Key: DiagnosticsFieldRule, Label: "Rule", Kind: FieldText, Value: filter.Rule, Placeholder: "code or prefix, e.g. F4"},
+			{Key: DiagnosticsFieldFile, Label: "File", Kind: FieldText, Value: filter.File, Placeholder: "part of the path"},
+		},
+	}
+	UpdateDiagnosticsFilterForm(&form, diagnostics)
+	return form
+}
+
+// UpdateDiagnosticsFilterForm previews how many diagnostics the filter of the
+// dialog shows.
+func UpdateDiagnosticsFilterForm(form *FormState, diagnostics []types.Diagnostic) {
+	filter := DiagnosticsFilterFormValue(form)
+	n := 0
+	for _, d := range diagnostics {
+		if filter.Matches(d) {
+			n++
+		}
+	}
+	form.Preview = fmt.Sprintf("%d of %d diagnostics shown", n, len(diagnostics))
+}
+
+// DiagnosticsFilterFormValue returns the filter entered in the dialog
+// (exported).
+func DiagnosticsFilterFormValue(form *FormState) DiagnosticsFilter {
+	filter := DiagnosticsFilter{
+		Tool: form.Value(DiagnosticsFieldTool),
+		Rule: strings.TrimSpace(form.Value(DiagnosticsFieldRule)),
+		File: strings.TrimSpace(form.Value(DiagnosticsFieldFile)),
+	}
+	if filter.Tool == allTools {
+		filter.Tool = ""
+	}
+	return filter
+}
+
+// GetDiagnosticsPanelHelp returns help text for the diagnostics panel.
+func GetDiagnosticsPanelHelp() string {
+	return "↑↓: Navigate | Enter: Open in editor | /: Filter | F: ruff --fix | r: Run checks"
+}
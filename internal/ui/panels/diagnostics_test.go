@@ -0,0 +1,133 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func newDiagnosticsTestState() *AppState {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Diagnostics.SetDiagnostics([]types.Diagnostic{
+		{Tool: types.DiagnosticsMypy, File: "src/app.py", Line: 12, Column: 5, Severity: types.SeverityError, Rule: "assignment", Message: "Incompatible types in assignment"},
+		{Tool: types.DiagnosticsRuff, File: "src/app.py", Line: 1, Column: 8, Severity: types.SeverityError, Rule: "F401", Message: "`os` imported but unused", Fixable: true},
+		{Tool: types.DiagnosticsMypy, File: "tests/test_app.py", Line: 3, Severity: types.SeverityNote, Message: "See the docs"},
+	}, nil)
+	return state
+}
+
+func TestDiagnosticsState_SetDiagnostics(t *testing.T) {
+	state := newDiagnosticsTestState()
+
+	var got []string
+	for _, d := range state.Diagnostics.Diagnostics {
+		got = append(got, d.Location())
+	}
+	if want := "src/app.py:1:8 src/app.py:12:5 tests/test_app.py:3"; strings.Join(got, " ") != want {
+		t.Errorf("SetDiagnostics() order = %v, want %s", got, want)
+	}
+
+	state.Diagnostics.Selected = 2
+	state.Diagnostics.Filter = DiagnosticsFilter{Tool: types.DiagnosticsRuff}
+	state.Diagnostics.SetDiagnostics(state.Diagnostics.Diagnostics, nil)
+	if state.Diagnostics.Selected != 0 {
+		t.Errorf("SetDiagnostics() Selected = %d, want 0", state.Diagnostics.Selected)
+	}
+}
+
+func TestDiagnosticsFilter(t *testing.T) {
+	state := newDiagnosticsTestState()
+
+	tests := []struct {
+		filter DiagnosticsFilter
+		want   int
+		text   string
+	}{
+		{DiagnosticsFilter{}, 3, ""},
+		{DiagnosticsFilter{Tool: types.DiagnosticsMypy}, 2, "mypy"},
+		{DiagnosticsFilter{Rule: "f4"}, 1, "rule f4"},
+		{DiagnosticsFilter{Tool: types.DiagnosticsMypy, File: "src/"}, 1, "mypy, file src/"},
+		{DiagnosticsFilter{Rule: "E501"}, 0, "rule E501"},
+	}
+	for _, tt := range tests {
+		state.Diagnostics.Filter = tt.filter
+		if got := len(state.Diagnostics.Visible()); got != tt.want {
+			t.Errorf("Visible() with %+v = %d diagnostics, want %d", tt.filter, got, tt.want)
+		}
+		if got := tt.filter.String(); got != tt.text {
+			t.Errorf("String() = %q, want %q", got, tt.text)
+		}
+	}
+}
+
+func TestRenderDiagnosticsPanel(t *testing.T) {
+	state := newDiagnosticsTestState()
+
+	output := RenderDiagnosticsPanel(state)
+	for _, want := range []string{"✗ 2 errors", "⚠ 0 warnings", "· 1 notes", "src/app.py:1:8", "F401", "`os` imported but unused", "tests/test_app.py:3", "(ruff F401)", "Fixable with ruff check --fix", GetDiagnosticsPanelHelp()} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderDiagnosticsPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "src/app.py:1:8") {
+		t.Errorf("RenderDiagnosticsPanel() should select the first diagnostic:\n%s", output)
+	}
+
+	state.Diagnostics.Filter = DiagnosticsFilter{Tool: types.DiagnosticsMypy}
+	output = RenderDiagnosticsPanel(state)
+	if !strings.Contains(output, "Filter: mypy") || !strings.Contains(output, "(2 of 3 shown)") || strings.Contains(output, "F401") {
+		t.Errorf("RenderDiagnosticsPanel() with a filter:\n%s", output)
+	}
+
+	state.Diagnostics.Filter = DiagnosticsFilter{Rule: "E501"}
+	if output := RenderDiagnosticsPanel(state); !strings.Contains(output, "No diagnostics match the filter.") {
+		t.Errorf("RenderDiagnosticsPanel() with nothing shown:\n%s", output)
+	}
+}
+
+func TestRenderDiagnosticsPanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderDiagnosticsPanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderDiagnosticsPanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	if output := RenderDiagnosticsPanel(state); !strings.Contains(output, "No checks have run yet.") {
+		t.Errorf("RenderDiagnosticsPanel() before a run = %q", output)
+	}
+
+	state.Diagnostics.SetDiagnostics([]types.Diagnostic{}, nil)
+	if output := RenderDiagnosticsPanel(state); !strings.Contains(output, "No problems found.") {
+		t.Errorf("RenderDiagnosticsPanel() with no diagnostics = %q", output)
+	}
+
+	state.Diagnostics.SetDiagnostics([]types.Diagnostic{}, map[string]error{types.DiagnosticsMypy: fmt.Errorf("exit status 2")})
+	output := RenderDiagnosticsPanel(state)
+	if !strings.Contains(output, "mypy could not run: exit status 2") || strings.Contains(output, "No problems found.") {
+		t.Errorf("RenderDiagnosticsPanel() with a failure = %q", output)
+	}
+}
+
+func TestDiagnosticsFilterForm(t *testing.T) {
+	state := newDiagnosticsTestState()
+
+	form := NewDiagnosticsFilterForm(DiagnosticsFilter{}, state.Diagnostics.Diagnostics)
+	if form.ID != DiagnosticsFilterForm || form.Value(DiagnosticsFieldTool) != "all" {
+		t.Fatalf("NewDiagnosticsFilterForm() = %+v", form)
+	}
+	if form.Preview != "3 of 3 diagnostics shown" {
+		t.Errorf("Preview = %q", form.Preview)
+	}
+
+	form.Fields[0].Value = types.DiagnosticsMypy
+	form.Fields[2].Value = " tests/ "
+	UpdateDiagnosticsFilterForm(&form, state.Diagnostics.Diagnostics)
+	if form.Preview != "1 of 3 diagnostics shown" {
+		t.Errorf("Preview = %q", form.Preview)
+	}
+	if got, want := DiagnosticsFilterFormValue(&form), (DiagnosticsFilter{Tool: types.DiagnosticsMypy, File: "tests/"}); got != want {
+		t.Errorf("DiagnosticsFilterFormValue() = %+v, want %+v", got, want)
+	}
+}
@@ -2,26 +2,202 @@
 package panels
 
 import (
+	"fmt"
+	"strings"
+
+	"uvui/internal/types"
 	"uvui/internal/ui"
 )
 
+// CreateVenvForm is the form ID of the create environment dialog.
+const CreateVenvForm = "create-venv"
+
+// Keys of the create environment dialog fields.
+const (
+	VenvFieldPath               = "path"
+	VenvFieldPython             = "python"
+	VenvFieldPrompt             = "prompt"
+	VenvFieldSeed               = "seed"
+	VenvFieldSystemSitePackages = "system-site-packages"
+)
+
+// EnvironmentState represents the state of the environment panel.
+type EnvironmentState struct {
+	Environments []types.VirtualEnv
+	Selected     int
+	Loading      bool
+	Packages     PackagesViewState
+}
+
 // RenderEnvironmentPanel renders the environment management panel.
 func RenderEnvironmentPanel(state *AppState) string {
-	content := "Environment Management\n\n"
+	var content strings.Builder
+
+	content.WriteString("Environment Management\n\n")
 
 	if !state.Installed {
-		content += ui.ErrorStyle.Render("UV must be installed first to manage environments.")
-		return content
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to manage environments."))
+		return content.String()
+	}
+
+	if state.Environments.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Looking for virtual environments..."))
+		return content.String()
+	}
+
+	if state.Environments.Packages.Visible {
+		content.WriteString(renderPackagesView(state.Environments.Packages))
+		content.WriteString("\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetPackagesViewHelp()))
+		return content.String()
+	}
+
+	envs := state.Environments.Environments
+	if len(envs) == 0 {
+		content.WriteString("No virtual environments found.\n")
+		content.WriteString("Press 'n' to create one with uv venv.")
+	} else {
+		content.WriteString(fmt.Sprintf("Virtual environments (%d):\n\n", len(envs)))
+		for i, env := range envs {
+			selected := i == state.Environments.Selected
+			content.WriteString(renderEnvironmentLine(env, selected))
+			content.WriteString("\n")
+			if selected {
+				content.WriteString(renderEnvironmentDetails(env))
+			}
+		}
 	}
 
-	content += "Virtual environments:\n"
-	content += "• No virtual environments found\n"
-	content += "• Use 'uv venv' to create environments (not implemented yet)\n"
+	content.WriteString("\n\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetEnvironmentPanelHelp()))
 
-	return content
+	return content.String()
+}
+
+// renderEnvironmentLine renders a single virtual environment.
+func renderEnvironmentLine(env types.VirtualEnv, selected bool) string {
+	var line strings.Builder
+
+	if selected {
+		line.WriteString(ui.SelectedItemStyle.Render("> "))
+	} else {
+		line.WriteString("  ")
+	}
+
+	if env.Project {
+		line.WriteString(ui.CurrentVersionStyle.Render(env.Name))
+	} else {
+		line.WriteString(env.Name)
+	}
+
+	python := env.PythonVersion
+	if env.Implementation != "" && python != "" {
+		python = env.Implementation + " " + python
+	}
+	if python != "" {
+		line.WriteString("  " + ui.InstalledVersionStyle.Render(python))
+	}
+	line.WriteString(ui.AvailableVersionStyle.Render("  " + FormatBytes(env.Size)))
+
+	if env.Project {
+		line.WriteString(" " + ui.PinnedVersionStyle.Render("[project]"))
+	}
+	if env.Active {
+		line.WriteString(" " + ui.SuccessStyle.Render("[active]"))
+	}
+	if env.Error != nil {
+		line.WriteString(" " + ui.ErrorStyle.Render(fmt.Sprintf("(unreadable: %v)", env.Error)))
+	}
+
+	return line.String()
+}
+
+// renderEnvironmentDetails renders the interpreter and options of the
+// selected environment.
+func renderEnvironmentDetails(env types.VirtualEnv) string {
+	details := []string{"Path: " + env.Path, "Interpreter: " + env.Interpreter}
+	if env.BaseInterpreter != "" {
+		details = append(details, "Created from: "+env.BaseInterpreter)
+	}
+	if env.Prompt != "" {
+		details = append(details, "Prompt: "+env.Prompt)
+	}
+
+	var options []string
+	if env.Seeded {
+		options = append(options, "seeded with pip")
+	}
+	if env.SystemSitePackages {
+		options = append(options, "system site packages")
+	}
+	if env.UVVersion != "" {
+		options = append(options, "created by uv "+env.UVVersion)
+	}
+	if len(options) > 0 {
+		details = append(details, strings.Join(options, ", "))
+	}
+
+	var content strings.Builder
+	for _, detail := range details {
+		content.WriteString(ui.HelpStyle.Render("    " + detail))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// NewVenvForm returns the create environment dialog, with python as the
+// suggested interpreter.
+func NewVenvForm(python string) FormState {
+	form := FormState{
+		ID:    CreateVenvForm,
+		Title: "Create Virtual Environment",
+		Fields: []FormField{
+			{Key: VenvFieldPath, Label: "Path", Kind: FieldText, Placeholder: ".venv"},
+			{Key: VenvFieldPython, Label: "Python", Kind: FieldText, Value: python, Placeholder: "version or path, e.g. 3.12"},
+			{Key: VenvFieldPrompt, Label: "Prompt", Kind: FieldText, Placeholder: "shown when activated"},
+			{Key: VenvFieldSeed, Label: "Seed", Kind: FieldToggle, Hint: "install pip, setuptools and wheel"},
+			{Key: VenvFieldSystemSitePackages, Label: "System site packages", Kind: FieldToggle, Hint: "see the base interpreter's packages"},
+		},
+	}
+	UpdateVenvForm(&form)
+	return form
+}
+
+// UpdateVenvForm previews the uv command of the create environment dialog.
+func UpdateVenvForm(form *FormState) {
+	options := VenvFormOptions(form)
+	args := []string{"uv", "venv"}
+	if options.Path != "" {
+		args = append(args, options.Path)
+	}
+	if options.PythonVersion != "" {
+		args = append(args, "--python", options.PythonVersion)
+	}
+	if options.Prompt != "" {
+		args = append(args, "--prompt", options.Prompt)
+	}
+	if options.Seed {
+		args = append(args, "--seed")
+	}
+	if options.SystemSitePackages {
+		args = append(args, "--system-site-packages")
+	}
+	form.Preview = strings.Join(args, " ")
+}
+
+// VenvFormOptions returns the options entered in the create environment
+// dialog (exported).
+func VenvFormOptions(form *FormState) types.VenvOptions {
+	return types.VenvOptions{
+		Path:               form.Value(VenvFieldPath),
+		PythonVersion:      form.Value(VenvFieldPython),
+		Prompt:             form.Value(VenvFieldPrompt),
+		Seed:               form.Checked(VenvFieldSeed),
+		SystemSitePackages: form.Checked(VenvFieldSystemSitePackages),
+	}
 }
 
 // GetEnvironmentPanelHelp returns help text for the environment panel.
 func GetEnvironmentPanelHelp() string {
-	return "Coming in Phase 5: Environment operations"
+	return "↑↓: Navigate | Enter: Installed packages | n: Create | R: Recreate | d/Del: Delete | r: Refresh"
 }
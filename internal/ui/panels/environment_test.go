@@ -0,0 +1,74 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestRenderEnvironmentPanel(t *testing.T) {
+	state := &AppState{
+		UVStatus: types.UVStatus{Installed: true},
+		Environments: EnvironmentState{
+			Environments: []types.VirtualEnv{
+				{Name: ".venv", Path: "/project/.venv", Interpreter: "/project/.venv/bin/python", Implementation: "CPython", PythonVersion: "3.12.7", Size: 3 << 20, Project: true, UVVersion: "0.5.4"},
+				{Name: "envs/py311", Path: "/project/envs/py311", PythonVersion: "3.11.9", Active: true},
+				{Name: "envs/broken", Path: "/project/envs/broken", Error: fmt.Errorf("permission denied")},
+			},
+		},
+	}
+
+	output := RenderEnvironmentPanel(state)
+	for _, want := range []string{"Virtual environments (3)", "CPython 3.12.7", "3.0 MiB", "[project]", "[active]", "Interpreter: /project/.venv/bin/python", "created by uv 0.5.4", "envs/broken", "(unreadable: permission denied)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderEnvironmentPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, ".venv") {
+		t.Errorf("RenderEnvironmentPanel() should select .venv:\n%s", output)
+	}
+	if strings.Contains(output, "Path: /project/envs/py311") {
+		t.Error("RenderEnvironmentPanel() should only show details of the selected environment")
+	}
+}
+
+func TestRenderEnvironmentPanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderEnvironmentPanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderEnvironmentPanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	state.Environments.Loading = true
+	if output := RenderEnvironmentPanel(state); !strings.Contains(output, "Looking for virtual environments") {
+		t.Errorf("RenderEnvironmentPanel() while loading = %q", output)
+	}
+
+	state.Environments.Loading = false
+	if output := RenderEnvironmentPanel(state); !strings.Contains(output, "No virtual environments found") {
+		t.Errorf("RenderEnvironmentPanel() without environments = %q", output)
+	}
+}
+
+func TestVenvForm(t *testing.T) {
+	form := NewVenvForm("3.12")
+	if form.Preview != "uv venv --python 3.12" {
+		t.Errorf("NewVenvForm() preview = %q", form.Preview)
+	}
+
+	form.Field(VenvFieldPath).Value = " envs/test "
+	form.Field(VenvFieldPrompt).Value = "test"
+	form.Field(VenvFieldSystemSitePackages).Checked = true
+	UpdateVenvForm(&form)
+	if want := "uv venv envs/test --python 3.12 --prompt test --system-site-packages"; form.Preview != want {
+		t.Errorf("UpdateVenvForm() preview = %q, want %q", form.Preview, want)
+	}
+
+	options := VenvFormOptions(&form)
+	want := types.VenvOptions{Path: "envs/test", PythonVersion: "3.12", Prompt: "test", SystemSitePackages: true}
+	if options != want {
+		t.Errorf("VenvFormOptions() = %+v, want %+v", options, want)
+	}
+}
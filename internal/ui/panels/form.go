@@ -0,0 +1,268 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"uvui/internal/ui"
+)
+
+// Kinds of form fields.
+const (
+	FieldText   = iota // free text, edited through the text input
+	FieldToggle        // an on/off option
+	FieldChoice        // one of a fixed list of values
+)
+
+// FormField is a labelled field of a form.
+type FormField struct {
+	Key         string
+	Label       string
+	Kind        int
+	Value       string // text, or the selected choice
+	Checked     bool   // toggle state
+	Choices     []string
+	Placeholder string
+	Hint        string // shown after a toggle, e.g. "don't lock or sync"
+	Hidden      bool
+}
+
+// FormState represents a modal dialog made of fields. ID tells the model what
+// to do when the form is submitted.
+type FormState struct {
+	Visible bool
+	ID      string
+	Title   string
+	Fields  []FormField
+	Focus   int    // index of the focused field
+	Cursor  int    // cursor position within the focused text field
+	Preview string // e.g. the command the form will run
+	Error   string
+	Details []string // further explanation of Error, one line each
+}
+
+// Field returns the field with the given key, or nil.
+func (f *FormState) Field(key string) *FormField {
+	for i := range f.Fields {
+		if f.Fields[i].Key == key {
+			return &f.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Value returns the trimmed value of a field, or "" if there is no such field
+// or it is hidden.
+func (f *FormState) Value(key string) string {
+	if field := f.Field(key); field != nil && !field.Hidden {
+		return strings.TrimSpace(field.Value)
+	}
+	return ""
+}
+
+// Checked reports whether a toggle field is on and shown.
+func (f *FormState) Checked(key string) bool {
+	field := f.Field(key)
+	return field != nil && !field.Hidden && field.Checked
+}
+
+// SetHidden shows or hides a field.
+func (f *FormState) SetHidden(key string, hidden bool) {
+	if field := f.Field(key); field != nil {
+		field.Hidden = hidden
+	}
+}
+
+// Focused returns the focused field, or nil.
+func (f *FormState) Focused() *FormField {
+	if f.Focus < 0 || f.Focus >= len(f.Fields) {
+		return nil
+	}
+	return &f.Fields[f.Focus]
+}
+
+// MoveFocus moves the focus by delta visible fields, wrapping around.
+func (f *FormState) MoveFocus(delta int) {
+	var visible []int
+	current := 0
+	for i, field := range f.Fields {
+		if field.Hidden {
+			continue
+		}
+		if i == f.Focus {
+			current = len(visible)
+		}
+		visible = append(visible, i)
+	}
+	if len(visible) == 0 {
+		return
+	}
+	f.Focus = visible[((current+delta)%len(visible)+len(visible))%len(visible)]
+}
+
+// Change cycles the focused choice field by delta, or flips the focused
+// toggle. It reports whether anything changed.
+func (f *FormState) Change(delta int) bool {
+	field := f.Focused()
+	if field == nil {
+		return false
+	}
+	switch field.Kind {
+	case FieldToggle:
+		field.Checked = !field.Checked
+		return true
+	case FieldChoice:
+		if len(field.Choices) == 0 {
+			return false
+		}
+		current := 0
+		for i, choice := range field.Choices {
+			if choice == field.Value {
+				current = i
+			}
+		}
+		n := len(field.Choices)
+		field.Value = field.Choices[((current+delta)%n+n)%n]
+		return true
+	}
+	return false
+}
+
+// RenderForm renders a form.
+func RenderForm(form FormState) string {
+	var content strings.Builder
+
+	content.WriteString(ui.CurrentVersionStyle.Render(form.Title))
+	content.WriteString("\n")
+
+	width := 0
+	for _, field := range form.Fields {
+		if !field.Hidden && len(field.Label) > width {
+			width = len(field.Label)
+		}
+	}
+
+	for i, field := range form.Fields {
+		if field.Hidden {
+			continue
+		}
+		line := fmt.Sprintf("%-*s %s", width+1, field.Label+":", renderFormValue(field, i == form.Focus, form.Cursor))
+		if i == form.Focus {
+			content.WriteString(ui.SelectedItemStyle.Render("> ") + line)
+		} else {
+			content.WriteString("  " + ui.UnselectedItemStyle.Render(line))
+		}
+		content.WriteString("\n")
+	}
+
+	if form.Preview != "" {
+		content.WriteString(ui.HelpStyle.Render("  " + form.Preview))
+		content.WriteString("\n")
+	}
+
+	if form.Error != "" {
+		content.WriteString("\n")
+		content.WriteString(ui.ErrorStyle.Render("  " + form.Error))
+		content.WriteString("\n")
+		for _, line := range form.Details {
+			content.WriteString(ui.UnselectedItemStyle.Render("    " + line))
+			content.WriteString("\n")
+		}
+	}
+
+	content.WriteString("\n")
+	content.WriteString(ui.HelpStyle.Render(GetFormHelp()))
+	return content.String()
+}
+
+// renderFormValue renders the value of a field, with the cursor if it is the
+// focused text field.
+func renderFormValue(field FormField, focused bool, cursor int) string {
+	switch field.Kind {
+	case FieldToggle:
+		value := "[ ]"
+		if field.Checked {
+			value = "[x]"
+		}
+		if field.Hint != "" {
+			value += " " + field.Hint
+		}
+		return value
+	case FieldChoice:
+		return "◂ " + field.Value + " ▸"
+	}
+
+	value := field.Value
+	if focused {
+		runes := []rune(value)
+		cursor = min(max(cursor, 0), len(runes))
+		value = string(runes[:cursor]) + "▏" + string(runes[cursor:])
+	}
+	if field.Value == "" && field.Placeholder != "" {
+		value += ui.HelpStyle.Render(field.Placeholder)
+	}
+	return value
+}
+
+// SplitArgs splits a command line into arguments the way a POSIX shell would
+// for plain words, single and double quotes and backslash escapes. It does not
+// expand variables or globs (exported).
+func SplitArgs(line string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// JoinArgs joins arguments into a command line that SplitArgs splits back
+// into the same arguments, quoting those that need it (exported).
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// GetFormHelp returns help text for forms.
+func GetFormHelp() string {
+	return "↑↓/Tab: Move | ←→/Space: Change | Enter: Run | Esc: Cancel"
+}
@@ -0,0 +1,119 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+)
+
+func testForm() FormState {
+	return FormState{
+		ID:    "test",
+		Title: "Test Form",
+		Fields: []FormField{
+			{Key: "name", Label: "Name", Kind: FieldText, Value: "demo"},
+			{Key: "hidden", Label: "Hidden", Kind: FieldText, Hidden: true},
+			{Key: "kind", Label: "Kind", Kind: FieldChoice, Value: "a", Choices: []string{"a", "b", "c"}},
+			{Key: "seed", Label: "Seed", Kind: FieldToggle, Hint: "install pip"},
+		},
+	}
+}
+
+func TestFormState_MoveFocus(t *testing.T) {
+	form := testForm()
+
+	form.MoveFocus(1)
+	if form.Focus != 2 {
+		t.Errorf("MoveFocus(1) focused field %d, want 2 (skipping the hidden field)", form.Focus)
+	}
+	form.MoveFocus(2)
+	if form.Focus != 0 {
+		t.Errorf("MoveFocus(2) focused field %d, want 0 (wrapping around)", form.Focus)
+	}
+	form.MoveFocus(-1)
+	if form.Focus != 3 {
+		t.Errorf("MoveFocus(-1) focused field %d, want 3", form.Focus)
+	}
+}
+
+func TestFormState_Change(t *testing.T) {
+	form := testForm()
+
+	if form.Change(1) {
+		t.Error("Change() on a text field should do nothing")
+	}
+	form.Focus = 2
+	form.Change(-1)
+	if got := form.Value("kind"); got != "c" {
+		t.Errorf("Change(-1) selected %q, want c", got)
+	}
+	form.Focus = 3
+	form.Change(1)
+	if !form.Checked("seed") {
+		t.Error("Change() should flip the toggle")
+	}
+}
+
+func TestFormState_Values(t *testing.T) {
+	form := testForm()
+	form.Field("name").Value = "  spaced  "
+	form.Field("hidden").Value = "secret"
+
+	if got := form.Value("name"); got != "spaced" {
+		t.Errorf("Value(name) = %q, want spaced", got)
+	}
+	if got := form.Value("hidden"); got != "" {
+		t.Errorf("Value(hidden) = %q, want empty for a hidden field", got)
+	}
+	if form.Field("missing") != nil || form.Value("missing") != "" {
+		t.Error("missing fields should have no value")
+	}
+}
+
+func TestRenderForm(t *testing.T) {
+	form := testForm()
+	form.Cursor = 2
+	form.Preview = "uv venv demo"
+	form.Error = "Failed to create demo"
+	form.Details = []string{"error: No interpreter found"}
+
+	result := RenderForm(form)
+
+	expected := []string{"Test Form", "Name:", "de▏mo", "◂ a ▸", "[ ] install pip", "uv venv demo", "Failed to create demo", "No interpreter found", "Esc: Cancel"}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in form, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "Hidden") {
+		t.Errorf("Hidden fields should not be rendered, got:\n%s", result)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"  check  . ", []string{"check", "."}},
+		{`--select "E501 W" 'it''s'`, []string{"--select", "E501 W", "its"}},
+		{`a\ b "c\"d" ''`, []string{"a b", `c"d`, ""}},
+	}
+	for _, tt := range tests {
+		got := SplitArgs(tt.line)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestJoinArgs(t *testing.T) {
+	args := []string{"check", "my dir", "it's", "", `a"b`}
+	line := JoinArgs(args)
+	if line != `check 'my dir' 'it'\''s' '' 'a"b'` {
+		t.Errorf("JoinArgs() = %q", line)
+	}
+	if got := SplitArgs(line); strings.Join(got, "|") != strings.Join(args, "|") || len(got) != len(args) {
+		t.Errorf("SplitArgs(JoinArgs()) = %q, want %q", got, args)
+	}
+}
@@ -0,0 +1,89 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// JobsState represents the state of the jobs panel.
+type JobsState struct {
+	Jobs     []types.Job
+	Selected int
+	Limit    int
+}
+
+// RenderJobsPanel renders the background job queue.
+func RenderJobsPanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Background Jobs\n\n")
+
+	running, queued := 0, 0
+	for _, job := range state.Jobs.Jobs {
+		switch job.State {
+		case types.JobRunning:
+			running++
+		case types.JobQueued:
+			queued++
+		}
+	}
+	content.WriteString(fmt.Sprintf("Running: %d/%d | Queued: %d\n\n", running, state.Jobs.Limit, queued))
+
+	if len(state.Jobs.Jobs) == 0 {
+		content.WriteString("No jobs yet.\n")
+	}
+
+	now := time.Now()
+	for i, job := range state.Jobs.Jobs {
+		content.WriteString(renderJobLine(job, i == state.Jobs.Selected, now) + "\n")
+	}
+
+	content.WriteString("\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetJobsPanelHelp()))
+
+	return content.String()
+}
+
+// renderJobLine renders a single job line.
+func renderJobLine(job types.Job, selected bool, now time.Time) string {
+	var line strings.Builder
+
+	if selected {
+		line.WriteString(ui.SelectedItemStyle.Render("> "))
+	} else {
+		line.WriteString("  ")
+	}
+
+	name := strings.TrimSpace(job.Operation + " " + job.Target)
+	line.WriteString(fmt.Sprintf("#%d %s ", job.ID, name))
+
+	state := job.State.String()
+	switch job.State {
+	case types.JobRunning:
+		line.WriteString(ui.LoadingStyle.Render("⏳ " + state))
+	case types.JobSucceeded:
+		line.WriteString(ui.SuccessStyle.Render("✓ " + state))
+	case types.JobFailed, types.JobTimedOut:
+		line.WriteString(ui.ErrorStyle.Render("✗ " + state))
+	case types.JobCancelled:
+		line.WriteString(ui.WarningMessageStyle.Render("⚠ " + state))
+	default:
+		line.WriteString(ui.AvailableVersionStyle.Render(state))
+	}
+
+	if duration := job.Duration(now); duration > 0 {
+		line.WriteString(fmt.Sprintf(" (%s)", duration.Round(time.Second)))
+	}
+
+	return line.String()
+}
+
+// GetJobsPanelHelp returns help text for the jobs panel.
+func GetJobsPanelHelp() string {
+	return "↑↓: Navigate | Enter: Show output | x: Cancel job | d: Clear finished"
+}
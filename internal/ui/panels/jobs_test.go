@@ -0,0 +1,59 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func TestRenderJobsPanel_Empty(t *testing.T) {
+	state := &AppState{Jobs: JobsState{Limit: 2}}
+
+	result := RenderJobsPanel(state)
+
+	if !strings.Contains(result, "Running: 0/2 | Queued: 0") {
+		t.Errorf("Expected job counts in result, got %q", result)
+	}
+	if !strings.Contains(result, "No jobs yet.") {
+		t.Error("Expected empty queue message")
+	}
+}
+
+func TestRenderJobsPanel_Jobs(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	state := &AppState{
+		Jobs: JobsState{
+			Limit: 2,
+			Jobs: []types.Job{
+				{ID: 1, Operation: "install", Target: "3.12", State: types.JobRunning, StartedAt: started},
+				{ID: 2, Operation: "sync", State: types.JobQueued},
+				{ID: 3, Operation: "lock", State: types.JobFailed, StartedAt: started, FinishedAt: started.Add(3 * time.Second)},
+			},
+		},
+	}
+
+	result := RenderJobsPanel(state)
+
+	for _, expected := range []string{
+		"Running: 1/2 | Queued: 1",
+		"#1 install 3.12",
+		"running",
+		"#2 sync",
+		"queued",
+		"#3 lock",
+		"failed (3s)",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected %q in result, got %q", expected, result)
+		}
+	}
+}
+
+func TestGetJobsPanelHelp(t *testing.T) {
+	help := GetJobsPanelHelp()
+	if !strings.Contains(help, "x: Cancel job") {
+		t.Errorf("Unexpected help text: %q", help)
+	}
+}
@@ -0,0 +1,259 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+	"uvui/pkg/version"
+)
+
+// lockBrowserRows is the number of packages shown at once in the lockfile
+// browser.
+const lockBrowserRows = 12
+
+// LockBrowserState represents the state of the uv.lock browser on the Project
+// panel.
+type LockBrowserState struct {
+	Visible  bool
+	Loading  bool
+	Lock     *types.LockFile
+	Query    string
+	Selected int
+	Expanded bool // show the wheels and dependency edges of the selection
+}
+
+// FilterLockPackages returns the locked packages whose name or version
+// contains query, ignoring case and name separators (exported).
+func FilterLockPackages(lock *types.LockFile, query string) []types.LockPackage {
+	if lock == nil {
+		return nil
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return lock.Packages
+	}
+
+	needle := version.NormalizeName(query)
+	var matches []types.LockPackage
+	for _, pkg := range lock.Packages {
+		if strings.Contains(version.NormalizeName(pkg.Name), needle) || strings.Contains(pkg.Version, query) {
+			matches = append(matches, pkg)
+		}
+	}
+	return matches
+}
+
+// renderLockBrowser renders the uv.lock browser.
+func renderLockBrowser(state LockBrowserState) string {
+	var content strings.Builder
+
+	content.WriteString(ui.CurrentVersionStyle.Render("Lockfile"))
+	content.WriteString("\n")
+
+	if state.Loading {
+		content.WriteString(ui.LoadingStyle.Render("  Loading uv.lock..."))
+		return content.String()
+	}
+	if state.Lock == nil {
+		content.WriteString(ui.UnselectedItemStyle.Render("  No lockfile loaded"))
+		return content.String()
+	}
+
+	summary := fmt.Sprintf("  %d packages", len(state.Lock.Packages))
+	if state.Lock.RequiresPython != "" {
+		summary += fmt.Sprintf(" | requires-python %s", state.Lock.RequiresPython)
+	}
+	if n := len(state.Lock.ResolutionMarkers); n > 0 {
+		summary += fmt.Sprintf(" | %d resolution forks", n)
+	}
+	content.WriteString(ui.UnselectedItemStyle.Render(summary))
+	content.WriteString("\n")
+
+	packages := FilterLockPackages(state.Lock, state.Query)
+	if state.Query != "" {
+		content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("  Search: %s (%d matches)", state.Query, len(packages))))
+		content.WriteString("\n")
+	}
+	if len(packages) == 0 {
+		content.WriteString(ui.UnselectedItemStyle.Render("  No matching packages"))
+		return content.String()
+	}
+
+	start, end := visibleRange(state.Selected, len(packages), lockBrowserRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		content.WriteString(renderLockPackageLine(packages[i], i == state.Selected))
+		content.WriteString("\n")
+	}
+	if end < len(packages) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(packages)-end)))
+		content.WriteString("\n")
+	}
+
+	if state.Expanded && state.Selected >= 0 && state.Selected < len(packages) {
+		content.WriteString("\n")
+		content.WriteString(renderLockPackageDetails(state.Lock, packages[state.Selected]))
+	}
+
+	return content.String()
+}
+
+// renderLockPackageLine renders a single locked package.
+func renderLockPackageLine(pkg types.LockPackage, selected bool) string {
+	line := fmt.Sprintf("%s %s", pkg.Name, pkg.Version)
+	if pkg.Source.Kind != "" && pkg.Source.Kind != types.LockSourceRegistry {
+		line += " " + ui.WarningMessageStyle.Render("["+pkg.Source.Kind+"]")
+	}
+	if selected {
+		return ui.SelectedItemStyle.Render("> ") + line
+	}
+	return "  " + line
+}
+
+// renderLockPackageDetails renders the source, artifacts and dependency edges
+// of a locked package.
+func renderLockPackageDetails(lock *types.LockFile, pkg types.LockPackage) string {
+	var content strings.Builder
+
+	content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("  %s %s", pkg.Name, pkg.Version)))
+	content.WriteString("\n")
+	if pkg.Source.Kind != "" {
+		content.WriteString(fmt.Sprintf("    Source: %s\n", pkg.Source))
+	}
+	for _, marker := range pkg.ResolutionMarkers {
+		content.WriteString(fmt.Sprintf("    Resolved for: %s\n", marker))
+	}
+
+	if pkg.Sdist != nil {
+		content.WriteString("    Sdist:\n")
+		content.WriteString(renderLockArtifact(*pkg.Sdist))
+	}
+	if len(pkg.Wheels) > 0 {
+		content.WriteString(fmt.Sprintf("    Wheels (%d):\n", len(pkg.Wheels)))
+		for _, wheel := range pkg.Wheels {
+			content.WriteString(renderLockArtifact(wheel))
+		}
+	}
+
+	if len(pkg.Dependencies) > 0 {
+		content.WriteString("    Depends on:\n")
+		content.WriteString(renderLockDependencies(pkg.Dependencies))
+	}
+	for _, extra := range sortedGroupNames(pkg.OptionalDependencies) {
+		content.WriteString(fmt.Sprintf("    Extra [%s]:\n", extra))
+		content.WriteString(renderLockDependencies(pkg.OptionalDependencies[extra]))
+	}
+	for _, group := range sortedGroupNames(pkg.DevDependencies) {
+		content.WriteString(fmt.Sprintf("    Group [%s]:\n", group))
+		content.WriteString(renderLockDependencies(pkg.DevDependencies[group]))
+	}
+
+	if dependents := lock.Dependents(pkg.Name); len(dependents) > 0 {
+		names := make([]string, len(dependents))
+		for i, dependent := range dependents {
+			names[i] = dependent.Name
+		}
+		content.WriteString(fmt.Sprintf("    Required by: %s\n", strings.Join(names, ", ")))
+	}
+
+	return content.String()
+}
+
+// renderLockArtifact renders an sdist or wheel line.
+func renderLockArtifact(artifact types.LockArtifact) string {
+	line := "      " + artifact.Filename
+	if artifact.Size > 0 {
+		line += fmt.Sprintf(" (%s)", FormatBytes(artifact.Size))
+	}
+	if artifact.Hash != "" {
+		line += " " + ui.HelpStyle.Render(shortHash(artifact.Hash))
+	}
+	return line + "\n"
+}
+
+// renderLockDependencies renders dependency edges with their extras and
+// markers.
+func renderLockDependencies(deps []types.LockDependency) string {
+	var content strings.Builder
+	for _, dep := range deps {
+		line := "      → " + dep.Name
+		if len(dep.Extras) > 0 {
+			line += "[" + strings.Join(dep.Extras, ",") + "]"
+		}
+		if dep.Version != "" {
+			line += " " + dep.Version
+		}
+		if dep.Marker != "" {
+			line += ui.HelpStyle.Render("; " + dep.Marker)
+		}
+		content.WriteString(line + "\n")
+	}
+	return content.String()
+}
+
+// GetLockBrowserHelp returns help text for the lockfile browser.
+func GetLockBrowserHelp() string {
+	return "↑↓: Navigate | /: Search | Enter: Wheels and dependencies | b: Close lockfile"
+}
+
+// visibleRange returns the window of at most rows items that keeps selected
+// in view.
+func visibleRange(selected, total, rows int) (int, int) {
+	if total <= rows {
+		return 0, total
+	}
+	start := selected - rows/2
+	if start < 0 {
+		start = 0
+	}
+	if start+rows > total {
+		start = total - rows
+	}
+	return start, start + rows
+}
+
+// sortedGroupNames returns the names of extras or groups in sorted order.
+func sortedGroupNames(groups map[string][]types.LockDependency) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// shortHash abbreviates a "sha256:..." hash for display.
+func shortHash(hash string) string {
+	algorithm, digest, found := strings.Cut(hash, ":")
+	if !found {
+		algorithm, digest = "", hash
+	}
+	if len(digest) > 12 {
+		digest = digest[:12] + "…"
+	}
+	if algorithm == "" {
+		return digest
+	}
+	return algorithm + ":" + digest
+}
+
+// FormatBytes formats a size in bytes using binary units (exported).
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,153 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func testLockFile() *types.LockFile {
+	return &types.LockFile{
+		Version:        1,
+		RequiresPython: ">=3.11",
+		Packages: []types.LockPackage{
+			{
+				Name:    "anyio",
+				Version: "4.4.0",
+				Source:  types.LockSource{Kind: types.LockSourceRegistry, Location: "https://pypi.org/simple"},
+				Dependencies: []types.LockDependency{
+					{Name: "idna"},
+					{Name: "sniffio", Marker: "python_full_version < '3.12'"},
+				},
+				Sdist: &types.LockArtifact{Filename: "anyio-4.4.0.tar.gz", Hash: "sha256:0123456789abcdef0123", Size: 163930},
+				Wheels: []types.LockArtifact{
+					{Filename: "anyio-4.4.0-py3-none-any.whl", Hash: "sha256:fedcba9876543210", Size: 86780},
+				},
+			},
+			{
+				Name:         "demo",
+				Version:      "0.1.0",
+				Source:       types.LockSource{Kind: types.LockSourceEditable, Location: "."},
+				Dependencies: []types.LockDependency{{Name: "anyio", Extras: []string{"trio"}}},
+				DevDependencies: map[string][]types.LockDependency{
+					"dev": {{Name: "pytest"}},
+				},
+			},
+			{Name: "idna", Version: "3.7"},
+			{Name: "typing_extensions", Version: "4.12.2"},
+		},
+	}
+}
+
+func TestFilterLockPackages(t *testing.T) {
+	lock := testLockFile()
+
+	if got := FilterLockPackages(lock, ""); len(got) != 4 {
+		t.Errorf("FilterLockPackages(\"\") returned %d packages, want 4", len(got))
+	}
+	if got := FilterLockPackages(lock, "Typing-Ext"); len(got) != 1 || got[0].Name != "typing_extensions" {
+		t.Errorf("FilterLockPackages(Typing-Ext) = %+v, want typing_extensions", got)
+	}
+	if got := FilterLockPackages(lock, "4.4"); len(got) != 1 || got[0].Name != "anyio" {
+		t.Errorf("FilterLockPackages(4.4) = %+v, want anyio", got)
+	}
+	if got := FilterLockPackages(nil, "x"); got != nil {
+		t.Errorf("FilterLockPackages(nil) = %+v, want nil", got)
+	}
+}
+
+func TestRenderLockBrowser(t *testing.T) {
+	state := LockBrowserState{Visible: true, Lock: testLockFile(), Selected: 1}
+
+	result := renderLockBrowser(state)
+
+	if !strings.Contains(result, "4 packages | requires-python >=3.11") {
+		t.Errorf("Expected lockfile summary, got:\n%s", result)
+	}
+	if !hasSelectedLine(result, "demo 0.1.0") {
+		t.Errorf("Expected demo to be selected, got:\n%s", result)
+	}
+	if !strings.Contains(result, "[editable]") {
+		t.Errorf("Expected the editable source badge, got:\n%s", result)
+	}
+	if strings.Contains(result, "Depends on:") {
+		t.Errorf("Expected details to be hidden until expanded, got:\n%s", result)
+	}
+}
+
+func TestRenderLockBrowser_Details(t *testing.T) {
+	state := LockBrowserState{Visible: true, Lock: testLockFile(), Query: "any", Expanded: true}
+
+	result := renderLockBrowser(state)
+
+	expected := []string{
+		"Search: any (1 matches)",
+		"Source: registry https://pypi.org/simple",
+		"anyio-4.4.0.tar.gz (160.1 KiB)",
+		"sha256:0123456789ab…",
+		"Wheels (1):",
+		"anyio-4.4.0-py3-none-any.whl (84.7 KiB)",
+		"→ sniffio",
+		"python_full_version < '3.12'",
+		"Required by: demo",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in lockfile details, got:\n%s", want, result)
+		}
+	}
+}
+
+func TestRenderLockBrowser_States(t *testing.T) {
+	if result := renderLockBrowser(LockBrowserState{Loading: true}); !strings.Contains(result, "Loading uv.lock") {
+		t.Errorf("Expected loading message, got:\n%s", result)
+	}
+	if result := renderLockBrowser(LockBrowserState{Lock: testLockFile(), Query: "zzz"}); !strings.Contains(result, "No matching packages") {
+		t.Errorf("Expected no matches message, got:\n%s", result)
+	}
+}
+
+func TestVisibleRange(t *testing.T) {
+	tests := []struct {
+		selected, total, rows int
+		start, end            int
+	}{
+		{0, 5, 12, 0, 5},
+		{0, 30, 12, 0, 12},
+		{15, 30, 12, 9, 21},
+		{29, 30, 12, 18, 30},
+	}
+	for _, tt := range tests {
+		start, end := visibleRange(tt.selected, tt.total, tt.rows)
+		if start != tt.start || end != tt.end {
+			t.Errorf("visibleRange(%d, %d, %d) = %d, %d, want %d, %d", tt.selected, tt.total, tt.rows, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for size, want := range tests {
+		if got := FormatBytes(size); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+// hasSelectedLine reports whether a line of output holds both the selection
+// marker and text.
+func hasSelectedLine(output, text string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, ">") && strings.Contains(line, text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// matrixLogLines is the number of lines shown from the end of a matrix log.
+const matrixLogLines = 12
+
+// MatrixState represents the state of the test matrix view of the Tests
+// panel.
+type MatrixState struct {
+	Visible  bool
+	Loading  bool
+	Matrix   *types.TestMatrix // nil until loaded
+	Selected int
+	Results  map[string]types.MatrixResult // last result with each Python
+}
+
+// Pythons returns the Pythons of the matrix, the rows of the grid (exported).
+func (s MatrixState) Pythons() []string {
+	if s.Matrix == nil {
+		return nil
+	}
+	return s.Matrix.Python
+}
+
+// AddResults records the results of a matrix run (exported).
+func (s *MatrixState) AddResults(results []types.MatrixResult) {
+	if s.Results == nil {
+		s.Results = make(map[string]types.MatrixResult)
+	}
+	for _, result := range results {
+		s.Results[result.Python] = result
+	}
+}
+
+// Failed returns the Pythons of the matrix whose last run failed (exported).
+func (s MatrixState) Failed() []string {
+	var failed []string
+	for _, python := range s.Pythons() {
+		if result, ok := s.Results[python]; ok && result.Error != nil {
+			failed = append(failed, python)
+		}
+	}
+	return failed
+}
+
+// MatrixJobOperation is the job operation of a test matrix run. Its target
+// lists the Pythons it runs with, separated by spaces.
+const MatrixJobOperation = "matrix"
+
+// matrixRunning returns the Pythons of the queued and running matrix jobs.
+func matrixRunning(jobs []types.Job) map[string]bool {
+	running := make(map[string]bool)
+	for _, job := range jobs {
+		if job.Operation != MatrixJobOperation || job.State.Finished() {
+			continue
+		}
+		for _, python := range strings.Fields(job.Target) {
+			running[python] = true
+		}
+	}
+	return running
+}
+
+// renderMatrixView renders the test matrix: a row per Python with the outcome
+// and duration of its last run, and the log of the selected one.
+func renderMatrixView(state MatrixState, jobs []types.Job) string {
+	var content strings.Builder
+
+	if state.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Reading the test matrix..."))
+		return content.String()
+	}
+	if state.Matrix == nil {
+		return content.String()
+	}
+
+	content.WriteString(fmt.Sprintf("Python matrix: uv run --python <python> --isolated %s", JoinArgs(state.Matrix.Command)))
+	content.WriteString(ui.HelpStyle.Render(fmt.Sprintf(" (%d at a time)", state.Matrix.Parallel)))
+	content.WriteString("\n\n")
+
+	pythons := state.Pythons()
+	if len(pythons) == 0 {
+		content.WriteString("No Pythons to run the matrix with.\n")
+		content.WriteString("Install some on the Python panel, or list them under python in [tool.uvui.matrix].")
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	width := len("Python")
+	for _, python := range pythons {
+		width = maxInt(width, len(python))
+	}
+	content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  %-*s  Result", width, "Python")))
+	content.WriteString("\n")
+	running := matrixRunning(jobs)
+	for i, python := range pythons {
+		if i == state.Selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(fmt.Sprintf("%-*s  ", width, python))
+		result, ok := state.Results[python]
+		switch {
+		case running[python]:
+			content.WriteString(ui.LoadingStyle.Render("⏳ running"))
+		case ok:
+			content.WriteString(renderRunStatus(result.Error, result.ExitCode))
+			content.WriteString(ui.HelpStyle.Render(" " + result.Duration.Round(100*time.Millisecond).String()))
+		default:
+			content.WriteString(ui.UnselectedItemStyle.Render("· not run"))
+		}
+		content.WriteString("\n")
+	}
+
+	if state.Selected >= 0 && state.Selected < len(pythons) {
+		content.WriteString("\n")
+		content.WriteString(renderMatrixLog(pythons[state.Selected], state.Results))
+	}
+	return content.String()
+}
+
+// renderMatrixLog renders the end of the output of the last run with a
+// Python.
+func renderMatrixLog(python string, results map[string]types.MatrixResult) string {
+	var content strings.Builder
+
+	result, ok := results[python]
+	if !ok {
+		content.WriteString(ui.UnselectedItemStyle.Render(fmt.Sprintf("  Python %s has not run yet. Press Enter to run the matrix.", python)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("  Log of Python %s", python)))
+	if !result.StartedAt.IsZero() {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf(" %s, %s", result.StartedAt.Format("15:04:05"), result.Duration.Round(100*time.Millisecond))))
+	}
+	content.WriteString("\n")
+
+	lines := result.Log
+	if len(lines) > matrixLogLines {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("    … %d earlier lines", len(lines)-matrixLogLines)))
+		content.WriteString("\n")
+		lines = lines[len(lines)-matrixLogLines:]
+	}
+	if len(lines) == 0 {
+		content.WriteString(ui.UnselectedItemStyle.Render("    (no output)"))
+		content.WriteString("\n")
+	}
+	for _, line := range lines {
+		if line.Stream == types.StreamStderr {
+			content.WriteString("    " + ui.OutputStderrStyle.Render(line.Text))
+		} else {
+			content.WriteString("    " + line.Text)
+		}
+		content.WriteString("\n")
+	}
+	if result.Error != nil && result.ExitCode <= 0 {
+		content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("    %v", result.Error)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// GetMatrixViewHelp returns help text for the test matrix view.
+func GetMatrixViewHelp() string {
+	return "↑↓: Navigate | Enter: Run matrix | f: Rerun failed | r: Reload | M/Esc: Back to results"
+}
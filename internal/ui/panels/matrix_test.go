@@ -0,0 +1,88 @@
+package panels
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func newMatrixTestState() *AppState {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Tests.Matrix = MatrixState{
+		Visible: true,
+		Matrix:  &types.TestMatrix{Command: []string{"pytest", "-q"}, Python: []string{"3.11", "3.12", "pypy@3.10"}, Parallel: 2},
+	}
+	state.Tests.Matrix.AddResults([]types.MatrixResult{
+		{Python: "3.11", Duration: 2 * time.Second, ExitCode: 1, Error: errors.New("exit status 1"), Log: []types.OutputLine{{Stream: types.StreamStdout, Text: "1 failed, 3 passed"}}},
+	})
+	return state
+}
+
+func TestRenderMatrixView(t *testing.T) {
+	state := newMatrixTestState()
+	state.Jobs.Jobs = []types.Job{{Operation: MatrixJobOperation, Target: "3.12", State: types.JobRunning}}
+
+	output := RenderTestsPanel(state)
+	for _, want := range []string{"uv run --python <python> --isolated pytest -q", "(2 at a time)", "✗ exit 1", "2s", "⏳ running", "· not run", "Log of Python 3.11", "1 failed, 3 passed", GetMatrixViewHelp()} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTestsPanel() matrix missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "3.11") {
+		t.Errorf("RenderTestsPanel() should select 3.11:\n%s", output)
+	}
+	if strings.Contains(output, "No test results yet") {
+		t.Errorf("RenderTestsPanel() should show the matrix instead of the results:\n%s", output)
+	}
+
+	state.Jobs.Jobs[0].State = types.JobCancelled
+	state.Tests.Matrix.Selected = 1
+	output = RenderTestsPanel(state)
+	if strings.Contains(output, "running") || !strings.Contains(output, "Python 3.12 has not run yet") {
+		t.Errorf("RenderTestsPanel() after the job ended:\n%s", output)
+	}
+}
+
+func TestRenderMatrixView_States(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Tests.Matrix = MatrixState{Visible: true, Loading: true}
+	if output := RenderTestsPanel(state); !strings.Contains(output, "Reading the test matrix") {
+		t.Errorf("RenderTestsPanel() while loading the matrix = %q", output)
+	}
+
+	state.Tests.Matrix = MatrixState{Visible: true, Matrix: &types.TestMatrix{Command: []string{"pytest"}, Parallel: 2}}
+	if output := RenderTestsPanel(state); !strings.Contains(output, "No Pythons to run the matrix with") {
+		t.Errorf("RenderTestsPanel() without Pythons = %q", output)
+	}
+}
+
+func TestRenderMatrixView_Log(t *testing.T) {
+	var log []types.OutputLine
+	for i := 1; i <= matrixLogLines+3; i++ {
+		log = append(log, types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("line %d", i)})
+	}
+	state := newMatrixTestState()
+	state.Tests.Matrix.AddResults([]types.MatrixResult{{Python: "3.11", StartedAt: time.Date(2024, 5, 1, 9, 5, 0, 0, time.UTC), Log: log}})
+
+	output := RenderTestsPanel(state)
+	for _, want := range []string{"09:05:00", "… 3 earlier lines", "line 15", "✓ exit 0"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTestsPanel() matrix log missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "line 3\n") {
+		t.Errorf("RenderTestsPanel() matrix log shows too much:\n%s", output)
+	}
+}
+
+func TestMatrixState_Failed(t *testing.T) {
+	state := newMatrixTestState().Tests.Matrix
+	state.AddResults([]types.MatrixResult{{Python: "3.12"}, {Python: "pypy@3.10", Error: errors.New("exit status 2")}})
+	if failed := state.Failed(); len(failed) != 2 || failed[0] != "3.11" || failed[1] != "pypy@3.10" {
+		t.Errorf("Failed() = %v", failed)
+	}
+}
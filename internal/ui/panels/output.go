@@ -0,0 +1,86 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// MaxOutputLines is the number of output lines kept for the output pane.
+const MaxOutputLines = 1000
+
+// OutputState represents the state of the command output pane.
+type OutputState struct {
+	Title   string
+	Lines   []types.OutputLine
+	Scroll  int // lines scrolled up from the bottom; 0 follows the tail
+	Visible bool
+	Running bool
+	JobID   int // job whose output is shown; 0 for none
+}
+
+// RenderOutputPane renders the last lines of command output that fit in height.
+func RenderOutputPane(output OutputState, height int) string {
+	var content strings.Builder
+
+	title := "Output"
+	if output.Title != "" {
+		title = fmt.Sprintf("Output: %s", output.Title)
+	}
+	if output.Running {
+		content.WriteString(ui.LoadingStyle.Render("⏳ " + title))
+	} else {
+		content.WriteString(ui.CurrentVersionStyle.Render(title))
+	}
+	content.WriteString("\n")
+
+	if len(output.Lines) == 0 {
+		content.WriteString(ui.UnselectedItemStyle.Render("  No output yet"))
+		return ui.OutputPaneStyle.Render(content.String())
+	}
+
+	visible := maxInt(1, height)
+	end := len(output.Lines) - ClampOutputScroll(output, height)
+	start := maxInt(0, end-visible)
+
+	for i, line := range output.Lines[start:end] {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		if line.Stream == types.StreamStderr {
+			content.WriteString(ui.OutputStderrStyle.Render(line.Text))
+		} else {
+			content.WriteString(line.Text)
+		}
+	}
+
+	if end < len(output.Lines) {
+		content.WriteString("\n")
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("↓ %d more lines", len(output.Lines)-end)))
+	}
+
+	return ui.OutputPaneStyle.Render(content.String())
+}
+
+// ClampOutputScroll returns the scroll offset limited to the scrollable range.
+func ClampOutputScroll(output OutputState, height int) int {
+	maxScroll := maxInt(0, len(output.Lines)-maxInt(1, height))
+	if output.Scroll > maxScroll {
+		return maxScroll
+	}
+	if output.Scroll < 0 {
+		return 0
+	}
+	return output.Scroll
+}
+
+// maxInt returns the larger of two integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,68 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestRenderOutputPane_Empty(t *testing.T) {
+	result := RenderOutputPane(OutputState{Title: "sync"}, 5)
+
+	if !strings.Contains(result, "Output: sync") {
+		t.Error("Expected output title not found")
+	}
+	if !strings.Contains(result, "No output yet") {
+		t.Error("Expected empty output message not found")
+	}
+}
+
+func TestRenderOutputPane_FollowsTail(t *testing.T) {
+	output := OutputState{Title: "sync"}
+	for i := 0; i < 10; i++ {
+		output.Lines = append(output.Lines, types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("line-%d", i)})
+	}
+
+	result := RenderOutputPane(output, 3)
+
+	for _, want := range []string{"line-7", "line-8", "line-9"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in output pane", want)
+		}
+	}
+	if strings.Contains(result, "line-6") {
+		t.Error("Should only show the last lines that fit")
+	}
+}
+
+func TestRenderOutputPane_Scrolled(t *testing.T) {
+	output := OutputState{Scroll: 5}
+	for i := 0; i < 10; i++ {
+		output.Lines = append(output.Lines, types.OutputLine{Stream: types.StreamStderr, Text: fmt.Sprintf("line-%d", i)})
+	}
+
+	result := RenderOutputPane(output, 3)
+
+	if !strings.Contains(result, "line-4") || strings.Contains(result, "line-9") {
+		t.Error("Expected scrolled window of output")
+	}
+	if !strings.Contains(result, "5 more lines") {
+		t.Error("Expected indicator for lines below the view")
+	}
+}
+
+func TestClampOutputScroll(t *testing.T) {
+	output := OutputState{Lines: make([]types.OutputLine, 10)}
+
+	output.Scroll = 100
+	if got := ClampOutputScroll(output, 4); got != 6 {
+		t.Errorf("ClampOutputScroll() = %d, want %d", got, 6)
+	}
+
+	output.Scroll = -1
+	if got := ClampOutputScroll(output, 4); got != 0 {
+		t.Errorf("ClampOutputScroll() = %d, want %d", got, 0)
+	}
+}
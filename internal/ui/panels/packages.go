@@ -0,0 +1,225 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+	"uvui/pkg/version"
+)
+
+// packagesViewRows is the number of packages shown at once in the package
+// inspector.
+const packagesViewRows = 12
+
+// packageFilesShown is the number of files listed in a package's details.
+const packageFilesShown = 8
+
+// PackagesViewState represents the state of the installed package inspector
+// on the Environment panel.
+type PackagesViewState struct {
+	Visible        bool
+	Loading        bool
+	Env            types.VirtualEnv
+	Packages       []types.InstalledPackage
+	Issues         []types.PackageIssue // broken requirements reported by uv pip check
+	Selected       int
+	Expanded       bool // show the metadata and files of the selection
+	Details        *types.PackageDetails
+	DetailsLoading bool
+	ShowFreeze     bool // show uv pip freeze instead of the package list
+	Freeze         []string
+	FreezeLoading  bool
+}
+
+// PackageIssues returns the broken requirements of the named package
+// (exported).
+func PackageIssues(issues []types.PackageIssue, name string) []types.PackageIssue {
+	var matches []types.PackageIssue
+	for _, issue := range issues {
+		if version.NormalizeName(issue.Package) == version.NormalizeName(name) {
+			matches = append(matches, issue)
+		}
+	}
+	return matches
+}
+
+// renderPackagesView renders the installed package inspector.
+func renderPackagesView(state PackagesViewState) string {
+	var content strings.Builder
+
+	content.WriteString(ui.CurrentVersionStyle.Render("Packages in " + state.Env.Name))
+	content.WriteString("\n")
+
+	if state.Loading {
+		content.WriteString(ui.LoadingStyle.Render("  Loading installed packages..."))
+		return content.String()
+	}
+
+	content.WriteString(ui.UnselectedItemStyle.Render(fmt.Sprintf("  %d packages", len(state.Packages))))
+	content.WriteString("\n")
+	if len(state.Issues) > 0 {
+		content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("  ⚠ %d broken requirements (uv pip check)", len(state.Issues))))
+		content.WriteString("\n")
+		for _, issue := range state.Issues {
+			content.WriteString(ui.WarningMessageStyle.Render("    " + issue.Message))
+			content.WriteString("\n")
+		}
+	} else if len(state.Packages) > 0 {
+		content.WriteString(ui.SuccessStyle.Render("  ✓ No broken requirements"))
+		content.WriteString("\n")
+	}
+
+	if state.ShowFreeze {
+		content.WriteString(renderFreeze(state))
+		return content.String()
+	}
+	if len(state.Packages) == 0 {
+		content.WriteString(ui.UnselectedItemStyle.Render("  No packages installed"))
+		return content.String()
+	}
+
+	start, end := visibleRange(state.Selected, len(state.Packages), packagesViewRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		pkg := state.Packages[i]
+		content.WriteString(renderInstalledPackageLine(pkg, state.Env, len(PackageIssues(state.Issues, pkg.Name)) > 0, i == state.Selected))
+		content.WriteString("\n")
+	}
+	if end < len(state.Packages) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(state.Packages)-end)))
+		content.WriteString("\n")
+	}
+
+	if state.Expanded && state.Selected >= 0 && state.Selected < len(state.Packages) {
+		content.WriteString("\n")
+		content.WriteString(renderInstalledPackageDetails(state, state.Packages[state.Selected]))
+	}
+
+	return content.String()
+}
+
+// renderInstalledPackageLine renders a single installed package.
+func renderInstalledPackageLine(pkg types.InstalledPackage, env types.VirtualEnv, broken, selected bool) string {
+	line := fmt.Sprintf("%s %s", pkg.Name, pkg.Version)
+	if broken {
+		line = ui.ErrorStyle.Render(line + " ⚠")
+	}
+	if pkg.EditableLocation != "" {
+		line += " " + ui.WarningMessageStyle.Render("[editable "+pkg.EditableLocation+"]")
+	} else if pkg.Location != "" {
+		line += " " + ui.HelpStyle.Render(packageLocation(pkg.Location, env))
+	}
+	if selected {
+		return ui.SelectedItemStyle.Render("> ") + line
+	}
+	return "  " + line
+}
+
+// renderInstalledPackageDetails renders the dependencies, metadata, problems
+// and files of an installed package.
+func renderInstalledPackageDetails(state PackagesViewState, pkg types.InstalledPackage) string {
+	var content strings.Builder
+
+	content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("  %s %s", pkg.Name, pkg.Version)))
+	content.WriteString("\n")
+	if pkg.Location != "" {
+		content.WriteString(fmt.Sprintf("    Location: %s\n", pkg.Location))
+	}
+	if pkg.EditableLocation != "" {
+		content.WriteString(fmt.Sprintf("    Editable project: %s\n", pkg.EditableLocation))
+	}
+	content.WriteString(fmt.Sprintf("    Requires: %s\n", joinOrNone(pkg.Requires)))
+	content.WriteString(fmt.Sprintf("    Required by: %s\n", joinOrNone(pkg.RequiredBy)))
+	for _, issue := range PackageIssues(state.Issues, pkg.Name) {
+		content.WriteString(ui.ErrorStyle.Render("    ⚠ " + issue.Message))
+		content.WriteString("\n")
+	}
+
+	if state.DetailsLoading {
+		content.WriteString(ui.LoadingStyle.Render("    Loading metadata and files..."))
+		content.WriteString("\n")
+		return content.String()
+	}
+	details := state.Details
+	if details == nil || version.NormalizeName(details.Name) != version.NormalizeName(pkg.Name) {
+		return content.String()
+	}
+
+	for _, field := range details.Metadata {
+		content.WriteString(fmt.Sprintf("    %s: %s\n", field.Key, field.Value))
+	}
+	if len(details.Files) > 0 {
+		content.WriteString(fmt.Sprintf("    Files (%d):\n", len(details.Files)))
+		for _, file := range details.Files[:min(len(details.Files), packageFilesShown)] {
+			content.WriteString("      " + file + "\n")
+		}
+		if len(details.Files) > packageFilesShown {
+			content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("      … %d more", len(details.Files)-packageFilesShown)))
+			content.WriteString("\n")
+		}
+	}
+
+	return content.String()
+}
+
+// renderFreeze renders the output of uv pip freeze.
+func renderFreeze(state PackagesViewState) string {
+	var content strings.Builder
+
+	content.WriteString(ui.InfoMessageStyle.Render("  uv pip freeze"))
+	content.WriteString("\n")
+	if state.FreezeLoading {
+		content.WriteString(ui.LoadingStyle.Render("  Freezing packages..."))
+		return content.String()
+	}
+
+	start, end := visibleRange(state.Selected, len(state.Freeze), packagesViewRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		if i == state.Selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> ") + state.Freeze[i])
+		} else {
+			content.WriteString("  " + state.Freeze[i])
+		}
+		content.WriteString("\n")
+	}
+	if end < len(state.Freeze) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(state.Freeze)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// packageLocation shortens a site-packages directory inside env to a path
+// relative to it.
+func packageLocation(location string, env types.VirtualEnv) string {
+	if env.Path != "" {
+		if rel, err := filepath.Rel(env.Path, location); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return location
+}
+
+// joinOrNone joins names with commas, or returns "none".
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// GetPackagesViewHelp returns help text for the installed package inspector.
+func GetPackagesViewHelp() string {
+	return "↑↓: Navigate | Enter: Metadata and files | t: Toggle pip freeze | r: Refresh | Esc: Back to environments"
+}
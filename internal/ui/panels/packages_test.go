@@ -0,0 +1,114 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func testPackagesView() PackagesViewState {
+	return PackagesViewState{
+		Visible: true,
+		Env:     types.VirtualEnv{Name: ".venv", Path: "/project/.venv"},
+		Packages: []types.InstalledPackage{
+			{Name: "anyio", Version: "4.4.0", Location: "/project/.venv/lib/python3.12/site-packages", Requires: []string{"idna", "sniffio"}, RequiredBy: []string{"httpx"}},
+			{Name: "demo", Version: "0.1.0", EditableLocation: "/project"},
+			{Name: "httpx", Version: "0.27.0", Requires: []string{"anyio", "idna"}},
+		},
+		Issues: []types.PackageIssue{
+			{Package: "httpx", Requirement: "idna", Message: "The package `httpx` requires `idna`, but it's not installed"},
+		},
+	}
+}
+
+func TestPackageIssues(t *testing.T) {
+	issues := testPackagesView().Issues
+	if got := PackageIssues(issues, "HTTPX"); len(got) != 1 {
+		t.Errorf("PackageIssues(HTTPX) = %+v, want the httpx issue", got)
+	}
+	if got := PackageIssues(issues, "anyio"); got != nil {
+		t.Errorf("PackageIssues(anyio) = %+v, want none", got)
+	}
+}
+
+func TestRenderPackagesView(t *testing.T) {
+	state := testPackagesView()
+	output := renderPackagesView(state)
+
+	for _, want := range []string{"Packages in .venv", "3 packages", "1 broken requirements", "requires `idna`", "lib/python3.12/site-packages", "[editable /project]"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("renderPackagesView() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "anyio 4.4.0") {
+		t.Errorf("renderPackagesView() should select anyio:\n%s", output)
+	}
+	if !strings.Contains(output, "httpx 0.27.0 ⚠") {
+		t.Errorf("renderPackagesView() should mark httpx as broken:\n%s", output)
+	}
+	if strings.Contains(output, "Required by:") {
+		t.Error("renderPackagesView() should not show details until expanded")
+	}
+}
+
+func TestRenderPackagesView_Details(t *testing.T) {
+	state := testPackagesView()
+	state.Expanded = true
+	state.DetailsLoading = true
+	output := renderPackagesView(state)
+	for _, want := range []string{"Requires: idna, sniffio", "Required by: httpx", "Loading metadata and files"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("renderPackagesView() missing %q:\n%s", want, output)
+		}
+	}
+
+	state.DetailsLoading = false
+	state.Details = &types.PackageDetails{
+		InstalledPackage: state.Packages[0],
+		Metadata:         []types.PackageMetadata{{Key: "Summary", Value: "High level compatibility layer"}},
+		Files:            []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"},
+	}
+	output = renderPackagesView(state)
+	for _, want := range []string{"Summary: High level compatibility layer", "Files (10):", "… 2 more"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("renderPackagesView() missing %q:\n%s", want, output)
+		}
+	}
+
+	state.Selected = 2
+	output = renderPackagesView(state)
+	if strings.Contains(output, "Summary:") {
+		t.Error("renderPackagesView() should not show the details of another package")
+	}
+	if !strings.Contains(output, "⚠ The package `httpx`") {
+		t.Errorf("renderPackagesView() should show the selection's broken requirements:\n%s", output)
+	}
+}
+
+func TestRenderPackagesView_Freeze(t *testing.T) {
+	state := testPackagesView()
+	state.ShowFreeze = true
+	state.FreezeLoading = true
+	if output := renderPackagesView(state); !strings.Contains(output, "Freezing packages") {
+		t.Errorf("renderPackagesView() while freezing = %q", output)
+	}
+
+	state.FreezeLoading = false
+	state.Freeze = []string{"anyio==4.4.0", "-e file:///project"}
+	output := renderPackagesView(state)
+	if !hasSelectedLine(output, "anyio==4.4.0") || !strings.Contains(output, "-e file:///project") {
+		t.Errorf("renderPackagesView() freeze output:\n%s", output)
+	}
+}
+
+func TestRenderEnvironmentPanel_Packages(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Environments.Packages = testPackagesView()
+	state.Environments.Packages.Loading = true
+
+	output := RenderEnvironmentPanel(state)
+	if !strings.Contains(output, "Loading installed packages") || !strings.Contains(output, GetPackagesViewHelp()) {
+		t.Errorf("RenderEnvironmentPanel() with the package inspector open:\n%s", output)
+	}
+}
@@ -11,7 +11,19 @@ type AppState struct {
 	types.UVStatus
 	PythonVersions PythonVersions
 	Installing     bool
+	MinUVVersion   string // oldest supported uv release; older ones get a warning
 	Messages       []string
 	Operation      types.OperationStatus
 	ProjectState   ProjectState
+	Environments   EnvironmentState
+	Run            RunState
+	Tasks          TasksState
+	Tests          TestsState
+	Diagnostics    DiagnosticsState
+	Tools          ToolsState
+	Cache          CacheState
+	UVBinaries     UVBinariesState
+	Output         OutputState
+	Jobs           JobsState
+	Form           FormState // the open dialog, if any
 }
@@ -3,10 +3,12 @@ package panels
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"uvui/internal/types"
 	"uvui/internal/ui"
+	"uvui/pkg/version"
 )
 
 // ProjectState represents the project panel state.
@@ -18,6 +20,9 @@ type ProjectState struct {
 	Selected       int
 	Loading        bool
 	ShowTree       bool
+	Tree           TreeViewState
+	Lock           LockBrowserState
+	DependencyForm FormState // the last add/remove dependency dialog, reopened as it was left
 }
 
 // RenderProjectPanel renders the project management panel.
@@ -47,11 +52,17 @@ func RenderProjectPanel(state *AppState) string {
 		content.WriteString(renderProjectOperations(state))
 		content.WriteString("\n")
 
-		// Show dependencies or tree based on current view
-		if state.ProjectState.ShowTree {
-			content.WriteString(renderDependencyTree(state.ProjectState.DependencyTree))
+		// Show the lockfile, dependencies or tree based on current view
+		if state.ProjectState.Lock.Visible {
+			content.WriteString(renderLockBrowser(state.ProjectState.Lock))
+			content.WriteString("\n---\n")
+			content.WriteString(ui.HelpStyle.Render(GetLockBrowserHelp()))
+		} else if state.ProjectState.ShowTree {
+			content.WriteString(renderDependencyTree(state.ProjectState.DependencyTree, state.ProjectState.Tree))
+			content.WriteString("\n---\n")
+			content.WriteString(ui.HelpStyle.Render(GetDependencyTreeHelp()))
 		} else {
-			content.WriteString(renderProjectDependencies(state.ProjectState.Dependencies))
+			content.WriteString(renderProjectDependencies(state.ProjectState.Dependencies, targetPython(state)))
 		}
 	} else {
 		content.WriteString(renderInitializationHelp())
@@ -107,7 +118,7 @@ func renderProjectStatus(status *types.ProjectStatus) string {
 }
 
 // renderProjectOperations renders available project operations.
-func renderProjectOperations(_ *AppState) string {
+func renderProjectOperations(state *AppState) string {
 	var content strings.Builder
 
 	content.WriteString(ui.CurrentVersionStyle.Render("Available Operations"))
@@ -120,7 +131,10 @@ func renderProjectOperations(_ *AppState) string {
 	}{
 		{"s", "Sync dependencies", true},
 		{"l", "Lock dependencies", true},
+		{"a", "Add dependency", true},
+		{"d", "Remove dependency", len(state.ProjectState.Dependencies) > 0},
 		{"t", "Toggle dependency tree view", true},
+		{"b", "Browse lockfile", state.ProjectState.Status != nil && state.ProjectState.Status.HasLockFile},
 		{"r", "Refresh project status", true},
 	}
 
@@ -136,8 +150,9 @@ func renderProjectOperations(_ *AppState) string {
 	return content.String()
 }
 
-// renderProjectDependencies renders the project dependencies list.
-func renderProjectDependencies(dependencies []types.ProjectDependency) string {
+// renderProjectDependencies renders the project dependencies list. When python
+// is set, dependencies whose markers exclude that interpreter are dimmed.
+func renderProjectDependencies(dependencies []types.ProjectDependency, python *types.PythonVersion) string {
 	var content strings.Builder
 
 	content.WriteString(ui.CurrentVersionStyle.Render("Dependencies"))
@@ -148,34 +163,38 @@ func renderProjectDependencies(dependencies []types.ProjectDependency) string {
 		return content.String()
 	}
 
-	// Group dependencies by type
-	mainDeps := []types.ProjectDependency{}
-	devDeps := []types.ProjectDependency{}
-
-	for _, dep := range dependencies {
-		if dep.Type == "dev" {
-			devDeps = append(devDeps, dep)
-		} else {
-			mainDeps = append(mainDeps, dep)
+	var env version.Environment
+	if python != nil {
+		env = version.NewEnvironment(python.Version, python.Implementation)
+		applicable := 0
+		for _, dep := range dependencies {
+			if dependencyApplies(dep, env) {
+				applicable++
+			}
 		}
+		content.WriteString(ui.UnselectedItemStyle.Render(fmt.Sprintf("  %d of %d apply to Python %s", applicable, len(dependencies), python.Version)))
+		content.WriteString("\n")
 	}
 
-	// Render main dependencies
-	if len(mainDeps) > 0 {
-		content.WriteString(ui.InfoMessageStyle.Render("  Main:"))
-		content.WriteString("\n")
-		for _, dep := range mainDeps {
-			content.WriteString(ui.UnselectedItemStyle.Render(fmt.Sprintf("    • %s %s", dep.Name, dep.Version)))
-			content.WriteString("\n")
+	// Group dependencies by section, keeping their order within a section
+	var sections []string
+	grouped := map[string][]types.ProjectDependency{}
+	for _, dep := range dependencies {
+		section := dependencySection(dep)
+		if _, ok := grouped[section]; !ok {
+			sections = append(sections, section)
 		}
+		grouped[section] = append(grouped[section], dep)
 	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sectionRank(sections[i]) < sectionRank(sections[j])
+	})
 
-	// Render dev dependencies
-	if len(devDeps) > 0 {
-		content.WriteString(ui.InfoMessageStyle.Render("  Development:"))
+	for _, section := range sections {
+		content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("  %s:", section)))
 		content.WriteString("\n")
-		for _, dep := range devDeps {
-			content.WriteString(ui.UnselectedItemStyle.Render(fmt.Sprintf("    • %s %s", dep.Name, dep.Version)))
+		for _, dep := range grouped[section] {
+			content.WriteString(renderDependencyLine(dep, env))
 			content.WriteString("\n")
 		}
 	}
@@ -183,40 +202,80 @@ func renderProjectDependencies(dependencies []types.ProjectDependency) string {
 	return content.String()
 }
 
-// renderDependencyTree renders the dependency tree.
-func renderDependencyTree(tree *types.DependencyTree) string {
-	var content strings.Builder
-
-	content.WriteString(ui.CurrentVersionStyle.Render("Dependency Tree"))
-	content.WriteString("\n")
-
-	if tree == nil || len(tree.Dependencies) == 0 {
-		content.WriteString(ui.UnselectedItemStyle.Render("  No dependency tree available"))
-		return content.String()
+// dependencySection returns the heading a dependency is listed under.
+func dependencySection(dep types.ProjectDependency) string {
+	switch dep.Type {
+	case "dev":
+		return "Development"
+	case "optional":
+		return fmt.Sprintf("Optional [%s]", dep.Group)
+	case "group":
+		return fmt.Sprintf("Group [%s]", dep.Group)
+	default:
+		return "Main"
 	}
+}
 
-	for _, node := range tree.Dependencies {
-		indent := strings.Repeat("  ", node.Level)
-		prefix := "├─"
-		if node.Level == 0 {
-			prefix = ""
-		}
+// sectionRank orders dependency sections: main first, then extras, groups
+// and development dependencies.
+func sectionRank(section string) int {
+	switch {
+	case section == "Main":
+		return 0
+	case strings.HasPrefix(section, "Optional"):
+		return 1
+	case strings.HasPrefix(section, "Group"):
+		return 2
+	default:
+		return 3
+	}
+}
 
-		line := fmt.Sprintf("%s%s %s", indent, prefix, node.Name)
-		if node.Version != "" {
-			line += fmt.Sprintf(" (%s)", node.Version)
-		}
+// renderDependencyLine renders a single dependency with its markers.
+func renderDependencyLine(dep types.ProjectDependency, env version.Environment) string {
+	name := dep.Name
+	if len(dep.Extras) > 0 {
+		name += "[" + strings.Join(dep.Extras, ",") + "]"
+	}
+	line := strings.TrimRight(fmt.Sprintf("    • %s %s", name, dep.Version), " ")
+	if dep.Markers != "" {
+		line += fmt.Sprintf("; %s", dep.Markers)
+	}
+	if env != nil && !dependencyApplies(dep, env) {
+		return ui.HelpStyle.Render(line + " (not applicable)")
+	}
+	return ui.UnselectedItemStyle.Render(line)
+}
 
-		// Use different styling for different levels
-		if node.Level == 0 {
-			content.WriteString(ui.InfoMessageStyle.Render(line))
-		} else {
-			content.WriteString(ui.UnselectedItemStyle.Render(line))
-		}
-		content.WriteString("\n")
+// dependencyApplies reports whether a dependency's markers hold in env, with
+// its extra active for optional dependencies. Dependencies with markers that
+// cannot be parsed are assumed to apply.
+func dependencyApplies(dep types.ProjectDependency, env version.Environment) bool {
+	if dep.Markers == "" {
+		return true
+	}
+	marker, err := version.ParseMarker(dep.Markers)
+	if err != nil {
+		return true
 	}
+	if dep.Type == "optional" {
+		env = env.With("extra", dep.Group)
+	}
+	return marker.Evaluate(env)
+}
 
-	return content.String()
+// targetPython returns the Python that dependency markers are evaluated
+// against: the version selected on the Python panel, or else the version
+// pinned by the project.
+func targetPython(state *AppState) *types.PythonVersion {
+	versions := MergePythonVersions(state.PythonVersions.Available, state.PythonVersions.Installed)
+	if selected := state.PythonVersions.Selected; selected >= 0 && selected < len(versions) {
+		return &versions[selected]
+	}
+	if pinned := getPinnedVersion(state); pinned != "" {
+		return &types.PythonVersion{Version: pinned}
+	}
+	return nil
 }
 
 // renderInitializationHelp renders help for project initialization.
@@ -258,7 +317,10 @@ func GetProjectPanelHelp() string {
 		"When in project:",
 		"  s - Sync dependencies",
 		"  l - Lock dependencies",
-		"  t - Toggle tree view",
+		"  a - Add a dependency (version, section, path/git source, --frozen/--no-sync)",
+		"  d - Remove a dependency",
+		"  t - Toggle tree view (Enter or ←/→ to collapse, * and # to jump between repeats)",
+		"  b - Browse uv.lock (/ to search, Enter for details)",
 		"  r - Refresh status",
 		"",
 		"Navigation:",
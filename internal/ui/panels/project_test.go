@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"uvui/internal/types"
+	"uvui/pkg/version"
 )
 
 func TestRenderProjectPanel_UVNotInstalled(t *testing.T) {
@@ -288,7 +289,7 @@ func TestRenderProjectOperations(t *testing.T) {
 
 func TestRenderProjectDependencies_Empty(t *testing.T) {
 	dependencies := []types.ProjectDependency{}
-	result := renderProjectDependencies(dependencies)
+	result := renderProjectDependencies(dependencies, nil)
 
 	if !strings.Contains(result, "Dependencies") {
 		t.Error("Expected dependencies header not found")
@@ -305,7 +306,7 @@ func TestRenderProjectDependencies_MainOnly(t *testing.T) {
 		{Name: "click", Version: "8.1.3", Type: "main"},
 	}
 
-	result := renderProjectDependencies(dependencies)
+	result := renderProjectDependencies(dependencies, nil)
 
 	if !strings.Contains(result, "Main:") {
 		t.Error("Expected main dependencies section not found")
@@ -331,7 +332,7 @@ func TestRenderProjectDependencies_DevOnly(t *testing.T) {
 		{Name: "black", Version: "22.10.0", Type: "dev"},
 	}
 
-	result := renderProjectDependencies(dependencies)
+	result := renderProjectDependencies(dependencies, nil)
 
 	if !strings.Contains(result, "Development:") {
 		t.Error("Expected development dependencies section not found")
@@ -359,7 +360,7 @@ func TestRenderProjectDependencies_Mixed(t *testing.T) {
 		{Name: "black", Version: "22.10.0", Type: "dev"},
 	}
 
-	result := renderProjectDependencies(dependencies)
+	result := renderProjectDependencies(dependencies, nil)
 
 	// Should show both sections
 	if !strings.Contains(result, "Main:") {
@@ -386,123 +387,6 @@ func TestRenderProjectDependencies_Mixed(t *testing.T) {
 	}
 }
 
-func TestRenderDependencyTree_Nil(t *testing.T) {
-	result := renderDependencyTree(nil)
-
-	if !strings.Contains(result, "Dependency Tree") {
-		t.Error("Expected dependency tree header not found")
-	}
-
-	if !strings.Contains(result, "No dependency tree available") {
-		t.Error("Expected no tree message for nil tree not found")
-	}
-}
-
-func TestRenderDependencyTree_Empty(t *testing.T) {
-	tree := &types.DependencyTree{
-		Dependencies: []types.TreeNode{},
-	}
-
-	result := renderDependencyTree(tree)
-
-	if !strings.Contains(result, "Dependency Tree") {
-		t.Error("Expected dependency tree header not found")
-	}
-
-	if !strings.Contains(result, "No dependency tree available") {
-		t.Error("Expected no tree message for empty tree not found")
-	}
-}
-
-func TestRenderDependencyTree_WithNodes(t *testing.T) {
-	tree := &types.DependencyTree{
-		Dependencies: []types.TreeNode{
-			{Name: "requests", Version: "2.28.1", Level: 0},
-			{Name: "urllib3", Version: "1.26.12", Level: 1},
-			{Name: "certifi", Version: "2022.9.24", Level: 1},
-			{Name: "charset-normalizer", Version: "2.1.1", Level: 1},
-		},
-	}
-
-	result := renderDependencyTree(tree)
-
-	if !strings.Contains(result, "Dependency Tree") {
-		t.Error("Expected dependency tree header not found")
-	}
-
-	// Check root level (no prefix, no indent)
-	if !strings.Contains(result, "requests (2.28.1)") {
-		t.Error("Expected root dependency not found")
-	}
-
-	// Check level 1 dependencies (with prefix and indent)
-	expectedLevel1 := []string{
-		"├─ urllib3 (1.26.12)",
-		"├─ certifi (2022.9.24)",
-		"├─ charset-normalizer (2.1.1)",
-	}
-
-	for _, expected := range expectedLevel1 {
-		if !strings.Contains(result, expected) {
-			t.Errorf("Expected level 1 dependency '%s' not found", expected)
-		}
-	}
-}
-
-func TestRenderDependencyTree_WithoutVersions(t *testing.T) {
-	tree := &types.DependencyTree{
-		Dependencies: []types.TreeNode{
-			{Name: "root-package", Version: "", Level: 0},
-			{Name: "child-package", Version: "", Level: 1},
-		},
-	}
-
-	result := renderDependencyTree(tree)
-
-	// Should show names without version info
-	if !strings.Contains(result, "root-package\n") {
-		t.Error("Expected root package without version not found")
-	}
-
-	if !strings.Contains(result, "├─ child-package") {
-		t.Error("Expected child package without version not found")
-	}
-
-	// Should not contain version parentheses
-	if strings.Contains(result, "()") {
-		t.Error("Should not show empty version parentheses")
-	}
-}
-
-func TestRenderDependencyTree_MultipleRoots(t *testing.T) {
-	tree := &types.DependencyTree{
-		Dependencies: []types.TreeNode{
-			{Name: "requests", Version: "2.28.1", Level: 0},
-			{Name: "urllib3", Version: "1.26.12", Level: 1},
-			{Name: "flask", Version: "2.2.2", Level: 0},
-			{Name: "jinja2", Version: "3.1.2", Level: 1},
-		},
-	}
-
-	result := renderDependencyTree(tree)
-
-	// Should show both root packages
-	if !strings.Contains(result, "requests (2.28.1)") {
-		t.Error("Expected first root package not found")
-	}
-	if !strings.Contains(result, "flask (2.2.2)") {
-		t.Error("Expected second root package not found")
-	}
-
-	// Should show children with proper indentation
-	if !strings.Contains(result, "├─ urllib3 (1.26.12)") {
-		t.Error("Expected first child dependency not found")
-	}
-	if !strings.Contains(result, "├─ jinja2 (3.1.2)") {
-		t.Error("Expected second child dependency not found")
-	}
-}
-
 func TestRenderInitializationHelp(t *testing.T) {
 	result := renderInitializationHelp()
 
@@ -672,3 +556,94 @@ func TestProjectState_AllFields(t *testing.T) {
 		t.Error("Should show dependency tree when ShowTree is true")
 	}
 }
+
+func TestRenderProjectDependencies_Markers(t *testing.T) {
+	dependencies := []types.ProjectDependency{
+		{Name: "requests", Version: ">=2.31", Type: "main"},
+		{Name: "tomli", Version: ">=2.0", Type: "main", Markers: `python_version < "3.11"`},
+		{Name: "exceptiongroup", Version: "", Type: "main", Markers: `python_version < "3.13"`},
+	}
+
+	result := renderProjectDependencies(dependencies, &types.PythonVersion{Version: "3.12.7"})
+
+	if !strings.Contains(result, "2 of 3 apply to Python 3.12.7") {
+		t.Errorf("Expected applicability summary, got:\n%s", result)
+	}
+	if !strings.Contains(result, `• tomli >=2.0; python_version < "3.11" (not applicable)`) {
+		t.Errorf("Expected tomli to be marked not applicable, got:\n%s", result)
+	}
+	if strings.Contains(result, `python_version < "3.13" (not applicable)`) {
+		t.Errorf("Expected exceptiongroup to apply, got:\n%s", result)
+	}
+}
+
+func TestDependencyApplies(t *testing.T) {
+	env := version.NewEnvironment("3.10.14", "pypy")
+
+	tests := []struct {
+		markers string
+		want    bool
+	}{
+		{"", true},
+		{`python_version < "3.11"`, true},
+		{`implementation_name == "cpython"`, false},
+		{`platform_python_implementation == "PyPy" and python_version >= "3.10"`, true},
+		{`not a marker`, true},
+	}
+	for _, tt := range tests {
+		dep := types.ProjectDependency{Name: "pkg", Markers: tt.markers}
+		if got := dependencyApplies(dep, env); got != tt.want {
+			t.Errorf("dependencyApplies(%q) = %v, want %v", tt.markers, got, tt.want)
+		}
+	}
+}
+
+func TestTargetPython(t *testing.T) {
+	state := &AppState{}
+	if got := targetPython(state); got != nil {
+		t.Errorf("targetPython() = %+v, want nil", got)
+	}
+
+	state.ProjectState.Status = &types.ProjectStatus{PythonVersion: "3.11"}
+	if got := targetPython(state); got == nil || got.Version != "3.11" {
+		t.Errorf("targetPython() = %+v, want the pinned 3.11", got)
+	}
+
+	state.PythonVersions.Available = []types.PythonVersion{{Version: "3.13.0"}, {Version: "3.12.7"}}
+	state.PythonVersions.Selected = 1
+	if got := targetPython(state); got == nil || got.Version != "3.12.7" {
+		t.Errorf("targetPython() = %+v, want the selected 3.12.7", got)
+	}
+}
+
+func TestRenderProjectDependencies_Sections(t *testing.T) {
+	dependencies := []types.ProjectDependency{
+		{Name: "pytest", Version: ">=8", Type: "dev", Group: "dev"},
+		{Name: "mypy", Version: "==1.11.*", Type: "group", Group: "typing"},
+		{Name: "uvicorn", Version: ">=0.30", Type: "optional", Group: "server"},
+		{Name: "requests", Version: ">=2.31", Type: "main", Extras: []string{"socks"}},
+		{Name: "click", Version: ">=8", Type: "optional", Group: "cli", Markers: `extra == "cli"`},
+	}
+
+	result := renderProjectDependencies(dependencies, &types.PythonVersion{Version: "3.12.7"})
+
+	order := []string{"Main:", "Optional [server]:", "Optional [cli]:", "Group [typing]:", "Development:"}
+	last := -1
+	for _, heading := range order {
+		index := strings.Index(result, heading)
+		if index < 0 {
+			t.Fatalf("Expected section %q, got:\n%s", heading, result)
+		}
+		if index < last {
+			t.Errorf("Section %q is out of order:\n%s", heading, result)
+		}
+		last = index
+	}
+
+	if !strings.Contains(result, "• requests[socks] >=2.31") {
+		t.Errorf("Expected extras in the dependency line, got:\n%s", result)
+	}
+	if strings.Contains(result, "(not applicable)") {
+		t.Errorf("Expected the extra marker to hold for its optional dependency, got:\n%s", result)
+	}
+}
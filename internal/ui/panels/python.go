@@ -3,14 +3,12 @@ package panels
 
 import (
 	"fmt"
-	"os"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 
 	"uvui/internal/types"
 	"uvui/internal/ui"
+	"uvui/pkg/version"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -52,10 +50,16 @@ func RenderPythonPanel(state *AppState) string {
 	content.WriteString(fmt.Sprintf("Python Versions (%d available):\n\n", len(allVersions)))
 
 	// Render version list
-	pinnedVersion := getPinnedVersion()
+	pinnedVersion := getPinnedVersion(state)
 	for i, version := range allVersions {
-		line := renderVersionLine(version, i == state.PythonVersions.Selected, pinnedVersion)
+		selected := i == state.PythonVersions.Selected
+		line := renderVersionLine(version, selected, pinnedVersion)
 		content.WriteString(line + "\n")
+		if selected {
+			if details := renderVersionDetails(version); details != "" {
+				content.WriteString(details + "\n")
+			}
+		}
 	}
 
 	// Show operation status
@@ -89,7 +93,7 @@ func renderVersionLine(version types.PythonVersion, selected bool, pinnedVersion
 
 	// Version number with status styling
 	versionText := version.Version
-	if version.Version == pinnedVersion {
+	if version.PinRequest() == pinnedVersion {
 		versionText = ui.PinnedVersionStyle.Render(versionText + " (pinned)")
 	} else if version.Current {
 		versionText = ui.CurrentVersionStyle.Render(versionText + " (current)")
@@ -101,6 +105,14 @@ func renderVersionLine(version types.PythonVersion, selected bool, pinnedVersion
 
 	line.WriteString(versionText)
 
+	// Badges for builds other than the default CPython
+	if version.Implementation != "" && version.Implementation != "cpython" {
+		line.WriteString(" " + ui.WarningMessageStyle.Render(version.Implementation))
+	}
+	if version.Variant != "" && version.Variant != "default" {
+		line.WriteString(" " + ui.WarningMessageStyle.Render(version.Variant))
+	}
+
 	// Path information for installed versions
 	if version.Installed && version.Path != "" {
 		line.WriteString(ui.AvailableVersionStyle.Render(fmt.Sprintf(" [%s]", version.Path)))
@@ -109,24 +121,52 @@ func renderVersionLine(version types.PythonVersion, selected bool, pinnedVersion
 	return line.String()
 }
 
+// renderVersionDetails renders the build details of the selected version.
+func renderVersionDetails(version types.PythonVersion) string {
+	if version.Key == "" {
+		return ""
+	}
+
+	var details strings.Builder
+	details.WriteString("    " + version.Key)
+	var platform []string
+	for _, part := range []string{version.Arch, version.OS, version.Libc} {
+		if part != "" && part != "none" {
+			platform = append(platform, part)
+		}
+	}
+	if len(platform) > 0 {
+		details.WriteString(fmt.Sprintf(" (%s)", strings.Join(platform, "/")))
+	}
+	if version.Symlink != "" {
+		details.WriteString(fmt.Sprintf("\n    → %s", version.Symlink))
+	} else if !version.Installed && version.URL != "" {
+		details.WriteString(fmt.Sprintf("\n    %s", version.URL))
+	}
+	return ui.HelpStyle.Render(details.String())
+}
+
 // MergePythonVersions merges available and installed versions for display (exported).
+// Entries are matched by key, so different implementations and variants of the
+// same version are listed separately.
 func MergePythonVersions(available, installed []types.PythonVersion) []types.PythonVersion {
 	versionMap := make(map[string]types.PythonVersion)
 
 	// Add all available versions
 	for _, version := range available {
-		versionMap[version.Version] = version
+		versionMap[version.Request()] = version
 	}
 
 	// Update with installed status
 	for _, version := range installed {
-		if existing, exists := versionMap[version.Version]; exists {
+		if existing, exists := versionMap[version.Request()]; exists {
 			existing.Installed = true
 			existing.Current = version.Current
 			existing.Path = version.Path
-			versionMap[version.Version] = existing
+			existing.Symlink = version.Symlink
+			versionMap[version.Request()] = existing
 		} else {
-			versionMap[version.Version] = version
+			versionMap[version.Request()] = version
 		}
 	}
 
@@ -138,63 +178,25 @@ func MergePythonVersions(available, installed []types.PythonVersion) []types.Pyt
 
 	// Sort by version number to ensure consistent order
 	sort.Slice(merged, func(i, j int) bool {
-		return compareVersions(merged[i].Version, merged[j].Version) > 0
+		if c := version.CompareVersions(merged[i].Version, merged[j].Version); c != 0 {
+			return c > 0
+		}
+		return merged[i].Key < merged[j].Key
 	})
 
 	return merged
 }
 
-// compareVersions compares two version strings (higher versions first).
-func compareVersions(a, b string) int {
-	// Simple version comparison - split by dots and compare numerically
-	partsA := strings.Split(a, ".")
-	partsB := strings.Split(b, ".")
-
-	maxLen := len(partsA)
-	if len(partsB) > maxLen {
-		maxLen = len(partsB)
-	}
-
-	for i := 0; i < maxLen; i++ {
-		var numA, numB int
-		if i < len(partsA) {
-			numA = parseVersionPart(partsA[i])
-		}
-		if i < len(partsB) {
-			numB = parseVersionPart(partsB[i])
-		}
-
-		if numA != numB {
-			return numA - numB
-		}
-	}
-
-	return 0
-}
-
-// parseVersionPart parses a version part (e.g., "12", "1", "alpha1").
-func parseVersionPart(part string) int {
-	// Extract numeric part
-	re := regexp.MustCompile(`^(\d+)`)
-	matches := re.FindStringSubmatch(part)
-	if len(matches) > 1 {
-		if num, err := strconv.Atoi(matches[1]); err == nil {
-			return num
-		}
-	}
-	return 0
-}
-
 // GetPythonPanelHelp returns help text for the Python panel.
 func GetPythonPanelHelp() string {
 	return "↑↓: Navigate | Enter: Install | d/Del: Delete | p: Pin | i: Refresh"
 }
 
-// getPinnedVersion reads the pinned Python version from the .python-version file.
-func getPinnedVersion() string {
-	content, err := os.ReadFile(".python-version")
-	if err != nil {
-		return "3.12"
+// getPinnedVersion returns the Python version pinned by the current project's
+// .python-version file, as reported in the project status.
+func getPinnedVersion(state *AppState) string {
+	if state.ProjectState.Status == nil {
+		return ""
 	}
-	return strings.TrimSpace(string(content))
+	return state.ProjectState.Status.PythonVersion
 }
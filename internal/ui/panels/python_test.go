@@ -231,6 +231,73 @@ func TestMergePythonVersions_Sorting(t *testing.T) {
 	assert.Equal(t, "3.10.0", merged[2].Version)
 }
 
+func TestMergePythonVersions_PreReleases(t *testing.T) {
+	available := []types.PythonVersion{
+		{Version: "3.13.0rc1"},
+		{Version: "3.14.0a3"},
+		{Version: "3.13.0"},
+	}
+
+	merged := MergePythonVersions(available, nil)
+
+	assert.Equal(t, "3.14.0a3", merged[0].Version)
+	assert.Equal(t, "3.13.0", merged[1].Version)
+	assert.Equal(t, "3.13.0rc1", merged[2].Version)
+}
+
+func TestMergePythonVersions_ByKey(t *testing.T) {
+	available := []types.PythonVersion{
+		{Version: "3.13.0", Key: "cpython-3.13.0-linux-x86_64-gnu", Implementation: "cpython", Variant: "default"},
+		{Version: "3.13.0", Key: "cpython-3.13.0+freethreaded-linux-x86_64-gnu", Implementation: "cpython", Variant: "freethreaded"},
+		{Version: "3.10.14", Key: "pypy-3.10.14-linux-x86_64-gnu", Implementation: "pypy", Variant: "default"},
+	}
+	installed := []types.PythonVersion{
+		{Version: "3.13.0", Key: "cpython-3.13.0+freethreaded-linux-x86_64-gnu", Installed: true, Path: "/uv/python3.13t", Symlink: "/uv/real/python3.13t"},
+	}
+
+	merged := MergePythonVersions(available, installed)
+
+	assert.Len(t, merged, 3)
+	assert.Equal(t, "cpython-3.13.0+freethreaded-linux-x86_64-gnu", merged[0].Key)
+	assert.True(t, merged[0].Installed)
+	assert.Equal(t, "freethreaded", merged[0].Variant)
+	assert.Equal(t, "/uv/real/python3.13t", merged[0].Symlink)
+	assert.Equal(t, "cpython-3.13.0-linux-x86_64-gnu", merged[1].Key)
+	assert.False(t, merged[1].Installed)
+	assert.Equal(t, "pypy-3.10.14-linux-x86_64-gnu", merged[2].Key)
+}
+
+func TestRenderVersionLine_Badges(t *testing.T) {
+	pypy := types.PythonVersion{Version: "3.10.14", Key: "pypy-3.10.14-linux-x86_64-gnu", Implementation: "pypy", Variant: "default"}
+	line := renderVersionLine(pypy, false, "")
+	assert.Contains(t, line, "pypy")
+	assert.NotContains(t, line, "default")
+
+	freethreaded := types.PythonVersion{Version: "3.13.0", Implementation: "cpython", Variant: "freethreaded"}
+	line = renderVersionLine(freethreaded, false, "")
+	assert.Contains(t, line, "freethreaded")
+	assert.NotContains(t, line, "cpython")
+}
+
+func TestRenderPythonPanel_SelectedDetails(t *testing.T) {
+	state := &AppState{
+		UVStatus: types.UVStatus{Installed: true},
+		PythonVersions: PythonVersions{
+			Available: []types.PythonVersion{
+				{Version: "3.12.7", Key: "cpython-3.12.7-linux-x86_64-gnu", Arch: "x86_64", OS: "linux", Libc: "gnu", URL: "https://example.com/cpython-3.12.7.tar.gz"},
+				{Version: "3.11.2", Key: "cpython-3.11.2-linux-x86_64-gnu", Arch: "x86_64", OS: "linux", Libc: "gnu"},
+			},
+			Selected: 0,
+		},
+	}
+
+	content := RenderPythonPanel(state)
+
+	assert.Contains(t, content, "cpython-3.12.7-linux-x86_64-gnu (x86_64/linux/gnu)")
+	assert.Contains(t, content, "https://example.com/cpython-3.12.7.tar.gz")
+	assert.NotContains(t, content, "cpython-3.11.2-linux-x86_64-gnu")
+}
+
 func TestGetPythonPanelHelp(t *testing.T) {
 	help := GetPythonPanelHelp()
 
@@ -257,17 +324,14 @@ func TestCompareVersions(t *testing.T) {
 	assert.Equal(t, "3.11.0", merged[1].Version)
 }
 
-func TestParseVersionPart(t *testing.T) {
-	// Test numeric parts
-	assert.Equal(t, 12, parseVersionPart("12"))
-	assert.Equal(t, 1, parseVersionPart("1"))
-	assert.Equal(t, 0, parseVersionPart("0"))
-
-	// Test with non-numeric suffix
-	assert.Equal(t, 12, parseVersionPart("12alpha"))
-	assert.Equal(t, 1, parseVersionPart("1beta"))
+func TestGetPinnedVersion(t *testing.T) {
+	state := &AppState{}
+	if got := getPinnedVersion(state); got != "" {
+		t.Errorf("getPinnedVersion() = %q, want empty without project status", got)
+	}
 
-	// Test non-numeric
-	assert.Equal(t, 0, parseVersionPart("alpha"))
-	assert.Equal(t, 0, parseVersionPart(""))
+	state.ProjectState.Status = &types.ProjectStatus{IsProject: true, PythonVersion: "3.11"}
+	if got := getPinnedVersion(state); got != "3.11" {
+		t.Errorf("getPinnedVersion() = %q, want %q", got, "3.11")
+	}
 }
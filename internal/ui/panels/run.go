@@ -0,0 +1,272 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// RunScriptForm is the form ID of the run dialog.
+const RunScriptForm = "run-script"
+
+// Keys of the run dialog fields.
+const (
+	ScriptFieldTarget = "target"
+	ScriptFieldArgs   = "args"
+	ScriptFieldEnv    = "env"
+	ScriptFieldWith   = "with"
+)
+
+// MaxScriptRuns is the number of runs kept in the run history.
+const MaxScriptRuns = 20
+
+// runTargetRows is the number of run targets shown at once.
+const runTargetRows = 15
+
+// runTargetHeadings are the headings of the groups of run targets.
+var runTargetHeadings = map[string]string{
+	types.RunTargetScript:    "Scripts [project.scripts]",
+	types.RunTargetGUIScript: "GUI scripts [project.gui-scripts]",
+	types.RunTargetFile:      "Python files",
+}
+
+// RunState represents the state of the run panel.
+type RunState struct {
+	Targets  []types.RunTarget
+	Selected int
+	Loading  bool
+
+	History     []types.ScriptRun // recent runs, most recent first
+	ShowHistory bool
+	RunSelected int // selected run in the history
+
+	Script ScriptViewState
+}
+
+// AddRun records a finished run at the top of the history. An earlier run of
+// the same command line is replaced, and the history is capped at
+// MaxScriptRuns entries (exported).
+func (s *RunState) AddRun(run types.ScriptRun) {
+	line := RunCommandLine(run.Options)
+	history := []types.ScriptRun{run}
+	for _, previous := range s.History {
+		if RunCommandLine(previous.Options) != line && len(history) < MaxScriptRuns {
+			history = append(history, previous)
+		}
+	}
+	s.History = history
+	s.RunSelected = 0
+}
+
+// RemoveRun removes a run from the history (exported).
+func (s *RunState) RemoveRun(index int) {
+	if index < 0 || index >= len(s.History) {
+		return
+	}
+	s.History = append(s.History[:index:index], s.History[index+1:]...)
+	if s.RunSelected >= len(s.History) && s.RunSelected > 0 {
+		s.RunSelected = len(s.History) - 1
+	}
+}
+
+// LastRun returns the most recent run of target, if any (exported).
+func (s RunState) LastRun(target string) (types.ScriptRun, bool) {
+	for _, run := range s.History {
+		if run.Options.Target == target {
+			return run, true
+		}
+	}
+	return types.ScriptRun{}, false
+}
+
+// RunCommandLine returns the shell command line of a run, with its
+// environment overrides in front (exported).
+func RunCommandLine(options types.RunOptions) string {
+	line := "uv run " + JoinArgs(options.RunArgs())
+	if len(options.Env) > 0 {
+		line = JoinArgs(options.Env) + " " + line
+	}
+	return line
+}
+
+// RenderRunPanel renders the script and entry point panel.
+func RenderRunPanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Run Scripts\n\n")
+
+	if !state.Installed {
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to run scripts."))
+		return content.String()
+	}
+
+	if state.Run.Script.Visible {
+		content.WriteString(renderScriptView(state.Run.Script))
+		content.WriteString("\n\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetScriptViewHelp()))
+		return content.String()
+	}
+
+	if state.Run.ShowHistory {
+		content.WriteString(renderScriptRuns(state.Run))
+		content.WriteString("\n\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetRunHistoryHelp()))
+		return content.String()
+	}
+
+	if state.Run.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Looking for scripts..."))
+		return content.String()
+	}
+
+	if len(state.Run.Targets) == 0 {
+		content.WriteString("No scripts or Python files found.\n")
+		content.WriteString("Declare entry points in [project.scripts] or add .py files to the project.")
+	} else {
+		content.WriteString(renderRunTargets(state.Run))
+	}
+
+	content.WriteString("\n\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetRunPanelHelp()))
+
+	return content.String()
+}
+
+// renderRunTargets renders the visible run targets, grouped by kind.
+func renderRunTargets(state RunState) string {
+	var content strings.Builder
+
+	targets := state.Targets
+	start, end := visibleRange(state.Selected, len(targets), runTargetRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		target := targets[i]
+		if i == start || targets[i-1].Kind != target.Kind {
+			if i > start {
+				content.WriteString("\n")
+			}
+			content.WriteString(ui.CurrentVersionStyle.Render(runTargetHeadings[target.Kind]))
+			content.WriteString("\n")
+		}
+
+		if i == state.Selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(target.Name)
+		if target.Spec != "" {
+			content.WriteString(ui.HelpStyle.Render(" → " + target.Spec))
+		}
+		if target.Inline {
+			content.WriteString(ui.InfoMessageStyle.Render(" [PEP 723]"))
+		}
+		if run, ok := state.LastRun(target.Name); ok {
+			content.WriteString(" " + renderRunStatus(run.Error, run.ExitCode))
+		}
+		content.WriteString("\n")
+	}
+	if end < len(targets) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(targets)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderScriptRuns renders the run history.
+func renderScriptRuns(state RunState) string {
+	var content strings.Builder
+
+	if len(state.History) == 0 {
+		content.WriteString("No runs yet.\n")
+		content.WriteString("Press 't' to go back and Enter on a script to run it.")
+		return content.String()
+	}
+
+	content.WriteString(fmt.Sprintf("Recent runs (%d):\n\n", len(state.History)))
+	for i, run := range state.History {
+		selected := i == state.RunSelected
+		if selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(renderRunStatus(run.Error, run.ExitCode))
+		content.WriteString(" " + RunCommandLine(run.Options))
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf(" %s, %s", run.StartedAt.Format("15:04:05"), run.Duration.Round(100*time.Millisecond))))
+		content.WriteString("\n")
+
+		if selected && run.Error != nil && run.ExitCode <= 0 {
+			content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("    %v", run.Error)))
+			content.WriteString("\n")
+		}
+	}
+	return content.String()
+}
+
+// renderRunStatus renders the outcome of a run.
+func renderRunStatus(err error, exitCode int) string {
+	switch {
+	case err == nil:
+		return ui.SuccessStyle.Render("✓ exit 0")
+	case exitCode > 0:
+		return ui.ErrorStyle.Render(fmt.Sprintf("✗ exit %d", exitCode))
+	default:
+		return ui.ErrorStyle.Render("✗")
+	}
+}
+
+// NewRunForm returns the run dialog, filled in from options.
+func NewRunForm(options types.RunOptions) FormState {
+	form := FormState{
+		ID:    RunScriptForm,
+		Title: "Run (uv run)",
+		Fields: []FormField{
+			{Key: ScriptFieldTarget, Label: "Target", Kind: FieldText, Value: options.Target, Placeholder: "entry point or file"},
+			{Key: ScriptFieldArgs, Label: "Arguments", Kind: FieldText, Value: JoinArgs(options.Args)},
+			{Key: ScriptFieldEnv, Label: "Environment", Kind: FieldText, Value: JoinArgs(options.Env), Placeholder: "KEY=VALUE ..."},
+			{Key: ScriptFieldWith, Label: "With", Kind: FieldText, Value: strings.Join(options.With, ", "), Placeholder: "extra packages, comma-separated"},
+		},
+	}
+	// Start on the arguments, the field most often changed between runs.
+	if options.Target != "" {
+		form.Focus = 1
+	}
+	UpdateRunForm(&form)
+	return form
+}
+
+// UpdateRunForm previews the command of the run dialog.
+func UpdateRunForm(form *FormState) {
+	form.Preview = ""
+	if options := RunFormOptions(form); options.Target != "" {
+		form.Preview = RunCommandLine(options)
+	}
+}
+
+// RunFormOptions returns the options entered in the run dialog (exported).
+func RunFormOptions(form *FormState) types.RunOptions {
+	return types.RunOptions{
+		Target: form.Value(ScriptFieldTarget),
+		Args:   SplitArgs(form.Value(ScriptFieldArgs)),
+		Env:    SplitArgs(form.Value(ScriptFieldEnv)),
+		With:   splitList(form.Value(ScriptFieldWith)),
+	}
+}
+
+// GetRunPanelHelp returns help text for the run panel.
+func GetRunPanelHelp() string {
+	return "↑↓: Navigate | Enter: Run... | m: Script metadata | t: Run history | r: Refresh"
+}
+
+// GetRunHistoryHelp returns help text for the run history.
+func GetRunHistoryHelp() string {
+	return "↑↓: Navigate | Enter: Run again | l: Edit and run | d/Del: Forget | t: Scripts"
+}
@@ -0,0 +1,125 @@
+package panels
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func TestRenderRunPanel(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Run = RunState{
+		Targets: []types.RunTarget{
+			{Name: "demo", Kind: types.RunTargetScript, Spec: "demo.cli:main"},
+			{Name: "demo-gui", Kind: types.RunTargetGUIScript, Spec: "demo.gui:run"},
+			{Name: "main.py", Kind: types.RunTargetFile},
+			{Name: "scripts/seed.py", Kind: types.RunTargetFile},
+		},
+		Selected: 2,
+	}
+	state.Run.AddRun(types.ScriptRun{Options: types.RunOptions{Target: "main.py"}, ExitCode: 2, Error: errors.New("exit status 2")})
+	state.Run.AddRun(types.ScriptRun{Options: types.RunOptions{Target: "demo"}})
+
+	output := RenderRunPanel(state)
+	for _, want := range []string{"Scripts [project.scripts]", "→ demo.cli:main", "GUI scripts [project.gui-scripts]", "Python files", "scripts/seed.py", "✓ exit 0", "✗ exit 2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderRunPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "main.py") {
+		t.Errorf("RenderRunPanel() should select main.py:\n%s", output)
+	}
+}
+
+func TestRenderRunPanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderRunPanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderRunPanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	state.Run.Loading = true
+	if output := RenderRunPanel(state); !strings.Contains(output, "Looking for scripts") {
+		t.Errorf("RenderRunPanel() while loading = %q", output)
+	}
+
+	state.Run.Loading = false
+	if output := RenderRunPanel(state); !strings.Contains(output, "No scripts or Python files found") {
+		t.Errorf("RenderRunPanel() without targets = %q", output)
+	}
+
+	state.Run.ShowHistory = true
+	if output := RenderRunPanel(state); !strings.Contains(output, "No runs yet") {
+		t.Errorf("RenderRunPanel() without runs = %q", output)
+	}
+}
+
+func TestRenderRunPanel_History(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Run.ShowHistory = true
+	started := time.Date(2024, 5, 1, 9, 5, 0, 0, time.UTC)
+	state.Run.AddRun(types.ScriptRun{Options: types.RunOptions{Target: "serve"}, StartedAt: started, ExitCode: -1, Error: errors.New("context canceled")})
+	state.Run.AddRun(types.ScriptRun{Options: types.RunOptions{Target: "main.py", Args: []string{"a b"}, Env: []string{"DEBUG=1"}, With: []string{"rich"}}, StartedAt: started, Duration: 2500 * time.Millisecond, ExitCode: 1, Error: errors.New("exit status 1")})
+
+	output := RenderRunPanel(state)
+	for _, want := range []string{"Recent runs (2)", "✗ exit 1 DEBUG=1 uv run --with rich main.py 'a b'", "09:05:00, 2.5s", "uv run serve"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderRunPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "context canceled") {
+		t.Error("RenderRunPanel() should only show the error of the selected run")
+	}
+}
+
+func TestRunState_History(t *testing.T) {
+	var state RunState
+	for i := 0; i < MaxScriptRuns+3; i++ {
+		state.AddRun(types.ScriptRun{Options: types.RunOptions{Target: fmt.Sprintf("script%d.py", i)}})
+	}
+	if len(state.History) != MaxScriptRuns {
+		t.Fatalf("AddRun() kept %d runs, want %d", len(state.History), MaxScriptRuns)
+	}
+
+	// The same target with other arguments is a separate entry.
+	state.AddRun(types.ScriptRun{Options: types.RunOptions{Target: "script22.py", Args: []string{"-v"}}, ExitCode: 3})
+	if len(state.History) != MaxScriptRuns || state.History[1].Options.Target != "script22.py" {
+		t.Errorf("AddRun() with other arguments = %+v", state.History[:2])
+	}
+	if run, ok := state.LastRun("script22.py"); !ok || run.ExitCode != 3 {
+		t.Errorf("LastRun() = %+v, %v, want the run with -v", run, ok)
+	}
+	if _, ok := state.LastRun("missing.py"); ok {
+		t.Error("LastRun() found a target that never ran")
+	}
+
+	state.RemoveRun(0)
+	if len(state.History) != MaxScriptRuns-1 || state.History[0].Options.Target != "script22.py" {
+		t.Errorf("RemoveRun(0) left %+v", state.History[0])
+	}
+}
+
+func TestRunForm(t *testing.T) {
+	form := NewRunForm(types.RunOptions{Target: "main.py", Args: []string{"--name", "a b"}, Env: []string{"DEBUG=1"}, With: []string{"rich"}})
+	if form.Focus != 1 {
+		t.Errorf("NewRunForm() focused field %d, want the arguments", form.Focus)
+	}
+	if want := "DEBUG=1 uv run --with rich main.py --name 'a b'"; form.Preview != want {
+		t.Errorf("NewRunForm() preview = %q, want %q", form.Preview, want)
+	}
+
+	form.Field(ScriptFieldEnv).Value = "A=1 'B=two words'"
+	options := RunFormOptions(&form)
+	if strings.Join(options.Env, "|") != "A=1|B=two words" {
+		t.Errorf("RunFormOptions() env = %q", options.Env)
+	}
+
+	form = NewRunForm(types.RunOptions{})
+	if form.Focus != 0 || form.Preview != "" {
+		t.Errorf("NewRunForm() without a target = focus %d, preview %q", form.Focus, form.Preview)
+	}
+}
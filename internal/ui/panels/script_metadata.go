@@ -0,0 +1,127 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// AddScriptDependencyForm is the form ID of the add script dependency dialog.
+const AddScriptDependencyForm = "add-script-dependency"
+
+// scriptDependencyRows is the number of dependencies shown at once in the
+// script view.
+const scriptDependencyRows = 12
+
+// ScriptViewState represents the state of the inline metadata view of a
+// script on the Run panel.
+type ScriptViewState struct {
+	Visible  bool
+	Loading  bool
+	Path     string // the script, relative to the project
+	Metadata *types.ScriptMetadata
+	Selected int // selected dependency
+}
+
+// renderScriptView renders the PEP 723 metadata of a script.
+func renderScriptView(state ScriptViewState) string {
+	var content strings.Builder
+
+	content.WriteString(ui.CurrentVersionStyle.Render("Script " + state.Path))
+	content.WriteString("\n")
+
+	if state.Loading {
+		content.WriteString(ui.LoadingStyle.Render("  Reading inline metadata..."))
+		return content.String()
+	}
+	metadata := state.Metadata
+	if metadata == nil {
+		content.WriteString(ui.UnselectedItemStyle.Render("  No metadata loaded"))
+		return content.String()
+	}
+	if !metadata.HasBlock {
+		content.WriteString("  No inline metadata (# /// script block).\n")
+		content.WriteString("  Press 'a' to add a dependency, which creates it.")
+		return content.String()
+	}
+
+	requiresPython := metadata.RequiresPython
+	if requiresPython == "" {
+		requiresPython = "any"
+	}
+	content.WriteString(fmt.Sprintf("  Requires Python: %s\n", requiresPython))
+	if metadata.Locked {
+		content.WriteString("  Lockfile: " + ui.SuccessStyle.Render(metadata.Path+".lock") + "\n")
+	} else {
+		content.WriteString("  Lockfile: " + ui.WarningMessageStyle.Render("not locked") + "\n")
+	}
+	content.WriteString("\n")
+
+	deps := metadata.Dependencies
+	if len(deps) == 0 {
+		content.WriteString("  No dependencies.")
+		return content.String()
+	}
+	content.WriteString(fmt.Sprintf("Dependencies (%d):\n", len(deps)))
+	start, end := visibleRange(state.Selected, len(deps), scriptDependencyRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		dep := deps[i]
+		if i == state.Selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(dep.Name)
+		if len(dep.Extras) > 0 {
+			content.WriteString("[" + strings.Join(dep.Extras, ",") + "]")
+		}
+		if dep.Version != "" {
+			content.WriteString(" " + dep.Version)
+		}
+		if dep.Markers != "" {
+			content.WriteString(ui.HelpStyle.Render("; " + dep.Markers))
+		}
+		content.WriteString("\n")
+	}
+	if end < len(deps) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(deps)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// NewScriptDependencyForm returns the dialog adding a dependency to the inline
+// metadata of script.
+func NewScriptDependencyForm(script string) FormState {
+	form := FormState{
+		ID:    AddScriptDependencyForm,
+		Title: "Add Dependency to " + script,
+		Fields: []FormField{
+			{Key: DependencyFieldPackage, Label: "Package", Kind: FieldText, Placeholder: "name, path or git URL"},
+			{Key: DependencyFieldSpecifier, Label: "Version", Kind: FieldText, Placeholder: ">=1.0, or 1.2.3 to pin"},
+		},
+	}
+	UpdateScriptDependencyForm(&form, script)
+	return form
+}
+
+// UpdateScriptDependencyForm previews the uv add --script command of the
+// dialog.
+func UpdateScriptDependencyForm(form *FormState, script string) {
+	form.Preview = ""
+	if form.Value(DependencyFieldPackage) != "" {
+		form.Preview = "uv add --script " + JoinArgs([]string{script, DependencyRequirement(form)})
+	}
+}
+
+// GetScriptViewHelp returns help text for the script view.
+func GetScriptViewHelp() string {
+	return "↑↓: Navigate | a: Add | d/Del: Remove | l: Lock | Enter: Run... | r: Reload | m/Esc: Back"
+}
@@ -0,0 +1,84 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+func TestRenderScriptView(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Run.Script = ScriptViewState{
+		Visible: true,
+		Path:    "fetch.py",
+		Metadata: &types.ScriptMetadata{
+			Path:           "fetch.py",
+			HasBlock:       true,
+			RequiresPython: ">=3.12",
+			Dependencies: []types.ProjectDependency{
+				{Name: "httpx", Version: ">=0.27"},
+				{Name: "rich", Extras: []string{"jupyter"}, Markers: `sys_platform != "win32"`},
+			},
+			Locked: true,
+		},
+		Selected: 1,
+	}
+
+	output := RenderRunPanel(state)
+	for _, want := range []string{"Script fetch.py", "Requires Python: >=3.12", "fetch.py.lock", "Dependencies (2)", "httpx >=0.27", "rich[jupyter]", `sys_platform != "win32"`, GetScriptViewHelp()} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderRunPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "rich") {
+		t.Errorf("RenderRunPanel() should select rich:\n%s", output)
+	}
+}
+
+func TestRenderScriptView_States(t *testing.T) {
+	view := ScriptViewState{Visible: true, Path: "main.py", Loading: true}
+	if output := renderScriptView(view); !strings.Contains(output, "Reading inline metadata") {
+		t.Errorf("renderScriptView() while loading = %q", output)
+	}
+
+	view.Loading = false
+	view.Metadata = &types.ScriptMetadata{Path: "main.py"}
+	if output := renderScriptView(view); !strings.Contains(output, "No inline metadata") {
+		t.Errorf("renderScriptView() without a block = %q", output)
+	}
+
+	view.Metadata = &types.ScriptMetadata{Path: "main.py", HasBlock: true}
+	output := renderScriptView(view)
+	for _, want := range []string{"Requires Python: any", "not locked", "No dependencies"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("renderScriptView() missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRenderRunPanel_InlineTargets(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Run.Targets = []types.RunTarget{
+		{Name: "fetch.py", Kind: types.RunTargetFile, Inline: true},
+		{Name: "main.py", Kind: types.RunTargetFile},
+	}
+	output := RenderRunPanel(state)
+	if strings.Count(output, "[PEP 723]") != 1 || !strings.Contains(output, "fetch.py [PEP 723]") {
+		t.Errorf("RenderRunPanel() should mark only fetch.py:\n%s", output)
+	}
+}
+
+func TestScriptDependencyForm(t *testing.T) {
+	form := NewScriptDependencyForm("scripts/fetch data.py")
+	if form.Preview != "" {
+		t.Errorf("NewScriptDependencyForm() preview = %q, want none", form.Preview)
+	}
+
+	form.Field(DependencyFieldPackage).Value = "httpx"
+	form.Field(DependencyFieldSpecifier).Value = "0.27.0"
+	UpdateScriptDependencyForm(&form, "scripts/fetch data.py")
+	if want := "uv add --script 'scripts/fetch data.py' httpx==0.27.0"; form.Preview != want {
+		t.Errorf("UpdateScriptDependencyForm() preview = %q, want %q", form.Preview, want)
+	}
+}
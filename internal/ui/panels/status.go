@@ -5,12 +5,25 @@ import (
 	"fmt"
 	"strings"
 
+	"uvui/internal/types"
 	"uvui/internal/ui"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
+// UVBinariesState represents the uv binaries found on this machine.
+type UVBinariesState struct {
+	Binaries    []types.UVBinary
+	Selected    int
+	Loading     bool
+	Active      string // binary used by the services; "" for the one on PATH
+	ProjectPath string // binary configured for the current project
+	// ProjectTrusted is set once the user lets uvui run the project's own uv
+	// binaries and the one its settings ask for.
+	ProjectTrusted bool
+}
+
 // RenderStatusPanel renders the status panel content.
 func RenderStatusPanel(state *AppState, installCmd string) string {
 	var content strings.Builder
@@ -21,12 +34,25 @@ func RenderStatusPanel(state *AppState, installCmd string) string {
 		content.WriteString(ui.LoadingStyle.Render("⏳ Installing UV...") + "\n")
 	} else if state.Installed {
 		content.WriteString(ui.SuccessStyle.Render("✅ UV is installed") + "\n")
-		if state.Version != "" {
+		if state.Details.Version != "" {
+			content.WriteString(fmt.Sprintf("   Version: %s\n", state.Details.Version))
+			if build := renderUVBuild(state.Details); build != "" {
+				content.WriteString(fmt.Sprintf("   Build: %s\n", build))
+			}
+		} else if state.Version != "" {
 			content.WriteString(fmt.Sprintf("   Version: %s\n", state.Version))
 		}
 		if state.Path != "" {
 			content.WriteString(fmt.Sprintf("   Path: %s\n", state.Path))
 		}
+		if state.Managed {
+			content.WriteString("   Managed by uvui (d: uninstall)\n")
+		}
+		if !state.Details.AtLeast(state.MinUVVersion) {
+			content.WriteString(ui.WarningMessageStyle.Render(fmt.Sprintf(
+				"   ⚠ uv %s is older than %s, the oldest version uvui supports. Press 'u' to update.",
+				state.Details.Version, state.MinUVVersion)) + "\n")
+		}
 	} else {
 		content.WriteString(ui.ErrorStyle.Render("❌ UV is not installed") + "\n")
 		content.WriteString("   Press 'i' to install UV\n\n")
@@ -38,6 +64,8 @@ func RenderStatusPanel(state *AppState, installCmd string) string {
 		}
 	}
 
+	content.WriteString(renderUVBinaries(state.UVBinaries))
+
 	// Show recent messages
 	if len(state.Messages) > 0 {
 		content.WriteString("\nRecent Messages:\n")
@@ -52,12 +80,92 @@ func RenderStatusPanel(state *AppState, installCmd string) string {
 		content.WriteString(ui.LoadingStyle.Render(fmt.Sprintf("⏳ %s %s...",
 			cases.Title(language.English).String(state.Operation.Operation),
 			state.Operation.Target)))
+	} else if state.Operation.Cancelled || state.Operation.TimedOut {
+		result := "cancelled"
+		if state.Operation.TimedOut {
+			result = "timed out"
+		}
+		content.WriteString("\nLast Operation:\n")
+		content.WriteString(ui.WarningMessageStyle.Render(strings.TrimSpace(fmt.Sprintf("⚠ %s %s",
+			cases.Title(language.English).String(state.Operation.Operation),
+			state.Operation.Target)) + " " + result))
+	}
+
+	return content.String()
+}
+
+// renderUVBinaries renders the list of discovered uv binaries.
+func renderUVBinaries(binaries UVBinariesState) string {
+	var content strings.Builder
+
+	content.WriteString("\nUV Binaries:\n")
+	if binaries.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Searching for uv binaries...") + "\n")
+		return content.String()
+	}
+	if binaries.ProjectPath != "" && !binaries.ProjectTrusted {
+		content.WriteString(ui.WarningMessageStyle.Render(fmt.Sprintf("   The project asks for %s; press 'T' to trust the project and use it", binaries.ProjectPath)))
+		content.WriteString("\n")
+	}
+	if len(binaries.Binaries) == 0 {
+		content.WriteString("   No uv binaries found\n")
+		return content.String()
+	}
+
+	active := binaries.Active
+	if active == "" {
+		// Without a selection the first uv on PATH is used.
+		for _, binary := range binaries.Binaries {
+			if binary.Source == types.UVSourcePath {
+				active = binary.Path
+				break
+			}
+		}
 	}
 
+	for i, binary := range binaries.Binaries {
+		var line strings.Builder
+		if i == binaries.Selected {
+			line.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			line.WriteString("  ")
+		}
+
+		version := binary.Details.Version
+		if version == "" {
+			version = "unknown version"
+		}
+		text := fmt.Sprintf("%s  %s [%s]", binary.Path, version, binary.Source)
+		if binary.Path == active {
+			line.WriteString(ui.CurrentVersionStyle.Render("● " + text + " (active)"))
+		} else {
+			line.WriteString("  " + text)
+		}
+		if binary.Path == binaries.ProjectPath {
+			line.WriteString(ui.PinnedVersionStyle.Render(" (project)"))
+		}
+		content.WriteString(line.String() + "\n")
+	}
 	return content.String()
 }
 
+// renderUVBuild describes the commit, build date and build number of a uv
+// release, as far as they are known.
+func renderUVBuild(version types.UVVersion) string {
+	var parts []string
+	if version.Commit != "" {
+		parts = append(parts, version.Commit)
+	}
+	if version.Date != "" {
+		parts = append(parts, version.Date)
+	}
+	if version.Build != "" {
+		parts = append(parts, "+"+version.Build)
+	}
+	return strings.Join(parts, " ")
+}
+
 // GetStatusPanelHelp returns help text for the status panel.
 func GetStatusPanelHelp() string {
-	return "i: Install UV | r: Refresh status | ?: Help"
+	return "i: Install UV | u: Update UV | U: Update to version | ↑↓: Select uv | Enter: Use uv | p: Use uv for project | T: Trust project | r: Refresh status | x: Cancel operation | ?: Help"
 }
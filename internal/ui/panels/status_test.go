@@ -339,9 +339,45 @@ func TestRenderStatusPanel_OperationWithAllFields(t *testing.T) {
 	}
 }
 
+func TestRenderStatusPanel_CancelledOperation(t *testing.T) {
+	state := &AppState{
+		UVStatus: types.UVStatus{
+			Installed: true,
+		},
+		Operation: types.OperationStatus{
+			Operation: "sync",
+			Cancelled: true,
+		},
+	}
+
+	result := RenderStatusPanel(state, "")
+
+	if !strings.Contains(result, "Sync cancelled") {
+		t.Error("Expected cancelled operation result not found")
+	}
+}
+
+func TestRenderStatusPanel_TimedOutOperation(t *testing.T) {
+	state := &AppState{
+		UVStatus: types.UVStatus{
+			Installed: true,
+		},
+		Operation: types.OperationStatus{
+			Operation: "lock",
+			TimedOut:  true,
+		},
+	}
+
+	result := RenderStatusPanel(state, "")
+
+	if !strings.Contains(result, "Lock timed out") {
+		t.Error("Expected timed out operation result not found")
+	}
+}
+
 func TestGetStatusPanelHelp(t *testing.T) {
 	result := GetStatusPanelHelp()
-	expected := "i: Install UV | r: Refresh status | ?: Help"
+	expected := "i: Install UV | u: Update UV | U: Update to version | ↑↓: Select uv | Enter: Use uv | p: Use uv for project | T: Trust project | r: Refresh status | x: Cancel operation | ?: Help"
 
 	if result != expected {
 		t.Errorf("Expected help text '%s', got '%s'", expected, result)
@@ -528,3 +564,90 @@ func TestRenderStatusPanel_BooleanLogicCoverage(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderStatusPanel_VersionDetails(t *testing.T) {
+	state := &AppState{
+		MinUVVersion: "0.5.0",
+		UVStatus: types.UVStatus{
+			Installed: true,
+			Version:   "uv 0.5.1+3 (8d4d9b0 2024-11-07)",
+			Details:   types.UVVersion{Version: "0.5.1", Build: "3", Commit: "8d4d9b0", Date: "2024-11-07"},
+			Managed:   true,
+		},
+	}
+
+	result := RenderStatusPanel(state, "")
+
+	for _, expected := range []string{"Version: 0.5.1", "Build: 8d4d9b0 2024-11-07 +3", "Managed by uvui"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected %q in result, got %q", expected, result)
+		}
+	}
+	if strings.Contains(result, "older than") {
+		t.Error("Should not warn about a supported uv version")
+	}
+}
+
+func TestRenderStatusPanel_OldVersionWarning(t *testing.T) {
+	state := &AppState{
+		MinUVVersion: "0.5.0",
+		UVStatus: types.UVStatus{
+			Installed: true,
+			Details:   types.UVVersion{Version: "0.4.30"},
+		},
+	}
+
+	result := RenderStatusPanel(state, "")
+
+	if !strings.Contains(result, "uv 0.4.30 is older than 0.5.0") {
+		t.Errorf("Expected old version warning, got %q", result)
+	}
+}
+
+func TestRenderStatusPanel_UVBinaries(t *testing.T) {
+	state := &AppState{
+		UVStatus: types.UVStatus{Installed: true},
+		UVBinaries: UVBinariesState{
+			Binaries: []types.UVBinary{
+				{Path: "/repo/.venv/bin/uv", Source: types.UVSourceProject, Details: types.UVVersion{Version: "0.5.2"}},
+				{Path: "/usr/bin/uv", Source: types.UVSourcePath, Details: types.UVVersion{Version: "0.5.0"}},
+				{Path: "/home/me/.cargo/bin/uv", Source: types.UVSourceCargo},
+			},
+			Selected:    2,
+			ProjectPath: "/repo/.venv/bin/uv",
+		},
+	}
+
+	result := RenderStatusPanel(state, "")
+
+	for _, expected := range []string{
+		"/repo/.venv/bin/uv  0.5.2 [project] (project)",
+		"● /usr/bin/uv  0.5.0 [PATH] (active)",
+		"/home/me/.cargo/bin/uv  unknown version [~/.cargo/bin]",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected %q in result, got %q", expected, result)
+		}
+	}
+
+	if !strings.Contains(result, "The project asks for /repo/.venv/bin/uv; press 'T' to trust the project") {
+		t.Errorf("Expected the untrusted project uv to be pointed out, got %q", result)
+	}
+
+	state.UVBinaries.ProjectTrusted = true
+	state.UVBinaries.Active = "/repo/.venv/bin/uv"
+	result = RenderStatusPanel(state, "")
+	if strings.Contains(result, "press 'T'") {
+		t.Errorf("Expected no trust hint for a trusted project, got %q", result)
+	}
+	if !strings.Contains(result, "● /repo/.venv/bin/uv  0.5.2 [project] (active)") {
+		t.Errorf("Expected the selected binary to be active, got %q", result)
+	}
+}
+
+func TestRenderStatusPanel_NoUVBinaries(t *testing.T) {
+	result := RenderStatusPanel(&AppState{}, "")
+	if !strings.Contains(result, "No uv binaries found") {
+		t.Errorf("Expected empty binary list message, got %q", result)
+	}
+}
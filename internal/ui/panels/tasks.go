@@ -0,0 +1,180 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// taskRows is the number of tasks shown at once.
+const taskRows = 12
+
+// taskLogLines is the number of lines shown from the end of a task log.
+const taskLogLines = 15
+
+// TasksState represents the state of the task runner panel.
+type TasksState struct {
+	Tasks    []types.Task
+	Selected int
+	Loading  bool
+	Results  map[string]types.TaskResult // last result of each task
+	ShowLog  bool                        // show the log of the selected task
+}
+
+// AddResults records the results of a task run (exported).
+func (s *TasksState) AddResults(results []types.TaskResult) {
+	if s.Results == nil {
+		s.Results = make(map[string]types.TaskResult)
+	}
+	for _, result := range results {
+		s.Results[result.Task] = result
+	}
+}
+
+// TaskCommandLine returns the uv run command line of a task, with its
+// environment overrides in front (exported).
+func TaskCommandLine(task types.Task) string {
+	line := "uv run " + JoinArgs(task.RunArgs())
+	if len(task.Env) > 0 {
+		line = JoinArgs(task.Env) + " " + line
+	}
+	return line
+}
+
+// RenderTasksPanel renders the task runner panel.
+func RenderTasksPanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Project Tasks\n\n")
+
+	if !state.Installed {
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to run tasks."))
+		return content.String()
+	}
+
+	if state.Tasks.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Reading tasks..."))
+		return content.String()
+	}
+
+	tasks := state.Tasks.Tasks
+	if len(tasks) == 0 {
+		content.WriteString("No tasks found.\n")
+		content.WriteString("Declare them in the [tool.uvui.tasks] table of pyproject.toml.")
+	} else {
+		content.WriteString(fmt.Sprintf("Tasks [tool.uvui.tasks] (%d):\n\n", len(tasks)))
+		content.WriteString(renderTaskList(state.Tasks))
+		if state.Tasks.Selected >= 0 && state.Tasks.Selected < len(tasks) {
+			task := tasks[state.Tasks.Selected]
+			content.WriteString("\n")
+			if state.Tasks.ShowLog {
+				content.WriteString(renderTaskLog(task.Name, state.Tasks.Results))
+			} else {
+				content.WriteString(renderTaskDetails(task))
+			}
+		}
+	}
+
+	content.WriteString("\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetTasksPanelHelp()))
+
+	return content.String()
+}
+
+// renderTaskList renders the visible tasks with the outcome of their last
+// run.
+func renderTaskList(state TasksState) string {
+	var content strings.Builder
+
+	start, end := visibleRange(state.Selected, len(state.Tasks), taskRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		task := state.Tasks[i]
+		if i == state.Selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(task.Name)
+		content.WriteString(ui.HelpStyle.Render(" → " + JoinArgs(task.Command)))
+		if result, ok := state.Results[task.Name]; ok {
+			content.WriteString(" " + renderRunStatus(result.Error, result.ExitCode))
+			content.WriteString(ui.HelpStyle.Render(" " + result.Duration.Round(100*time.Millisecond).String()))
+		}
+		content.WriteString("\n")
+	}
+	if end < len(state.Tasks) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(state.Tasks)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderTaskDetails renders the description, command and dependencies of a
+// task.
+func renderTaskDetails(task types.Task) string {
+	var content strings.Builder
+
+	content.WriteString(ui.InfoMessageStyle.Render("  " + task.Name))
+	content.WriteString("\n")
+	if task.Description != "" {
+		content.WriteString(fmt.Sprintf("    %s\n", task.Description))
+	}
+	content.WriteString(fmt.Sprintf("    Runs: %s\n", TaskCommandLine(task)))
+	if len(task.Depends) > 0 {
+		content.WriteString(fmt.Sprintf("    After: %s\n", strings.Join(task.Depends, ", ")))
+	}
+	return content.String()
+}
+
+// renderTaskLog renders the end of the output of the last run of a task.
+func renderTaskLog(name string, results map[string]types.TaskResult) string {
+	var content strings.Builder
+
+	result, ok := results[name]
+	if !ok {
+		content.WriteString(ui.UnselectedItemStyle.Render(fmt.Sprintf("  %s has not run yet. Press Enter to run it.", name)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	content.WriteString(ui.InfoMessageStyle.Render(fmt.Sprintf("  Log of %s", name)))
+	content.WriteString(ui.HelpStyle.Render(fmt.Sprintf(" %s, %s", result.StartedAt.Format("15:04:05"), result.Duration.Round(100*time.Millisecond))))
+	content.WriteString("\n")
+
+	lines := result.Log
+	if len(lines) > taskLogLines {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("    … %d earlier lines", len(lines)-taskLogLines)))
+		content.WriteString("\n")
+		lines = lines[len(lines)-taskLogLines:]
+	}
+	if len(lines) == 0 {
+		content.WriteString(ui.UnselectedItemStyle.Render("    (no output)"))
+		content.WriteString("\n")
+	}
+	for _, line := range lines {
+		if line.Stream == types.StreamStderr {
+			content.WriteString("    " + ui.OutputStderrStyle.Render(line.Text))
+		} else {
+			content.WriteString("    " + line.Text)
+		}
+		content.WriteString("\n")
+	}
+	if result.Error != nil && result.ExitCode <= 0 {
+		content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("    %v", result.Error)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// GetTasksPanelHelp returns help text for the task runner panel.
+func GetTasksPanelHelp() string {
+	return "↑↓: Navigate | Enter: Run with dependencies | t: Details/Log | r: Refresh"
+}
@@ -0,0 +1,96 @@
+package panels
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func newTasksTestState() *AppState {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Tasks.Tasks = []types.Task{
+		{Name: "lint", Command: []string{"ruff", "check", "."}},
+		{
+			Name:        "test",
+			Description: "Run the test suite",
+			Command:     []string{"pytest", "-q"},
+			Env:         []string{"DEBUG=1"},
+			Depends:     []string{"lint"},
+			Python:      "3.12",
+		},
+	}
+	state.Tasks.Selected = 1
+	return state
+}
+
+func TestRenderTasksPanel(t *testing.T) {
+	state := newTasksTestState()
+	state.Tasks.AddResults([]types.TaskResult{
+		{Task: "lint", Duration: 1200 * time.Millisecond},
+		{Task: "test", Duration: time.Second, ExitCode: 1, Error: errors.New("exit status 1")},
+	})
+
+	output := RenderTasksPanel(state)
+	for _, want := range []string{"Tasks [tool.uvui.tasks] (2)", "→ ruff check .", "✓ exit 0", "✗ exit 1", "Run the test suite", "Runs: DEBUG=1 uv run --python 3.12 pytest -q", "After: lint", GetTasksPanelHelp()} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTasksPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "test") {
+		t.Errorf("RenderTasksPanel() should select test:\n%s", output)
+	}
+}
+
+func TestRenderTasksPanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderTasksPanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderTasksPanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	state.Tasks.Loading = true
+	if output := RenderTasksPanel(state); !strings.Contains(output, "Reading tasks") {
+		t.Errorf("RenderTasksPanel() while loading = %q", output)
+	}
+
+	state.Tasks.Loading = false
+	if output := RenderTasksPanel(state); !strings.Contains(output, "[tool.uvui.tasks] table") {
+		t.Errorf("RenderTasksPanel() without tasks = %q", output)
+	}
+}
+
+func TestRenderTasksPanel_Log(t *testing.T) {
+	state := newTasksTestState()
+	state.Tasks.ShowLog = true
+	if output := RenderTasksPanel(state); !strings.Contains(output, "test has not run yet") {
+		t.Errorf("RenderTasksPanel() log before a run = %q", output)
+	}
+
+	var log []types.OutputLine
+	for i := 1; i <= taskLogLines+5; i++ {
+		log = append(log, types.OutputLine{Stream: types.StreamStdout, Text: fmt.Sprintf("line %d", i)})
+	}
+	log = append(log, types.OutputLine{Stream: types.StreamStderr, Text: "1 failed"})
+	state.Tasks.AddResults([]types.TaskResult{{Task: "test", StartedAt: time.Date(2024, 5, 1, 9, 5, 0, 0, time.UTC), Log: log}})
+
+	output := RenderTasksPanel(state)
+	for _, want := range []string{"Log of test", "09:05:00", "… 6 earlier lines", "line 20", "1 failed"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTasksPanel() log missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "line 6\n") || strings.Contains(output, "Runs:") {
+		t.Errorf("RenderTasksPanel() log shows too much:\n%s", output)
+	}
+}
+
+func TestTaskCommandLine(t *testing.T) {
+	task := types.Task{Command: []string{"pytest", "-k", "not slow"}, Env: []string{"A=1", "B=two words"}}
+	if want := "A=1 'B=two words' uv run pytest -k 'not slow'"; TaskCommandLine(task) != want {
+		t.Errorf("TaskCommandLine() = %q, want %q", TaskCommandLine(task), want)
+	}
+}
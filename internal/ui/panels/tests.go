@@ -0,0 +1,290 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// PytestForm is the form ID of the run tests dialog.
+const PytestForm = "run-pytest"
+
+// Keys of the run tests dialog fields.
+const (
+	PytestFieldTests = "tests"
+	PytestFieldArgs  = "args"
+)
+
+// testRows is the number of test cases shown at once.
+const testRows = 10
+
+// tracebackLines is the number of lines shown from the end of a traceback.
+const tracebackLines = 20
+
+// testOutcomeIcons are the markers of the test outcomes.
+var testOutcomeIcons = map[string]string{
+	types.TestPassed:  ui.SuccessStyle.Render("✓"),
+	types.TestFailed:  ui.ErrorStyle.Render("✗"),
+	types.TestError:   ui.ErrorStyle.Render("!"),
+	types.TestSkipped: ui.WarningMessageStyle.Render("○"),
+}
+
+// TestsState represents the state of the test results panel.
+type TestsState struct {
+	Report   *types.TestReport
+	Options  types.PytestOptions // of the last run of the whole selection
+	ShowAll  bool                // list every test instead of the failures
+	Selected int
+	Matrix   MatrixState
+}
+
+// VisibleCases returns the test cases listed: the failures, or every test
+// (exported).
+func (s TestsState) VisibleCases() []types.TestCase {
+	if s.Report == nil {
+		return nil
+	}
+	if s.ShowAll {
+		return s.Report.Cases
+	}
+	return s.Report.Failures()
+}
+
+// SetReport shows the report of a run. The report of a rerun of some tests
+// is merged into the current one, replacing the results of the tests that ran
+// again (exported).
+func (s *TestsState) SetReport(report *types.TestReport, rerun bool) {
+	if !rerun || s.Report == nil {
+		s.Report = report
+		s.Selected = clampSelection(s.Selected, len(s.VisibleCases()))
+		return
+	}
+
+	merged := &types.TestReport{Cases: append([]types.TestCase(nil), s.Report.Cases...), Duration: s.Report.Duration}
+	index := make(map[string]int, len(merged.Cases))
+	for i, c := range merged.Cases {
+		index[c.NodeID] = i
+	}
+	for _, c := range report.Cases {
+		if i, ok := index[c.NodeID]; ok {
+			merged.Cases[i] = c
+		} else {
+			merged.Cases = append(merged.Cases, c)
+		}
+	}
+	s.Report = merged
+	s.Selected = clampSelection(s.Selected, len(s.VisibleCases()))
+}
+
+// TestSummary summarizes the outcomes of a report, e.g.
+// "10 passed, 2 failed, 1 skipped" (exported).
+func TestSummary(report *types.TestReport) string {
+	var parts []string
+	for _, outcome := range []string{types.TestPassed, types.TestFailed, types.TestError, types.TestSkipped} {
+		n := report.Count(outcome)
+		if n == 0 {
+			continue
+		}
+		label := outcome
+		if outcome == types.TestError {
+			label = "errors"
+			if n == 1 {
+				label = "error"
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", n, label))
+	}
+	if len(parts) == 0 {
+		return "no tests ran"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PytestCommandLine returns the command line of a pytest run as shown to the
+// user, without the report options uvui adds (exported).
+func PytestCommandLine(options types.PytestOptions) string {
+	return strings.TrimSpace("uv run pytest " + JoinArgs(append(append([]string(nil), options.Args...), options.Tests...)))
+}
+
+// RenderTestsPanel renders the test results panel.
+func RenderTestsPanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Tests (pytest)\n\n")
+
+	if !state.Installed {
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to run tests."))
+		return content.String()
+	}
+
+	if state.Tests.Matrix.Visible {
+		content.WriteString(renderMatrixView(state.Tests.Matrix, state.Jobs.Jobs))
+		content.WriteString("\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetMatrixViewHelp()))
+		return content.String()
+	}
+
+	report := state.Tests.Report
+	if report == nil {
+		content.WriteString("No test results yet.\n")
+		content.WriteString("Press 'a' to run the test suite with uv run pytest.")
+		content.WriteString("\n\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetTestsPanelHelp()))
+		return content.String()
+	}
+
+	content.WriteString(renderTestCounts(report))
+	content.WriteString("\n\n")
+
+	cases := state.Tests.VisibleCases()
+	switch {
+	case state.Tests.ShowAll:
+		content.WriteString(fmt.Sprintf("All tests (%d):\n", len(cases)))
+	case len(cases) == 0:
+		content.WriteString(ui.SuccessStyle.Render("No failures."))
+		content.WriteString("\n")
+	default:
+		content.WriteString(fmt.Sprintf("Failures (%d):\n", len(cases)))
+	}
+	content.WriteString(renderTestCases(cases, state.Tests.Selected))
+
+	if state.Tests.Selected >= 0 && state.Tests.Selected < len(cases) {
+		content.WriteString("\n")
+		content.WriteString(renderTestDetails(cases[state.Tests.Selected]))
+	}
+
+	content.WriteString("\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetTestsPanelHelp()))
+
+	return content.String()
+}
+
+// renderTestCounts renders the number of tests of each outcome.
+func renderTestCounts(report *types.TestReport) string {
+	counts := []string{
+		ui.SuccessStyle.Render(fmt.Sprintf("✓ %d passed", report.Count(types.TestPassed))),
+		ui.ErrorStyle.Render(fmt.Sprintf("✗ %d failed", report.Count(types.TestFailed))),
+	}
+	if n := report.Count(types.TestError); n > 0 {
+		counts = append(counts, ui.ErrorStyle.Render(fmt.Sprintf("! %d errors", n)))
+	}
+	counts = append(counts, ui.WarningMessageStyle.Render(fmt.Sprintf("○ %d skipped", report.Count(types.TestSkipped))))
+	return strings.Join(counts, "  ") + ui.HelpStyle.Render(fmt.Sprintf("  in %s", report.Duration.Round(10*time.Millisecond)))
+}
+
+// renderTestCases renders the visible test cases.
+func renderTestCases(cases []types.TestCase, selected int) string {
+	var content strings.Builder
+
+	start, end := visibleRange(selected, len(cases), testRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		c := cases[i]
+		if i == selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+		content.WriteString(testOutcomeIcons[c.Outcome] + " " + c.NodeID)
+		content.WriteString(ui.HelpStyle.Render(" " + c.Duration.Round(time.Millisecond).String()))
+		content.WriteString("\n")
+	}
+	if end < len(cases) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(cases)-end)))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderTestDetails renders the location, message and end of the traceback
+// of a test case.
+func renderTestDetails(c types.TestCase) string {
+	var content strings.Builder
+
+	title := "  " + c.NodeID
+	if c.File != "" && c.Line > 0 {
+		title += ui.HelpStyle.Render(fmt.Sprintf(" (%s:%d)", c.File, c.Line))
+	}
+	content.WriteString(ui.InfoMessageStyle.Render(title))
+	content.WriteString("\n")
+	if c.Message != "" {
+		content.WriteString(ui.ErrorStyle.Render("    " + firstLine(c.Message)))
+		content.WriteString("\n")
+	}
+
+	if c.Details == "" {
+		return content.String()
+	}
+	lines := strings.Split(c.Details, "\n")
+	if len(lines) > tracebackLines {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("    … %d earlier lines", len(lines)-tracebackLines)))
+		content.WriteString("\n")
+		lines = lines[len(lines)-tracebackLines:]
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "E ") {
+			content.WriteString("    " + ui.OutputStderrStyle.Render(line))
+		} else {
+			content.WriteString("    " + line)
+		}
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// clampSelection keeps a selection within n items.
+func clampSelection(selected, n int) int {
+	if selected >= n {
+		selected = n - 1
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	return selected
+}
+
+// NewPytestForm returns the run tests dialog, filled in from options.
+func NewPytestForm(options types.PytestOptions) FormState {
+	form := FormState{
+		ID:    PytestForm,
+		Title: "Run Tests (uv run pytest)",
+		Fields: []FormField{
+			{Key: PytestFieldTests, Label: "Tests", Kind: FieldText, Value: JoinArgs(options.Tests), Placeholder: "all, or paths and node IDs"},
+			{Key: PytestFieldArgs, Label: "Arguments", Kind: FieldText, Value: JoinArgs(options.Args), Placeholder: "-x -k expression"},
+		},
+	}
+	UpdatePytestForm(&form)
+	return form
+}
+
+// UpdatePytestForm previews the command of the run tests dialog.
+func UpdatePytestForm(form *FormState) {
+	form.Preview = PytestCommandLine(PytestFormOptions(form))
+}
+
+// PytestFormOptions returns the options entered in the run tests dialog
+// (exported).
+func PytestFormOptions(form *FormState) types.PytestOptions {
+	return types.PytestOptions{
+		Tests: SplitArgs(form.Value(PytestFieldTests)),
+		Args:  SplitArgs(form.Value(PytestFieldArgs)),
+	}
+}
+
+// GetTestsPanelHelp returns help text for the test results panel.
+func GetTestsPanelHelp() string {
+	return "↑↓: Navigate | Enter: Rerun selected | f: Rerun failed | a: Run tests... | t: Failures/All tests | M: Python matrix"
+}
@@ -0,0 +1,142 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func newTestsTestState() *AppState {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Tests.Report = &types.TestReport{
+		Duration: 2 * time.Second,
+		Cases: []types.TestCase{
+			{NodeID: "tests/test_app.py::test_ok", Outcome: types.TestPassed},
+			{
+				NodeID:  "tests/test_app.py::test_add",
+				File:    "tests/test_app.py",
+				Line:    12,
+				Outcome: types.TestFailed,
+				Message: "assert 3 == 4\n+  where 3 = add(1, 2)",
+				Details: "def test_add():\n>       assert add(1, 2) == 4\nE       assert 3 == 4",
+			},
+			{NodeID: "tests/test_app.py::test_later", Outcome: types.TestSkipped, Message: "not ready"},
+		},
+	}
+	return state
+}
+
+func TestRenderTestsPanel(t *testing.T) {
+	state := newTestsTestState()
+
+	output := RenderTestsPanel(state)
+	for _, want := range []string{"✓ 1 passed", "✗ 1 failed", "○ 1 skipped", "in 2s", "Failures (1):", "(tests/test_app.py:12)", "assert 3 == 4", "E       assert 3 == 4", GetTestsPanelHelp()} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTestsPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "test_add") {
+		t.Errorf("RenderTestsPanel() should select test_add:\n%s", output)
+	}
+	if strings.Contains(output, "test_ok") || strings.Contains(output, "where 3") {
+		t.Errorf("RenderTestsPanel() shows too much:\n%s", output)
+	}
+
+	state.Tests.ShowAll = true
+	output = RenderTestsPanel(state)
+	if !strings.Contains(output, "All tests (3):") || !hasSelectedLine(output, "test_ok") {
+		t.Errorf("RenderTestsPanel() with all tests:\n%s", output)
+	}
+}
+
+func TestRenderTestsPanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderTestsPanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderTestsPanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	if output := RenderTestsPanel(state); !strings.Contains(output, "No test results yet") {
+		t.Errorf("RenderTestsPanel() before a run = %q", output)
+	}
+
+	state.Tests.Report = &types.TestReport{Cases: []types.TestCase{{NodeID: "test_a.py::test_ok", Outcome: types.TestPassed}}}
+	if output := RenderTestsPanel(state); !strings.Contains(output, "No failures.") {
+		t.Errorf("RenderTestsPanel() without failures = %q", output)
+	}
+}
+
+func TestRenderTestsPanel_Traceback(t *testing.T) {
+	var lines []string
+	for i := 1; i <= tracebackLines+5; i++ {
+		lines = append(lines, fmt.Sprintf("frame %d", i))
+	}
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Tests.Report = &types.TestReport{Cases: []types.TestCase{
+		{NodeID: "test_a.py::test_deep", Outcome: types.TestError, Details: strings.Join(lines, "\n")},
+	}}
+
+	output := RenderTestsPanel(state)
+	for _, want := range []string{"! 1 errors", "… 5 earlier lines", "frame 25"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTestsPanel() traceback missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "frame 5\n") {
+		t.Errorf("RenderTestsPanel() traceback shows too much:\n%s", output)
+	}
+}
+
+func TestTestsState_SetReport(t *testing.T) {
+	state := newTestsTestState().Tests
+	state.SetReport(&types.TestReport{Cases: []types.TestCase{
+		{NodeID: "tests/test_app.py::test_add", Outcome: types.TestPassed},
+		{NodeID: "tests/test_new.py::test_new", Outcome: types.TestFailed},
+	}}, true)
+
+	if got := len(state.Report.Cases); got != 4 {
+		t.Fatalf("SetReport() rerun cases = %d, want 4", got)
+	}
+	if state.Report.Cases[1].Outcome != types.TestPassed || state.Report.Duration != 2*time.Second {
+		t.Errorf("SetReport() rerun = %+v", state.Report)
+	}
+	if failures := state.VisibleCases(); len(failures) != 1 || failures[0].NodeID != "tests/test_new.py::test_new" {
+		t.Errorf("VisibleCases() after rerun = %+v", failures)
+	}
+
+	report := &types.TestReport{}
+	state.SetReport(report, false)
+	if state.Report != report || state.Selected != 0 {
+		t.Errorf("SetReport() = %+v, selected %d", state.Report, state.Selected)
+	}
+}
+
+func TestTestSummary(t *testing.T) {
+	report := newTestsTestState().Tests.Report
+	report.Cases = append(report.Cases, types.TestCase{Outcome: types.TestError})
+	if want := "1 passed, 1 failed, 1 error, 1 skipped"; TestSummary(report) != want {
+		t.Errorf("TestSummary() = %q, want %q", TestSummary(report), want)
+	}
+	if got := TestSummary(&types.TestReport{}); got != "no tests ran" {
+		t.Errorf("TestSummary() of an empty report = %q", got)
+	}
+}
+
+func TestPytestForm(t *testing.T) {
+	form := NewPytestForm(types.PytestOptions{Tests: []string{"tests/test_app.py::test_add"}, Args: []string{"-k", "not slow"}})
+	if want := "uv run pytest -k 'not slow' tests/test_app.py::test_add"; form.Preview != want {
+		t.Errorf("NewPytestForm() preview = %q, want %q", form.Preview, want)
+	}
+
+	form.Field(PytestFieldTests).Value = ""
+	UpdatePytestForm(&form)
+	if form.Preview != "uv run pytest -k 'not slow'" {
+		t.Errorf("UpdatePytestForm() preview = %q", form.Preview)
+	}
+	if options := PytestFormOptions(&form); len(options.Tests) != 0 || len(options.Args) != 2 {
+		t.Errorf("PytestFormOptions() = %+v", options)
+	}
+}
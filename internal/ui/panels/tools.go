@@ -0,0 +1,346 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+)
+
+// Form IDs of the tools panel dialogs.
+const (
+	InstallToolForm = "install-tool"
+	RunToolForm     = "run-tool"
+)
+
+// MaxToolRuns is the number of tool runs kept in the history.
+const MaxToolRuns = 20
+
+// Keys of the install tool dialog fields.
+const (
+	ToolFieldPackage   = "package"
+	ToolFieldSpecifier = "specifier"
+	ToolFieldExtras    = "extras"
+	ToolFieldWith      = "with"
+	ToolFieldPython    = "python"
+	ToolFieldEditable  = "editable"
+	ToolFieldForce     = "force"
+)
+
+// Keys of the run tool (uvx) dialog fields.
+const (
+	RunFieldPackage     = "package"
+	RunFieldCommand     = "command"
+	RunFieldWith        = "with"
+	RunFieldPython      = "python"
+	RunFieldArgs        = "args"
+	RunFieldInteractive = "interactive"
+)
+
+// ToolsState represents the state of the tools panel.
+type ToolsState struct {
+	Tools    []types.Tool
+	Selected int
+	Loading  bool
+	BinDir   string // where uv installs tool executables
+	OnPath   bool   // BinDir is on PATH
+
+	History     []types.ToolRun // recent uvx runs, most recent first
+	ShowHistory bool
+	RunSelected int // selected run in the history
+}
+
+// AddToolRun records a finished tool run at the top of the history. An earlier
+// run of the same command line is replaced, and the history is capped at
+// MaxToolRuns entries (exported).
+func (s *ToolsState) AddToolRun(run types.ToolRun) {
+	line := JoinArgs(run.Options.RunArgs())
+	history := []types.ToolRun{run}
+	for _, previous := range s.History {
+		if JoinArgs(previous.Options.RunArgs()) != line && len(history) < MaxToolRuns {
+			history = append(history, previous)
+		}
+	}
+	s.History = history
+	s.RunSelected = 0
+}
+
+// RemoveToolRun removes a run from the history (exported).
+func (s *ToolsState) RemoveToolRun(index int) {
+	if index < 0 || index >= len(s.History) {
+		return
+	}
+	s.History = append(s.History[:index:index], s.History[index+1:]...)
+	if s.RunSelected >= len(s.History) && s.RunSelected > 0 {
+		s.RunSelected = len(s.History) - 1
+	}
+}
+
+// RenderToolsPanel renders the tool management panel.
+func RenderToolsPanel(state *AppState) string {
+	var content strings.Builder
+
+	content.WriteString("Tool Management\n\n")
+
+	if !state.Installed {
+		content.WriteString(ui.ErrorStyle.Render("UV must be installed first to manage tools."))
+		return content.String()
+	}
+
+	if state.Tools.ShowHistory {
+		content.WriteString(renderToolRuns(state.Tools))
+		content.WriteString("\n\n---\n")
+		content.WriteString(ui.HelpStyle.Render(GetToolRunsHelp()))
+		return content.String()
+	}
+
+	if state.Tools.Loading {
+		content.WriteString(ui.LoadingStyle.Render("⏳ Loading tools..."))
+		return content.String()
+	}
+
+	if state.Tools.BinDir != "" && !state.Tools.OnPath {
+		content.WriteString(ui.WarningMessageStyle.Render(fmt.Sprintf("⚠ %s is not on PATH, so tools cannot be run by name.", state.Tools.BinDir)))
+		content.WriteString("\n")
+		content.WriteString(ui.WarningMessageStyle.Render("  Press 's' to add it with uv tool update-shell, then restart your shell."))
+		content.WriteString("\n\n")
+	}
+
+	tools := state.Tools.Tools
+	if len(tools) == 0 {
+		content.WriteString("No tools installed.\n")
+		content.WriteString("Press 'i' to install one with uv tool install.")
+	} else {
+		content.WriteString(fmt.Sprintf("Installed tools (%d):\n\n", len(tools)))
+		for i, tool := range tools {
+			selected := i == state.Tools.Selected
+			content.WriteString(renderToolLine(tool, selected))
+			content.WriteString("\n")
+			if selected {
+				content.WriteString(renderToolDetails(tool))
+			}
+		}
+	}
+
+	content.WriteString("\n\n---\n")
+	content.WriteString(ui.HelpStyle.Render(GetToolsPanelHelp()))
+
+	return content.String()
+}
+
+// renderToolLine renders a single tool.
+func renderToolLine(tool types.Tool, selected bool) string {
+	var line strings.Builder
+
+	if selected {
+		line.WriteString(ui.SelectedItemStyle.Render("> "))
+	} else {
+		line.WriteString("  ")
+	}
+
+	line.WriteString(tool.Name)
+	line.WriteString(" " + ui.InstalledVersionStyle.Render(tool.Version))
+	if tool.Specifier != "" {
+		line.WriteString(ui.AvailableVersionStyle.Render(" [required: " + tool.Specifier + "]"))
+	}
+	if len(tool.Extras) > 0 {
+		line.WriteString(ui.AvailableVersionStyle.Render(" [extras: " + strings.Join(tool.Extras, ", ") + "]"))
+	}
+	if len(tool.With) > 0 {
+		line.WriteString(ui.AvailableVersionStyle.Render(" [with: " + strings.Join(tool.With, ", ") + "]"))
+	}
+
+	names := make([]string, len(tool.EntryPoints))
+	for i, entry := range tool.EntryPoints {
+		names[i] = entry.Name
+	}
+	if len(names) > 0 {
+		line.WriteString(ui.HelpStyle.Render(" → " + strings.Join(names, ", ")))
+	}
+
+	return line.String()
+}
+
+// renderToolDetails renders the entry points and environment of the selected
+// tool.
+func renderToolDetails(tool types.Tool) string {
+	var content strings.Builder
+	for _, entry := range tool.EntryPoints {
+		if entry.Path == "" {
+			continue
+		}
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("    %s: %s", entry.Name, entry.Path)))
+		content.WriteString("\n")
+	}
+	if tool.Path != "" {
+		content.WriteString(ui.HelpStyle.Render("    Environment: " + tool.Path))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderToolRuns renders the history of uvx runs.
+func renderToolRuns(state ToolsState) string {
+	var content strings.Builder
+
+	if len(state.History) == 0 {
+		content.WriteString("No recent runs.\n")
+		content.WriteString("Press 'l' to run a tool with uvx.")
+		return content.String()
+	}
+
+	content.WriteString(fmt.Sprintf("Recent runs (%d):\n\n", len(state.History)))
+	for i, run := range state.History {
+		selected := i == state.RunSelected
+		if selected {
+			content.WriteString(ui.SelectedItemStyle.Render("> "))
+		} else {
+			content.WriteString("  ")
+		}
+
+		switch {
+		case run.Error == nil:
+			content.WriteString(ui.SuccessStyle.Render("✓"))
+		case run.ExitCode > 0:
+			content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("✗ exit %d", run.ExitCode)))
+		default:
+			content.WriteString(ui.ErrorStyle.Render("✗"))
+		}
+		content.WriteString(" uvx " + JoinArgs(run.Options.RunArgs()))
+		if run.Options.Interactive {
+			content.WriteString(ui.AvailableVersionStyle.Render(" [interactive]"))
+		}
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf(" %s, %s", run.StartedAt.Format("15:04:05"), run.Duration.Round(100*time.Millisecond))))
+		content.WriteString("\n")
+
+		if selected && run.Error != nil && run.ExitCode <= 0 {
+			content.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("    %v", run.Error)))
+			content.WriteString("\n")
+		}
+	}
+	return content.String()
+}
+
+// NewToolForm returns the install tool dialog.
+func NewToolForm() FormState {
+	form := FormState{
+		ID:    InstallToolForm,
+		Title: "Install Tool",
+		Fields: []FormField{
+			{Key: ToolFieldPackage, Label: "Package", Kind: FieldText, Placeholder: "name, path or git URL"},
+			{Key: ToolFieldSpecifier, Label: "Version", Kind: FieldText, Placeholder: ">=1.0, or 1.2.3 to pin"},
+			{Key: ToolFieldExtras, Label: "Extras", Kind: FieldText, Placeholder: "comma-separated"},
+			{Key: ToolFieldWith, Label: "With", Kind: FieldText, Placeholder: "extra packages, comma-separated"},
+			{Key: ToolFieldPython, Label: "Python", Kind: FieldText, Placeholder: "version or path, e.g. 3.12"},
+			{Key: ToolFieldEditable, Label: "Editable", Kind: FieldToggle},
+			{Key: ToolFieldForce, Label: "Force", Kind: FieldToggle, Hint: "overwrite existing executables"},
+		},
+	}
+	UpdateToolForm(&form)
+	return form
+}
+
+// UpdateToolForm shows the fields that apply to the package being installed
+// and previews the uv command.
+func UpdateToolForm(form *FormState) {
+	registry := DependencySource(form.Value(ToolFieldPackage)) == DependencySourceRegistry
+	form.SetHidden(ToolFieldSpecifier, !registry)
+	form.SetHidden(ToolFieldExtras, !registry)
+	form.SetHidden(ToolFieldEditable, DependencySource(form.Value(ToolFieldPackage)) != DependencySourcePath)
+
+	form.Preview = ""
+	options := ToolFormOptions(form)
+	if options.Package == "" {
+		return
+	}
+	form.Preview = "uv tool install " + JoinArgs(options.InstallArgs())
+}
+
+// ToolFormOptions returns the options entered in the install tool dialog. A
+// package name is combined with its extras and version; a bare version
+// becomes an exact pin (exported).
+func ToolFormOptions(form *FormState) types.ToolInstallOptions {
+	pkg := form.Value(ToolFieldPackage)
+	if pkg != "" && DependencySource(pkg) == DependencySourceRegistry {
+		if extras := splitList(form.Value(ToolFieldExtras)); len(extras) > 0 {
+			pkg += "[" + strings.Join(extras, ",") + "]"
+		}
+		if spec := form.Value(ToolFieldSpecifier); spec != "" {
+			if strings.ContainsAny(spec[:1], "<>=!~") {
+				pkg += spec
+			} else {
+				pkg += "==" + spec
+			}
+		}
+	}
+	return types.ToolInstallOptions{
+		Package:       pkg,
+		With:          splitList(form.Value(ToolFieldWith)),
+		PythonVersion: form.Value(ToolFieldPython),
+		Editable:      form.Checked(ToolFieldEditable),
+		Force:         form.Checked(ToolFieldForce),
+	}
+}
+
+// NewToolRunForm returns the run tool (uvx) dialog, filled in from options.
+func NewToolRunForm(options types.ToolRunOptions) FormState {
+	form := FormState{
+		ID:    RunToolForm,
+		Title: "Run Tool (uvx)",
+		Fields: []FormField{
+			{Key: RunFieldPackage, Label: "Package", Kind: FieldText, Value: options.Package, Placeholder: "ruff, ruff@0.6.9, path or git URL"},
+			{Key: RunFieldCommand, Label: "Command", Kind: FieldText, Value: options.Command, Placeholder: "if not named after the package"},
+			{Key: RunFieldWith, Label: "With", Kind: FieldText, Value: strings.Join(options.With, ", "), Placeholder: "extra packages, comma-separated"},
+			{Key: RunFieldPython, Label: "Python", Kind: FieldText, Value: options.PythonVersion, Placeholder: "version or path, e.g. 3.12"},
+			{Key: RunFieldArgs, Label: "Arguments", Kind: FieldText, Value: JoinArgs(options.Args)},
+			{Key: RunFieldInteractive, Label: "Interactive", Kind: FieldToggle, Checked: options.Interactive, Hint: "suspend uvui and hand over the terminal"},
+		},
+	}
+	UpdateToolRunForm(&form)
+	return form
+}
+
+// UpdateToolRunForm previews the uvx command of the run tool dialog.
+func UpdateToolRunForm(form *FormState) {
+	form.Preview = ""
+	if options := ToolRunFormOptions(form); options.Package != "" {
+		form.Preview = "uvx " + JoinArgs(options.RunArgs())
+	}
+}
+
+// ToolRunFormOptions returns the options entered in the run tool dialog
+// (exported).
+func ToolRunFormOptions(form *FormState) types.ToolRunOptions {
+	return types.ToolRunOptions{
+		Package:       form.Value(RunFieldPackage),
+		Command:       form.Value(RunFieldCommand),
+		With:          splitList(form.Value(RunFieldWith)),
+		PythonVersion: form.Value(RunFieldPython),
+		Args:          SplitArgs(form.Value(RunFieldArgs)),
+		Interactive:   form.Checked(RunFieldInteractive),
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetToolsPanelHelp returns help text for the tools panel.
+func GetToolsPanelHelp() string {
+	return "↑↓: Navigate | i: Install | u: Upgrade | U: Upgrade all | d/Del: Uninstall | s: Update shell PATH | l: Run with uvx | t: Recent runs | r: Refresh"
+}
+
+// GetToolRunsHelp returns help text for the history of tool runs.
+func GetToolRunsHelp() string {
+	return "↑↓: Navigate | Enter: Run again | l: Edit and run | d/Del: Forget | t: Installed tools"
+}
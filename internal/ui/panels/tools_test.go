@@ -0,0 +1,181 @@
+package panels
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"uvui/internal/types"
+)
+
+func TestRenderToolsPanel(t *testing.T) {
+	state := &AppState{
+		UVStatus: types.UVStatus{Installed: true},
+		Tools: ToolsState{
+			Tools: []types.Tool{
+				{Name: "ruff", Version: "0.6.9", Specifier: ">=0.6", Path: "/tools/ruff", EntryPoints: []types.ToolEntryPoint{{Name: "ruff", Path: "/bin/ruff"}}},
+				{Name: "black", Version: "24.8.0", With: []string{"tomli"}, Path: "/tools/black"},
+			},
+			BinDir: "/home/user/.local/bin",
+			OnPath: true,
+		},
+	}
+
+	output := RenderToolsPanel(state)
+	for _, want := range []string{"Installed tools (2)", "[required: >=0.6]", "[with: tomli]", "→ ruff", "ruff: /bin/ruff", "Environment: /tools/ruff"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderToolsPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "ruff") {
+		t.Errorf("RenderToolsPanel() should select ruff:\n%s", output)
+	}
+	if strings.Contains(output, "Environment: /tools/black") {
+		t.Error("RenderToolsPanel() should only show details of the selected tool")
+	}
+	if strings.Contains(output, "not on PATH") {
+		t.Error("RenderToolsPanel() should not warn when the tool directory is on PATH")
+	}
+
+	state.Tools.OnPath = false
+	if output := RenderToolsPanel(state); !strings.Contains(output, "/home/user/.local/bin is not on PATH") {
+		t.Errorf("RenderToolsPanel() should warn about PATH:\n%s", output)
+	}
+}
+
+func TestRenderToolsPanel_States(t *testing.T) {
+	state := &AppState{}
+	if output := RenderToolsPanel(state); !strings.Contains(output, "UV must be installed first") {
+		t.Errorf("RenderToolsPanel() without uv = %q", output)
+	}
+
+	state.Installed = true
+	state.Tools.Loading = true
+	if output := RenderToolsPanel(state); !strings.Contains(output, "Loading tools") {
+		t.Errorf("RenderToolsPanel() while loading = %q", output)
+	}
+
+	state.Tools.Loading = false
+	if output := RenderToolsPanel(state); !strings.Contains(output, "No tools installed") {
+		t.Errorf("RenderToolsPanel() without tools = %q", output)
+	}
+}
+
+func TestToolForm(t *testing.T) {
+	form := NewToolForm()
+	if form.Preview != "" {
+		t.Errorf("NewToolForm() preview = %q", form.Preview)
+	}
+
+	form.Field(ToolFieldPackage).Value = "ruff"
+	form.Field(ToolFieldSpecifier).Value = "0.6.9"
+	form.Field(ToolFieldExtras).Value = "a, b"
+	form.Field(ToolFieldWith).Value = "tomli, ,pyyaml"
+	form.Field(ToolFieldPython).Value = "3.12"
+	form.Field(ToolFieldForce).Checked = true
+	UpdateToolForm(&form)
+	if want := "uv tool install ruff[a,b]==0.6.9 --with tomli --with pyyaml --python 3.12 --force"; form.Preview != want {
+		t.Errorf("UpdateToolForm() preview = %q, want %q", form.Preview, want)
+	}
+	if !form.Field(ToolFieldEditable).Hidden {
+		t.Error("UpdateToolForm() should hide editable for registry packages")
+	}
+
+	form.Field(ToolFieldPackage).Value = "./mytool"
+	form.Field(ToolFieldEditable).Checked = true
+	UpdateToolForm(&form)
+	if !form.Field(ToolFieldSpecifier).Hidden || !form.Field(ToolFieldExtras).Hidden {
+		t.Error("UpdateToolForm() should hide version and extras for paths")
+	}
+	options := ToolFormOptions(&form)
+	if options.Package != "./mytool" || !options.Editable {
+		t.Errorf("ToolFormOptions() = %+v", options)
+	}
+
+	form.Field(ToolFieldPackage).Value = "git+https://github.com/astral-sh/ruff"
+	UpdateToolForm(&form)
+	if options := ToolFormOptions(&form); options.Package != "git+https://github.com/astral-sh/ruff" || options.Editable {
+		t.Errorf("ToolFormOptions() for git = %+v", options)
+	}
+}
+
+func TestRenderToolsPanel_History(t *testing.T) {
+	state := &AppState{UVStatus: types.UVStatus{Installed: true}}
+	state.Tools.ShowHistory = true
+	if output := RenderToolsPanel(state); !strings.Contains(output, "No recent runs") {
+		t.Errorf("RenderToolsPanel() without runs = %q", output)
+	}
+
+	started := time.Date(2024, 5, 1, 14, 30, 0, 0, time.UTC)
+	state.Tools.AddToolRun(types.ToolRun{Options: types.ToolRunOptions{Package: "ruff", Args: []string{"check", "."}}, StartedAt: started, Duration: 1200 * time.Millisecond})
+	state.Tools.AddToolRun(types.ToolRun{Options: types.ToolRunOptions{Package: "ipython", Interactive: true}, StartedAt: started, ExitCode: -1, Error: errors.New("not found")})
+	state.Tools.AddToolRun(types.ToolRun{Options: types.ToolRunOptions{Package: "black", Args: []string{"--check", "src dir"}}, StartedAt: started, ExitCode: 1, Error: errors.New("exit status 1")})
+
+	output := RenderToolsPanel(state)
+	for _, want := range []string{"Recent runs (3)", "uvx ruff check .", "14:30:00, 1.2s", "[interactive]", "✗ exit 1 uvx black --check 'src dir'"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderToolsPanel() missing %q:\n%s", want, output)
+		}
+	}
+	if !hasSelectedLine(output, "uvx black") {
+		t.Errorf("RenderToolsPanel() should select the latest run:\n%s", output)
+	}
+	if strings.Contains(output, "not found") {
+		t.Error("RenderToolsPanel() should only show the error of the selected run")
+	}
+	if strings.Contains(output, "Installed tools (") {
+		t.Error("RenderToolsPanel() should not list tools in the history view")
+	}
+}
+
+func TestToolsState_History(t *testing.T) {
+	var state ToolsState
+	for i := 0; i < MaxToolRuns+5; i++ {
+		state.AddToolRun(types.ToolRun{Options: types.ToolRunOptions{Package: fmt.Sprintf("tool%d", i)}})
+	}
+	if len(state.History) != MaxToolRuns {
+		t.Fatalf("AddToolRun() kept %d runs, want %d", len(state.History), MaxToolRuns)
+	}
+
+	state.RunSelected = 3
+	rerun := state.History[3]
+	state.AddToolRun(rerun)
+	if len(state.History) != MaxToolRuns || state.History[0].Options.Package != rerun.Options.Package || state.RunSelected != 0 {
+		t.Errorf("AddToolRun() of a rerun should move it to the top, got %+v", state.History[:2])
+	}
+	for _, run := range state.History[1:] {
+		if run.Options.Package == rerun.Options.Package {
+			t.Errorf("AddToolRun() kept a duplicate of %s", rerun.Options.Package)
+		}
+	}
+
+	state.RunSelected = MaxToolRuns - 1
+	state.RemoveToolRun(MaxToolRuns - 1)
+	if len(state.History) != MaxToolRuns-1 || state.RunSelected != MaxToolRuns-2 {
+		t.Errorf("RemoveToolRun() left %d runs with %d selected", len(state.History), state.RunSelected)
+	}
+	state.RemoveToolRun(100)
+	if len(state.History) != MaxToolRuns-1 {
+		t.Error("RemoveToolRun() out of range should do nothing")
+	}
+}
+
+func TestToolRunForm(t *testing.T) {
+	options := types.ToolRunOptions{Package: "httpie", Command: "http", With: []string{"rich"}, Args: []string{"GET", "example.org/a b"}}
+	form := NewToolRunForm(options)
+	if want := "uvx --from httpie --with rich http GET 'example.org/a b'"; form.Preview != want {
+		t.Errorf("NewToolRunForm() preview = %q, want %q", form.Preview, want)
+	}
+	got := ToolRunFormOptions(&form)
+	if got.Package != "httpie" || got.Command != "http" || strings.Join(got.Args, "|") != "GET|example.org/a b" || got.Interactive {
+		t.Errorf("ToolRunFormOptions() = %+v", got)
+	}
+
+	form.Field(RunFieldPackage).Value = ""
+	UpdateToolRunForm(&form)
+	if form.Preview != "" {
+		t.Errorf("UpdateToolRunForm() without a package previews %q", form.Preview)
+	}
+}
@@ -0,0 +1,197 @@
+// Package panels provides UI panels for the application.
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"uvui/internal/types"
+	"uvui/internal/ui"
+	"uvui/pkg/version"
+)
+
+// dependencyTreeRows is the number of tree rows shown at once.
+const dependencyTreeRows = 20
+
+// TreeViewState represents the cursor and collapsed subtrees of the dependency
+// tree view.
+type TreeViewState struct {
+	Cursor    int
+	Collapsed map[string]bool // keyed by TreeRow.Path
+}
+
+// TreeRow is a row of the dependency tree view.
+type TreeRow struct {
+	Node   *types.TreeNode
+	Path   string // segments from the root, joined by "/"
+	Guides string // box-drawing guides drawn before the node
+}
+
+// VisibleTreeRows returns the rows of the tree that are not hidden inside a
+// collapsed subtree, in display order (exported).
+func VisibleTreeRows(tree *types.DependencyTree, collapsed map[string]bool) []TreeRow {
+	if tree == nil {
+		return nil
+	}
+	var rows []TreeRow
+	for i := range tree.Dependencies {
+		rows = appendTreeRows(rows, &tree.Dependencies[i], "", "", "", collapsed)
+	}
+	return rows
+}
+
+// appendTreeRows appends node and its visible descendants.
+func appendTreeRows(rows []TreeRow, node *types.TreeNode, parent, guides, indent string, collapsed map[string]bool) []TreeRow {
+	path := treePathSegment(node)
+	if parent != "" {
+		path = parent + "/" + path
+	}
+	rows = append(rows, TreeRow{Node: node, Path: path, Guides: guides})
+	if collapsed[path] {
+		return rows
+	}
+	for i := range node.Children {
+		branch, continuation := "├─ ", "│  "
+		if i == len(node.Children)-1 {
+			branch, continuation = "└─ ", "   "
+		}
+		rows = appendTreeRows(rows, &node.Children[i], path, indent+branch, indent+continuation, collapsed)
+	}
+	return rows
+}
+
+// treePathSegment identifies a node among its siblings. The same package can
+// be pulled in by a parent both directly and through an extra or group.
+func treePathSegment(node *types.TreeNode) string {
+	segment := version.NormalizeName(node.Name)
+	if node.Extra != "" {
+		segment += "@extra:" + node.Extra
+	}
+	if node.Group != "" {
+		segment += "@group:" + node.Group
+	}
+	return segment
+}
+
+// TreeOccurrences returns the paths of every node of the tree, collapsed or
+// not, for the package name, in display order (exported).
+func TreeOccurrences(tree *types.DependencyTree, name string) []string {
+	var paths []string
+	for _, row := range VisibleTreeRows(tree, nil) {
+		if version.NormalizeName(row.Node.Name) == version.NormalizeName(name) {
+			paths = append(paths, row.Path)
+		}
+	}
+	return paths
+}
+
+// ParentTreePath returns the path of the parent of the node at path, or ""
+// for a root (exported).
+func ParentTreePath(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// renderDependencyTree renders the dependency tree with the cursor and
+// collapsed subtrees of view.
+func renderDependencyTree(tree *types.DependencyTree, view TreeViewState) string {
+	var content strings.Builder
+
+	content.WriteString(ui.CurrentVersionStyle.Render("Dependency Tree"))
+	content.WriteString("\n")
+
+	rows := VisibleTreeRows(tree, view.Collapsed)
+	if len(rows) == 0 {
+		content.WriteString(ui.UnselectedItemStyle.Render("  No dependency tree available"))
+		return content.String()
+	}
+
+	start, end := visibleRange(view.Cursor, len(rows), dependencyTreeRows)
+	if start > 0 {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		content.WriteString(renderTreeRow(rows[i], view.Collapsed[rows[i].Path], i == view.Cursor))
+		content.WriteString("\n")
+	}
+	if end < len(rows) {
+		content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  ↓ %d more", len(rows)-end)))
+		content.WriteString("\n")
+	}
+
+	if view.Cursor >= 0 && view.Cursor < len(rows) {
+		row := rows[view.Cursor]
+		if occurrences := TreeOccurrences(tree, row.Node.Name); len(occurrences) > 1 {
+			position := 1
+			for i, path := range occurrences {
+				if path == row.Path {
+					position = i + 1
+				}
+			}
+			content.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  %s appears %d times (%d of %d)", row.Node.Name, len(occurrences), position, len(occurrences))))
+			content.WriteString("\n")
+		}
+	}
+
+	return content.String()
+}
+
+// renderTreeRow renders a single node of the dependency tree.
+func renderTreeRow(row TreeRow, collapsed, selected bool) string {
+	node := row.Node
+
+	toggle := "  "
+	if len(node.Children) > 0 {
+		toggle = "▾ "
+		if collapsed {
+			toggle = "▸ "
+		}
+	}
+
+	name := node.Name
+	if len(node.Extras) > 0 {
+		name += "[" + strings.Join(node.Extras, ",") + "]"
+	}
+	if node.Version != "" {
+		name += fmt.Sprintf(" (%s)", node.Version)
+	}
+	if node.Level == 0 {
+		name = ui.InfoMessageStyle.Render(name)
+	}
+
+	line := row.Guides + toggle + name
+	if node.Extra != "" {
+		line += " " + ui.HelpStyle.Render("extra: "+node.Extra)
+	}
+	if node.Group != "" {
+		line += " " + ui.HelpStyle.Render("group: "+node.Group)
+	}
+	if node.Repeated {
+		line += " " + ui.WarningMessageStyle.Render("(*)")
+	}
+	if collapsed {
+		line += " " + ui.HelpStyle.Render(fmt.Sprintf("+%d", countTreeNodes(node.Children)))
+	}
+
+	if selected {
+		return ui.SelectedItemStyle.Render("> ") + line
+	}
+	return "  " + line
+}
+
+// countTreeNodes returns the number of nodes in the given subtrees.
+func countTreeNodes(nodes []types.TreeNode) int {
+	count := len(nodes)
+	for _, node := range nodes {
+		count += countTreeNodes(node.Children)
+	}
+	return count
+}
+
+// GetDependencyTreeHelp returns help text for the dependency tree view.
+func GetDependencyTreeHelp() string {
+	return "↑↓: Navigate | Enter: Expand/collapse | ←→: Collapse/expand | */#: Next/previous occurrence"
+}
@@ -0,0 +1,156 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	"uvui/internal/types"
+)
+
+// testDependencyTree mirrors:
+//
+//	demo v0.1.0
+//	├── anyio v4.4.0
+//	│   ├── idna v3.7
+//	│   └── sniffio v1.3.1
+//	├── requests[socks] v2.32.3 (extra: net)
+//	│   └── idna v3.7
+//	└── pytest v8.3.2 (group: dev)
+//	    └── anyio v4.4.0 (*)
+func testDependencyTree() *types.DependencyTree {
+	return &types.DependencyTree{Dependencies: []types.TreeNode{
+		{Name: "demo", Version: "0.1.0", Level: 0, Children: []types.TreeNode{
+			{Name: "anyio", Version: "4.4.0", Level: 1, Children: []types.TreeNode{
+				{Name: "idna", Version: "3.7", Level: 2},
+				{Name: "sniffio", Version: "1.3.1", Level: 2},
+			}},
+			{Name: "requests", Version: "2.32.3", Extras: []string{"socks"}, Extra: "net", Level: 1, Children: []types.TreeNode{
+				{Name: "idna", Version: "3.7", Level: 2},
+			}},
+			{Name: "pytest", Version: "8.3.2", Group: "dev", Level: 1, Children: []types.TreeNode{
+				{Name: "anyio", Version: "4.4.0", Level: 2, Repeated: true},
+			}},
+		}},
+	}}
+}
+
+func TestVisibleTreeRows(t *testing.T) {
+	rows := VisibleTreeRows(testDependencyTree(), nil)
+
+	expected := []struct{ path, guides string }{
+		{"demo", ""},
+		{"demo/anyio", "├─ "},
+		{"demo/anyio/idna", "│  ├─ "},
+		{"demo/anyio/sniffio", "│  └─ "},
+		{"demo/requests@extra:net", "├─ "},
+		{"demo/requests@extra:net/idna", "│  └─ "},
+		{"demo/pytest@group:dev", "└─ "},
+		{"demo/pytest@group:dev/anyio", "   └─ "},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("VisibleTreeRows() returned %d rows, want %d", len(rows), len(expected))
+	}
+	for i, want := range expected {
+		if rows[i].Path != want.path || rows[i].Guides != want.guides {
+			t.Errorf("row %d = %q %q, want %q %q", i, rows[i].Path, rows[i].Guides, want.path, want.guides)
+		}
+	}
+
+	collapsed := VisibleTreeRows(testDependencyTree(), map[string]bool{"demo/anyio": true})
+	if len(collapsed) != 6 {
+		t.Errorf("VisibleTreeRows() with anyio collapsed returned %d rows, want 6", len(collapsed))
+	}
+	if rows := VisibleTreeRows(nil, nil); rows != nil {
+		t.Errorf("VisibleTreeRows(nil) = %v, want nil", rows)
+	}
+}
+
+func TestTreeOccurrences(t *testing.T) {
+	occurrences := TreeOccurrences(testDependencyTree(), "AnyIO")
+	expected := []string{"demo/anyio", "demo/pytest@group:dev/anyio"}
+	if strings.Join(occurrences, ",") != strings.Join(expected, ",") {
+		t.Errorf("TreeOccurrences() = %v, want %v", occurrences, expected)
+	}
+}
+
+func TestParentTreePath(t *testing.T) {
+	if got := ParentTreePath("demo/anyio/idna"); got != "demo/anyio" {
+		t.Errorf("ParentTreePath() = %q, want demo/anyio", got)
+	}
+	if got := ParentTreePath("demo"); got != "" {
+		t.Errorf("ParentTreePath(root) = %q, want empty", got)
+	}
+}
+
+func TestRenderDependencyTree_Nil(t *testing.T) {
+	result := renderDependencyTree(nil, TreeViewState{})
+
+	if !strings.Contains(result, "Dependency Tree") {
+		t.Error("Expected dependency tree header not found")
+	}
+	if !strings.Contains(result, "No dependency tree available") {
+		t.Error("Expected no tree message for nil tree not found")
+	}
+}
+
+func TestRenderDependencyTree_Empty(t *testing.T) {
+	result := renderDependencyTree(&types.DependencyTree{Dependencies: []types.TreeNode{}}, TreeViewState{})
+
+	if !strings.Contains(result, "No dependency tree available") {
+		t.Error("Expected no tree message for empty tree not found")
+	}
+}
+
+func TestRenderDependencyTree_WithNodes(t *testing.T) {
+	result := renderDependencyTree(testDependencyTree(), TreeViewState{})
+
+	expected := []string{
+		"demo (0.1.0)",
+		"├─ ▾ anyio (4.4.0)",
+		"│  ├─   idna (3.7)",
+		"│  └─   sniffio (1.3.1)",
+		"requests[socks] (2.32.3)",
+		"extra: net",
+		"group: dev",
+		"(*)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in dependency tree, got:\n%s", want, result)
+		}
+	}
+	if !hasSelectedLine(result, "demo (0.1.0)") {
+		t.Errorf("Expected the cursor on the root, got:\n%s", result)
+	}
+}
+
+func TestRenderDependencyTree_Collapsed(t *testing.T) {
+	view := TreeViewState{Cursor: 1, Collapsed: map[string]bool{"demo/anyio": true}}
+
+	result := renderDependencyTree(testDependencyTree(), view)
+
+	if !strings.Contains(result, "▸ anyio (4.4.0)") || !strings.Contains(result, "+2") {
+		t.Errorf("Expected anyio to be collapsed with 2 hidden nodes, got:\n%s", result)
+	}
+	if strings.Contains(result, "sniffio") {
+		t.Errorf("Expected sniffio to be hidden, got:\n%s", result)
+	}
+	if !strings.Contains(result, "anyio appears 2 times (1 of 2)") {
+		t.Errorf("Expected the occurrence count of anyio, got:\n%s", result)
+	}
+}
+
+func TestRenderDependencyTree_MultipleRoots(t *testing.T) {
+	tree := &types.DependencyTree{Dependencies: []types.TreeNode{
+		{Name: "requests", Version: "2.28.1", Children: []types.TreeNode{{Name: "urllib3", Version: "1.26.12", Level: 1}}},
+		{Name: "flask", Version: "2.2.2", Children: []types.TreeNode{{Name: "jinja2", Version: "3.1.2", Level: 1}}},
+	}}
+
+	result := renderDependencyTree(tree, TreeViewState{})
+
+	for _, want := range []string{"requests (2.28.1)", "└─   urllib3 (1.26.12)", "flask (2.2.2)", "└─   jinja2 (3.1.2)"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in dependency tree, got:\n%s", want, result)
+		}
+	}
+}
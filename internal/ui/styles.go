@@ -119,4 +119,14 @@ var (
 	// SuccessMessageStyle defines the style for success messages.
 	SuccessMessageStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color(SuccessColor))
+
+	// OutputPaneStyle defines the style for the command output pane.
+	OutputPaneStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color(BorderColor)).
+			Padding(0, 1)
+
+	// OutputStderrStyle defines the style for stderr lines in the output pane.
+	OutputStderrStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#9CA3AF"))
 )
@@ -0,0 +1,411 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// Environment holds the values of PEP 508 marker variables, keyed by name
+// (e.g. "python_version", "sys_platform", "extra").
+type Environment map[string]string
+
+// markerVariables are the environment marker variables defined by PEP 508.
+var markerVariables = map[string]bool{
+	"python_version":                 true,
+	"python_full_version":            true,
+	"os_name":                        true,
+	"sys_platform":                   true,
+	"platform_release":               true,
+	"platform_system":                true,
+	"platform_version":               true,
+	"platform_machine":               true,
+	"platform_python_implementation": true,
+	"implementation_name":            true,
+	"implementation_version":         true,
+	"extra":                          true,
+	"dependency_groups":              true,
+	"extras":                         true,
+}
+
+// versionVariables are compared as PEP 440 versions when possible.
+var versionVariables = map[string]bool{
+	"python_version":         true,
+	"python_full_version":    true,
+	"implementation_version": true,
+	"platform_release":       true,
+}
+
+// NewEnvironment returns the marker environment of the Python interpreter
+// with the given version and implementation (e.g. "cpython", "pypy") on the
+// current platform. An empty implementation means CPython.
+func NewEnvironment(pythonVersion, implementation string) Environment {
+	env := Environment{
+		"python_full_version":            pythonVersion,
+		"python_version":                 pythonVersion,
+		"implementation_version":         pythonVersion,
+		"implementation_name":            "cpython",
+		"platform_python_implementation": "CPython",
+		"platform_release":               "",
+		"platform_version":               "",
+	}
+	if v, err := Parse(pythonVersion); err == nil {
+		env["python_version"] = fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+	}
+
+	switch strings.ToLower(implementation) {
+	case "", "cpython":
+	case "pypy":
+		env["implementation_name"] = "pypy"
+		env["platform_python_implementation"] = "PyPy"
+	case "graalpy":
+		env["implementation_name"] = "graalpy"
+		env["platform_python_implementation"] = "GraalVM"
+	default:
+		env["implementation_name"] = strings.ToLower(implementation)
+		env["platform_python_implementation"] = implementation
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		env["os_name"], env["sys_platform"], env["platform_system"] = "nt", "win32", "Windows"
+	case "darwin":
+		env["os_name"], env["sys_platform"], env["platform_system"] = "posix", "darwin", "Darwin"
+	default:
+		env["os_name"], env["sys_platform"] = "posix", runtime.GOOS
+		env["platform_system"] = strings.ToUpper(runtime.GOOS[:1]) + runtime.GOOS[1:]
+	}
+
+	switch runtime.GOARCH {
+	case "amd64":
+		env["platform_machine"] = "x86_64"
+		if runtime.GOOS == "windows" {
+			env["platform_machine"] = "AMD64"
+		}
+	case "arm64":
+		env["platform_machine"] = "aarch64"
+		if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+			env["platform_machine"] = "arm64"
+		}
+	case "386":
+		env["platform_machine"] = "i686"
+	default:
+		env["platform_machine"] = runtime.GOARCH
+	}
+
+	return env
+}
+
+// With returns a copy of the environment with key set to value.
+func (e Environment) With(key, value string) Environment {
+	env := make(Environment, len(e)+1)
+	for k, v := range e {
+		env[k] = v
+	}
+	env[key] = value
+	return env
+}
+
+// Marker is a parsed PEP 508 environment marker expression.
+type Marker struct {
+	expr markerNode
+	raw  string
+}
+
+// markerNode is a node of a marker expression tree.
+type markerNode interface {
+	evaluate(env Environment) bool
+}
+
+// markerAnd and markerOr combine sub-expressions.
+type (
+	markerAnd []markerNode
+	markerOr  []markerNode
+)
+
+func (n markerAnd) evaluate(env Environment) bool {
+	for _, child := range n {
+		if !child.evaluate(env) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n markerOr) evaluate(env Environment) bool {
+	for _, child := range n {
+		if child.evaluate(env) {
+			return true
+		}
+	}
+	return false
+}
+
+// markerValue is either a variable or a quoted string literal.
+type markerValue struct {
+	variable string
+	literal  string
+}
+
+func (v markerValue) resolve(env Environment) string {
+	if v.variable == "" {
+		return v.literal
+	}
+	return env[v.variable]
+}
+
+// markerCompare compares two values.
+type markerCompare struct {
+	left, right markerValue
+	op          string
+}
+
+func (n markerCompare) evaluate(env Environment) bool {
+	left, right := n.left.resolve(env), n.right.resolve(env)
+
+	variable := n.left.variable
+	if variable == "" {
+		variable = n.right.variable
+	}
+	switch variable {
+	case "extra":
+		// Extra names are compared in normalized form.
+		left, right = NormalizeName(left), NormalizeName(right)
+	case "extras", "dependency_groups":
+		// Set-valued variables hold comma-separated names.
+		if n.op == "in" || n.op == "not in" {
+			found := false
+			for _, name := range strings.Split(right, ",") {
+				if name != "" && NormalizeName(name) == NormalizeName(left) {
+					found = true
+				}
+			}
+			return found == (n.op == "in")
+		}
+	}
+
+	switch n.op {
+	case "in":
+		return strings.Contains(right, left)
+	case "not in":
+		return !strings.Contains(right, left)
+	}
+
+	if versionVariables[variable] && n.op != "===" {
+		if spec, err := ParseSpecifier(n.op + right); err == nil {
+			if v, err := Parse(left); err == nil {
+				return spec.Matches(v)
+			}
+		}
+	}
+
+	switch n.op {
+	case "==", "===":
+		return left == right
+	case "!=":
+		return left != right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	}
+	return false
+}
+
+// ParseMarker parses a PEP 508 marker expression such as
+// `python_version < "3.12" and sys_platform != "win32"`.
+func ParseMarker(s string) (*Marker, error) {
+	tokens, err := tokenizeMarker(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &markerParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid marker %q: %w", s, err)
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("invalid marker %q: unexpected %q", s, p.peek().text)
+	}
+	return &Marker{expr: expr, raw: strings.TrimSpace(s)}, nil
+}
+
+// String returns the marker as written.
+func (m *Marker) String() string {
+	return m.raw
+}
+
+// Evaluate reports whether the marker holds in env. A nil marker always holds.
+func (m *Marker) Evaluate(env Environment) bool {
+	if m == nil {
+		return true
+	}
+	return m.expr.evaluate(env)
+}
+
+// Marker tokens.
+const (
+	tokenString = iota
+	tokenVariable
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenLParen
+	tokenRParen
+)
+
+type markerToken struct {
+	kind int
+	text string
+}
+
+var markerTokenPattern = regexp.MustCompile(`^(?:` +
+	`(?P<string>'[^']*'|"[^"]*")|` +
+	`(?P<op>~=|===|==|!=|<=|>=|<|>|not\s+in\b|in\b)|` +
+	`(?P<paren>[()])|` +
+	`(?P<word>[A-Za-z_][A-Za-z0-9_.]*))`)
+
+// tokenizeMarker splits a marker into tokens.
+func tokenizeMarker(s string) ([]markerToken, error) {
+	var tokens []markerToken
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		m := markerTokenPattern.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("invalid marker %q: unexpected %q", s, rest)
+		}
+		switch {
+		case m[1] != "":
+			tokens = append(tokens, markerToken{kind: tokenString, text: m[1][1 : len(m[1])-1]})
+		case m[2] != "":
+			tokens = append(tokens, markerToken{kind: tokenOperator, text: strings.Join(strings.Fields(m[2]), " ")})
+		case m[3] == "(":
+			tokens = append(tokens, markerToken{kind: tokenLParen, text: m[3]})
+		case m[3] == ")":
+			tokens = append(tokens, markerToken{kind: tokenRParen, text: m[3]})
+		case m[4] == "and":
+			tokens = append(tokens, markerToken{kind: tokenAnd, text: m[4]})
+		case m[4] == "or":
+			tokens = append(tokens, markerToken{kind: tokenOr, text: m[4]})
+		default:
+			name := strings.ReplaceAll(m[4], ".", "_")
+			if name == "platform_python_implementation" || name == "python_implementation" {
+				name = "platform_python_implementation"
+			}
+			if !markerVariables[name] {
+				return nil, fmt.Errorf("invalid marker %q: unknown variable %q", s, m[4])
+			}
+			tokens = append(tokens, markerToken{kind: tokenVariable, text: name})
+		}
+		rest = strings.TrimSpace(rest[len(m[0]):])
+	}
+	return tokens, nil
+}
+
+// markerParser is a recursive-descent parser over marker tokens.
+type markerParser struct {
+	tokens []markerToken
+	pos    int
+}
+
+func (p *markerParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *markerParser) peek() markerToken {
+	if p.done() {
+		return markerToken{kind: -1, text: "end of marker"}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *markerParser) next() markerToken {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *markerParser) parseOr() (markerNode, error) {
+	var nodes markerOr
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		if p.peek().kind != tokenOr {
+			break
+		}
+		p.next()
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *markerParser) parseAnd() (markerNode, error) {
+	var nodes markerAnd
+	for {
+		node, err := p.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		if p.peek().kind != tokenAnd {
+			break
+		}
+		p.next()
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *markerParser) parseAtom() (markerNode, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, fmt.Errorf("expected \")\", got %q", tok.text)
+		}
+		return node, nil
+	}
+
+	left, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, fmt.Errorf("expected an operator, got %q", op.text)
+	}
+	right, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if left.variable == "" && right.variable == "" {
+		return nil, fmt.Errorf("comparison of two strings")
+	}
+	return markerCompare{left: left, right: right, op: op.text}, nil
+}
+
+func (p *markerParser) parseValue() (markerValue, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return markerValue{literal: tok.text}, nil
+	case tokenVariable:
+		return markerValue{variable: tok.text}, nil
+	}
+	return markerValue{}, fmt.Errorf("expected a variable or string, got %q", tok.text)
+}
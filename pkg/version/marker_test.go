@@ -0,0 +1,101 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarker_Evaluate(t *testing.T) {
+	env := Environment{
+		"python_version":      "3.11",
+		"python_full_version": "3.11.4",
+		"sys_platform":        "linux",
+		"os_name":             "posix",
+		"platform_machine":    "x86_64",
+		"implementation_name": "cpython",
+	}
+
+	cases := []struct {
+		marker string
+		want   bool
+	}{
+		{`python_version < "3.12"`, true},
+		{`python_version >= "3.12"`, false},
+		{`python_version == "3.10.*"`, false},
+		{`python_full_version == "3.11.*"`, true},
+		{`python_version ~= "3.10"`, true},
+		{`"3.10" < python_version`, true},
+		{`sys_platform == 'win32'`, false},
+		{`sys_platform != 'win32' and platform_machine == 'x86_64'`, true},
+		{`sys_platform == 'win32' or (os_name == 'posix' and python_version > '3.8')`, true},
+		{`(sys_platform == 'win32' or sys_platform == 'darwin') and python_version > '3.8'`, false},
+		{`'linux' in sys_platform`, true},
+		{`'arm' not in platform_machine`, true},
+		{`os.name == "posix"`, true},
+		{`extra == "socks"`, false},
+	}
+	for _, tc := range cases {
+		marker, err := ParseMarker(tc.marker)
+		require.NoError(t, err, tc.marker)
+		assert.Equal(t, tc.want, marker.Evaluate(env), tc.marker)
+		assert.Equal(t, tc.marker, marker.String())
+	}
+}
+
+func TestParseMarker_Extras(t *testing.T) {
+	marker, err := ParseMarker(`extra == "Socks_Proxy"`)
+	require.NoError(t, err)
+	assert.True(t, marker.Evaluate(Environment{"extra": "socks-proxy"}))
+	assert.False(t, marker.Evaluate(Environment{}))
+
+	marker, err = ParseMarker(`"dev" in dependency_groups`)
+	require.NoError(t, err)
+	assert.True(t, marker.Evaluate(Environment{"dependency_groups": "lint,dev"}))
+	assert.False(t, marker.Evaluate(Environment{"dependency_groups": "development"}))
+}
+
+func TestParseMarker_Invalid(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`python_version`,
+		`python_version <`,
+		`"a" == "b"`,
+		`unknown_var == "x"`,
+		`(python_version > "3.8"`,
+		`python_version > "3.8" and`,
+		`python_version > "3.8" "3.9"`,
+	} {
+		_, err := ParseMarker(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNilMarker_Evaluate(t *testing.T) {
+	var marker *Marker
+	assert.True(t, marker.Evaluate(Environment{}))
+}
+
+func TestNewEnvironment(t *testing.T) {
+	env := NewEnvironment("3.12.7", "")
+	assert.Equal(t, "3.12", env["python_version"])
+	assert.Equal(t, "3.12.7", env["python_full_version"])
+	assert.Equal(t, "cpython", env["implementation_name"])
+	assert.Equal(t, "CPython", env["platform_python_implementation"])
+	if runtime.GOOS == "windows" {
+		assert.Equal(t, "win32", env["sys_platform"])
+	} else {
+		assert.Equal(t, runtime.GOOS, env["sys_platform"])
+		assert.Equal(t, "posix", env["os_name"])
+	}
+
+	pypy := NewEnvironment("3.10.14", "pypy")
+	assert.Equal(t, "pypy", pypy["implementation_name"])
+	assert.Equal(t, "PyPy", pypy["platform_python_implementation"])
+
+	withExtra := env.With("extra", "socks")
+	assert.Equal(t, "socks", withExtra["extra"])
+	assert.NotContains(t, env, "extra")
+}
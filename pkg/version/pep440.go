@@ -0,0 +1,310 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Pattern matches any version accepted by PEP 440, including the
+// alternative spellings that normalize to the canonical form.
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(\d+)!)?` + // epoch
+	`(\d+(?:\.\d+)*)` + // release
+	`(?:[-_.]?(alpha|a|beta|b|preview|pre|c|rc)[-_.]?(\d+)?)?` + // pre-release
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` + // post-release
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` + // development release
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?` + // local version
+	`\s*$`)
+
+// localSeparator splits a local version label into segments.
+var localSeparator = regexp.MustCompile(`[-_.]`)
+
+// Pre-release phases in canonical form, in ascending order.
+const (
+	PreAlpha = "a"
+	PreBeta  = "b"
+	PreRC    = "rc"
+)
+
+// Version is a parsed PEP 440 version.
+type Version struct {
+	Epoch   int
+	Release []int
+	// PreKind is one of PreAlpha, PreBeta or PreRC, or empty for none.
+	PreKind string
+	Pre     int
+	// Post and Dev are -1 when the version is not a post or dev release.
+	Post  int
+	Dev   int
+	Local []string
+}
+
+// Parse parses a PEP 440 version, accepting the spellings the specification
+// normalizes (e.g. "1.0-Alpha.1", "v2.0.post", "1.0-1").
+func Parse(s string) (Version, error) {
+	m := pep440Pattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid version: %q", s)
+	}
+
+	v := Version{Post: -1, Dev: -1}
+	if m[1] != "" {
+		v.Epoch = atoi(m[1])
+	}
+	for _, part := range strings.Split(m[2], ".") {
+		v.Release = append(v.Release, atoi(part))
+	}
+
+	if m[3] != "" {
+		switch strings.ToLower(m[3]) {
+		case "alpha", "a":
+			v.PreKind = PreAlpha
+		case "beta", "b":
+			v.PreKind = PreBeta
+		default:
+			v.PreKind = PreRC
+		}
+		v.Pre = atoi(m[4])
+	}
+
+	switch {
+	case m[5] != "":
+		v.Post = atoi(m[5])
+	case m[6] != "":
+		v.Post = atoi(m[7])
+	}
+
+	if m[8] != "" {
+		v.Dev = atoi(m[9])
+	}
+
+	if m[10] != "" {
+		for _, part := range localSeparator.Split(strings.ToLower(m[10]), -1) {
+			if n, err := strconv.Atoi(part); err == nil {
+				part = strconv.Itoa(n)
+			}
+			v.Local = append(v.Local, part)
+		}
+	}
+
+	return v, nil
+}
+
+// MustParse is like Parse but panics if s is not a valid version.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// IsValid reports whether s is a valid PEP 440 version.
+func IsValid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// String returns the canonical form of the version.
+func (v Version) String() string {
+	var b strings.Builder
+	if v.Epoch != 0 {
+		fmt.Fprintf(&b, "%d!", v.Epoch)
+	}
+	b.WriteString(v.releaseString())
+	if v.PreKind != "" {
+		fmt.Fprintf(&b, "%s%d", v.PreKind, v.Pre)
+	}
+	if v.Post >= 0 {
+		fmt.Fprintf(&b, ".post%d", v.Post)
+	}
+	if v.Dev >= 0 {
+		fmt.Fprintf(&b, ".dev%d", v.Dev)
+	}
+	if len(v.Local) > 0 {
+		b.WriteString("+" + strings.Join(v.Local, "."))
+	}
+	return b.String()
+}
+
+// Public returns the version without its local label.
+func (v Version) Public() Version {
+	v.Local = nil
+	return v
+}
+
+// BaseVersion returns the epoch and release segment only, e.g. "1.2.3" for
+// "1.2.3rc1.post2+local".
+func (v Version) BaseVersion() Version {
+	return Version{Epoch: v.Epoch, Release: v.Release, Post: -1, Dev: -1}
+}
+
+// IsPrerelease reports whether the version is a pre-release or a development
+// release.
+func (v Version) IsPrerelease() bool {
+	return v.PreKind != "" || v.Dev >= 0
+}
+
+// IsPostrelease reports whether the version is a post-release.
+func (v Version) IsPostrelease() bool {
+	return v.Post >= 0
+}
+
+// IsDevrelease reports whether the version is a development release.
+func (v Version) IsDevrelease() bool {
+	return v.Dev >= 0
+}
+
+// Major returns the first release component.
+func (v Version) Major() int {
+	return v.releasePart(0)
+}
+
+// Minor returns the second release component, or 0.
+func (v Version) Minor() int {
+	return v.releasePart(1)
+}
+
+// Micro returns the third release component, or 0.
+func (v Version) Micro() int {
+	return v.releasePart(2)
+}
+
+// Compare returns -1, 0 or 1 as v sorts before, equal to or after other under
+// PEP 440 ordering.
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Epoch, other.Epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(v.Release, other.Release); c != 0 {
+		return c
+	}
+	if c := compareInt(v.preKey(), other.preKey()); c != 0 {
+		return c
+	}
+	if v.PreKind != "" && v.PreKind == other.PreKind {
+		if c := compareInt(v.Pre, other.Pre); c != 0 {
+			return c
+		}
+	}
+	// Post and Dev are -1 when absent: no post-release sorts first, while no
+	// dev release sorts last.
+	if c := compareInt(v.Post, other.Post); c != 0 {
+		return c
+	}
+	if c := compareInt(devKey(v.Dev), devKey(other.Dev)); c != 0 {
+		return c
+	}
+	return compareLocal(v.Local, other.Local)
+}
+
+// Equal reports whether v and other are the same version.
+func (v Version) Equal(other Version) bool {
+	return v.Compare(other) == 0
+}
+
+// LessThan reports whether v sorts before other.
+func (v Version) LessThan(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// preKey orders the pre-release phase: a dev release of the final version
+// ("1.0.dev1") sorts before all of its pre-releases, and the final version
+// after them.
+func (v Version) preKey() int {
+	switch {
+	case v.PreKind == PreAlpha:
+		return 1
+	case v.PreKind == PreBeta:
+		return 2
+	case v.PreKind == PreRC:
+		return 3
+	case v.Post < 0 && v.Dev >= 0:
+		return 0
+	default:
+		return 4
+	}
+}
+
+// devKey maps "no dev release" above every dev release number.
+func devKey(dev int) int {
+	if dev < 0 {
+		return int(^uint(0) >> 1)
+	}
+	return dev
+}
+
+func (v Version) releasePart(i int) int {
+	if i < len(v.Release) {
+		return v.Release[i]
+	}
+	return 0
+}
+
+func (v Version) releaseString() string {
+	parts := make([]string, len(v.Release))
+	for i, n := range v.Release {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// compareRelease compares release segments, padding the shorter with zeros.
+func compareRelease(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareInt(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareLocal compares local labels: numeric segments sort after
+// alphanumeric ones, and a longer label sorts after its prefix.
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, errX := strconv.Atoi(a[i])
+		y, errY := strconv.Atoi(b[i])
+		switch {
+		case errX == nil && errY == nil:
+			if c := compareInt(x, y); c != 0 {
+				return c
+			}
+		case errX == nil:
+			return 1
+		case errY == nil:
+			return -1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// atoi converts a digit string, treating an omitted number as 0.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
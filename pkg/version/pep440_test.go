@@ -0,0 +1,118 @@
+package version
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Normalization(t *testing.T) {
+	cases := map[string]string{
+		"1.0":              "1.0",
+		"v1.0":             "1.0",
+		"1!2.0":            "1!2.0",
+		"0!1.0":            "1.0",
+		"3.13.0rc1":        "3.13.0rc1",
+		"3.14.0a3":         "3.14.0a3",
+		"1.0-Alpha.1":      "1.0a1",
+		"1.0beta":          "1.0b0",
+		"1.0c2":            "1.0rc2",
+		"1.0.preview-3":    "1.0rc3",
+		"1.0-1":            "1.0.post1",
+		"1.0.post":         "1.0.post0",
+		"1.0-r4":           "1.0.post4",
+		"1.0rev5":          "1.0.post5",
+		"1.0.dev":          "1.0.dev0",
+		"1.0a1.post2.dev3": "1.0a1.post2.dev3",
+		"1.01.002":         "1.1.2",
+		"1.0+Ubuntu-1":     "1.0+ubuntu.1",
+		"1.0+abc.007":      "1.0+abc.7",
+		" 2.0 ":            "2.0",
+	}
+	for input, want := range cases {
+		v, err := Parse(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, v.String(), input)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "abc", "1.0.x", "3.12.y", "1.0+", "1..0", "1.0-"} {
+		_, err := Parse(input)
+		assert.Error(t, err, input)
+		assert.False(t, IsValid(input), input)
+	}
+}
+
+func TestVersion_Ordering(t *testing.T) {
+	// Ascending order from PEP 440's examples.
+	ordered := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0+abc.7",
+		"1.0+5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.0.15",
+		"1.1.dev1",
+		"1!0.1",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, b := MustParse(ordered[i]), MustParse(ordered[i+1])
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	shuffled := []string{"3.12.0", "3.13.0rc1", "3.14.0a3", "3.13.0", "3.13.0b4", "3.12.10"}
+	sort.Slice(shuffled, func(i, j int) bool {
+		return MustParse(shuffled[i]).LessThan(MustParse(shuffled[j]))
+	})
+	assert.Equal(t, []string{"3.12.0", "3.12.10", "3.13.0b4", "3.13.0rc1", "3.13.0", "3.14.0a3"}, shuffled)
+}
+
+func TestVersion_Equal(t *testing.T) {
+	assert.True(t, MustParse("1.0").Equal(MustParse("1.0.0")))
+	assert.True(t, MustParse("1.0a1").Equal(MustParse("1.0-alpha-1")))
+	assert.True(t, MustParse("1.0+ABC").Equal(MustParse("1.0+abc")))
+	assert.False(t, MustParse("1.0").Equal(MustParse("1.0+local")))
+}
+
+func TestVersion_Accessors(t *testing.T) {
+	v := MustParse("2!3.13.1rc2.post1.dev4+build.5")
+	assert.Equal(t, 3, v.Major())
+	assert.Equal(t, 13, v.Minor())
+	assert.Equal(t, 1, v.Micro())
+	assert.True(t, v.IsPrerelease())
+	assert.True(t, v.IsPostrelease())
+	assert.True(t, v.IsDevrelease())
+	assert.Equal(t, "2!3.13.1rc2.post1.dev4", v.Public().String())
+	assert.Equal(t, "2!3.13.1", v.BaseVersion().String())
+
+	final := MustParse("3.12")
+	assert.Equal(t, 0, final.Micro())
+	assert.False(t, final.IsPrerelease())
+	assert.False(t, final.IsPostrelease())
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	assert.Panics(t, func() { MustParse("not a version") })
+}
+
+func TestCompareVersions_FallsBackForInvalid(t *testing.T) {
+	// Mixed valid and invalid input keeps the numeric comparison
+	assert.Equal(t, 1, CompareVersions("3.12.1", "3.12.x"))
+	assert.Equal(t, 0, CompareVersions("3.12", "3.12.x"))
+}
@@ -0,0 +1,116 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// requirementPattern splits a PEP 508 requirement into name, extras and the
+// rest (a specifier set or "@ url").
+var requirementPattern = regexp.MustCompile(`^\s*([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(?:\[([^\]]*)\])?\s*(.*)$`)
+
+// nameSeparators matches the runs of separators folded by NormalizeName.
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// Requirement is a parsed PEP 508 dependency specification such as
+// `requests[socks]>=2.31,<3; python_version < "3.12"`.
+type Requirement struct {
+	Name      string
+	Extras    []string
+	Specifier SpecifierSet
+	URL       string
+	Marker    *Marker
+}
+
+// ParseRequirement parses a PEP 508 requirement string.
+func ParseRequirement(s string) (Requirement, error) {
+	m := requirementPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Requirement{}, fmt.Errorf("invalid requirement: %q", s)
+	}
+
+	req := Requirement{Name: m[1]}
+	if m[2] != "" {
+		for _, extra := range strings.Split(m[2], ",") {
+			extra = strings.TrimSpace(extra)
+			if extra == "" {
+				continue
+			}
+			req.Extras = append(req.Extras, extra)
+		}
+	}
+
+	rest := strings.TrimSpace(m[3])
+	var marker string
+	if strings.HasPrefix(rest, "@") {
+		// A URL may contain ";", so the marker must be separated by whitespace.
+		rest = strings.TrimSpace(rest[1:])
+		if i := strings.Index(rest, " ;"); i >= 0 {
+			marker = rest[i+2:]
+			rest = rest[:i]
+		} else if i := strings.Index(rest, "\t;"); i >= 0 {
+			marker = rest[i+2:]
+			rest = rest[:i]
+		}
+		req.URL = strings.TrimSpace(rest)
+		if req.URL == "" {
+			return Requirement{}, fmt.Errorf("invalid requirement: %q: missing URL", s)
+		}
+	} else {
+		if i := strings.Index(rest, ";"); i >= 0 {
+			marker = rest[i+1:]
+			rest = rest[:i]
+		}
+		spec, err := ParseSpecifierSet(rest)
+		if err != nil {
+			return Requirement{}, fmt.Errorf("invalid requirement: %q: %w", s, err)
+		}
+		req.Specifier = spec
+	}
+
+	if strings.TrimSpace(marker) != "" {
+		parsed, err := ParseMarker(marker)
+		if err != nil {
+			return Requirement{}, fmt.Errorf("invalid requirement: %q: %w", s, err)
+		}
+		req.Marker = parsed
+	}
+
+	return req, nil
+}
+
+// String returns the requirement in normalized form.
+func (r Requirement) String() string {
+	var b strings.Builder
+	b.WriteString(r.Name)
+	if len(r.Extras) > 0 {
+		extras := append([]string(nil), r.Extras...)
+		sort.Strings(extras)
+		b.WriteString("[" + strings.Join(extras, ",") + "]")
+	}
+	if r.URL != "" {
+		b.WriteString(" @ " + r.URL)
+		if r.Marker != nil {
+			b.WriteString(" ")
+		}
+	} else {
+		b.WriteString(r.Specifier.String())
+	}
+	if r.Marker != nil {
+		b.WriteString("; " + r.Marker.String())
+	}
+	return b.String()
+}
+
+// Applies reports whether the requirement's marker holds in env.
+func (r Requirement) Applies(env Environment) bool {
+	return r.Marker.Evaluate(env)
+}
+
+// NormalizeName returns a project, extra or group name in PEP 503 normalized
+// form: lowercase with runs of "-", "_" and "." replaced by "-".
+func NormalizeName(name string) string {
+	return nameSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
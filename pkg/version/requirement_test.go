@@ -0,0 +1,61 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequirement(t *testing.T) {
+	req, err := ParseRequirement(`requests[socks, security] >= 2.31, <3 ; python_version < "3.12"`)
+	require.NoError(t, err)
+	assert.Equal(t, "requests", req.Name)
+	assert.Equal(t, []string{"socks", "security"}, req.Extras)
+	assert.Equal(t, ">=2.31,<3", req.Specifier.String())
+	require.NotNil(t, req.Marker)
+	assert.Equal(t, `python_version < "3.12"`, req.Marker.String())
+	assert.Equal(t, `requests[security,socks]>=2.31,<3; python_version < "3.12"`, req.String())
+
+	assert.True(t, req.Applies(Environment{"python_version": "3.11"}))
+	assert.False(t, req.Applies(Environment{"python_version": "3.12"}))
+}
+
+func TestParseRequirement_Forms(t *testing.T) {
+	req, err := ParseRequirement("flask")
+	require.NoError(t, err)
+	assert.Equal(t, "flask", req.Name)
+	assert.Empty(t, req.Specifier)
+	assert.Nil(t, req.Marker)
+	assert.True(t, req.Applies(Environment{}))
+
+	req, err = ParseRequirement("name (>=1.0,<2.0)")
+	require.NoError(t, err)
+	assert.Equal(t, ">=1.0,<2.0", req.Specifier.String())
+
+	req, err = ParseRequirement(`pip @ https://github.com/pypa/pip/archive/1.3.1.zip#sha1=da9234ee ; sys_platform == "win32"`)
+	require.NoError(t, err)
+	assert.Equal(t, "pip", req.Name)
+	assert.Equal(t, "https://github.com/pypa/pip/archive/1.3.1.zip#sha1=da9234ee", req.URL)
+	require.NotNil(t, req.Marker)
+	assert.Equal(t, `sys_platform == "win32"`, req.Marker.String())
+
+	req, err = ParseRequirement("pkg @ file:///tmp/a;b.whl")
+	require.NoError(t, err)
+	assert.Equal(t, "file:///tmp/a;b.whl", req.URL)
+	assert.Nil(t, req.Marker)
+}
+
+func TestParseRequirement_Invalid(t *testing.T) {
+	for _, input := range []string{"", ">=1.0", "pkg >=", "pkg @", `pkg; python_version <`, "pkg 1.0"} {
+		_, err := ParseRequirement(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "friendly-bard", NormalizeName("Friendly-Bard"))
+	assert.Equal(t, "friendly-bard", NormalizeName("FRIENDLY_BARD"))
+	assert.Equal(t, "friendly-bard", NormalizeName("friendly.bard"))
+	assert.Equal(t, "friendly-bard", NormalizeName("friendly--_.bard"))
+}
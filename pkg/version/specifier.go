@@ -0,0 +1,227 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// specifierPattern splits a single specifier into its operator and version.
+var specifierPattern = regexp.MustCompile(`^\s*(~=|===|==|!=|<=|>=|<|>)\s*([^\s,;]+)\s*$`)
+
+// Specifier is a single PEP 440 version clause such as ">=2.31" or "==1.4.*".
+type Specifier struct {
+	Operator string
+	// Version is the version as written; for prefix matches it excludes the
+	// trailing ".*".
+	Version string
+	// Wildcard is set for "==V.*" and "!=V.*".
+	Wildcard bool
+
+	parsed Version
+}
+
+// ParseSpecifier parses a single version specifier.
+func ParseSpecifier(s string) (Specifier, error) {
+	m := specifierPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Specifier{}, fmt.Errorf("invalid specifier: %q", s)
+	}
+
+	spec := Specifier{Operator: m[1], Version: m[2]}
+	if spec.Operator == "===" {
+		return spec, nil
+	}
+
+	if strings.HasSuffix(spec.Version, ".*") {
+		if spec.Operator != "==" && spec.Operator != "!=" {
+			return Specifier{}, fmt.Errorf("invalid specifier: %q: wildcards are only allowed with == and !=", s)
+		}
+		spec.Wildcard = true
+		spec.Version = strings.TrimSuffix(spec.Version, ".*")
+	}
+
+	v, err := Parse(spec.Version)
+	if err != nil {
+		return Specifier{}, fmt.Errorf("invalid specifier: %q: %w", s, err)
+	}
+	switch {
+	case spec.Wildcard && (v.Dev >= 0 || len(v.Local) > 0):
+		return Specifier{}, fmt.Errorf("invalid specifier: %q: prefix match on a dev or local version", s)
+	case spec.Operator == "~=" && len(v.Release) < 2:
+		return Specifier{}, fmt.Errorf("invalid specifier: %q: ~= needs at least two release segments", s)
+	case len(v.Local) > 0 && spec.Operator != "==" && spec.Operator != "!=":
+		return Specifier{}, fmt.Errorf("invalid specifier: %q: local versions are only allowed with == and !=", s)
+	}
+	spec.parsed = v
+	return spec, nil
+}
+
+// String returns the specifier in normalized form.
+func (s Specifier) String() string {
+	if s.Operator == "===" {
+		return s.Operator + s.Version
+	}
+	if s.Wildcard {
+		return s.Operator + s.parsed.String() + ".*"
+	}
+	return s.Operator + s.parsed.String()
+}
+
+// IsPrerelease reports whether the specifier names a pre-release, which lets
+// a specifier set match pre-releases.
+func (s Specifier) IsPrerelease() bool {
+	if s.Operator == "===" || s.Operator == "!=" {
+		return false
+	}
+	return s.parsed.IsPrerelease()
+}
+
+// Matches reports whether v satisfies the specifier, without applying the
+// pre-release exclusion of SpecifierSet.Contains.
+func (s Specifier) Matches(v Version) bool {
+	spec := s.parsed
+	switch s.Operator {
+	case "===":
+		return strings.EqualFold(v.String(), strings.TrimSpace(s.Version))
+	case "==":
+		if s.Wildcard {
+			return prefixMatch(v, spec)
+		}
+		if len(spec.Local) == 0 {
+			v = v.Public()
+		}
+		return v.Compare(spec) == 0
+	case "!=":
+		if s.Wildcard {
+			return !prefixMatch(v, spec)
+		}
+		if len(spec.Local) == 0 {
+			v = v.Public()
+		}
+		return v.Compare(spec) != 0
+	case "~=":
+		prefix := Version{Epoch: spec.Epoch, Release: spec.Release[:len(spec.Release)-1], Post: -1, Dev: -1}
+		return v.Public().Compare(spec) >= 0 && prefixMatch(v, prefix)
+	case "<=":
+		return v.Public().Compare(spec) <= 0
+	case ">=":
+		return v.Public().Compare(spec) >= 0
+	case "<":
+		if v.Compare(spec) >= 0 {
+			return false
+		}
+		// "<V" excludes pre-releases of V unless V is itself a pre-release.
+		return spec.IsPrerelease() || !v.IsPrerelease() || v.BaseVersion().Compare(spec.BaseVersion()) != 0
+	case ">":
+		if v.Compare(spec) <= 0 {
+			return false
+		}
+		// ">V" excludes post-releases and local versions of V unless V is
+		// itself a post-release.
+		if !spec.IsPostrelease() && v.IsPostrelease() && v.BaseVersion().Compare(spec.BaseVersion()) == 0 {
+			return false
+		}
+		return len(v.Local) == 0 || v.Public().Compare(spec) != 0
+	}
+	return false
+}
+
+// prefixMatch reports whether v starts with prefix, comparing the public
+// version after padding the release segments to the prefix's length.
+func prefixMatch(v, prefix Version) bool {
+	v = v.Public()
+	if v.Epoch != prefix.Epoch {
+		return false
+	}
+	for i, n := range prefix.Release {
+		if v.releasePart(i) != n {
+			return false
+		}
+	}
+	if prefix.PreKind != "" || prefix.Post >= 0 {
+		// Prefixes such as "1.0rc1.*" must match the remaining segments too.
+		if v.PreKind != prefix.PreKind || v.Pre != prefix.Pre {
+			return false
+		}
+		if prefix.Post >= 0 && v.Post != prefix.Post {
+			return false
+		}
+		return len(v.Release) <= len(prefix.Release) || allZero(v.Release[len(prefix.Release):])
+	}
+	return true
+}
+
+func allZero(parts []int) bool {
+	for _, n := range parts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SpecifierSet is a comma-separated list of specifiers that must all match.
+// The empty set matches every version.
+type SpecifierSet []Specifier
+
+// ParseSpecifierSet parses a comma-separated specifier set such as
+// ">=2.31,<3". Surrounding parentheses, as allowed in requirements, are
+// ignored.
+func ParseSpecifierSet(s string) (SpecifierSet, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if s == "" {
+		return SpecifierSet{}, nil
+	}
+
+	var set SpecifierSet
+	for _, part := range strings.Split(s, ",") {
+		spec, err := ParseSpecifier(part)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, spec)
+	}
+	return set, nil
+}
+
+// String returns the specifiers joined by commas.
+func (s SpecifierSet) String() string {
+	parts := make([]string, len(s))
+	for i, spec := range s {
+		parts[i] = spec.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Contains reports whether v satisfies every specifier. Pre-releases only
+// match when one of the specifiers names a pre-release.
+func (s SpecifierSet) Contains(v Version) bool {
+	if v.IsPrerelease() && !s.allowsPrereleases() {
+		return false
+	}
+	return s.Matches(v)
+}
+
+// Matches reports whether v satisfies every specifier, including
+// pre-releases.
+func (s SpecifierSet) Matches(v Version) bool {
+	for _, spec := range s {
+		if !spec.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s SpecifierSet) allowsPrereleases() bool {
+	for _, spec := range s {
+		if spec.IsPrerelease() {
+			return true
+		}
+	}
+	return false
+}
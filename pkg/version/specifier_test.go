@@ -0,0 +1,90 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpecifierSet(t *testing.T) {
+	set, err := ParseSpecifierSet(" >=2.31 , <3 ")
+	require.NoError(t, err)
+	assert.Len(t, set, 2)
+	assert.Equal(t, ">=2.31,<3", set.String())
+
+	set, err = ParseSpecifierSet("(==1.4.*)")
+	require.NoError(t, err)
+	assert.Equal(t, "==1.4.*", set.String())
+
+	set, err = ParseSpecifierSet("")
+	require.NoError(t, err)
+	assert.Empty(t, set)
+	assert.True(t, set.Contains(MustParse("1.0")))
+}
+
+func TestParseSpecifier_Invalid(t *testing.T) {
+	for _, input := range []string{"2.0", "=>2.0", ">=1.*", "~=1", "==1.0.dev1.*", "<1.0+local", ">=abc"} {
+		_, err := ParseSpecifier(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestSpecifier_Matches(t *testing.T) {
+	cases := []struct {
+		spec    string
+		version string
+		want    bool
+	}{
+		{"==1.0", "1.0.0", true},
+		{"==1.0", "1.0+local", true},
+		{"==1.0+local", "1.0", false},
+		{"==1.0.*", "1.0.5", true},
+		{"==1.0.*", "1.0rc1", true},
+		{"==1.0.*", "1.1", false},
+		{"==3.12.*", "3.12", true},
+		{"!=1.0.*", "1.0.5", false},
+		{"!=1.0.*", "1.1", true},
+		{"!=1.0", "1.0.1", true},
+		{"~=2.2", "2.5", true},
+		{"~=2.2", "3.0", false},
+		{"~=2.2", "2.1", false},
+		{"~=1.4.5", "1.4.9", true},
+		{"~=1.4.5", "1.5.0", false},
+		{"~=1.4.5a4", "1.4.5", true},
+		{"<3", "2.99", true},
+		{"<3", "3.0a1", false},
+		{"<3.0rc1", "3.0a1", true},
+		{">1.7", "1.7.post1", false},
+		{">1.7.post2", "1.7.post3", true},
+		{">1.7", "1.7+local", false},
+		{">1.7", "1.8", true},
+		{"<=2.0", "2.0+local", true},
+		{">=2.0", "2.0", true},
+		{"===1.0", "1.0", true},
+		{"===1.0", "1.0.0", false},
+	}
+	for _, tc := range cases {
+		spec, err := ParseSpecifier(tc.spec)
+		require.NoError(t, err, tc.spec)
+		assert.Equal(t, tc.want, spec.Matches(MustParse(tc.version)), "%s matches %s", tc.spec, tc.version)
+	}
+}
+
+func TestSpecifierSet_Contains_PreReleases(t *testing.T) {
+	set, err := ParseSpecifierSet(">=3.12")
+	require.NoError(t, err)
+	assert.False(t, set.Contains(MustParse("3.13.0rc1")))
+	assert.True(t, set.Matches(MustParse("3.13.0rc1")))
+	assert.True(t, set.Contains(MustParse("3.13.0")))
+
+	set, err = ParseSpecifierSet(">=3.13.0b1")
+	require.NoError(t, err)
+	assert.True(t, set.Contains(MustParse("3.13.0rc1")))
+
+	set, err = ParseSpecifierSet(">=2.31,<3")
+	require.NoError(t, err)
+	assert.True(t, set.Contains(MustParse("2.32.3")))
+	assert.False(t, set.Contains(MustParse("3.0")))
+	assert.False(t, set.Contains(MustParse("2.30")))
+}
@@ -8,7 +8,20 @@ import (
 
 // CompareVersions compares two version strings.
 // It returns: 1 if v1 > v2, -1 if v1 < v2, 0 if v1 == v2.
+// Valid PEP 440 versions are compared under PEP 440 ordering; anything else
+// falls back to comparing the numeric value of each dot-separated part.
 func CompareVersions(v1, v2 string) int {
+	if a, err := Parse(v1); err == nil {
+		if b, err := Parse(v2); err == nil {
+			return a.Compare(b)
+		}
+	}
+	return compareNumericParts(v1, v2)
+}
+
+// compareNumericParts compares dot-separated parts numerically, treating
+// missing and non-numeric parts as 0.
+func compareNumericParts(v1, v2 string) int {
 	parts1 := strings.Split(v1, ".")
 	parts2 := strings.Split(v2, ".")
 
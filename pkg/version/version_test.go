@@ -82,10 +82,14 @@ func TestCompareVersions_EdgeCases(t *testing.T) {
 }
 
 func TestCompareVersions_InvalidInput(t *testing.T) {
-	// Test with non-numeric components (should be treated as 0)
+	// Test with non-numeric components (treated as 0 outside PEP 440)
 	assert.Equal(t, 0, CompareVersions("3.12.0", "3.12.0"))
-	assert.Equal(t, 0, CompareVersions("3.12.0", "3.12.a")) // Non-numeric treated as 0
-	assert.Equal(t, 0, CompareVersions("3.12.a", "3.12.0")) // Non-numeric treated as 0
+	assert.Equal(t, 0, CompareVersions("3.12.0", "3.12.x")) // Non-numeric treated as 0
+	assert.Equal(t, 0, CompareVersions("3.12.x", "3.12.0")) // Non-numeric treated as 0
+
+	// "3.12.a" is the PEP 440 pre-release 3.12a0
+	assert.Equal(t, 1, CompareVersions("3.12.0", "3.12.a"))
+	assert.Equal(t, -1, CompareVersions("3.12.a", "3.12.0"))
 
 	// Test with mixed valid/invalid
 	assert.Equal(t, 0, CompareVersions("3.12.0", "3.12")) // Same when non-numeric parts are 0
@@ -120,14 +124,18 @@ func TestVersionComparison_RealWorldExamples(t *testing.T) {
 }
 
 func TestVersionComparison_PreRelease(t *testing.T) {
-	// Test pre-release versions (should be treated as regular versions for now)
-	// Note: The current implementation treats non-numeric parts as 0
+	// Test pre-release versions (ordered under PEP 440)
 	assert.True(t, IsNewerVersion("3.12.0", "3.11.0"))
 	assert.True(t, IsNewerVersion("3.12.1", "3.12.0"))
 	assert.True(t, IsNewerVersion("3.12.2", "3.12.1"))
 
 	// Test that pre-release versions are treated as newer than stable
 	assert.True(t, IsNewerVersion("3.12.0", "3.11.99"))
+
+	// Pre-releases sort before the final release
+	assert.True(t, IsNewerVersion("3.13.0", "3.13.0rc1"))
+	assert.True(t, IsNewerVersion("3.13.0rc1", "3.13.0b4"))
+	assert.True(t, IsNewerVersion("3.14.0a3", "3.13.1"))
 }
 
 func TestVersionComparison_ZeroPadding(t *testing.T) {